@@ -0,0 +1,207 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Action is an invocable action: a standard action such as "chatterPost", or a custom action such as a flow or an
+// invocable Apex method. The Name of custom actions is qualified by their type, e.g. "flow/Notify_Owner" or
+// "apex/InvoiceService", as expected by DescribeAction and InvokeAction.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_action.meta/api_action/actions_intro_invocable_actions.htm
+type Action struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+}
+
+// ActionParameter is an input or output of an invocable action.
+type ActionParameter struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	// Type is the type of the values of the parameter, e.g. "STRING", "BOOLEAN" or "SOBJECT".
+	Type        string `json:"type"`
+	SObjectType string `json:"sobjectType"`
+	Required    bool   `json:"required"`
+	// MaxOccurs is greater than 1 for parameters holding a collection of values.
+	MaxOccurs int `json:"maxOccurs"`
+}
+
+// ActionDescription describes the inputs and outputs of an invocable action.
+type ActionDescription struct {
+	Name     string            `json:"name"`
+	Label    string            `json:"label"`
+	Type     string            `json:"type"`
+	Category string            `json:"category"`
+	Inputs   []ActionParameter `json:"inputs"`
+	Outputs  []ActionParameter `json:"outputs"`
+}
+
+// ActionResult is the outcome of an invocation of an action for a single input.
+type ActionResult struct {
+	ActionName string      `json:"actionName"`
+	IsSuccess  bool        `json:"isSuccess"`
+	Errors     []SaveError `json:"errors"`
+	// OutputValues holds the outputs of the action, decoded with Outputs.
+	OutputValues json.RawMessage `json:"outputValues"`
+}
+
+// Err returns nil if the invocation succeeded, or a SalesforceError describing the first error otherwise.
+func (result ActionResult) Err() error {
+	return SaveResult{ID: result.ActionName, Success: result.IsSuccess, Errors: result.Errors}.Err()
+}
+
+// Outputs decodes the outputs of the action into v, e.g. a pointer to a struct whose JSON tags are the names of the
+// outputs.
+func (result ActionResult) Outputs(v interface{}) error {
+	if len(result.OutputValues) == 0 || bytes.Equal(result.OutputValues, []byte("null")) {
+		return errors.New("no output values")
+	}
+	return json.Unmarshal(result.OutputValues, v)
+}
+
+// actionURL returns the URL of the action name, standard unless qualified by the type of a custom action.
+func (client *Client) actionURL(name string) string {
+	if strings.Contains(name, "/") {
+		return client.makeURL("actions/custom/" + name)
+	}
+	return client.makeURL("actions/standard/" + name)
+}
+
+// listActions returns the actions listed by the resource u.
+func (client *Client) listActions(ctx context.Context, u string) ([]Action, error) {
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var result struct {
+		Actions []Action `json:"actions"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Actions, nil
+}
+
+// ListActions lists the standard actions of the org, followed by its custom actions of each type, with their names
+// qualified by their type.
+func (client *Client) ListActions() ([]Action, error) {
+	return client.ListActionsContext(context.Background())
+}
+
+// ListActionsContext is like ListActions, with the requests bound to ctx.
+func (client *Client) ListActionsContext(ctx context.Context) ([]Action, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	actions, err := client.listActions(ctx, client.makeURL("actions/standard"))
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("actions/custom")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+	var customTypes map[string]string
+	err = json.Unmarshal(data, &customTypes)
+	if err != nil {
+		return nil, err
+	}
+	types := make([]string, 0, len(customTypes))
+	for customType := range customTypes {
+		types = append(types, customType)
+	}
+	sort.Strings(types)
+
+	for _, customType := range types {
+		custom, err := client.listActions(ctx, client.makeURL("actions/custom/"+customType))
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range custom {
+			action.Name = customType + "/" + action.Name
+			actions = append(actions, action)
+		}
+	}
+	return actions, nil
+}
+
+// DescribeAction describes the inputs and outputs of the action name, e.g. "chatterPost" or "flow/Notify_Owner".
+func (client *Client) DescribeAction(name string) (*ActionDescription, error) {
+	return client.DescribeActionContext(context.Background(), name)
+}
+
+// DescribeActionContext is like DescribeAction, with the request bound to ctx.
+func (client *Client) DescribeActionContext(ctx context.Context, name string) (*ActionDescription, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.actionURL(name)
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var description ActionDescription
+	err = json.Unmarshal(data, &description)
+	if err != nil {
+		return nil, err
+	}
+	return &description, nil
+}
+
+// InvokeAction invokes the action name once for each of inputs, which are maps or structs whose JSON encoding holds
+// the inputs of the action, e.g. struct{ RecordID string `json:"recordId"` }. Each invocation succeeds or fails on
+// its own; the results are returned in the order of inputs.
+func (client *Client) InvokeAction(name string, inputs ...interface{}) ([]ActionResult, error) {
+	return client.InvokeActionContext(context.Background(), name, inputs...)
+}
+
+// InvokeActionContext is like InvokeAction, with the request bound to ctx.
+func (client *Client) InvokeActionContext(ctx context.Context, name string, inputs ...interface{}) ([]ActionResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	if len(inputs) == 0 {
+		// Actions without inputs are invoked with a single empty input.
+		inputs = []interface{}{map[string]interface{}{}}
+	}
+
+	reqData, err := json.Marshal(map[string]interface{}{"inputs": inputs})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.actionURL(name)
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+
+	var results []ActionResult
+	err = json.Unmarshal(data, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(inputs) {
+		return nil, errors.Errorf("expected %d results, got %d", len(inputs), len(results))
+	}
+	return results, nil
+}
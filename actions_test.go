@@ -0,0 +1,113 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestClient_ListActions(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v54.0/actions/standard":
+			w.Write([]byte(`{"actions":[{"label":"Post to Chatter","name":"chatterPost","type":"CHATTERPOST"}]}`))
+		case "/services/data/v54.0/actions/custom":
+			w.Write([]byte(`{"flow":"/services/data/v54.0/actions/custom/flow","apex":"/services/data/v54.0/actions/custom/apex"}`))
+		case "/services/data/v54.0/actions/custom/apex":
+			w.Write([]byte(`{"actions":[{"label":"Invoice Service","name":"InvoiceService","type":"APEX"}]}`))
+		case "/services/data/v54.0/actions/custom/flow":
+			w.Write([]byte(`{"actions":[{"label":"Notify Owner","name":"Notify_Owner","type":"FLOW"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	actions, err := client.ListActions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, len(actions))
+	for i, action := range actions {
+		names[i] = action.Name
+	}
+	if len(names) != 3 || names[0] != "chatterPost" || names[1] != "apex/InvoiceService" || names[2] != "flow/Notify_Owner" {
+		t.Errorf("unexpected actions %v", names)
+	}
+}
+
+func TestClient_DescribeAction(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/services/data/v54.0/actions/custom/flow/Notify_Owner" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"name":"Notify_Owner","label":"Notify Owner","type":"FLOW","category":"Flow",
+			"inputs":[{"name":"recordId","type":"STRING","required":true,"maxOccurs":1}],
+			"outputs":[{"name":"notified","type":"BOOLEAN","maxOccurs":1}]}`))
+	})
+
+	description, err := client.DescribeAction("flow/Notify_Owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(description.Inputs) != 1 || !description.Inputs[0].Required || len(description.Outputs) != 1 ||
+		description.Outputs[0].Type != "BOOLEAN" {
+		t.Errorf("unexpected description %+v", description)
+	}
+}
+
+func TestClient_InvokeAction(t *testing.T) {
+	type notifyInput struct {
+		RecordID string `json:"recordId"`
+	}
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/actions/custom/flow/Notify_Owner" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var req struct {
+			Inputs []notifyInput `json:"inputs"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Inputs) != 2 || req.Inputs[1].RecordID != "0013000000Db2wLAAR" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		w.Write([]byte(`[
+			{"actionName":"Notify_Owner","errors":null,"isSuccess":true,"outputValues":{"notified":true,"Flow__InterviewStatus":"Finished"}},
+			{"actionName":"Notify_Owner","errors":[{"statusCode":"CANNOT_EXECUTE_FLOW_TRIGGER","message":"Owner is inactive"}],"isSuccess":false,"outputValues":null}
+		]`))
+	})
+
+	results, err := client.InvokeAction("flow/Notify_Owner",
+		notifyInput{RecordID: "0013000000Db2wKAAR"}, notifyInput{RecordID: "0013000000Db2wLAAR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Err() != nil || results[1].Err() == nil {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	var outputs struct {
+		Notified bool `json:"notified"`
+	}
+	if err = results[0].Outputs(&outputs); err != nil || !outputs.Notified {
+		t.Errorf("unexpected outputs %+v: %v", outputs, err)
+	}
+}
+
+func TestClient_InvokeStandardAction(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/actions/standard/chatterPost" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`[{"actionName":"chatterPost","isSuccess":true,"outputValues":{"feedItemId":"0D53000000AbcdeCAB"}}]`))
+	})
+
+	results, err := client.InvokeAction("chatterPost", map[string]interface{}{"subjectNameOrId": "me", "text": "Deployed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outputs struct {
+		FeedItemID string `json:"feedItemId"`
+	}
+	if err = results[0].Outputs(&outputs); err != nil || outputs.FeedItemID != "0D53000000AbcdeCAB" {
+		t.Errorf("unexpected outputs %+v: %v", outputs, err)
+	}
+}
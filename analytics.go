@@ -0,0 +1,424 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ReportFilter filters the rows of a report, e.g. {Column: "ACCOUNT.INDUSTRY", Operator: "equals", Value: "Energy"}.
+type ReportFilter struct {
+	Column   string `json:"column"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// ReportMetadata describes how a report is run: its columns, groupings and filters.
+type ReportMetadata struct {
+	ID                  string         `json:"id"`
+	Name                string         `json:"name"`
+	DeveloperName       string         `json:"developerName"`
+	ReportFormat        string         `json:"reportFormat"`
+	DetailColumns       []string       `json:"detailColumns"`
+	Aggregates          []string       `json:"aggregates"`
+	ReportFilters       []ReportFilter `json:"reportFilters"`
+	ReportBooleanFilter string         `json:"reportBooleanFilter"`
+	GroupingsDown       []struct {
+		Name      string `json:"name"`
+		SortOrder string `json:"sortOrder"`
+	} `json:"groupingsDown"`
+}
+
+// ReportColumn describes a detail column or aggregate of a report.
+type ReportColumn struct {
+	Label    string `json:"label"`
+	DataType string `json:"dataType"`
+}
+
+// ReportDescription is the metadata of a report along with the labels and types of its columns.
+type ReportDescription struct {
+	Metadata ReportMetadata `json:"reportMetadata"`
+	Extended struct {
+		// DetailColumnInfo is keyed by the names of ReportMetadata.DetailColumns.
+		DetailColumnInfo    map[string]ReportColumn `json:"detailColumnInfo"`
+		AggregateColumnInfo map[string]ReportColumn `json:"aggregateColumnInfo"`
+	} `json:"reportExtendedMetadata"`
+}
+
+// ReportCell is a value of a report, along with the label Salesforce displays for it, e.g. "$1,000.00" for 1000.
+type ReportCell struct {
+	Label string      `json:"label"`
+	Value interface{} `json:"value"`
+}
+
+// ReportRow is a detail row of a report, keyed by the names of the detail columns.
+type ReportRow map[string]ReportCell
+
+// ReportFact holds the aggregates and detail rows of a grouping of a report.
+type ReportFact struct {
+	// Aggregates are in the order of ReportMetadata.Aggregates.
+	Aggregates []ReportCell
+	Rows       []ReportRow
+}
+
+// ReportGrouping is a group of the rows of a report. Its Key identifies its facts in the fact map.
+type ReportGrouping struct {
+	Key       string           `json:"key"`
+	Label     string           `json:"label"`
+	Value     interface{}      `json:"value"`
+	Groupings []ReportGrouping `json:"groupings"`
+}
+
+// ReportResult is the outcome of running a report.
+type ReportResult struct {
+	ReportDescription
+	// AllData is false if the report returned more rows than the 2000 the API returns.
+	AllData bool
+	// FactMap is keyed by the groupings down and across the fact belongs to, e.g. "T!T" for the grand total and
+	// "0!T" for the first grouping down.
+	FactMap         map[string]ReportFact
+	GroupingsDown   []ReportGrouping
+	GroupingsAcross []ReportGrouping
+	// Status is the status of the report instance the result is read from, e.g. "Success"; it's empty for
+	// synchronous runs.
+	Status string
+}
+
+// Rows returns the detail rows of the report in the order of its groupings down.
+func (result *ReportResult) Rows() []ReportRow {
+	var keys []string
+	var walk func(groupings []ReportGrouping)
+	walk = func(groupings []ReportGrouping) {
+		for _, grouping := range groupings {
+			if len(grouping.Groupings) > 0 {
+				walk(grouping.Groupings)
+			} else {
+				keys = append(keys, grouping.Key+"!T")
+			}
+		}
+	}
+	walk(result.GroupingsDown)
+	if len(keys) == 0 {
+		keys = []string{"T!T"}
+	}
+
+	var rows []ReportRow
+	for _, key := range keys {
+		rows = append(rows, result.FactMap[key].Rows...)
+	}
+	return rows
+}
+
+// UnmarshalJSON maps the data cells of the detail rows to their columns.
+func (result *ReportResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ReportDescription
+		AllData    bool `json:"allData"`
+		Attributes struct {
+			Status string `json:"status"`
+		} `json:"attributes"`
+		FactMap map[string]struct {
+			Aggregates []ReportCell `json:"aggregates"`
+			Rows       []struct {
+				DataCells []ReportCell `json:"dataCells"`
+			} `json:"rows"`
+		} `json:"factMap"`
+		GroupingsDown struct {
+			Groupings []ReportGrouping `json:"groupings"`
+		} `json:"groupingsDown"`
+		GroupingsAcross struct {
+			Groupings []ReportGrouping `json:"groupings"`
+		} `json:"groupingsAcross"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*result = ReportResult{
+		ReportDescription: raw.ReportDescription,
+		AllData:           raw.AllData,
+		FactMap:           make(map[string]ReportFact, len(raw.FactMap)),
+		GroupingsDown:     raw.GroupingsDown.Groupings,
+		GroupingsAcross:   raw.GroupingsAcross.Groupings,
+		Status:            raw.Attributes.Status,
+	}
+	columns := raw.Metadata.DetailColumns
+	for key, fact := range raw.FactMap {
+		rows := make([]ReportRow, 0, len(fact.Rows))
+		for _, row := range fact.Rows {
+			if len(row.DataCells) != len(columns) {
+				return errors.Errorf("expected %d cells in the rows of %s, got %d", len(columns), key, len(row.DataCells))
+			}
+			cells := make(ReportRow, len(columns))
+			for i, column := range columns {
+				cells[column] = row.DataCells[i]
+			}
+			rows = append(rows, cells)
+		}
+		result.FactMap[key] = ReportFact{Aggregates: fact.Aggregates, Rows: rows}
+	}
+	return nil
+}
+
+// reportURL returns the URL of the analytics resource of report reportID, e.g. "describe".
+func (client *Client) reportURL(reportID, resource string) string {
+	u := client.makeURL("analytics/reports/" + url.PathEscape(reportID))
+	if resource != "" {
+		u += "/" + resource
+	}
+	return u
+}
+
+// DescribeReport returns the metadata of a report and the labels and types of its columns.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_analytics.meta/api_analytics/sforce_analytics_rest_api_get_reportmetadata.htm
+func (client *Client) DescribeReport(reportID string) (*ReportDescription, error) {
+	return client.DescribeReportContext(context.Background(), reportID)
+}
+
+// DescribeReportContext is like DescribeReport, with the request bound to ctx.
+func (client *Client) DescribeReportContext(ctx context.Context, reportID string) (*ReportDescription, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.reportURL(reportID, "describe")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var description ReportDescription
+	err = json.Unmarshal(data, &description)
+	if err != nil {
+		return nil, err
+	}
+	return &description, nil
+}
+
+// runReport runs a report through u, replacing its filters with non-nil filters, and returns the response.
+func (client *Client) runReport(ctx context.Context, method, u string, filters []ReportFilter) ([]byte, error) {
+	var body io.Reader
+	if filters != nil {
+		reqData, err := json.Marshal(map[string]interface{}{
+			"reportMetadata": map[string]interface{}{"reportFilters": filters},
+		})
+		if err != nil {
+			return nil, err
+		}
+		method, body = http.MethodPost, bytes.NewReader(reqData)
+	}
+
+	data, err := client.httpRequestContext(ctx, method, u, body)
+	if err != nil {
+		client.logError("HTTP", method, "request failed:", u)
+		return nil, err
+	}
+	return data, nil
+}
+
+// RunReport runs a report synchronously with its detail rows, and returns its results; see ReportResult.Rows. Non-nil
+// filters replace the filters of the report. The API returns up to 2000 detail rows; see ReportResult.AllData.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_analytics.meta/api_analytics/sforce_analytics_rest_api_getreportrundata.htm
+func (client *Client) RunReport(reportID string, filters []ReportFilter) (*ReportResult, error) {
+	return client.RunReportContext(context.Background(), reportID, filters)
+}
+
+// RunReportContext is like RunReport, with the request bound to ctx.
+func (client *Client) RunReportContext(ctx context.Context, reportID string, filters []ReportFilter) (*ReportResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.reportURL(reportID, "") + "?includeDetails=true"
+	data, err := client.runReport(ctx, http.MethodGet, u, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ReportResult
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Statuses of report instances.
+const (
+	ReportInstanceStatusNew     = "New"
+	ReportInstanceStatusRunning = "Running"
+	ReportInstanceStatusSuccess = "Success"
+	ReportInstanceStatusError   = "Error"
+)
+
+// ReportInstance is an asynchronous run of a report.
+type ReportInstance struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	RequestDate    string `json:"requestDate"`
+	CompletionDate string `json:"completionDate"`
+	OwnerID        string `json:"ownerId"`
+}
+
+// RunReportAsync runs a report asynchronously with its detail rows, like RunReport. Its results are read with
+// ReportInstanceResult once done, and are kept by Salesforce for 24 hours.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_analytics.meta/api_analytics/sforce_analytics_rest_api_instances_reportdata.htm
+func (client *Client) RunReportAsync(reportID string, filters []ReportFilter) (*ReportInstance, error) {
+	return client.RunReportAsyncContext(context.Background(), reportID, filters)
+}
+
+// RunReportAsyncContext is like RunReportAsync, with the request bound to ctx.
+func (client *Client) RunReportAsyncContext(ctx context.Context, reportID string, filters []ReportFilter) (*ReportInstance, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.reportURL(reportID, "instances") + "?includeDetails=true"
+	data, err := client.runReport(ctx, http.MethodPost, u, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var instance ReportInstance
+	err = json.Unmarshal(data, &instance)
+	if err != nil {
+		return nil, err
+	}
+	return &instance, nil
+}
+
+// ReportInstanceResult returns the results of an asynchronous run of a report. Its Status tells whether the run is
+// done; the results are empty until then.
+func (client *Client) ReportInstanceResult(reportID, instanceID string) (*ReportResult, error) {
+	return client.ReportInstanceResultContext(context.Background(), reportID, instanceID)
+}
+
+// ReportInstanceResultContext is like ReportInstanceResult, with the request bound to ctx.
+func (client *Client) ReportInstanceResultContext(ctx context.Context, reportID, instanceID string) (*ReportResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.reportURL(reportID, "instances/"+url.PathEscape(instanceID))
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var result ReportResult
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WaitForReportInstance polls an asynchronous run of a report every interval until it's done or timeout elapses, and
+// returns its results. An error is returned if the run failed or the timeout elapsed first.
+func (client *Client) WaitForReportInstance(reportID, instanceID string, interval, timeout time.Duration) (*ReportResult, error) {
+	return client.WaitForReportInstanceContext(context.Background(), reportID, instanceID, interval, timeout)
+}
+
+// WaitForReportInstanceContext is like WaitForReportInstance, with the requests bound to ctx. It stops waiting once ctx
+// is done, returning the last known results along with the error of ctx.
+func (client *Client) WaitForReportInstanceContext(ctx context.Context, reportID, instanceID string, interval, timeout time.Duration) (*ReportResult, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		result, err := client.ReportInstanceResultContext(ctx, reportID, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		switch result.Status {
+		case ReportInstanceStatusSuccess:
+			return result, nil
+		case ReportInstanceStatusError:
+			return result, errors.Errorf("report instance %s failed", instanceID)
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return result, errors.Errorf("report instance %s still %s after %s", instanceID, result.Status, timeout)
+		}
+		if err = sleepContext(ctx, interval); err != nil {
+			return result, err
+		}
+	}
+}
+
+// DashboardComponent is a component of a dashboard along with the results of its source report.
+type DashboardComponent struct {
+	ID       string `json:"componentId"`
+	Header   string `json:"-"`
+	ReportID string `json:"-"`
+	// Status is the status of the refresh of the component, e.g. "DATA".
+	Status struct {
+		DataStatus    string `json:"dataStatus"`
+		RefreshDate   string `json:"refreshDate"`
+		RefreshStatus string `json:"refreshStatus"`
+		ErrorMessage  string `json:"errorMessage"`
+	} `json:"status"`
+	// ReportResult holds the aggregates computed for the component, without detail rows.
+	ReportResult *ReportResult `json:"reportResult"`
+}
+
+// DashboardResult is the data of a dashboard as of its last refresh.
+type DashboardResult struct {
+	ID         string
+	Name       string
+	Components []DashboardComponent
+}
+
+// GetDashboardResults returns the components of a dashboard and their data as of the last refresh of the dashboard.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_analytics.meta/api_analytics/analytics_api_dashboard_get_results.htm
+func (client *Client) GetDashboardResults(dashboardID string) (*DashboardResult, error) {
+	return client.GetDashboardResultsContext(context.Background(), dashboardID)
+}
+
+// GetDashboardResultsContext is like GetDashboardResults, with the request bound to ctx.
+func (client *Client) GetDashboardResultsContext(ctx context.Context, dashboardID string) (*DashboardResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("analytics/dashboards/" + url.PathEscape(dashboardID))
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var raw struct {
+		ComponentData     []DashboardComponent `json:"componentData"`
+		DashboardMetadata struct {
+			ID         string `json:"id"`
+			Name       string `json:"name"`
+			Components []struct {
+				ID       string `json:"id"`
+				Header   string `json:"header"`
+				ReportID string `json:"reportId"`
+			} `json:"components"`
+		} `json:"dashboardMetadata"`
+	}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &DashboardResult{ID: raw.DashboardMetadata.ID, Name: raw.DashboardMetadata.Name, Components: raw.ComponentData}
+	for i := range dashboard.Components {
+		for _, meta := range raw.DashboardMetadata.Components {
+			if meta.ID == dashboard.Components[i].ID {
+				dashboard.Components[i].Header = meta.Header
+				dashboard.Components[i].ReportID = meta.ReportID
+			}
+		}
+	}
+	return dashboard, nil
+}
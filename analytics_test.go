@@ -0,0 +1,150 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// summaryReport is the result of a summary report of opportunities grouped by stage.
+const summaryReport = `{
+	"allData": true,
+	"attributes": {"status": "Success"},
+	"reportMetadata": {"id": "00O30000001KmZcEAK", "name": "Pipeline", "reportFormat": "SUMMARY",
+		"detailColumns": ["OPPORTUNITY_NAME", "AMOUNT"], "aggregates": ["s!AMOUNT", "RowCount"],
+		"reportFilters": [{"column": "AMOUNT", "operator": "greaterThan", "value": "100"}]},
+	"reportExtendedMetadata": {"detailColumnInfo": {
+		"OPPORTUNITY_NAME": {"label": "Opportunity Name", "dataType": "string"},
+		"AMOUNT": {"label": "Amount", "dataType": "currency"}}},
+	"groupingsDown": {"groupings": [
+		{"key": "0", "label": "Prospecting", "value": "Prospecting", "groupings": []},
+		{"key": "1", "label": "Closed Won", "value": "Closed Won", "groupings": []}]},
+	"groupingsAcross": {"groupings": []},
+	"factMap": {
+		"0!T": {"aggregates": [{"label": "$500.00", "value": 500}, {"label": "1", "value": 1}],
+			"rows": [{"dataCells": [{"label": "Acme", "value": "0063000000D8cuIAAR"}, {"label": "$500.00", "value": {"amount": 500, "currency": null}}]}]},
+		"1!T": {"aggregates": [{"label": "$1,200.00", "value": 1200}, {"label": "2", "value": 2}],
+			"rows": [{"dataCells": [{"label": "Globex", "value": "0063000000D8cuJAAR"}, {"label": "$700.00", "value": {"amount": 700, "currency": null}}]},
+				{"dataCells": [{"label": "Initech", "value": "0063000000D8cuKAAR"}, {"label": "$500.00", "value": {"amount": 500, "currency": null}}]}]},
+		"T!T": {"aggregates": [{"label": "$1,700.00", "value": 1700}, {"label": "3", "value": 3}], "rows": []}
+	}
+}`
+
+func TestClient_RunReport(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/analytics/reports/00O30000001KmZcEAK" ||
+			r.URL.Query().Get("includeDetails") != "true" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var req struct {
+			ReportMetadata struct {
+				ReportFilters []ReportFilter `json:"reportFilters"`
+			} `json:"reportMetadata"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.ReportMetadata.ReportFilters) != 1 || req.ReportMetadata.ReportFilters[0].Column != "AMOUNT" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		w.Write([]byte(summaryReport))
+	})
+
+	result, err := client.RunReport("00O30000001KmZcEAK", []ReportFilter{{Column: "AMOUNT", Operator: "greaterThan", Value: "100"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := result.Rows()
+	if len(rows) != 3 || rows[0]["OPPORTUNITY_NAME"].Label != "Acme" || rows[2]["OPPORTUNITY_NAME"].Label != "Initech" ||
+		rows[1]["AMOUNT"].Label != "$700.00" {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+	total := result.FactMap["T!T"]
+	if len(total.Aggregates) != 2 || total.Aggregates[0].Value != float64(1700) {
+		t.Errorf("unexpected grand total %+v", total)
+	}
+	if !result.AllData || result.Extended.DetailColumnInfo["AMOUNT"].DataType != "currency" || len(result.GroupingsDown) != 2 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestClient_RunReportAsync(t *testing.T) {
+	polls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v54.0/analytics/reports/00O30000001KmZcEAK/instances":
+			if r.Method != http.MethodPost || r.ContentLength > 0 {
+				t.Errorf("unexpected request %s with %d bytes", r.Method, r.ContentLength)
+			}
+			w.Write([]byte(`{"id":"0LGD000000000IjOAI","status":"New","requestDate":"2022-05-02T10:00:00Z","ownerId":"005D0000001KyEIIA0"}`))
+		case "/services/data/v54.0/analytics/reports/00O30000001KmZcEAK/instances/0LGD000000000IjOAI":
+			polls++
+			if polls == 1 {
+				w.Write([]byte(`{"attributes":{"status":"Running"},"factMap":{}}`))
+				return
+			}
+			w.Write([]byte(summaryReport))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	instance, err := client.RunReportAsync("00O30000001KmZcEAK", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if instance.ID != "0LGD000000000IjOAI" || instance.Status != ReportInstanceStatusNew {
+		t.Errorf("unexpected instance %+v", instance)
+	}
+	result, err := client.WaitForReportInstance("00O30000001KmZcEAK", instance.ID, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls != 2 || result.Status != ReportInstanceStatusSuccess || len(result.Rows()) != 3 {
+		t.Errorf("unexpected result after %d polls: %+v", polls, result)
+	}
+}
+
+func TestClient_DescribeReport(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/analytics/reports/00O30000001KmZcEAK/describe" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(summaryReport))
+	})
+
+	description, err := client.DescribeReport("00O30000001KmZcEAK")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if description.Metadata.ReportFormat != "SUMMARY" || len(description.Metadata.DetailColumns) != 2 ||
+		description.Extended.DetailColumnInfo["OPPORTUNITY_NAME"].Label != "Opportunity Name" {
+		t.Errorf("unexpected description %+v", description)
+	}
+}
+
+func TestClient_GetDashboardResults(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/services/data/v54.0/analytics/dashboards/01ZD00000007S89MAE" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{
+			"componentData": [{"componentId": "01aD0000000a36LIAQ", "status": {"dataStatus": "DATA", "refreshStatus": "IDLE"},
+				"reportResult": {"reportMetadata": {"detailColumns": []}, "factMap": {"T!T": {"aggregates": [{"label": "3", "value": 3}]}}}}],
+			"dashboardMetadata": {"id": "01ZD00000007S89MAE", "name": "Sales",
+				"components": [{"id": "01aD0000000a36LIAQ", "header": "Deals", "reportId": "00O30000001KmZcEAK"}]}
+		}`))
+	})
+
+	dashboard, err := client.GetDashboardResults("01ZD00000007S89MAE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dashboard.Name != "Sales" || len(dashboard.Components) != 1 {
+		t.Fatalf("unexpected dashboard %+v", dashboard)
+	}
+	component := dashboard.Components[0]
+	if component.Header != "Deals" || component.ReportID != "00O30000001KmZcEAK" || component.Status.DataStatus != "DATA" ||
+		component.ReportResult.FactMap["T!T"].Aggregates[0].Label != "3" {
+		t.Errorf("unexpected component %+v", component)
+	}
+}
@@ -0,0 +1,106 @@
+package simpleforce
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// multipartEscaper escapes the quoted names of multipart form fields and files.
+var multipartEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// MultipartPart is a single part of a multipart/form-data request. Parts with a FileName are sent as file uploads.
+type MultipartPart struct {
+	Name        string
+	FileName    string
+	ContentType string
+	Body        io.Reader
+}
+
+// ApexRESTStream executes a custom rest request like ApexREST, but returns the response body without reading it so
+// large or binary responses (CSV exports, generated PDFs...) can be streamed. header overrides the default headers,
+// e.g. to set the Content-Type of requestBody or the Accept header; it may be nil. The caller must close the
+// returned body.
+func (client *Client) ApexRESTStream(method, path string, requestBody io.Reader, header http.Header) (io.ReadCloser, error) {
+	return client.ApexRESTStreamContext(context.Background(), method, path, requestBody, header)
+}
+
+// ApexRESTStreamContext is like ApexRESTStream, with the request bound to ctx.
+func (client *Client) ApexRESTStreamContext(ctx context.Context, method, path string, requestBody io.Reader, header http.Header) (io.ReadCloser, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := fmt.Sprintf("%s/%s", client.getInstanceURL(), path)
+
+	resp, err := client.httpResponseContext(ctx, method, u, requestBody, header)
+	if err != nil {
+		client.logError(fmt.Sprintf("HTTP %s request failed:", method), u)
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// ApexRESTMultipart executes a custom rest request with a multipart/form-data body made of parts. The parts are
+// streamed to Salesforce as they are read, so large files are not buffered in memory.
+func (client *Client) ApexRESTMultipart(method, path string, parts []MultipartPart) ([]byte, error) {
+	return client.ApexRESTMultipartContext(context.Background(), method, path, parts)
+}
+
+// ApexRESTMultipartContext is like ApexRESTMultipart, with the request bound to ctx.
+func (client *Client) ApexRESTMultipartContext(ctx context.Context, method, path string, parts []MultipartPart) ([]byte, error) {
+	body, writer := io.Pipe()
+	mw := multipart.NewWriter(writer)
+	go func() {
+		writer.CloseWithError(writeMultipart(mw, parts))
+	}()
+	// Unblock the writing goroutine if the request fails before the body is fully consumed.
+	defer body.Close()
+
+	header := http.Header{"Content-Type": {mw.FormDataContentType()}}
+	respBody, err := client.ApexRESTStreamContext(ctx, method, path, body, header)
+	if err != nil {
+		return nil, err
+	}
+	defer respBody.Close()
+
+	return ioutil.ReadAll(respBody)
+}
+
+// writeMultipart writes parts to mw and closes it.
+func writeMultipart(mw *multipart.Writer, parts []MultipartPart) error {
+	for _, part := range parts {
+		h := textproto.MIMEHeader{}
+		if part.FileName != "" {
+			h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+				multipartEscaper.Replace(part.Name), multipartEscaper.Replace(part.FileName)))
+		} else {
+			h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, multipartEscaper.Replace(part.Name)))
+		}
+		contentType := part.ContentType
+		if contentType == "" && part.FileName != "" {
+			contentType = "application/octet-stream"
+		}
+		if contentType != "" {
+			h.Set("Content-Type", contentType)
+		}
+
+		w, err := mw.CreatePart(h)
+		if err != nil {
+			return err
+		}
+		if part.Body != nil {
+			_, err = io.Copy(w, part.Body)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return mw.Close()
+}
@@ -0,0 +1,75 @@
+package simpleforce
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_ApexRESTStream(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/apexrest/reports/export" || r.Header.Get("Accept") != "text/csv" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("Id,Name\n001,Acme\n"))
+	})
+
+	body, err := client.ApexRESTStream(http.MethodGet, "services/apexrest/reports/export", nil,
+		http.Header{"Accept": {"text/csv"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	data, _ := ioutil.ReadAll(body)
+	if string(data) != "Id,Name\n001,Acme\n" {
+		t.Errorf("unexpected body %q", data)
+	}
+}
+
+func TestClient_ApexRESTStreamError(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`[{"errorCode":"NOT_FOUND","message":"Could not find a match for URL"}]`))
+	})
+
+	_, err := client.ApexRESTStream(http.MethodGet, "services/apexrest/missing", nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestClient_ApexRESTMultipart(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseMultipartForm(1 << 20)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if r.FormValue("caseId") != "500000000000001" {
+			t.Errorf("unexpected form %v", r.MultipartForm.Value)
+		}
+		file, header, err := r.FormFile("document")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		data, _ := ioutil.ReadAll(file)
+		if header.Filename != "invoice.pdf" || header.Header.Get("Content-Type") != "application/pdf" || string(data) != "%PDF-1.4" {
+			t.Errorf("unexpected file %v %q", header.Header, data)
+		}
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	data, err := client.ApexRESTMultipart(http.MethodPost, "services/apexrest/documents", []MultipartPart{
+		{Name: "caseId", Body: strings.NewReader("500000000000001")},
+		{Name: "document", FileName: "invoice.pdf", ContentType: "application/pdf", Body: strings.NewReader("%PDF-1.4")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"success":true}` {
+		t.Errorf("unexpected response %s", data)
+	}
+}
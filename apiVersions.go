@@ -0,0 +1,142 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// APIVersion describes a version of the REST API supported by the org.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_versions.htm
+type APIVersion struct {
+	Label   string `json:"label"`
+	URL     string `json:"url"`
+	Version string `json:"version"`
+}
+
+// APIVersions lists the versions of the REST API supported by the org, oldest first.
+func (client *Client) APIVersions() ([]APIVersion, error) {
+	return client.APIVersionsContext(context.Background())
+}
+
+// APIVersionsContext is like APIVersions, with the request bound to ctx.
+func (client *Client) APIVersionsContext(ctx context.Context) ([]APIVersion, error) {
+	base := client.getInstanceURL()
+	if base == "" {
+		base = client.loginBaseURL()
+	}
+
+	u := base + "/services/data/"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", "Bearer "+client.getSessionID())
+	resp, err := client.sendRequest(req)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var versions []APIVersion
+	err = json.NewDecoder(resp.Body).Decode(&versions)
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// LatestAPIVersion returns the newest version of the REST API supported by the org, e.g. "56.0".
+func (client *Client) LatestAPIVersion() (string, error) {
+	return client.LatestAPIVersionContext(context.Background())
+}
+
+// LatestAPIVersionContext is like LatestAPIVersion, with the request bound to ctx.
+func (client *Client) LatestAPIVersionContext(ctx context.Context) (string, error) {
+	versions, err := client.APIVersionsContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	latest, latestNumber := "", 0.0
+	for _, version := range versions {
+		number, err := strconv.ParseFloat(version.Version, 64)
+		if err == nil && number > latestNumber {
+			latest, latestNumber = version.Version, number
+		}
+	}
+	if latest == "" {
+		return "", errors.New("no API version available")
+	}
+	return latest, nil
+}
+
+// APIVersion returns the API version the requests of the client are sent to, e.g. "54.0".
+func (client *Client) APIVersion() string {
+	client.session.tokenMu.RLock()
+	defer client.session.tokenMu.RUnlock()
+	return client.apiVersion
+}
+
+// WithAPIVersion returns a copy of the client sending its requests to another API version, for the calls which need
+// endpoints or fields only available in a newer version, or the behaviour of an older one:
+//
+//	client.WithAPIVersion("58.0").SObject("Account").Get(id)
+//
+// The copy shares the session of the client: logging in again on either of them, or the org moving to another instance,
+// applies to both. Switching API versions on either of them doesn't affect the other.
+func (client *Client) WithAPIVersion(version string) *Client {
+	override := client.clone()
+	override.apiVersion = strings.TrimPrefix(version, "v")
+	return override
+}
+
+// SetAutoUpgradeAPIVersion makes the client switch to the newest API version supported by the org when a request
+// fails with ErrAPIVersionRetired, and send the request again. Responses of the newer version may differ from the
+// ones of the configured version, which is why this is disabled by default.
+func (client *Client) SetAutoUpgradeAPIVersion(enabled bool) {
+	client.autoUpgradeAPIVersion = enabled
+}
+
+// versionedPathPattern matches the API version of the URL of a REST request, e.g. "/v54.0/".
+var versionedPathPattern = regexp.MustCompile(`/v(\d+\.\d+)/`)
+
+// upgradeAPIVersion switches the client to the newest API version and returns url rewritten for it. false is returned
+// if url isn't versioned or no newer version is available. Concurrent requests failing with the retired version switch
+// the client only once.
+func (client *Client) upgradeAPIVersion(ctx context.Context, url string) (string, bool) {
+	match := versionedPathPattern.FindStringSubmatch(url)
+	if match == nil {
+		return "", false
+	}
+	retired, latest := match[1], client.APIVersion()
+
+	if latest == retired {
+		discovered, err := client.LatestAPIVersionContext(ctx)
+		if err != nil {
+			client.logError("failed to discover the latest API version,", err)
+			return "", false
+		}
+		if discovered == retired {
+			return "", false
+		}
+
+		client.session.tokenMu.Lock()
+		switched := client.apiVersion == retired
+		if switched {
+			client.apiVersion = discovered
+		}
+		latest = client.apiVersion
+		client.session.tokenMu.Unlock()
+		if switched {
+			client.logInfo("API version", retired, "is retired, switching to", latest)
+		}
+	}
+	return strings.Replace(url, "/v"+retired+"/", "/v"+latest+"/", 1), true
+}
@@ -0,0 +1,133 @@
+package simpleforce
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const versionsResponse = `[
+	{"label":"Winter '22","url":"/services/data/v53.0","version":"53.0"},
+	{"label":"Summer '22","url":"/services/data/v55.0","version":"55.0"},
+	{"label":"Spring '22","url":"/services/data/v54.0","version":"54.0"}
+]`
+
+func TestClient_LatestAPIVersion(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(versionsResponse))
+	})
+
+	latest, err := client.LatestAPIVersion()
+	if err != nil || latest != "55.0" {
+		t.Errorf("unexpected latest version %s, %v", latest, err)
+	}
+}
+
+func TestClient_AutoUpgradeAPIVersion(t *testing.T) {
+	var bodies []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/services/data/":
+			w.Write([]byte(versionsResponse))
+		case strings.HasPrefix(r.URL.Path, "/services/data/v20.0/"):
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`[{"errorCode":"GONE","message":"API version 20.0 has been retired"}]`))
+		case r.URL.Path == "/services/data/v55.0/sobjects/Account/":
+			data, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(data))
+			w.Write([]byte(`{"id":"001000000000001","success":true}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	client.apiVersion = "20.0"
+
+	_, err := client.httpRequest(http.MethodPost, client.makeURL("sobjects/Account/"), strings.NewReader(`{"Name":"Acme"}`))
+	if !errors.Is(err, ErrAPIVersionRetired) {
+		t.Fatalf("expected ErrAPIVersionRetired, got %v", err)
+	}
+
+	client.SetAutoUpgradeAPIVersion(true)
+	_, err = client.httpRequest(http.MethodPost, client.makeURL("sobjects/Account/"), strings.NewReader(`{"Name":"Acme"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.apiVersion != "55.0" || len(bodies) != 1 || bodies[0] != `{"Name":"Acme"}` {
+		t.Errorf("expected request to be sent again with version 55.0, got %s %v", client.apiVersion, bodies)
+	}
+}
+
+func TestClient_AutoUpgradeAPIVersionConcurrently(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/services/data/":
+			w.Write([]byte(versionsResponse))
+		case strings.HasPrefix(r.URL.Path, "/services/data/v20.0/"):
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`[{"errorCode":"GONE","message":"API version 20.0 has been retired"}]`))
+		default:
+			w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+		}
+	})
+	client.apiVersion = "20.0"
+	client.SetAutoUpgradeAPIVersion(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Staggered, some requests are built while others switch the version.
+			time.Sleep(time.Duration(i) * 200 * time.Microsecond)
+			if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if client.APIVersion() != "55.0" {
+		t.Errorf("expected the client to switch to 55.0, got %s", client.APIVersion())
+	}
+}
+
+func TestClient_WithAPIVersion(t *testing.T) {
+	var paths []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"attributes":{"type":"Account"},"Id":"0013000000Db2wKAAR"}`))
+	})
+
+	override := client.WithAPIVersion("v58.0")
+	if override.APIVersion() != "58.0" || client.APIVersion() != DefaultAPIVersion {
+		t.Errorf("unexpected versions %s and %s", override.APIVersion(), client.APIVersion())
+	}
+	override.SObject("Account").Get("0013000000Db2wKAAR")
+	client.SObject("Account").Get("0013000000Db2wKAAR")
+	if len(paths) != 2 || paths[0] != "/services/data/v58.0/sobjects/Account/0013000000Db2wKAAR" ||
+		paths[1] != "/services/data/v54.0/sobjects/Account/0013000000Db2wKAAR" {
+		t.Errorf("unexpected paths %v", paths)
+	}
+
+	client.SetSidLoc("00D000000000062!AQzz", "https://na2.salesforce.com/")
+	if override.GetSid() != "00D000000000062!AQzz" || override.InstanceURL() != "https://na2.salesforce.com" {
+		t.Errorf("expected the copy to follow the new session, got %s at %s", override.GetSid(), override.InstanceURL())
+	}
+}
+
+func TestSalesforceError_IsAPIVersionRetired(t *testing.T) {
+	err := ParseSalesforceError(http.StatusBadRequest, []byte(`[{"errorCode":"UNSUPPORTED_API_VERSION","message":"Unsupported API version"}]`))
+	if !errors.Is(err, ErrAPIVersionRetired) {
+		t.Errorf("expected %v to match ErrAPIVersionRetired", err)
+	}
+	if errors.Is(ParseSalesforceError(http.StatusBadRequest, []byte("nope")), ErrAPIVersionRetired) {
+		t.Error("expected unrelated errors not to match")
+	}
+}
@@ -0,0 +1,208 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Actions of approval requests.
+const (
+	ApprovalActionSubmit  = "Submit"
+	ApprovalActionApprove = "Approve"
+	ApprovalActionReject  = "Reject"
+)
+
+// ApprovalRequest is a request submitting a record for approval, or approving or rejecting a pending work item.
+type ApprovalRequest struct {
+	// ActionType is one of the approval action constants.
+	ActionType string `json:"actionType"`
+	// ContextID is the record submitted, or the work item approved or rejected.
+	ContextID string `json:"contextId"`
+	Comments  string `json:"comments,omitempty"`
+	// NextApproverIDs are the users to assign the next step of the process to, when it's not automatic.
+	NextApproverIDs []string `json:"nextApproverIds,omitempty"`
+	// ProcessDefinitionNameOrID is the approval process to submit the record to; by default, the first process whose
+	// entry criteria the record meets.
+	ProcessDefinitionNameOrID string `json:"processDefinitionNameOrId,omitempty"`
+	// SkipEntryCriteria submits the record even if it doesn't meet the entry criteria of the process.
+	SkipEntryCriteria bool `json:"skipEntryCriteria,omitempty"`
+}
+
+// ApprovalResult is the outcome of an approval request.
+type ApprovalResult struct {
+	// EntityID is the record of the approval process.
+	EntityID string `json:"entityId"`
+	// InstanceID is the ProcessInstance of the approval process, and InstanceStatus its status, e.g. "Pending".
+	InstanceID     string `json:"instanceId"`
+	InstanceStatus string `json:"instanceStatus"`
+	// ActorIDs are the users or queues the new work items are assigned to.
+	ActorIDs       []string    `json:"actorIds"`
+	NewWorkitemIDs []string    `json:"newWorkitemIds"`
+	Success        bool        `json:"success"`
+	Errors         []SaveError `json:"errors"`
+}
+
+// Err returns nil if the request succeeded, or a SalesforceError describing the first error otherwise.
+func (result ApprovalResult) Err() error {
+	return SaveResult{ID: result.EntityID, Success: result.Success, Errors: result.Errors}.Err()
+}
+
+// ProcessApprovals sends approval requests, and returns their results in the order of requests.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_process_approvals.htm
+func (client *Client) ProcessApprovals(requests []ApprovalRequest) ([]ApprovalResult, error) {
+	return client.ProcessApprovalsContext(context.Background(), requests)
+}
+
+// ProcessApprovalsContext is like ProcessApprovals, with the request bound to ctx.
+func (client *Client) ProcessApprovalsContext(ctx context.Context, requests []ApprovalRequest) ([]ApprovalResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	reqData, err := json.Marshal(map[string]interface{}{"requests": requests})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("process/approvals/")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+
+	var results []ApprovalResult
+	err = json.Unmarshal(data, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(requests) {
+		return nil, errors.Errorf("expected %d results, got %d", len(requests), len(results))
+	}
+	return results, nil
+}
+
+// SubmitForApproval submits records to their approval process with comments. Each record succeeds or fails on its
+// own; the results are returned in the order of recordIDs.
+func (client *Client) SubmitForApproval(recordIDs []string, comments string) ([]ApprovalResult, error) {
+	return client.SubmitForApprovalContext(context.Background(), recordIDs, comments)
+}
+
+// SubmitForApprovalContext is like SubmitForApproval, with the request bound to ctx.
+func (client *Client) SubmitForApprovalContext(ctx context.Context, recordIDs []string, comments string) ([]ApprovalResult, error) {
+	requests := make([]ApprovalRequest, len(recordIDs))
+	for i, id := range recordIDs {
+		requests[i] = ApprovalRequest{ActionType: ApprovalActionSubmit, ContextID: id, Comments: comments}
+	}
+	return client.ProcessApprovalsContext(ctx, requests)
+}
+
+// ApproveWorkItem approves the pending work item workItemID, e.g. from PendingApprovals, with comments.
+func (client *Client) ApproveWorkItem(workItemID, comments string) (*ApprovalResult, error) {
+	return client.ApproveWorkItemContext(context.Background(), workItemID, comments)
+}
+
+// ApproveWorkItemContext is like ApproveWorkItem, with the request bound to ctx.
+func (client *Client) ApproveWorkItemContext(ctx context.Context, workItemID, comments string) (*ApprovalResult, error) {
+	return client.processWorkItem(ctx, ApprovalActionApprove, workItemID, comments)
+}
+
+// RejectWorkItem rejects the pending work item workItemID with comments.
+func (client *Client) RejectWorkItem(workItemID, comments string) (*ApprovalResult, error) {
+	return client.RejectWorkItemContext(context.Background(), workItemID, comments)
+}
+
+// RejectWorkItemContext is like RejectWorkItem, with the request bound to ctx.
+func (client *Client) RejectWorkItemContext(ctx context.Context, workItemID, comments string) (*ApprovalResult, error) {
+	return client.processWorkItem(ctx, ApprovalActionReject, workItemID, comments)
+}
+
+func (client *Client) processWorkItem(ctx context.Context, action, workItemID, comments string) (*ApprovalResult, error) {
+	results, err := client.ProcessApprovalsContext(ctx, []ApprovalRequest{{ActionType: action, ContextID: workItemID, Comments: comments}})
+	if err != nil {
+		return nil, err
+	}
+	return &results[0], results[0].Err()
+}
+
+// ApprovalWorkItem is a step of an approval process waiting for the decision of a user or queue.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.object_reference.meta/object_reference/sforce_api_objects_processinstanceworkitem.htm
+type ApprovalWorkItem struct {
+	ID string `json:"Id"`
+	// ActorID is the user or queue the work item is assigned to.
+	ActorID           string `json:"ActorId"`
+	ProcessInstanceID string `json:"ProcessInstanceId"`
+	ProcessInstance   struct {
+		// TargetObjectID is the record submitted for approval.
+		TargetObjectID string `json:"TargetObjectId"`
+		Status         string `json:"Status"`
+	} `json:"ProcessInstance"`
+	CreatedDate DateTime `json:"CreatedDate"`
+}
+
+// PendingApprovals lists the work items waiting for the decision of the user or queue actorID, oldest first.
+func (client *Client) PendingApprovals(actorID string) ([]ApprovalWorkItem, error) {
+	return client.PendingApprovalsContext(context.Background(), actorID)
+}
+
+// PendingApprovalsContext is like PendingApprovals, with the requests bound to ctx.
+func (client *Client) PendingApprovalsContext(ctx context.Context, actorID string) ([]ApprovalWorkItem, error) {
+	q, err := Select("Id", "ActorId", "ProcessInstanceId", "ProcessInstance.TargetObjectId", "ProcessInstance.Status",
+		"CreatedDate").From("ProcessInstanceWorkitem").Where(Eq("ActorId", actorID)).OrderBy("CreatedDate").Build()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []ApprovalWorkItem{}
+	err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+		var page []ApprovalWorkItem
+		err := json.Unmarshal(records, &page)
+		items = append(items, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ApprovalProcess is an approval process of the org.
+type ApprovalProcess struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Object      string `json:"object"`
+	SortOrder   int    `json:"sortOrder"`
+}
+
+// ApprovalProcesses lists the approval processes of the org, keyed by the type of their records, e.g. "Account".
+func (client *Client) ApprovalProcesses() (map[string][]ApprovalProcess, error) {
+	return client.ApprovalProcessesContext(context.Background())
+}
+
+// ApprovalProcessesContext is like ApprovalProcesses, with the request bound to ctx.
+func (client *Client) ApprovalProcessesContext(ctx context.Context) (map[string][]ApprovalProcess, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("process/approvals/")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var result struct {
+		Approvals map[string][]ApprovalProcess `json:"approvals"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Approvals, nil
+}
@@ -0,0 +1,112 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_SubmitForApproval(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/process/approvals/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var req struct {
+			Requests []ApprovalRequest `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Requests) != 2 || req.Requests[1].ActionType != ApprovalActionSubmit ||
+			req.Requests[1].ContextID != "0063000000D8cuJAAR" || req.Requests[1].Comments != "Discount over 20%" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		w.Write([]byte(`[
+			{"actorIds":["0053000000AbcdeAAA"],"entityId":"0063000000D8cuIAAR","errors":null,"instanceId":"04g3000000AbcdeAAA",
+				"instanceStatus":"Pending","newWorkitemIds":["04i3000000AbcdeAAA"],"success":true},
+			{"actorIds":null,"entityId":"0063000000D8cuJAAR","errors":[{"statusCode":"NO_APPLICABLE_PROCESS",
+				"message":"No applicable approval process was found.","fields":[]}],"success":false}
+		]`))
+	})
+
+	results, err := client.SubmitForApproval([]string{"0063000000D8cuIAAR", "0063000000D8cuJAAR"}, "Discount over 20%")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Err() != nil || results[0].InstanceStatus != "Pending" ||
+		len(results[0].NewWorkitemIDs) != 1 || results[0].NewWorkitemIDs[0] != "04i3000000AbcdeAAA" {
+		t.Errorf("unexpected results %+v", results)
+	}
+	if err, ok := results[1].Err().(SalesforceError); !ok || err.ErrorCode != "NO_APPLICABLE_PROCESS" {
+		t.Errorf("unexpected error %v", results[1].Err())
+	}
+}
+
+func TestClient_ApproveWorkItem(t *testing.T) {
+	var actions []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Requests []ApprovalRequest `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		actions = append(actions, req.Requests[0].ActionType+" "+req.Requests[0].ContextID)
+		if req.Requests[0].ActionType == ApprovalActionReject {
+			w.Write([]byte(`[{"entityId":"0063000000D8cuIAAR","errors":[{"statusCode":"INVALID_CROSS_REFERENCE_KEY",
+				"message":"invalid cross reference id"}],"success":false}]`))
+			return
+		}
+		w.Write([]byte(`[{"entityId":"0063000000D8cuIAAR","instanceId":"04g3000000AbcdeAAA","instanceStatus":"Approved","success":true}]`))
+	})
+
+	result, err := client.ApproveWorkItem("04i3000000AbcdeAAA", "Looks good")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.InstanceStatus != "Approved" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if _, err = client.RejectWorkItem("04i3000000AbcdfAAA", "Too late"); err == nil {
+		t.Error("expected the rejection to fail")
+	}
+	if strings.Join(actions, ", ") != "Approve 04i3000000AbcdeAAA, Reject 04i3000000AbcdfAAA" {
+		t.Errorf("unexpected actions %v", actions)
+	}
+}
+
+func TestClient_PendingApprovals(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		if !strings.Contains(q, "FROM ProcessInstanceWorkitem WHERE ActorId = '0053000000AbcdeAAA' ORDER BY CreatedDate") {
+			t.Errorf("unexpected query %s", q)
+		}
+		w.Write([]byte(`{"totalSize":1,"done":true,"records":[{"attributes":{"type":"ProcessInstanceWorkitem"},
+			"Id":"04i3000000AbcdeAAA","ActorId":"0053000000AbcdeAAA","ProcessInstanceId":"04g3000000AbcdeAAA",
+			"ProcessInstance":{"attributes":{"type":"ProcessInstance"},"TargetObjectId":"0063000000D8cuIAAR","Status":"Pending"},
+			"CreatedDate":"2022-05-02T10:00:00.000+0000"}]}`))
+	})
+
+	items, err := client.PendingApprovals("0053000000AbcdeAAA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].ID != "04i3000000AbcdeAAA" || items[0].ProcessInstance.TargetObjectID != "0063000000D8cuIAAR" ||
+		items[0].CreatedDate.IsZero() {
+		t.Errorf("unexpected items %+v", items)
+	}
+}
+
+func TestClient_ApprovalProcesses(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/services/data/v54.0/process/approvals/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"approvals":{"Opportunity":[{"description":null,"id":"04aD00000008Py9IAE","name":"Discounts","object":"Opportunity","sortOrder":1}]}}`))
+	})
+
+	processes, err := client.ApprovalProcesses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(processes["Opportunity"]) != 1 || processes["Opportunity"][0].Name != "Discounts" {
+		t.Errorf("unexpected processes %+v", processes)
+	}
+}
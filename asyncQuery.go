@@ -0,0 +1,194 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Async SOQL job statuses.
+const (
+	AsyncQueryStatusNew      = "New"
+	AsyncQueryStatusRunning  = "Running"
+	AsyncQueryStatusComplete = "Complete"
+	AsyncQueryStatusFailed   = "Failed"
+	AsyncQueryStatusError    = "Error"
+	AsyncQueryStatusCanceled = "Canceled"
+)
+
+// AsyncQueryRequest describes an Async SOQL job: the query to run and how its results map onto the target object.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.bigobjects.meta/bigobjects/async_query_running_queries.htm
+type AsyncQueryRequest struct {
+	Query string `json:"query"`
+	// Operation is either "insert" (default) or "upsert".
+	Operation string `json:"operation,omitempty"`
+	// TargetObject receives the query results, typically a custom object or a Big Object.
+	TargetObject string `json:"targetObject"`
+	// TargetFieldMap maps the fields selected by the query to the fields of TargetObject.
+	TargetFieldMap map[string]string `json:"targetFieldMap"`
+	// TargetValueMap sets fields of TargetObject to constant values, e.g. {"$JOB_ID": "BackfillJobId__c"}.
+	TargetValueMap map[string]string `json:"targetValueMap,omitempty"`
+	// TargetExternalIdField is the external ID used to match records for upserts.
+	TargetExternalIdField string `json:"targetExternalIdField,omitempty"`
+}
+
+// AsyncQueryJob describes the state of an Async SOQL job.
+type AsyncQueryJob struct {
+	JobID                 string            `json:"jobId"`
+	Message               string            `json:"message"`
+	Operation             string            `json:"operation"`
+	Query                 string            `json:"query"`
+	Status                string            `json:"status"`
+	TargetObject          string            `json:"targetObject"`
+	TargetFieldMap        map[string]string `json:"targetFieldMap"`
+	TargetValueMap        map[string]string `json:"targetValueMap"`
+	TargetExternalIdField string            `json:"targetExternalIdField"`
+}
+
+// Done reports whether the job reached a final status.
+func (job *AsyncQueryJob) Done() bool {
+	switch job.Status {
+	case AsyncQueryStatusComplete, AsyncQueryStatusFailed, AsyncQueryStatusError, AsyncQueryStatusCanceled:
+		return true
+	}
+	return false
+}
+
+// SubmitAsyncQuery submits an Async SOQL job.
+func (client *Client) SubmitAsyncQuery(req AsyncQueryRequest) (*AsyncQueryJob, error) {
+	return client.SubmitAsyncQueryContext(context.Background(), req)
+}
+
+// SubmitAsyncQueryContext is like SubmitAsyncQuery, with the request bound to ctx.
+func (client *Client) SubmitAsyncQueryContext(ctx context.Context, req AsyncQueryRequest) (*AsyncQueryJob, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	if req.Query == "" || req.TargetObject == "" || len(req.TargetFieldMap) == 0 {
+		return nil, errors.New("query, target object and target field map are required")
+	}
+
+	reqData, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("async-queries/")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+
+	var job AsyncQueryJob
+	err = json.Unmarshal(data, &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// AsyncQuery returns the current state of an Async SOQL job.
+func (client *Client) AsyncQuery(jobID string) (*AsyncQueryJob, error) {
+	return client.AsyncQueryContext(context.Background(), jobID)
+}
+
+// AsyncQueryContext is like AsyncQuery, with the request bound to ctx.
+func (client *Client) AsyncQueryContext(ctx context.Context, jobID string) (*AsyncQueryJob, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("async-queries/" + jobID)
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var job AsyncQueryJob
+	err = json.Unmarshal(data, &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// AsyncQueries lists the Async SOQL jobs of the org.
+func (client *Client) AsyncQueries() ([]AsyncQueryJob, error) {
+	return client.AsyncQueriesContext(context.Background())
+}
+
+// AsyncQueriesContext is like AsyncQueries, with the request bound to ctx.
+func (client *Client) AsyncQueriesContext(ctx context.Context) ([]AsyncQueryJob, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("async-queries/")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var result struct {
+		AsyncQueries []AsyncQueryJob `json:"asyncQueries"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.AsyncQueries, nil
+}
+
+// CancelAsyncQuery cancels a running Async SOQL job.
+func (client *Client) CancelAsyncQuery(jobID string) error {
+	return client.CancelAsyncQueryContext(context.Background(), jobID)
+}
+
+// CancelAsyncQueryContext is like CancelAsyncQuery, with the request bound to ctx.
+func (client *Client) CancelAsyncQueryContext(ctx context.Context, jobID string) error {
+	if !client.isLoggedIn() {
+		return ErrAuthentication
+	}
+
+	u := client.makeURL("async-queries/" + jobID)
+	_, err := client.httpRequestContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		client.logError("HTTP DELETE request failed:", u)
+		return err
+	}
+	return nil
+}
+
+// WaitForAsyncQuery polls an Async SOQL job every interval until it reaches a final status or timeout elapses. The
+// last known state of the job is returned along with an error if the timeout elapsed first.
+func (client *Client) WaitForAsyncQuery(jobID string, interval, timeout time.Duration) (*AsyncQueryJob, error) {
+	return client.WaitForAsyncQueryContext(context.Background(), jobID, interval, timeout)
+}
+
+// WaitForAsyncQueryContext is like WaitForAsyncQuery, with the requests bound to ctx. It stops waiting once ctx is
+// done, returning the last known state of the job along with the error of ctx.
+func (client *Client) WaitForAsyncQueryContext(ctx context.Context, jobID string, interval, timeout time.Duration) (*AsyncQueryJob, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := client.AsyncQueryContext(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.Done() {
+			return job, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return job, errors.Errorf("async query %s still %s after %s", jobID, job.Status, timeout)
+		}
+		if err = sleepContext(ctx, interval); err != nil {
+			return job, err
+		}
+	}
+}
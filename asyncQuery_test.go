@@ -0,0 +1,77 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_AsyncQuery(t *testing.T) {
+	polls := 0
+	canceled := false
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		prefix := "/services/data/v" + DefaultAPIVersion + "/async-queries/"
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == prefix:
+			var req AsyncQueryRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(AsyncQueryJob{
+				JobID:          "08PD00000000001",
+				Query:          req.Query,
+				Status:         AsyncQueryStatusNew,
+				TargetObject:   req.TargetObject,
+				TargetFieldMap: req.TargetFieldMap,
+			})
+		case r.Method == http.MethodGet && r.URL.Path == prefix+"08PD00000000001":
+			polls++
+			status := AsyncQueryStatusRunning
+			if polls == 3 {
+				status = AsyncQueryStatusComplete
+			}
+			json.NewEncoder(w).Encode(AsyncQueryJob{JobID: "08PD00000000001", Status: status})
+		case r.Method == http.MethodDelete && r.URL.Path == prefix+"08PD00000000001":
+			canceled = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	job, err := client.SubmitAsyncQuery(AsyncQueryRequest{
+		Query:          "SELECT Account__c, EventTime__c FROM EventArchive__b",
+		TargetObject:   "EventSummary__c",
+		TargetFieldMap: map[string]string{"Account__c": "Account__c", "EventTime__c": "Time__c"},
+	})
+	if err != nil || job.JobID != "08PD00000000001" || job.Done() {
+		t.Fatalf("unexpected job %v, %v", job, err)
+	}
+
+	job, err = client.WaitForAsyncQuery(job.JobID, time.Millisecond, time.Second)
+	if err != nil || job.Status != AsyncQueryStatusComplete || polls != 3 {
+		t.Errorf("unexpected job %v after %d polls, %v", job, polls, err)
+	}
+
+	if err = client.CancelAsyncQuery(job.JobID); err != nil || !canceled {
+		t.Errorf("failed to cancel job, %v", err)
+	}
+
+	if _, err = client.SubmitAsyncQuery(AsyncQueryRequest{Query: "SELECT Id FROM Account"}); err == nil {
+		t.Error("expected error for missing target")
+	}
+}
+
+func TestClient_WaitForAsyncQueryContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		time.AfterFunc(10*time.Millisecond, cancel)
+		json.NewEncoder(w).Encode(AsyncQueryJob{JobID: "08PD00000000001", Status: AsyncQueryStatusRunning})
+	})
+
+	job, err := client.WaitForAsyncQueryContext(ctx, "08PD00000000001", time.Hour, 2*time.Hour)
+	if err != context.Canceled || job == nil || job.Status != AsyncQueryStatusRunning {
+		t.Fatalf("unexpected job %v, %v", job, err)
+	}
+}
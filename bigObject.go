@@ -0,0 +1,144 @@
+package simpleforce
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// BigObjectIndex lists the fields of a Big Object index in their defined order.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.bigobjects.meta/bigobjects/big_object_querying.htm
+type BigObjectIndex []string
+
+// bigObjectFilter is a filter on a single index field.
+type bigObjectFilter struct {
+	field     string
+	operators []string
+}
+
+// QueryBigObject validates qb against the restricted SOQL subset supported by Big Objects and runs it. Validation
+// errors wrap ErrInvalidQuery and explain which part of the index the filters violate.
+func (client *Client) QueryBigObject(qb *QueryBuilder, index BigObjectIndex) (*QueryResult, error) {
+	err := ValidateBigObjectQuery(qb, index)
+	if err != nil {
+		return nil, err
+	}
+	return client.RunQuery(qb)
+}
+
+// ValidateBigObjectQuery checks that qb only uses the SOQL subset supported by Big Objects: filters combined with AND
+// on a leading prefix of index, where every field but the last uses "=" and the last one may use =, <, >, <=, >= or
+// IN. OFFSET, ORDER BY, FOR clauses and raw conditions are not accepted.
+func ValidateBigObjectQuery(qb *QueryBuilder, index BigObjectIndex) error {
+	if !strings.HasSuffix(strings.ToLower(qb.object), "__b") {
+		return errors.Wrap(ErrInvalidQuery, qb.object+" is not a Big Object")
+	}
+	if len(index) == 0 {
+		return errors.Wrap(ErrInvalidQuery, "the index of "+qb.object+" is required")
+	}
+	if qb.offset > 0 {
+		return errors.Wrap(ErrInvalidQuery, "OFFSET is not supported on Big Objects")
+	}
+	if len(qb.orderBy) > 0 {
+		return errors.Wrap(ErrInvalidQuery, "ORDER BY is not supported on Big Objects, records are returned in index order")
+	}
+	if qb.forClause != "" {
+		return errors.Wrap(ErrInvalidQuery, qb.forClause+" is not supported on Big Objects")
+	}
+
+	filters := map[string]*bigObjectFilter{}
+	err := collectBigObjectFilters(And(qb.where...), filters)
+	if err != nil {
+		return err
+	}
+
+	// The filtered fields must form a prefix of the index.
+	prefix := 0
+	for prefix < len(index) && filters[strings.ToLower(index[prefix])] != nil {
+		prefix++
+	}
+	if prefix < len(filters) {
+		for _, filter := range filters {
+			if !indexContains(index[:prefix], filter.field) {
+				if !indexContains(index, filter.field) {
+					return errors.Wrap(ErrInvalidQuery, fmt.Sprintf("%s is not part of the index (%s)",
+						filter.field, strings.Join(index, ", ")))
+				}
+				return errors.Wrap(ErrInvalidQuery, fmt.Sprintf("filtering on %s requires filters on all preceding index fields (%s)",
+					filter.field, strings.Join(index, ", ")))
+			}
+		}
+	}
+
+	for i := 0; i < prefix; i++ {
+		filter := filters[strings.ToLower(index[i])]
+		if i == prefix-1 {
+			if len(filter.operators) > 1 {
+				for _, op := range filter.operators {
+					if op == "=" || op == "IN" {
+						return errors.Wrap(ErrInvalidQuery, "only range operators can be combined on "+filter.field)
+					}
+				}
+			}
+			continue
+		}
+		if len(filter.operators) != 1 || filter.operators[0] != "=" {
+			return errors.Wrap(ErrInvalidQuery, fmt.Sprintf("%s must use \"=\" as it is followed by %s in the index",
+				filter.field, index[prefix-1]))
+		}
+	}
+
+	return nil
+}
+
+// collectBigObjectFilters flattens cond into per-field filters, rejecting constructs Big Objects don't support.
+func collectBigObjectFilters(cond Condition, filters map[string]*bigObjectFilter) error {
+	record := func(field, operator string) {
+		key := strings.ToLower(field)
+		if filters[key] == nil {
+			filters[key] = &bigObjectFilter{field: field}
+		}
+		filters[key].operators = append(filters[key].operators, operator)
+	}
+
+	switch c := cond.(type) {
+	case junction:
+		if c.operator != "AND" {
+			return errors.Wrap(ErrInvalidQuery, c.operator+" is not supported on Big Objects")
+		}
+		for _, child := range c.conditions {
+			err := collectBigObjectFilters(child, filters)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case comparison:
+		switch c.operator {
+		case "=", "<", ">", "<=", ">=":
+			record(c.field, c.operator)
+			return nil
+		}
+		return errors.Wrap(ErrInvalidQuery, c.operator+" is not supported on Big Objects")
+	case membership:
+		if c.operator != "IN" {
+			return errors.Wrap(ErrInvalidQuery, c.operator+" is not supported on Big Objects")
+		}
+		record(c.field, c.operator)
+		return nil
+	case negation:
+		return errors.Wrap(ErrInvalidQuery, "NOT is not supported on Big Objects")
+	default:
+		return errors.Wrap(ErrInvalidQuery, "raw conditions can't be validated against a Big Object index, use typed conditions")
+	}
+}
+
+func indexContains(index BigObjectIndex, field string) bool {
+	for _, f := range index {
+		if strings.EqualFold(f, field) {
+			return true
+		}
+	}
+	return false
+}
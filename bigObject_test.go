@@ -0,0 +1,53 @@
+package simpleforce
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var archiveIndex = BigObjectIndex{"Account__c", "EventType__c", "EventTime__c"}
+
+func TestValidateBigObjectQuery(t *testing.T) {
+	valid := []*QueryBuilder{
+		Select("Id").From("EventArchive__b"),
+		Select("Id").From("EventArchive__b").Where(Eq("Account__c", "001")),
+		Select("Id").From("EventArchive__b").Where(In("Account__c", "001", "002")).Limit(100),
+		Select("Id").From("EventArchive__b").
+			Where(Eq("Account__c", "001")).
+			Where(And(Eq("EventType__c", "Login"), Gte("EventTime__c", time.Now()), Lt("EventTime__c", time.Now()))),
+	}
+	for _, qb := range valid {
+		if err := ValidateBigObjectQuery(qb, archiveIndex); err != nil {
+			t.Errorf("unexpected error for %s: %s", qb, err)
+		}
+	}
+
+	invalid := map[string]*QueryBuilder{
+		"not a Big Object": Select("Id").From("Account"),
+		"not part of":      Select("Id").From("EventArchive__b").Where(Eq("Name__c", "x")),
+		"preceding index":  Select("Id").From("EventArchive__b").Where(Eq("EventType__c", "Login")),
+		`must use "="`:     Select("Id").From("EventArchive__b").Where(Gt("Account__c", "001")).Where(Eq("EventType__c", "Login")),
+		"OR is not":        Select("Id").From("EventArchive__b").Where(Or(Eq("Account__c", "001"), Eq("Account__c", "002"))),
+		"LIKE is not":      Select("Id").From("EventArchive__b").Where(Like("Account__c", "001%")),
+		"raw conditions":   Select("Id").From("EventArchive__b").Where(Raw("Account__c = ?", "001")),
+		"OFFSET":           Select("Id").From("EventArchive__b").Offset(10),
+		"ORDER BY":         Select("Id").From("EventArchive__b").OrderBy("Account__c"),
+		"range operators":  Select("Id").From("EventArchive__b").Where(Eq("Account__c", "001")).Where(Eq("Account__c", "002")),
+		"NOT is not":       Select("Id").From("EventArchive__b").Where(Not(Eq("Account__c", "001"))),
+		"FOR VIEW is not":  Select("Id").From("EventArchive__b").ForView(),
+		"NOT IN is not":    Select("Id").From("EventArchive__b").Where(NotIn("Account__c", "001")),
+		"!= is not":        Select("Id").From("EventArchive__b").Where(Ne("Account__c", "001")),
+		"index of":         Select("Id").From("EventArchive__b").Where(Eq("Account__c", "001")).Limit(1),
+	}
+	for message, qb := range invalid {
+		index := archiveIndex
+		if message == "index of" {
+			index = nil
+		}
+		err := ValidateBigObjectQuery(qb, index)
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("expected error containing %q, got %v", message, err)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Blob streams the binary content of the blob field of the record id of sobject, e.g. the Body of an Attachment,
+// without buffering it in memory. The returned body must be closed by the caller.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/dome_sobject_blob_retrieve.htm
+func (client *Client) Blob(sobject, id, field string) (io.ReadCloser, error) {
+	return client.BlobContext(context.Background(), sobject, id, field)
+}
+
+// BlobContext is like Blob, with the request bound to ctx.
+func (client *Client) BlobContext(ctx context.Context, sobject, id, field string) (io.ReadCloser, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.sobjectURL(sobject + "/" + id + "/" + field)
+	resp, err := client.httpResponseContext(ctx, http.MethodGet, u, nil, http.Header{"Accept": {"*/*"}})
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// AttachmentBody streams the content of the Attachment id, see Blob.
+func (client *Client) AttachmentBody(id string) (io.ReadCloser, error) {
+	return client.Blob("Attachment", id, "Body")
+}
+
+// DocumentBody streams the content of the Document id, see Blob.
+func (client *Client) DocumentBody(id string) (io.ReadCloser, error) {
+	return client.Blob("Document", id, "Body")
+}
+
+// ContentVersionData streams the content of the ContentVersion id, see Blob. Files are usually known by the ID of
+// their ContentDocument, whose latest version is queried with
+// "SELECT Id FROM ContentVersion WHERE ContentDocumentId = ? AND IsLatest = true".
+func (client *Client) ContentVersionData(id string) (io.ReadCloser, error) {
+	return client.Blob("ContentVersion", id, "VersionData")
+}
+
+// UploadFile creates a ContentVersion titled title with the content of r, and returns its ID. pathOnClient is the
+// name of the file, whose extension determines the file type, e.g. "report.pdf". If linkedEntityID isn't empty, the
+// file is shared with that record, e.g. an Account, through a ContentDocumentLink created along with the file.
+func (client *Client) UploadFile(title, pathOnClient string, r io.Reader, linkedEntityID string) (string, error) {
+	return client.UploadFileContext(context.Background(), title, pathOnClient, r, linkedEntityID)
+}
+
+// UploadFileContext is like UploadFile, with the request bound to ctx.
+func (client *Client) UploadFileContext(ctx context.Context, title, pathOnClient string, r io.Reader, linkedEntityID string) (string, error) {
+	fields := map[string]interface{}{
+		"Title":        title,
+		"PathOnClient": pathOnClient,
+	}
+	if linkedEntityID != "" {
+		fields["FirstPublishLocationId"] = linkedEntityID
+	}
+	return client.InsertBlobContext(ctx, "ContentVersion", fields, "VersionData", filepath.Base(pathOnClient), r)
+}
+
+// UploadFileFromPath uploads the file at path with UploadFile, titled with its name without extension.
+func (client *Client) UploadFileFromPath(path, linkedEntityID string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	name := filepath.Base(path)
+	return client.UploadFile(strings.TrimSuffix(name, filepath.Ext(name)), name, f, linkedEntityID)
+}
+
+// InsertBlob creates a record of sobject with fields and the content of r in its blob field, e.g. the Body of a
+// Document, and returns its ID. The content is streamed in a multipart request, so it can be larger than the limit
+// of base64 encoded content in JSON requests, and is never buffered in memory. filename is sent along with the
+// content. As r can't be read again, the request isn't retried.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/dome_sobject_insert_update_blob.htm
+func (client *Client) InsertBlob(sobject string, fields map[string]interface{}, field, filename string, r io.Reader) (string, error) {
+	return client.InsertBlobContext(context.Background(), sobject, fields, field, filename, r)
+}
+
+// InsertBlobContext is like InsertBlob, with the request bound to ctx.
+func (client *Client) InsertBlobContext(ctx context.Context, sobject string, fields map[string]interface{}, field, filename string, r io.Reader) (string, error) {
+	if !client.isLoggedIn() {
+		return "", ErrAuthentication
+	}
+	entity, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	entityPart := "entity_" + strings.ToLower(sobject)
+	if sobject == "ContentVersion" {
+		entityPart = "entity_content"
+	}
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipart(mw, []MultipartPart{
+			{Name: entityPart, ContentType: "application/json", Body: bytes.NewReader(entity)},
+			{Name: field, FileName: filename, ContentType: "application/octet-stream", Body: r},
+		}))
+	}()
+
+	u := client.sobjectURL(sobject + "/")
+	resp, err := client.httpResponseContext(ctx, http.MethodPost, u, pr, http.Header{"Content-Type": {mw.FormDataContentType()}})
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var result SaveResult
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return "", err
+	}
+	err = result.Err()
+	if err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// CreateAttachment attaches the content of r to the record parentID as an Attachment named name, and returns its ID.
+func (client *Client) CreateAttachment(parentID, name string, r io.Reader) (string, error) {
+	fields := map[string]interface{}{
+		"ParentId": parentID,
+		"Name":     name,
+	}
+	return client.InsertBlob("Attachment", fields, "Body", name, r)
+}
+
+// CreateDocument creates a Document named name in the folder folderID with the content of r, and returns its ID.
+// The user's personal folder is the ID of the user.
+func (client *Client) CreateDocument(folderID, name string, r io.Reader) (string, error) {
+	fields := map[string]interface{}{
+		"FolderId": folderID,
+		"Name":     name,
+	}
+	return client.InsertBlob("Document", fields, "Body", name, r)
+}
@@ -0,0 +1,160 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_Blob(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v54.0/sobjects/Attachment/00P000000000001AAA/Body":
+			w.Write([]byte("attachment"))
+		case "/services/data/v54.0/sobjects/Document/015000000000001AAA/Body":
+			w.Write([]byte("document"))
+		case "/services/data/v54.0/sobjects/ContentVersion/068000000000001AAA/VersionData":
+			w.Write([]byte("version"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]`))
+		}
+	})
+
+	cases := map[string]func() (string, error){
+		"attachment": func() (string, error) { return readBlob(client.AttachmentBody("00P000000000001AAA")) },
+		"document":   func() (string, error) { return readBlob(client.DocumentBody("015000000000001AAA")) },
+		"version":    func() (string, error) { return readBlob(client.ContentVersionData("068000000000001AAA")) },
+	}
+	for expected, download := range cases {
+		content, err := download()
+		if err != nil || content != expected {
+			t.Errorf("unexpected content %q, %v", content, err)
+		}
+	}
+
+	_, err := client.AttachmentBody("00P000000000002AAA")
+	var sfErr SalesforceError
+	if !errors.As(err, &sfErr) || sfErr.ErrorCode != "NOT_FOUND" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "file")
+	if err = client.DownloadFile("068000000000001AAA", path); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := ioutil.ReadFile(path); string(data) != "version" {
+		t.Errorf("unexpected file content %q", data)
+	}
+	if err = client.DownloadAttachment("00P000000000002AAA", path); err == nil {
+		t.Error("expected error for missing attachment")
+	}
+}
+
+func TestClient_UploadFile(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/sobjects/ContentVersion/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		part, err := reader.NextPart()
+		if err != nil || part.FormName() != "entity_content" || part.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected entity part %v, %v", part, err)
+			return
+		}
+		var fields map[string]string
+		json.NewDecoder(part).Decode(&fields)
+		expected := map[string]string{"Title": "report", "PathOnClient": "report.pdf", "FirstPublishLocationId": "001D000000IqhSLIAZ"}
+		if !reflect.DeepEqual(fields, expected) {
+			t.Errorf("unexpected fields %v", fields)
+		}
+
+		part, err = reader.NextPart()
+		if err != nil || part.FormName() != "VersionData" || part.FileName() != "report.pdf" {
+			t.Errorf("unexpected binary part %v, %v", part, err)
+			return
+		}
+		content, _ := ioutil.ReadAll(part)
+		if string(content) != "%PDF-1.4" {
+			t.Errorf("unexpected content %q", content)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"068000000000001AAA","success":true,"errors":[]}`))
+	})
+
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	if err := ioutil.WriteFile(path, []byte("%PDF-1.4"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	id, err := client.UploadFileFromPath(path, "001D000000IqhSLIAZ")
+	if err != nil || id != "068000000000001AAA" {
+		t.Errorf("unexpected result %q, %v", id, err)
+	}
+}
+
+func TestClient_CreateAttachmentAndDocument(t *testing.T) {
+	var parts []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			content, _ := ioutil.ReadAll(part)
+			parts = append(parts, r.URL.Path+" "+part.FormName()+" "+part.FileName()+" "+string(content))
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"00P000000000001AAA","success":true,"errors":[]}`))
+	})
+
+	id, err := client.CreateAttachment("001D000000IqhSLIAZ", "notes.txt", strings.NewReader("notes"))
+	if err != nil || id != "00P000000000001AAA" {
+		t.Errorf("unexpected result %q, %v", id, err)
+	}
+	_, err = client.CreateDocument("005D0000001KyEIIA0", `logo "v2".png`, strings.NewReader("png"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := []string{
+		`/services/data/v54.0/sobjects/Attachment/ entity_attachment  {"Name":"notes.txt","ParentId":"001D000000IqhSLIAZ"}`,
+		"/services/data/v54.0/sobjects/Attachment/ Body notes.txt notes",
+		`/services/data/v54.0/sobjects/Document/ entity_document  {"FolderId":"005D0000001KyEIIA0","Name":"logo \"v2\".png"}`,
+		`/services/data/v54.0/sobjects/Document/ Body logo "v2".png png`,
+	}
+	if !reflect.DeepEqual(parts, expected) {
+		t.Errorf("unexpected parts:\n%s", strings.Join(parts, "\n"))
+	}
+}
+
+// readBlob reads and closes a blob.
+func readBlob(body io.ReadCloser, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	return string(data), err
+}
@@ -0,0 +1,295 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Bulk API 2.0 operations.
+const (
+	BulkOperationInsert     = "insert"
+	BulkOperationUpdate     = "update"
+	BulkOperationUpsert     = "upsert"
+	BulkOperationDelete     = "delete"
+	BulkOperationHardDelete = "hardDelete"
+)
+
+// Bulk API 2.0 job states.
+const (
+	BulkJobStateOpen           = "Open"
+	BulkJobStateUploadComplete = "UploadComplete"
+	BulkJobStateInProgress     = "InProgress"
+	BulkJobStateJobComplete    = "JobComplete"
+	BulkJobStateFailed         = "Failed"
+	BulkJobStateAborted        = "Aborted"
+)
+
+// BulkJobRequest describes a Bulk API 2.0 ingest job: the records of Object are loaded with Operation from CSV data.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/create_job.htm
+type BulkJobRequest struct {
+	Object    string `json:"object"`
+	Operation string `json:"operation"`
+	// ExternalIDFieldName is the external ID used to match records, required for upserts.
+	ExternalIDFieldName string `json:"externalIdFieldName,omitempty"`
+	// ColumnDelimiter is one of BACKQUOTE, CARET, COMMA (default), PIPE, SEMICOLON or TAB.
+	ColumnDelimiter string `json:"columnDelimiter,omitempty"`
+	// LineEnding is either LF (default) or CRLF.
+	LineEnding string `json:"lineEnding,omitempty"`
+}
+
+// BulkJob describes the state of a Bulk API 2.0 job.
+type BulkJob struct {
+	ID                     string  `json:"id"`
+	Object                 string  `json:"object"`
+	Operation              string  `json:"operation"`
+	State                  string  `json:"state"`
+	ExternalIDFieldName    string  `json:"externalIdFieldName"`
+	ContentType            string  `json:"contentType"`
+	ColumnDelimiter        string  `json:"columnDelimiter"`
+	LineEnding             string  `json:"lineEnding"`
+	APIVersion             float64 `json:"apiVersion"`
+	CreatedByID            string  `json:"createdById"`
+	CreatedDate            string  `json:"createdDate"`
+	SystemModstamp         string  `json:"systemModstamp"`
+	NumberRecordsProcessed int     `json:"numberRecordsProcessed"`
+	NumberRecordsFailed    int     `json:"numberRecordsFailed"`
+	Retries                int     `json:"retries"`
+	TotalProcessingTime    int64   `json:"totalProcessingTime"`
+	ErrorMessage           string  `json:"errorMessage"`
+}
+
+// Done reports whether the job reached a final state.
+func (job *BulkJob) Done() bool {
+	switch job.State {
+	case BulkJobStateJobComplete, BulkJobStateFailed, BulkJobStateAborted:
+		return true
+	}
+	return false
+}
+
+// CreateBulkJob creates a Bulk API 2.0 ingest job, whose data is then uploaded with UploadJobData.
+func (client *Client) CreateBulkJob(req BulkJobRequest) (*BulkJob, error) {
+	return client.CreateBulkJobContext(context.Background(), req)
+}
+
+// CreateBulkJobContext is like CreateBulkJob, with the request bound to ctx.
+func (client *Client) CreateBulkJobContext(ctx context.Context, req BulkJobRequest) (*BulkJob, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	if req.Object == "" || req.Operation == "" {
+		return nil, errors.New("object and operation are required")
+	}
+	if req.Operation == BulkOperationUpsert && req.ExternalIDFieldName == "" {
+		return nil, errors.New("external ID field is required for upserts")
+	}
+
+	reqData, err := json.Marshal(struct {
+		BulkJobRequest
+		ContentType string `json:"contentType"`
+	}{req, "CSV"})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("jobs/ingest/")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+	return decodeBulkJob(data)
+}
+
+// UploadJobData uploads the CSV data of an open ingest job. The first line of csv holds the field names, e.g.
+// "Name,Industry", or "Id" for deletions. Up to 150 MB can be uploaded per job.
+func (client *Client) UploadJobData(jobID string, csv io.Reader) error {
+	return client.UploadJobDataContext(context.Background(), jobID, csv)
+}
+
+// UploadJobDataContext is like UploadJobData, with the request bound to ctx.
+func (client *Client) UploadJobDataContext(ctx context.Context, jobID string, csv io.Reader) error {
+	if !client.isLoggedIn() {
+		return ErrAuthentication
+	}
+
+	u := client.makeURL("jobs/ingest/" + jobID + "/batches")
+	resp, err := client.httpResponseContext(ctx, http.MethodPut, u, csv, http.Header{"Content-Type": {"text/csv"}})
+	if err != nil {
+		client.logError("HTTP PUT request failed:", u)
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// CloseJob marks the data of an ingest job as uploaded, which queues the job for processing.
+func (client *Client) CloseJob(jobID string) (*BulkJob, error) {
+	return client.CloseJobContext(context.Background(), jobID)
+}
+
+// CloseJobContext is like CloseJob, with the request bound to ctx.
+func (client *Client) CloseJobContext(ctx context.Context, jobID string) (*BulkJob, error) {
+	return client.setBulkJobState(ctx, jobID, BulkJobStateUploadComplete)
+}
+
+// AbortJob aborts an ingest job. The records already processed are not rolled back.
+func (client *Client) AbortJob(jobID string) (*BulkJob, error) {
+	return client.AbortJobContext(context.Background(), jobID)
+}
+
+// AbortJobContext is like AbortJob, with the request bound to ctx.
+func (client *Client) AbortJobContext(ctx context.Context, jobID string) (*BulkJob, error) {
+	return client.setBulkJobState(ctx, jobID, BulkJobStateAborted)
+}
+
+// setBulkJobState changes the state of an ingest job.
+func (client *Client) setBulkJobState(ctx context.Context, jobID, state string) (*BulkJob, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	reqData, err := json.Marshal(map[string]string{"state": state})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("jobs/ingest/" + jobID + "/")
+	data, err := client.httpRequestContext(ctx, http.MethodPatch, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP PATCH request failed:", u)
+		return nil, err
+	}
+	return decodeBulkJob(data)
+}
+
+// GetJobStatus returns the current state of an ingest job.
+func (client *Client) GetJobStatus(jobID string) (*BulkJob, error) {
+	return client.GetJobStatusContext(context.Background(), jobID)
+}
+
+// GetJobStatusContext is like GetJobStatus, with the request bound to ctx.
+func (client *Client) GetJobStatusContext(ctx context.Context, jobID string) (*BulkJob, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("jobs/ingest/" + jobID + "/")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+	return decodeBulkJob(data)
+}
+
+// DeleteJob deletes an ingest job in a final state, along with its data and results.
+func (client *Client) DeleteJob(jobID string) error {
+	return client.DeleteJobContext(context.Background(), jobID)
+}
+
+// DeleteJobContext is like DeleteJob, with the request bound to ctx.
+func (client *Client) DeleteJobContext(ctx context.Context, jobID string) error {
+	if !client.isLoggedIn() {
+		return ErrAuthentication
+	}
+
+	u := client.makeURL("jobs/ingest/" + jobID + "/")
+	_, err := client.httpRequestContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		client.logError("HTTP DELETE request failed:", u)
+		return err
+	}
+	return nil
+}
+
+// WaitForBulkJob polls an ingest job every interval until it reaches a final state or timeout elapses. The last
+// known state of the job is returned along with an error if the timeout elapsed first.
+func (client *Client) WaitForBulkJob(jobID string, interval, timeout time.Duration) (*BulkJob, error) {
+	return client.WaitForBulkJobContext(context.Background(), jobID, interval, timeout)
+}
+
+// WaitForBulkJobContext is like WaitForBulkJob, with the requests bound to ctx. It stops waiting once ctx is done,
+// returning the last known state of the job along with the error of ctx.
+func (client *Client) WaitForBulkJobContext(ctx context.Context, jobID string, interval, timeout time.Duration) (*BulkJob, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := client.GetJobStatusContext(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.Done() {
+			return job, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return job, errors.Errorf("bulk job %s still %s after %s", jobID, job.State, timeout)
+		}
+		if err = sleepContext(ctx, interval); err != nil {
+			return job, err
+		}
+	}
+}
+
+// GetSuccessfulResults streams the records processed successfully by a completed ingest job, as CSV with the
+// sf__Id and sf__Created columns followed by the uploaded fields. The caller must close the returned reader.
+func (client *Client) GetSuccessfulResults(jobID string) (io.ReadCloser, error) {
+	return client.GetSuccessfulResultsContext(context.Background(), jobID)
+}
+
+// GetSuccessfulResultsContext is like GetSuccessfulResults, with the request bound to ctx.
+func (client *Client) GetSuccessfulResultsContext(ctx context.Context, jobID string) (io.ReadCloser, error) {
+	return client.bulkJobResults(ctx, jobID, "successfulResults")
+}
+
+// GetFailedResults streams the records which failed, as CSV with the sf__Id and sf__Error columns followed by the
+// uploaded fields. The caller must close the returned reader.
+func (client *Client) GetFailedResults(jobID string) (io.ReadCloser, error) {
+	return client.GetFailedResultsContext(context.Background(), jobID)
+}
+
+// GetFailedResultsContext is like GetFailedResults, with the request bound to ctx.
+func (client *Client) GetFailedResultsContext(ctx context.Context, jobID string) (io.ReadCloser, error) {
+	return client.bulkJobResults(ctx, jobID, "failedResults")
+}
+
+// GetUnprocessedRecords streams the records which weren't processed, e.g. because the job was aborted, as uploaded.
+// The caller must close the returned reader.
+func (client *Client) GetUnprocessedRecords(jobID string) (io.ReadCloser, error) {
+	return client.GetUnprocessedRecordsContext(context.Background(), jobID)
+}
+
+// GetUnprocessedRecordsContext is like GetUnprocessedRecords, with the request bound to ctx.
+func (client *Client) GetUnprocessedRecordsContext(ctx context.Context, jobID string) (io.ReadCloser, error) {
+	return client.bulkJobResults(ctx, jobID, "unprocessedrecords")
+}
+
+// bulkJobResults streams a CSV result of an ingest job.
+func (client *Client) bulkJobResults(ctx context.Context, jobID, result string) (io.ReadCloser, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("jobs/ingest/" + jobID + "/" + result + "/")
+	resp, err := client.httpResponseContext(ctx, http.MethodGet, u, nil, http.Header{"Accept": {"text/csv"}})
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// decodeBulkJob decodes the state of a Bulk API 2.0 job.
+func decodeBulkJob(data []byte) (*BulkJob, error) {
+	var job BulkJob
+	err := json.Unmarshal(data, &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -0,0 +1,242 @@
+package simpleforce
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Bulk API 2.0 query operations.
+const (
+	BulkOperationQuery    = "query"
+	BulkOperationQueryAll = "queryAll"
+)
+
+// CreateBulkQueryJob creates a Bulk API 2.0 query job running soql, whose results are retrieved with
+// GetQueryJobResults once the job completed. Use BulkOperationQueryAll as operation to include deleted and archived
+// records, BulkOperationQuery otherwise.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/query_create_job.htm
+func (client *Client) CreateBulkQueryJob(soql, operation string) (*BulkJob, error) {
+	return client.CreateBulkQueryJobContext(context.Background(), soql, operation)
+}
+
+// CreateBulkQueryJobContext is like CreateBulkQueryJob, with the request bound to ctx.
+func (client *Client) CreateBulkQueryJobContext(ctx context.Context, soql, operation string) (*BulkJob, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	if operation == "" {
+		operation = BulkOperationQuery
+	}
+
+	reqData, err := json.Marshal(map[string]string{"operation": operation, "query": soql})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("jobs/query")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+	return decodeBulkJob(data)
+}
+
+// GetQueryJobStatus returns the current state of a query job.
+func (client *Client) GetQueryJobStatus(jobID string) (*BulkJob, error) {
+	return client.GetQueryJobStatusContext(context.Background(), jobID)
+}
+
+// GetQueryJobStatusContext is like GetQueryJobStatus, with the request bound to ctx.
+func (client *Client) GetQueryJobStatusContext(ctx context.Context, jobID string) (*BulkJob, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("jobs/query/" + jobID)
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+	return decodeBulkJob(data)
+}
+
+// AbortQueryJob aborts a running query job.
+func (client *Client) AbortQueryJob(jobID string) (*BulkJob, error) {
+	return client.AbortQueryJobContext(context.Background(), jobID)
+}
+
+// AbortQueryJobContext is like AbortQueryJob, with the request bound to ctx.
+func (client *Client) AbortQueryJobContext(ctx context.Context, jobID string) (*BulkJob, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	reqData, err := json.Marshal(map[string]string{"state": BulkJobStateAborted})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("jobs/query/" + jobID)
+	data, err := client.httpRequestContext(ctx, http.MethodPatch, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP PATCH request failed:", u)
+		return nil, err
+	}
+	return decodeBulkJob(data)
+}
+
+// DeleteQueryJob deletes a query job in a final state, along with its results.
+func (client *Client) DeleteQueryJob(jobID string) error {
+	return client.DeleteQueryJobContext(context.Background(), jobID)
+}
+
+// DeleteQueryJobContext is like DeleteQueryJob, with the request bound to ctx.
+func (client *Client) DeleteQueryJobContext(ctx context.Context, jobID string) error {
+	if !client.isLoggedIn() {
+		return ErrAuthentication
+	}
+
+	u := client.makeURL("jobs/query/" + jobID)
+	_, err := client.httpRequestContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		client.logError("HTTP DELETE request failed:", u)
+		return err
+	}
+	return nil
+}
+
+// WaitForQueryJob polls a query job every interval until it reaches a final state or timeout elapses. The last known
+// state of the job is returned along with an error if the timeout elapsed first.
+func (client *Client) WaitForQueryJob(jobID string, interval, timeout time.Duration) (*BulkJob, error) {
+	return client.WaitForQueryJobContext(context.Background(), jobID, interval, timeout)
+}
+
+// WaitForQueryJobContext is like WaitForQueryJob, with the requests bound to ctx. It stops waiting once ctx is done,
+// returning the last known state of the job along with the error of ctx.
+func (client *Client) WaitForQueryJobContext(ctx context.Context, jobID string, interval, timeout time.Duration) (*BulkJob, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := client.GetQueryJobStatusContext(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.Done() {
+			return job, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return job, errors.Errorf("query job %s still %s after %s", jobID, job.State, timeout)
+		}
+		if err = sleepContext(ctx, interval); err != nil {
+			return job, err
+		}
+	}
+}
+
+// GetQueryJobResults streams the results of a completed query job as a single CSV document, whose first line holds
+// the field names. Salesforce splits large results in chunks of up to maxRecords records, which are requested one
+// after the other while the reader is read, following the Sforce-Locator header; maxRecords <= 0 lets Salesforce
+// choose. The caller must close the returned reader.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/query_get_job_results.htm
+func (client *Client) GetQueryJobResults(jobID string, maxRecords int) (io.ReadCloser, error) {
+	return client.GetQueryJobResultsContext(context.Background(), jobID, maxRecords)
+}
+
+// GetQueryJobResultsContext is like GetQueryJobResults, with the requests of the chunks bound to ctx.
+func (client *Client) GetQueryJobResultsContext(ctx context.Context, jobID string, maxRecords int) (io.ReadCloser, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	results := &queryJobResults{ctx: ctx, client: client, jobID: jobID, maxRecords: maxRecords}
+	err := results.next("")
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// queryJobResults reads the chunks of the results of a query job in sequence.
+type queryJobResults struct {
+	ctx        context.Context
+	client     *Client
+	jobID      string
+	maxRecords int
+
+	body    io.ReadCloser
+	reader  *bufio.Reader
+	locator string
+}
+
+// next requests the chunk of results identified by locator, the first one if empty.
+func (r *queryJobResults) next(locator string) error {
+	params := url.Values{}
+	if locator != "" {
+		params.Set("locator", locator)
+	}
+	if r.maxRecords > 0 {
+		params.Set("maxRecords", strconv.Itoa(r.maxRecords))
+	}
+	u := r.client.makeURL("jobs/query/" + r.jobID + "/results")
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+
+	resp, err := r.client.httpResponseContext(r.ctx, http.MethodGet, u, nil, http.Header{"Accept": {"text/csv"}})
+	if err != nil {
+		r.client.logError("HTTP GET request failed:", u)
+		return err
+	}
+	r.body = resp.Body
+	r.reader = bufio.NewReader(resp.Body)
+	r.locator = resp.Header.Get("Sforce-Locator")
+	if r.locator == "null" {
+		r.locator = ""
+	}
+
+	if locator != "" {
+		// Every chunk repeats the header line, which was already read with the first one.
+		_, err = r.reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read implements io.Reader.
+func (r *queryJobResults) Read(p []byte) (int, error) {
+	for {
+		n, err := r.reader.Read(p)
+		if err != io.EOF || r.locator == "" {
+			return n, err
+		}
+		r.body.Close()
+		err = r.next(r.locator)
+		if err != nil {
+			r.locator = ""
+			r.reader = bufio.NewReader(bytes.NewReader(nil))
+			r.body = ioutil.NopCloser(r.reader)
+			return n, err
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// Close implements io.Closer.
+func (r *queryJobResults) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,69 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_BulkQueryJob(t *testing.T) {
+	polls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /services/data/v54.0/jobs/query":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			if req["operation"] != "query" || req["query"] != "SELECT Id, Name FROM Account" {
+				t.Errorf("unexpected job request %v", req)
+			}
+			w.Write([]byte(`{"id":"7505e00000QrStU","operation":"query","object":"Account","state":"UploadComplete"}`))
+		case "GET /services/data/v54.0/jobs/query/7505e00000QrStU":
+			polls++
+			if polls == 1 {
+				w.Write([]byte(`{"id":"7505e00000QrStU","state":"InProgress"}`))
+				return
+			}
+			w.Write([]byte(`{"id":"7505e00000QrStU","state":"JobComplete","numberRecordsProcessed":3}`))
+		case "GET /services/data/v54.0/jobs/query/7505e00000QrStU/results":
+			if r.URL.Query().Get("maxRecords") != "2" {
+				t.Errorf("unexpected maxRecords %s", r.URL.Query().Get("maxRecords"))
+			}
+			switch r.URL.Query().Get("locator") {
+			case "":
+				w.Header().Set("Sforce-Locator", "MjAwMDAw")
+				w.Write([]byte("\"Id\",\"Name\"\n\"1\",\"Acme\"\n\"2\",\"Globex\"\n"))
+			case "MjAwMDAw":
+				w.Header().Set("Sforce-Locator", "null")
+				w.Write([]byte("\"Id\",\"Name\"\n\"3\",\"Initech\"\n"))
+			default:
+				t.Errorf("unexpected locator %s", r.URL.Query().Get("locator"))
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	job, err := client.CreateBulkQueryJob("SELECT Id, Name FROM Account", "")
+	if err != nil || job.ID != "7505e00000QrStU" {
+		t.Fatalf("unexpected job %+v, %v", job, err)
+	}
+	job, err = client.WaitForQueryJob(job.ID, time.Millisecond, time.Second)
+	if err != nil || job.State != BulkJobStateJobComplete || job.NumberRecordsProcessed != 3 {
+		t.Fatalf("unexpected completed job %+v, %v", job, err)
+	}
+
+	results, err := client.GetQueryJobResults(job.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer results.Close()
+	data, err := ioutil.ReadAll(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "\"Id\",\"Name\"\n\"1\",\"Acme\"\n\"2\",\"Globex\"\n\"3\",\"Initech\"\n"; string(data) != expected {
+		t.Errorf("unexpected results %q", data)
+	}
+}
@@ -0,0 +1,119 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_BulkJob(t *testing.T) {
+	state := BulkJobStateOpen
+	var uploaded string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/services/data/v54.0/jobs/ingest/")
+		switch {
+		case r.Method == http.MethodPost && path == "":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			if req["object"] != "Account" || req["operation"] != "upsert" || req["externalIdFieldName"] != "Ref__c" ||
+				req["contentType"] != "CSV" {
+				t.Errorf("unexpected job request %v", req)
+			}
+			w.Write([]byte(`{"id":"7505e00000AbCdE","object":"Account","operation":"upsert","state":"Open","contentType":"CSV","apiVersion":54.0}`))
+		case r.Method == http.MethodPut && path == "7505e00000AbCdE/batches":
+			if r.Header.Get("Content-Type") != "text/csv" {
+				t.Errorf("unexpected content type %s", r.Header.Get("Content-Type"))
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			uploaded = string(body)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPatch && path == "7505e00000AbCdE/":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			state = req["state"]
+			w.Write([]byte(`{"id":"7505e00000AbCdE","state":"` + state + `"}`))
+		case r.Method == http.MethodGet && path == "7505e00000AbCdE/":
+			if state == BulkJobStateUploadComplete {
+				state = BulkJobStateJobComplete
+				w.Write([]byte(`{"id":"7505e00000AbCdE","state":"InProgress"}`))
+				return
+			}
+			w.Write([]byte(`{"id":"7505e00000AbCdE","state":"` + state + `","numberRecordsProcessed":2,"numberRecordsFailed":1}`))
+		case r.Method == http.MethodGet && path == "7505e00000AbCdE/successfulResults/":
+			if r.Header.Get("Accept") != "text/csv" {
+				t.Errorf("unexpected accept %s", r.Header.Get("Accept"))
+			}
+			w.Write([]byte("\"sf__Id\",\"sf__Created\",Name,Ref__c\n\"0013000000Db2wKAAR\",\"true\",Acme,A1\n"))
+		case r.Method == http.MethodGet && path == "7505e00000AbCdE/failedResults/":
+			w.Write([]byte("\"sf__Id\",\"sf__Error\",Name,Ref__c\n\"\",\"REQUIRED_FIELD_MISSING:Required fields are missing: [Name]:Name --\",,A2\n"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	job, err := client.CreateBulkJob(BulkJobRequest{Object: "Account", Operation: BulkOperationUpsert, ExternalIDFieldName: "Ref__c"})
+	if err != nil || job.ID != "7505e00000AbCdE" || job.State != BulkJobStateOpen || job.APIVersion != 54 {
+		t.Fatalf("unexpected job %+v, %v", job, err)
+	}
+	if err := client.UploadJobData(job.ID, strings.NewReader("Name,Ref__c\nAcme,A1\n,A2\n")); err != nil {
+		t.Fatal(err)
+	}
+	if uploaded != "Name,Ref__c\nAcme,A1\n,A2\n" {
+		t.Errorf("unexpected upload %q", uploaded)
+	}
+	if job, err = client.CloseJob(job.ID); err != nil || job.State != BulkJobStateUploadComplete {
+		t.Fatalf("unexpected closed job %+v, %v", job, err)
+	}
+
+	job, err = client.WaitForBulkJob(job.ID, time.Millisecond, time.Second)
+	if err != nil || !job.Done() || job.NumberRecordsProcessed != 2 || job.NumberRecordsFailed != 1 {
+		t.Fatalf("unexpected completed job %+v, %v", job, err)
+	}
+
+	results, err := client.GetSuccessfulResults(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := ioutil.ReadAll(results)
+	results.Close()
+	if !strings.Contains(string(data), "0013000000Db2wKAAR") {
+		t.Errorf("unexpected successful results %s", data)
+	}
+	results, err = client.GetFailedResults(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ = ioutil.ReadAll(results)
+	results.Close()
+	if !strings.Contains(string(data), "REQUIRED_FIELD_MISSING") {
+		t.Errorf("unexpected failed results %s", data)
+	}
+
+	if _, err := client.CreateBulkJob(BulkJobRequest{Object: "Account", Operation: BulkOperationUpsert}); err == nil {
+		t.Error("expected an error for an upsert without external ID field")
+	}
+}
+
+func TestClient_WaitForBulkJobContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Cancel while the job is still running, once its status is received: the wait must not last for the
+		// polling interval.
+		time.AfterFunc(10*time.Millisecond, cancel)
+		w.Write([]byte(`{"id":"7505e00000AbCdE","state":"InProgress"}`))
+	})
+
+	start := time.Now()
+	job, err := client.WaitForBulkJobContext(ctx, "7505e00000AbCdE", time.Hour, 2*time.Hour)
+	if err != context.Canceled || job == nil || job.State != BulkJobStateInProgress {
+		t.Fatalf("unexpected job %+v, %v", job, err)
+	}
+	if time.Since(start) > time.Minute {
+		t.Errorf("the wait lasted %s after the cancellation", time.Since(start))
+	}
+}
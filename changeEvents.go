@@ -0,0 +1,133 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Change types of change events. Gap events notify of changes which couldn't be captured, e.g. made by a bulk job
+// or in the database directly: the records have to be read again to know their current state.
+const (
+	ChangeTypeCreate      = "CREATE"
+	ChangeTypeUpdate      = "UPDATE"
+	ChangeTypeDelete      = "DELETE"
+	ChangeTypeUndelete    = "UNDELETE"
+	ChangeTypeGapCreate   = "GAP_CREATE"
+	ChangeTypeGapUpdate   = "GAP_UPDATE"
+	ChangeTypeGapDelete   = "GAP_DELETE"
+	ChangeTypeGapUndelete = "GAP_UNDELETE"
+	ChangeTypeGapOverflow = "GAP_OVERFLOW"
+)
+
+const (
+	// changeEventPrefix is the prefix of the channels of change events.
+	changeEventPrefix = "/data/"
+	// AllChangeEvents is the channel name receiving the change events of all the objects selected for Change Data
+	// Capture.
+	AllChangeEvents = "ChangeEvents"
+
+	changeEventHeaderKey = "ChangeEventHeader"
+)
+
+// ChangeEventHeader describes the change of a change event.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.change_data_capture.meta/change_data_capture/cdc_event_fields_header.htm
+type ChangeEventHeader struct {
+	EntityName string `json:"entityName"`
+	// RecordIDs lists the records which changed the same way in a single transaction. It may hold a wildcard ID, of
+	// which only the 3 characters prefix is significant, for gap overflow events.
+	RecordIDs    []string `json:"recordIds"`
+	ChangeType   string   `json:"changeType"`
+	ChangeOrigin string   `json:"changeOrigin"`
+	// TransactionKey identifies the transaction of the change, and SequenceNumber orders the changes of a transaction.
+	TransactionKey string `json:"transactionKey"`
+	SequenceNumber int    `json:"sequenceNumber"`
+	// CommitTimestamp is the time of the transaction in milliseconds since the epoch, see CommitTime.
+	CommitTimestamp int64  `json:"commitTimestamp"`
+	CommitNumber    int64  `json:"commitNumber"`
+	CommitUser      string `json:"commitUser"`
+	// ChangedFields, NulledFields and DiffFields name the fields which changed, were set to null and hold a diff of
+	// a long text respectively. The Streaming API sends the names of the fields, rather than the bitmaps of the Pub/Sub
+	// API.
+	ChangedFields []string `json:"changedFields"`
+	NulledFields  []string `json:"nulledFields"`
+	DiffFields    []string `json:"diffFields"`
+}
+
+// CommitTime returns the time of the transaction of the change.
+func (header *ChangeEventHeader) CommitTime() time.Time {
+	return time.Unix(0, header.CommitTimestamp*int64(time.Millisecond))
+}
+
+// IsGap reports whether the event is a gap event, which doesn't carry the values of the changed fields.
+func (header *ChangeEventHeader) IsGap() bool {
+	return strings.HasPrefix(header.ChangeType, "GAP_")
+}
+
+// ChangeEvent is a Change Data Capture event, notifying of the creation, update, deletion or undeletion of records.
+type ChangeEvent struct {
+	Channel  string
+	ReplayID int64
+	Header   ChangeEventHeader
+	// Fields holds the values of the fields set or changed, as an SObject of the type of the records. Fields set to
+	// null are listed in Header.NulledFields only.
+	Fields SObject
+}
+
+// ChangeEvent decodes a Change Data Capture event from the payload of the event.
+func (event Event) ChangeEvent() (*ChangeEvent, error) {
+	var fields SObject
+	err := json.Unmarshal(event.Payload, &fields)
+	if err != nil || fields == nil {
+		return nil, errors.Errorf("invalid change event on %s", event.Channel)
+	}
+	rawHeader, err := json.Marshal(fields[changeEventHeaderKey])
+	if err != nil {
+		return nil, err
+	}
+	var header ChangeEventHeader
+	err = json.Unmarshal(rawHeader, &header)
+	if err != nil || header.ChangeType == "" {
+		return nil, errors.Errorf("invalid change event header on %s", event.Channel)
+	}
+
+	delete(fields, changeEventHeaderKey)
+	fields.setType(header.EntityName)
+	return &ChangeEvent{
+		Channel:  event.Channel,
+		ReplayID: event.ReplayID,
+		Header:   header,
+		Fields:   fields,
+	}, nil
+}
+
+// ChangeEventChannel returns the name of the change event channel of object, e.g. "AccountChangeEvent" for
+// "Account" and "Order__ChangeEvent" for "Order__c".
+func ChangeEventChannel(object string) string {
+	if strings.HasSuffix(object, "__c") {
+		return strings.TrimSuffix(object, "c") + "ChangeEvent"
+	}
+	return object + "ChangeEvent"
+}
+
+// SubscribeChanges subscribes to the change events of object, e.g. "Account", or of all the objects with
+// AllChangeEvents, like Subscribe. Events which can't be decoded are logged and skipped.
+func (s *StreamingClient) SubscribeChanges(object string, handler func(event *ChangeEvent)) error {
+	if handler == nil {
+		return errors.New("handler is required")
+	}
+	channel := AllChangeEvents
+	if object != AllChangeEvents {
+		channel = ChangeEventChannel(object)
+	}
+	return s.Subscribe(changeEventPrefix+channel, func(event Event) {
+		change, err := event.ChangeEvent()
+		if err != nil {
+			s.client.logError(err)
+			return
+		}
+		handler(change)
+	})
+}
@@ -0,0 +1,83 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChangeEventChannel(t *testing.T) {
+	if channel := ChangeEventChannel("Account"); channel != "AccountChangeEvent" {
+		t.Errorf("unexpected channel %s", channel)
+	}
+	if channel := ChangeEventChannel("Order__c"); channel != "Order__ChangeEvent" {
+		t.Errorf("unexpected channel %s", channel)
+	}
+}
+
+func TestStreamingClient_SubscribeChanges(t *testing.T) {
+	var subscription string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var messages []map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&messages)
+		switch messages[0]["channel"] {
+		case "/meta/handshake":
+			w.Write([]byte(`[{"channel":"/meta/handshake","clientId":"client1","successful":true}]`))
+		case "/meta/subscribe":
+			subscription, _ = messages[0]["subscription"].(string)
+			w.Write([]byte(`[{"channel":"/meta/subscribe","successful":true}]`))
+		case "/meta/connect":
+			w.Write([]byte(`[
+				{"channel":"/data/AccountChangeEvent","data":{"schema":"abc","payload":{
+					"ChangeEventHeader":{"entityName":"Account","recordIds":["0013000000Db2wKAAR"],"changeType":"UPDATE",
+						"changeOrigin":"com/salesforce/api/rest/54.0","transactionKey":"0002343d-9d2b-4c4c-a0d4-5f5d3e1a7b32",
+						"sequenceNumber":1,"commitTimestamp":1651228500000,"commitNumber":10525420869,"commitUser":"0053000000B8jh0AAB",
+						"changedFields":["Name","LastModifiedDate"],"nulledFields":["Website"],"diffFields":[]},
+					"Name":"Acme","LastModifiedDate":"2022-04-29T10:35:00.000Z"},"event":{"replayId":7}}},
+				{"channel":"/meta/connect","successful":true}
+			]`))
+		default:
+			w.Write([]byte(`[{"channel":"/meta/disconnect","successful":true}]`))
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var change *ChangeEvent
+	streaming := client.NewStreamingClient()
+	err := streaming.SubscribeChanges("Account", func(event *ChangeEvent) {
+		change = event
+		cancel()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = streaming.Run(ctx); err != context.Canceled {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if subscription != "/data/AccountChangeEvent" {
+		t.Errorf("unexpected subscription %s", subscription)
+	}
+	if change == nil {
+		t.Fatal("no change event received")
+	}
+	header := change.Header
+	if header.ChangeType != ChangeTypeUpdate || header.IsGap() || header.EntityName != "Account" ||
+		!reflect.DeepEqual(header.RecordIDs, []string{"0013000000Db2wKAAR"}) ||
+		!reflect.DeepEqual(header.ChangedFields, []string{"Name", "LastModifiedDate"}) ||
+		!reflect.DeepEqual(header.NulledFields, []string{"Website"}) ||
+		header.TransactionKey != "0002343d-9d2b-4c4c-a0d4-5f5d3e1a7b32" {
+		t.Errorf("unexpected header %+v", header)
+	}
+	if !header.CommitTime().Equal(time.Date(2022, 4, 29, 10, 35, 0, 0, time.UTC)) {
+		t.Errorf("unexpected commit time %s", header.CommitTime())
+	}
+	if change.ReplayID != 7 || change.Fields.Type() != "Account" || change.Fields.StringField("Name") != "Acme" ||
+		change.Fields.InterfaceField(changeEventHeaderKey) != nil {
+		t.Errorf("unexpected change event %+v", change)
+	}
+}
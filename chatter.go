@@ -0,0 +1,293 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ChatterClient posts to and reads Chatter feeds, e.g. to notify users inside Salesforce. For clients created by
+// WithCommunity, feeds are those of the Experience Cloud site.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.chatterapi.meta/chatterapi/connect_resources_feed_element.htm
+type ChatterClient struct {
+	client *Client
+}
+
+// Chatter returns a client of the Chatter feeds of the org.
+func (client *Client) Chatter() *ChatterClient {
+	return &ChatterClient{client: client}
+}
+
+// request sends a request to the Chatter resource path, e.g. "feed-elements".
+func (chatter *ChatterClient) request(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	resource := "chatter/"
+	if chatter.client.communityID != "" {
+		resource = fmt.Sprintf("connect/communities/%s/chatter/", chatter.client.communityID)
+	}
+	var requestBody io.Reader
+	if body != nil {
+		reqData, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = bytes.NewReader(reqData)
+	}
+	return chatter.client.connectRequest(ctx, method, resource+path, requestBody)
+}
+
+// MessageSegment is a part of the body of a feed element or comment: text, a mention of a user or group, or a link.
+type MessageSegment struct {
+	// Type is "Text", "Mention", "Link" or one of the other segment types.
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+	// ID is the ID of the user or group mentioned by Mention segments.
+	ID string `json:"id,omitempty"`
+	// URL is the target of Link segments.
+	URL string `json:"url,omitempty"`
+}
+
+// MessageBody is the body of a feed element or comment.
+type MessageBody struct {
+	// Text is the plain text of the body, as read from Salesforce.
+	Text            string           `json:"text,omitempty"`
+	MessageSegments []MessageSegment `json:"messageSegments"`
+}
+
+// messageBody returns the body of a message made of text followed by mentions of the users or groups mentions.
+func messageBody(text string, mentions []string) MessageBody {
+	var segments []MessageSegment
+	if text != "" {
+		segments = append(segments, MessageSegment{Type: "Text", Text: text})
+	}
+	for _, id := range mentions {
+		if len(segments) > 0 {
+			segments = append(segments, MessageSegment{Type: "Text", Text: " "})
+		}
+		segments = append(segments, MessageSegment{Type: "Mention", ID: id})
+	}
+	return MessageBody{MessageSegments: segments}
+}
+
+// ChatterActor is the user or group which posted a feed element or comment.
+type ChatterActor struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Type        string `json:"type"`
+}
+
+// FeedFile is a file attached to a feed element.
+type FeedFile struct {
+	// ID is the ID of the ContentDocument of the file.
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	FileType string `json:"fileType"`
+	// VersionID is the ID of the ContentVersion of the file.
+	VersionID string `json:"versionId"`
+}
+
+// FeedElement is a post of a feed.
+type FeedElement struct {
+	ID          string       `json:"id"`
+	Type        string       `json:"feedElementType"`
+	Body        MessageBody  `json:"body"`
+	Actor       ChatterActor `json:"actor"`
+	CreatedDate DateTime     `json:"createdDate"`
+	// Parent is the record, user or group the element was posted to.
+	Parent struct {
+		ID string `json:"id"`
+	} `json:"parent"`
+	Capabilities struct {
+		Comments struct {
+			Page struct {
+				Items []FeedComment `json:"items"`
+				Total int           `json:"total"`
+			} `json:"page"`
+		} `json:"comments"`
+		Files struct {
+			Items []FeedFile `json:"items"`
+		} `json:"files"`
+	} `json:"capabilities"`
+}
+
+// FeedComment is a comment of a feed element.
+type FeedComment struct {
+	ID          string       `json:"id"`
+	Body        MessageBody  `json:"body"`
+	User        ChatterActor `json:"user"`
+	CreatedDate DateTime     `json:"createdDate"`
+	// FeedElement is the element the comment was posted on.
+	FeedElement struct {
+		ID string `json:"id"`
+	} `json:"feedElement"`
+}
+
+// FeedItemInput is a post to the feed of a record, user or group.
+type FeedItemInput struct {
+	// SubjectID is the record, user or group posted to, or "me" for the feed of the user of the client.
+	SubjectID string
+	Text      string
+	// Mentions are the IDs of the users or groups mentioned after Text, who are notified of the post.
+	Mentions []string
+	// Files are the ContentDocument IDs of files attached to the post, e.g. uploaded with UploadFile.
+	Files []string
+}
+
+// PostFeedItem posts text to the feed of a record, user or group, mentioning the users or groups mentions.
+func (chatter *ChatterClient) PostFeedItem(subjectID, text string, mentions []string) (*FeedElement, error) {
+	return chatter.PostFeedItemContext(context.Background(), subjectID, text, mentions)
+}
+
+// PostFeedItemContext is like PostFeedItem, with the request bound to ctx.
+func (chatter *ChatterClient) PostFeedItemContext(ctx context.Context, subjectID, text string, mentions []string) (*FeedElement, error) {
+	return chatter.PostFeedElementContext(ctx, FeedItemInput{SubjectID: subjectID, Text: text, Mentions: mentions})
+}
+
+// PostFeedElement posts input to the feed of its subject, along with its files.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.chatterapi.meta/chatterapi/connect_resources_feed_element_post_and_search.htm
+func (chatter *ChatterClient) PostFeedElement(input FeedItemInput) (*FeedElement, error) {
+	return chatter.PostFeedElementContext(context.Background(), input)
+}
+
+// PostFeedElementContext is like PostFeedElement, with the request bound to ctx.
+func (chatter *ChatterClient) PostFeedElementContext(ctx context.Context, input FeedItemInput) (*FeedElement, error) {
+	if input.SubjectID == "" {
+		return nil, errors.New("subject id is required")
+	}
+	body := map[string]interface{}{
+		"body":            messageBody(input.Text, input.Mentions),
+		"feedElementType": "FeedItem",
+		"subjectId":       input.SubjectID,
+	}
+	if len(input.Files) > 0 {
+		items := make([]map[string]string, len(input.Files))
+		for i, id := range input.Files {
+			items[i] = map[string]string{"id": id}
+		}
+		body["capabilities"] = map[string]interface{}{"files": map[string]interface{}{"items": items}}
+	}
+
+	data, err := chatter.request(ctx, http.MethodPost, "feed-elements", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var element FeedElement
+	err = json.Unmarshal(data, &element)
+	if err != nil {
+		return nil, err
+	}
+	return &element, nil
+}
+
+// PostFile uploads a file from r and posts it with text to the feed of a record, user or group.
+func (chatter *ChatterClient) PostFile(subjectID, text, filename string, r io.Reader) (*FeedElement, error) {
+	return chatter.PostFileContext(context.Background(), subjectID, text, filename, r)
+}
+
+// PostFileContext is like PostFile, with the requests bound to ctx.
+func (chatter *ChatterClient) PostFileContext(ctx context.Context, subjectID, text, filename string, r io.Reader) (*FeedElement, error) {
+	versionID, err := chatter.client.UploadFileContext(ctx, filename, filename, r, "")
+	if err != nil {
+		return nil, err
+	}
+	version := chatter.client.SObject("ContentVersion").GetWithContext(ctx, versionID, WithFields("ContentDocumentId"))
+	if version == nil {
+		return nil, errors.Errorf("failed to read content version %s", versionID)
+	}
+	return chatter.PostFeedElementContext(ctx, FeedItemInput{
+		SubjectID: subjectID,
+		Text:      text,
+		Files:     []string{version.StringField("ContentDocumentId")},
+	})
+}
+
+// FeedPage is a page of the elements of a feed, most recent first.
+type FeedPage struct {
+	Elements []FeedElement `json:"elements"`
+	// NextPageURL is the URL of the next page, read with NextFeedPage, or empty on the last page.
+	NextPageURL string `json:"nextPageUrl"`
+}
+
+// GetFeed returns the first page of the feed of a record, user or group, or of the news feed of the user of the
+// client if subjectID is "me".
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.chatterapi.meta/chatterapi/connect_resources_feeds_record.htm
+func (chatter *ChatterClient) GetFeed(subjectID string) (*FeedPage, error) {
+	return chatter.GetFeedContext(context.Background(), subjectID)
+}
+
+// GetFeedContext is like GetFeed, with the request bound to ctx.
+func (chatter *ChatterClient) GetFeedContext(ctx context.Context, subjectID string) (*FeedPage, error) {
+	feed := "record/" + url.PathEscape(subjectID)
+	if subjectID == "me" {
+		feed = "news/me"
+	}
+	data, err := chatter.request(ctx, http.MethodGet, "feeds/"+feed+"/feed-elements", nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeFeedPage(data)
+}
+
+// NextFeedPage returns the page of a feed following page, or nil and no error once the last page was reached.
+func (chatter *ChatterClient) NextFeedPage(page *FeedPage) (*FeedPage, error) {
+	return chatter.NextFeedPageContext(context.Background(), page)
+}
+
+// NextFeedPageContext is like NextFeedPage, with the request bound to ctx.
+func (chatter *ChatterClient) NextFeedPageContext(ctx context.Context, page *FeedPage) (*FeedPage, error) {
+	if page.NextPageURL == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(page.NextPageURL, "/services/data") {
+		return nil, errors.New("not a nextPageUrl: " + page.NextPageURL)
+	}
+	if !chatter.client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := chatter.client.getInstanceURL() + page.NextPageURL
+	data, err := chatter.client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		chatter.client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+	return decodeFeedPage(data)
+}
+
+func decodeFeedPage(data []byte) (*FeedPage, error) {
+	var page FeedPage
+	err := json.Unmarshal(data, &page)
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// PostComment comments a feed element with text, mentioning the users or groups mentions.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.chatterapi.meta/chatterapi/connect_resources_feed_element_capability_comments_items.htm
+func (chatter *ChatterClient) PostComment(feedElementID, text string, mentions []string) (*FeedComment, error) {
+	return chatter.PostCommentContext(context.Background(), feedElementID, text, mentions)
+}
+
+// PostCommentContext is like PostComment, with the request bound to ctx.
+func (chatter *ChatterClient) PostCommentContext(ctx context.Context, feedElementID, text string, mentions []string) (*FeedComment, error) {
+	body := map[string]interface{}{"body": messageBody(text, mentions)}
+	data, err := chatter.request(ctx, http.MethodPost, "feed-elements/"+url.PathEscape(feedElementID)+"/capabilities/comments/items", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment FeedComment
+	err = json.Unmarshal(data, &comment)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
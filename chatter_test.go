@@ -0,0 +1,139 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestChatterClient_PostFeedItem(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/chatter/feed-elements" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var req struct {
+			Body            MessageBody `json:"body"`
+			FeedElementType string      `json:"feedElementType"`
+			SubjectID       string      `json:"subjectId"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		segments := req.Body.MessageSegments
+		if req.SubjectID != "0013000000Db2wKAAR" || req.FeedElementType != "FeedItem" || len(segments) != 5 ||
+			segments[0].Text != "Renewal is due" || segments[1].Text != " " ||
+			segments[2].Type != "Mention" || segments[2].ID != "0053000000AbcdeAAA" || segments[4].ID != "0F93000000AbcdeCAA" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"0D53000000AbcdeCAB","feedElementType":"FeedItem","createdDate":"2022-05-02T10:00:00.000Z",
+			"body":{"text":"Renewal is due @Jane Doe @Sales"},"actor":{"id":"0053000000ZyxwvAAA","displayName":"Integration"},
+			"parent":{"id":"0013000000Db2wKAAR"}}`))
+	})
+
+	element, err := client.Chatter().PostFeedItem("0013000000Db2wKAAR", "Renewal is due",
+		[]string{"0053000000AbcdeAAA", "0F93000000AbcdeCAA"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if element.ID != "0D53000000AbcdeCAB" || element.Parent.ID != "0013000000Db2wKAAR" ||
+		element.Actor.DisplayName != "Integration" || element.CreatedDate.IsZero() {
+		t.Errorf("unexpected element %+v", element)
+	}
+}
+
+func TestChatterClient_PostFile(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/services/data/v54.0/sobjects/ContentVersion/":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"0683000000AbcdeAAA","success":true,"errors":[]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/services/data/v54.0/sobjects/ContentVersion/0683000000AbcdeAAA":
+			if r.URL.Query().Get("fields") != "ContentDocumentId" {
+				t.Errorf("unexpected fields %s", r.URL.Query().Get("fields"))
+			}
+			w.Write([]byte(`{"attributes":{"type":"ContentVersion"},"Id":"0683000000AbcdeAAA","ContentDocumentId":"0693000000AbcdeAAA"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/services/data/v54.0/chatter/feed-elements":
+			var req struct {
+				Capabilities struct {
+					Files struct {
+						Items []FeedFile `json:"items"`
+					} `json:"files"`
+				} `json:"capabilities"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req.Capabilities.Files.Items) != 1 || req.Capabilities.Files.Items[0].ID != "0693000000AbcdeAAA" {
+				t.Errorf("unexpected request %+v", req)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"0D53000000AbcdeCAB","capabilities":{"files":{"items":[{"id":"0693000000AbcdeAAA","title":"report.csv"}]}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	element, err := client.Chatter().PostFile("0013000000Db2wKAAR", "Weekly report", "report.csv", strings.NewReader("a,b\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files := element.Capabilities.Files.Items; len(files) != 1 || files[0].Title != "report.csv" {
+		t.Errorf("unexpected element %+v", element)
+	}
+}
+
+func TestChatterClient_GetFeed(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/services/data/v54.0/connect/communities/0DB30000000AbcdGAC/chatter/feeds/record/0013000000Db2wKAAR/feed-elements":
+			w.Write([]byte(`{"elements":[{"id":"0D53000000AbcdeCAB","capabilities":{"comments":{"page":{"total":1,"items":[{"id":"0D73000000AbcdeCAA"}]}}}}],
+				"nextPageUrl":"/services/data/v54.0/connect/communities/0DB30000000AbcdGAC/chatter/feeds/record/0013000000Db2wKAAR/feed-elements?page=2022-05-02T10:00:00Z"}`))
+		case "/services/data/v54.0/connect/communities/0DB30000000AbcdGAC/chatter/feeds/record/0013000000Db2wKAAR/feed-elements?page=2022-05-02T10:00:00Z":
+			w.Write([]byte(`{"elements":[{"id":"0D53000000AbcdfCAB"}],"nextPageUrl":null}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	chatter := client.WithCommunity("0DB30000000AbcdGAC").Chatter()
+	page, err := chatter.GetFeed("0013000000Db2wKAAR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Elements) != 1 || page.Elements[0].Capabilities.Comments.Page.Total != 1 || page.NextPageURL == "" {
+		t.Fatalf("unexpected page %+v", page)
+	}
+	page, err = chatter.NextFeedPage(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Elements) != 1 || page.Elements[0].ID != "0D53000000AbcdfCAB" {
+		t.Fatalf("unexpected page %+v", page)
+	}
+	if page, err = chatter.NextFeedPage(page); page != nil || err != nil {
+		t.Errorf("expected no page after the last one, got %v and %v", page, err)
+	}
+}
+
+func TestChatterClient_PostComment(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/chatter/feed-elements/0D53000000AbcdeCAB/capabilities/comments/items" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var req struct {
+			Body MessageBody `json:"body"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Body.MessageSegments) != 1 || req.Body.MessageSegments[0].Text != "Done" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"0D73000000AbcdeCAA","body":{"text":"Done"},"user":{"id":"0053000000ZyxwvAAA"},"feedElement":{"id":"0D53000000AbcdeCAB"}}`))
+	})
+
+	comment, err := client.Chatter().PostComment("0D53000000AbcdeCAB", "Done", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.ID != "0D73000000AbcdeCAA" || comment.Body.Text != "Done" || comment.FeedElement.ID != "0D53000000AbcdeCAB" {
+		t.Errorf("unexpected comment %+v", comment)
+	}
+}
@@ -0,0 +1,73 @@
+package simpleforce
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(client *Client)
+
+// WithHTTPClient makes the client send all its requests with httpClient, e.g. to share the connections of an
+// application or use a client instrumented by it. A nil httpClient leaves the HTTP client of the client unchanged.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) {
+		if httpClient == nil {
+			return
+		}
+		client.httpClient = httpClient
+	}
+}
+
+// WithTimeout limits the time of every request of the client, including reading the response body.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		// Copy the HTTP client, which may be shared with others through WithHTTPClient.
+		httpClient := *client.httpClient
+		httpClient.Timeout = timeout
+		client.httpClient = &httpClient
+	}
+}
+
+// WithTransport makes the client send its requests through transport, e.g. an http.Transport with proxy or TLS
+// settings.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(client *Client) {
+		httpClient := *client.httpClient
+		httpClient.Transport = transport
+		client.httpClient = &httpClient
+	}
+}
+
+// WithRetry makes the client send requests failing with transient errors again, up to max times: the
+// REQUEST_LIMIT_EXCEEDED and SERVER_UNAVAILABLE errors and failed connections, and for idempotent requests such as
+// GET, PUT or DELETE, network errors and 5xx responses as well. Other requests, such as a POST creating records,
+// aren't retried once they may have been processed, unless their context is marked by
+// ContextWithNonIdempotentRetry. The delay before the first retry is about backoff, and doubles with every retry.
+// ContextWithRetry overrides the policy for the requests of a context.
+func WithRetry(max int, backoff time.Duration) ClientOption {
+	return func(client *Client) {
+		client.retry = retryPolicy{max: max, backoff: backoff}
+	}
+}
+
+// WithLoginURL makes the client log in at loginURL instead of the URL given to NewClient, e.g. the My Domain URL of
+// the org. Requests following the login are sent to the instance URL returned by it, see InstanceURL: loginURL is
+// never used for them, even by a client created by NewClientFromToken.
+func WithLoginURL(loginURL string) ClientOption {
+	return func(client *Client) {
+		client.loginURL = strings.TrimRight(loginURL, "/")
+	}
+}
+
+// WithSandbox makes the client log in at SandboxURL, the login URL of sandboxes.
+func WithSandbox() ClientOption {
+	return WithLoginURL(SandboxURL)
+}
+
+// WithMyDomain makes the client log in at the My Domain URL of domain, e.g. "acme" for
+// https://acme.my.salesforce.com, or "acme--uat.sandbox" for a sandbox of it.
+func WithMyDomain(domain string) ClientOption {
+	return WithLoginURL("https://" + domain + ".my.salesforce.com")
+}
@@ -0,0 +1,107 @@
+package simpleforce
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClient_Options(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer server.Close()
+
+	shared := &http.Client{}
+	client := NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithHTTPClient(shared))
+	if client.httpClient != shared {
+		t.Error("expected the HTTP client to be used")
+	}
+
+	client = NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithHTTPClient(shared), WithTimeout(time.Second))
+	if client.httpClient.Timeout != time.Second || shared.Timeout != 0 {
+		t.Errorf("unexpected timeouts %v, shared %v", client.httpClient.Timeout, shared.Timeout)
+	}
+
+	client = NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithHTTPClient(nil), WithTimeout(time.Second))
+	if client.httpClient == nil || client.httpClient.Timeout != time.Second {
+		t.Errorf("expected a nil HTTP client to keep the default one, got %v", client.httpClient)
+	}
+
+	sent := 0
+	client = NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent++
+		return http.DefaultTransport.RoundTrip(req)
+	})))
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Errorf("expected the request to go through the transport, got %d", sent)
+	}
+}
+
+func TestNewClient_LoginURLOptions(t *testing.T) {
+	tests := []struct {
+		opt      ClientOption
+		expected string
+	}{
+		{WithSandbox(), SandboxURL},
+		{WithLoginURL("https://acme.my.salesforce.com/"), "https://acme.my.salesforce.com"},
+		{WithMyDomain("acme--uat.sandbox"), "https://acme--uat.sandbox.my.salesforce.com"},
+	}
+	for _, test := range tests {
+		client := NewClient(DefaultURL, DefaultClientID, DefaultAPIVersion, test.opt)
+		if client.loginBaseURL() != test.expected {
+			t.Errorf("expected login URL %s, got %s", test.expected, client.loginBaseURL())
+		}
+		if client.baseURL != DefaultURL {
+			t.Errorf("expected base URL %s to be kept, got %s", DefaultURL, client.baseURL)
+		}
+	}
+}
+
+func TestClient_LoginPasswordInstanceURL(t *testing.T) {
+	instance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/query" || r.Header.Get("Authorization") != "Bearer 00D000000000062!AQ0AQ" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer instance.Close()
+	login := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/Soap/u/54.0" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+			<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:partner.soap.sforce.com">
+				<soapenv:Body><loginResponse><result>
+					<serverUrl>` + instance.URL + `/services/Soap/u/54.0/00D000000000062</serverUrl>
+					<sessionId>00D000000000062!AQ0AQ</sessionId>
+					<userId>005000000000001AAA</userId>
+					<userInfo><userName>jdoe@example.com</userName></userInfo>
+				</result></loginResponse></soapenv:Body>
+			</soapenv:Envelope>`))
+	}))
+	defer login.Close()
+
+	client := NewClient("", DefaultClientID, DefaultAPIVersion, WithLoginURL(login.URL))
+	if err := client.LoginPassword("jdoe@example.com", "secret", ""); err != nil {
+		t.Fatal(err)
+	}
+	if client.InstanceURL() != instance.URL {
+		t.Errorf("expected instance URL %s, got %s", instance.URL, client.InstanceURL())
+	}
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,356 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxCollectionRecords is the maximum number of records of a single SObject Collections call.
+const maxCollectionRecords = 200
+
+// maxCollectionTypes is the maximum number of object types of a single SObject Collections call.
+const maxCollectionTypes = 10
+
+// rolledBackCode is the error code of the records which were valid but rolled back along with invalid ones.
+const rolledBackCode = "ALL_OR_NONE_OPERATION_ROLLED_BACK"
+
+// ErrRolledBack matches the RollbackError returned when an allOrNone write is rolled back.
+var ErrRolledBack = errors.New("all or none operation rolled back")
+
+// SaveResult is the outcome for a single record of a create, update or delete of several records at once.
+type SaveResult struct {
+	ID      string      `json:"id" xml:"id"`
+	Success bool        `json:"success" xml:"success"`
+	Errors  []SaveError `json:"errors" xml:"errors"`
+}
+
+// SaveError describes why a record couldn't be saved.
+type SaveError struct {
+	StatusCode string   `json:"statusCode" xml:"statusCode"`
+	Message    string   `json:"message" xml:"message"`
+	Fields     []string `json:"fields" xml:"fields"`
+}
+
+// Err returns nil if the record was saved, or a SalesforceError describing the first error otherwise.
+func (result SaveResult) Err() error {
+	if result.Success {
+		return nil
+	}
+	if len(result.Errors) == 0 {
+		return ErrFailure
+	}
+	first := result.Errors[0]
+	return SalesforceError{
+		Message:      logPrefix + " Error. Error Message: " + first.Message + " Error Code: " + first.StatusCode,
+		ErrorCode:    first.StatusCode,
+		ErrorMessage: first.Message,
+		fields:       errorFields(first.Fields),
+	}
+}
+
+// RecordFailure identifies a record which made an allOrNone write roll back and why.
+type RecordFailure struct {
+	// Index is the position of the record in the records of the write.
+	Index  int
+	Errors []SaveError
+}
+
+// RollbackError is returned when a write with allOrNone set is rolled back. It lists the records which caused the
+// rollback, leaving out the valid records which were only rolled back along with them.
+type RollbackError struct {
+	Failures []RecordFailure
+}
+
+func (err *RollbackError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(ErrRolledBack.Error())
+	for i, failure := range err.Failures {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString("; ")
+		}
+		sb.WriteString("record ")
+		sb.WriteString(strconv.Itoa(failure.Index))
+		for _, saveErr := range failure.Errors {
+			sb.WriteString(" ")
+			sb.WriteString(saveErr.StatusCode)
+			sb.WriteString(" ")
+			sb.WriteString(saveErr.Message)
+			if len(saveErr.Fields) > 0 {
+				sb.WriteString(" (" + strings.Join(saveErr.Fields, ", ") + ")")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Is makes errors.Is(err, ErrRolledBack) hold.
+func (err *RollbackError) Is(target error) bool {
+	return target == ErrRolledBack
+}
+
+// rollbackError returns a RollbackError if some of results failed, nil otherwise. offset is the index of the first
+// result among the records of the write.
+func rollbackError(results []SaveResult, offset int) error {
+	var failures, rolledBack []RecordFailure
+	for idx, result := range results {
+		if result.Success {
+			continue
+		}
+		failure := RecordFailure{Index: offset + idx, Errors: result.Errors}
+		onlyRolledBack := len(result.Errors) > 0
+		for _, saveErr := range result.Errors {
+			onlyRolledBack = onlyRolledBack && saveErr.StatusCode == rolledBackCode
+		}
+		if onlyRolledBack {
+			rolledBack = append(rolledBack, failure)
+		} else {
+			failures = append(failures, failure)
+		}
+	}
+	if len(failures) == 0 && len(rolledBack) == 0 {
+		return nil
+	}
+	if len(failures) == 0 {
+		// Salesforce didn't say which record caused the rollback.
+		failures = rolledBack
+	}
+	return &RollbackError{Failures: failures}
+}
+
+// collectionRecord converts obj into the representation expected by SObject Collections: the record fields along with
+// its type in the attributes. The ID is kept if withID is true.
+func collectionRecord(obj *SObject, withID bool) (map[string]interface{}, error) {
+	if obj.Type() == "" {
+		return nil, errors.New("record type is required")
+	}
+	record := obj.makeCopy()
+	record[sobjectAttributesKey] = map[string]string{"type": obj.Type()}
+	if withID {
+		if obj.ID() == "" {
+			return nil, errors.New("record id is required")
+		}
+		record[sobjectIDKey] = obj.ID()
+	}
+	return record, nil
+}
+
+// saveCollection creates (POST) or updates (PATCH) records through SObject Collections, splitting them into calls of
+// up to 200 records. Results are returned in the order of records. If allOrNone is set and a call is rolled back, the
+// following calls are not sent and a RollbackError is returned along with the results so far. Note allOrNone only
+// applies within a single call: the records of the previous calls remain saved.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_sobjects_collections.htm
+func (client *Client) saveCollection(method string, records []*SObject, allOrNone bool) ([]SaveResult, error) {
+	return client.saveCollectionContext(context.Background(), method, records, allOrNone)
+}
+
+// saveCollectionContext is like saveCollection, with the requests bound to ctx.
+func (client *Client) saveCollectionContext(ctx context.Context, method string, records []*SObject, allOrNone bool) ([]SaveResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	var rollbackErr error
+	results := make([]SaveResult, 0, len(records))
+	for start := 0; start < len(records); start += maxCollectionRecords {
+		end := start + maxCollectionRecords
+		if end > len(records) {
+			end = len(records)
+		}
+
+		payload := make([]map[string]interface{}, 0, end-start)
+		for _, obj := range records[start:end] {
+			record, err := collectionRecord(obj, method == http.MethodPatch)
+			if err != nil {
+				return nil, err
+			}
+			payload = append(payload, record)
+		}
+		reqData, err := json.Marshal(map[string]interface{}{
+			"allOrNone": allOrNone,
+			"records":   payload,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		u := client.makeURL("composite/sobjects")
+		data, err := client.httpRequestContext(ctx, method, u, bytes.NewReader(reqData))
+		if err != nil {
+			client.logError("HTTP", method, "request failed:", u)
+			return nil, err
+		}
+
+		var page []SaveResult
+		err = json.Unmarshal(data, &page)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) != end-start {
+			return nil, errors.Errorf("expected %d results, got %d", end-start, len(page))
+		}
+		results = append(results, page...)
+		if allOrNone {
+			if rollbackErr = rollbackError(page, start); rollbackErr != nil {
+				break
+			}
+		}
+	}
+
+	// Reflect the IDs of created records in the SObjects, as Create does.
+	if method == http.MethodPost {
+		for idx, result := range results {
+			if result.Success && idx < len(records) {
+				records[idx].setID(result.ID)
+			}
+		}
+	}
+	return results, rollbackErr
+}
+
+// CreateMultiple creates records, which may be of different types, with as few calls as possible: SObject
+// Collections save up to 200 records per call. The IDs of the created records are set on their SObjects. Results
+// are returned in the order of records; see saveCollection for allOrNone.
+func (client *Client) CreateMultiple(records []*SObject, allOrNone bool) ([]SaveResult, error) {
+	return client.CreateMultipleContext(context.Background(), records, allOrNone)
+}
+
+// CreateMultipleContext is like CreateMultiple, with the requests bound to ctx.
+func (client *Client) CreateMultipleContext(ctx context.Context, records []*SObject, allOrNone bool) ([]SaveResult, error) {
+	return client.saveCollectionContext(ctx, http.MethodPost, records, allOrNone)
+}
+
+// UpdateMultiple updates records by ID with as few calls as possible, like CreateMultiple.
+func (client *Client) UpdateMultiple(records []*SObject, allOrNone bool) ([]SaveResult, error) {
+	return client.UpdateMultipleContext(context.Background(), records, allOrNone)
+}
+
+// UpdateMultipleContext is like UpdateMultiple, with the requests bound to ctx.
+func (client *Client) UpdateMultipleContext(ctx context.Context, records []*SObject, allOrNone bool) ([]SaveResult, error) {
+	return client.saveCollectionContext(ctx, http.MethodPatch, records, allOrNone)
+}
+
+// DeleteMultiple deletes records by ID with as few calls as possible, like CreateMultiple.
+func (client *Client) DeleteMultiple(ids []string, allOrNone bool) ([]SaveResult, error) {
+	return client.DeleteMultipleContext(context.Background(), ids, allOrNone)
+}
+
+// DeleteMultipleContext is like DeleteMultiple, with the requests bound to ctx.
+func (client *Client) DeleteMultipleContext(ctx context.Context, ids []string, allOrNone bool) ([]SaveResult, error) {
+	return client.deleteCollectionContext(ctx, ids, allOrNone)
+}
+
+// deleteCollection deletes records by ID through SObject Collections, splitting them into calls of up to 200 IDs.
+// allOrNone behaves as with saveCollection.
+func (client *Client) deleteCollection(ids []string, allOrNone bool) ([]SaveResult, error) {
+	return client.deleteCollectionContext(context.Background(), ids, allOrNone)
+}
+
+// deleteCollectionContext is like deleteCollection, with the requests bound to ctx.
+func (client *Client) deleteCollectionContext(ctx context.Context, ids []string, allOrNone bool) ([]SaveResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	results := make([]SaveResult, 0, len(ids))
+	for start := 0; start < len(ids); start += maxCollectionRecords {
+		end := start + maxCollectionRecords
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		params := url.Values{}
+		params.Set("ids", strings.Join(ids[start:end], ","))
+		params.Set("allOrNone", strconv.FormatBool(allOrNone))
+		u := client.makeURL("composite/sobjects?" + params.Encode())
+		data, err := client.httpRequestContext(ctx, http.MethodDelete, u, nil)
+		if err != nil {
+			client.logError("HTTP DELETE request failed:", u)
+			return nil, err
+		}
+
+		var page []SaveResult
+		err = json.Unmarshal(data, &page)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) != end-start {
+			return nil, errors.Errorf("expected %d results, got %d", end-start, len(page))
+		}
+		results = append(results, page...)
+		if allOrNone {
+			if err = rollbackError(page, start); err != nil {
+				return results, err
+			}
+		}
+	}
+	return results, nil
+}
+
+// maxRetrieveRecords is the maximum number of records of a single SObject Collections retrieve call.
+const maxRetrieveRecords = 2000
+
+// RetrieveCollection retrieves the fields of the records of sobject with the provided IDs through SObject Collections,
+// splitting them into calls of up to 2000 IDs. This is cheaper than a query for point lookups. Records are returned
+// in the order of ids; records which don't exist or can't be read are nil.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_sobjects_collections_retrieve.htm
+func (client *Client) RetrieveCollection(sobject string, ids []string, fields []string) ([]SObject, error) {
+	return client.RetrieveCollectionContext(context.Background(), sobject, ids, fields)
+}
+
+// RetrieveCollectionContext is like RetrieveCollection, with the requests bound to ctx.
+func (client *Client) RetrieveCollectionContext(ctx context.Context, sobject string, ids []string, fields []string) ([]SObject, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("at least one field is required")
+	}
+
+	records := make([]SObject, 0, len(ids))
+	for start := 0; start < len(ids); start += maxRetrieveRecords {
+		end := start + maxRetrieveRecords
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		reqData, err := json.Marshal(map[string]interface{}{
+			"ids":    ids[start:end],
+			"fields": fields,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		u := client.makeURL("composite/sobjects/" + sobject)
+		data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+		if err != nil {
+			client.logError("HTTP POST request failed:", u)
+			return nil, err
+		}
+
+		var page []SObject
+		err = json.Unmarshal(data, &page)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) != end-start {
+			return nil, errors.Errorf("expected %d records, got %d", end-start, len(page))
+		}
+		for idx := range page {
+			if page[idx] != nil {
+				page[idx].setClient(client)
+			}
+		}
+		records = append(records, page...)
+	}
+	return records, nil
+}
@@ -0,0 +1,175 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_SaveCollection(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req struct {
+			AllOrNone bool                     `json:"allOrNone"`
+			Records   []map[string]interface{} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.AllOrNone || len(req.Records) > maxCollectionRecords {
+			t.Errorf("unexpected request %v", req)
+		}
+
+		results := []SaveResult{}
+		for _, record := range req.Records {
+			if record["attributes"].(map[string]interface{})["type"] != "Contact" {
+				t.Errorf("missing attributes in %v", record)
+			}
+			id, _ := record["Id"].(string)
+			if r.Method == http.MethodPost {
+				id = fmt.Sprintf("003%012v", record["LastName"])
+			}
+			results = append(results, SaveResult{ID: id, Success: true})
+		}
+		json.NewEncoder(w).Encode(results)
+	})
+
+	var contacts []*SObject
+	for i := 0; i < 250; i++ {
+		contacts = append(contacts, client.SObject("Contact").Set("LastName", i))
+	}
+	results, err := client.CreateMultiple(contacts, true)
+	if err != nil || len(results) != 250 || calls != 2 {
+		t.Fatalf("unexpected results %v after %d calls, %v", results, calls, err)
+	}
+	if contacts[249].ID() != "003000000000249" {
+		t.Errorf("unexpected id %s", contacts[249].ID())
+	}
+
+	results, err = client.UpdateMultiple(contacts[:2], true)
+	if err != nil || results[1].ID != "003000000000001" {
+		t.Errorf("unexpected results %v, %v", results, err)
+	}
+
+	if _, err = client.saveCollection(http.MethodPatch, []*SObject{client.SObject("Contact")}, true); err == nil {
+		t.Error("expected error for update without id")
+	}
+}
+
+func TestClient_DeleteMultiple(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Query().Get("allOrNone") != "false" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		results := []SaveResult{}
+		for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			results = append(results, SaveResult{
+				ID:      id,
+				Success: id != "003000000000002",
+				Errors:  []SaveError{{StatusCode: "ENTITY_IS_DELETED", Message: "entity is deleted"}},
+			})
+		}
+		json.NewEncoder(w).Encode(results)
+	})
+
+	results, err := client.DeleteMultiple([]string{"003000000000001", "003000000000002"}, false)
+	if err != nil || len(results) != 2 || results[0].Err() != nil {
+		t.Fatalf("unexpected results %v, %v", results, err)
+	}
+	if sfErr, ok := results[1].Err().(SalesforceError); !ok || sfErr.ErrorCode != "ENTITY_IS_DELETED" {
+		t.Errorf("unexpected error %v", results[1].Err())
+	}
+}
+
+func TestClient_SaveCollectionRollback(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req struct {
+			Records []map[string]interface{} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		results := []SaveResult{}
+		for _, record := range req.Records {
+			if record["LastName"] == nil {
+				results = append(results, SaveResult{Errors: []SaveError{{StatusCode: "REQUIRED_FIELD_MISSING", Message: "Required fields are missing: [LastName]", Fields: []string{"LastName"}}}})
+				continue
+			}
+			results = append(results, SaveResult{Errors: []SaveError{{StatusCode: rolledBackCode, Message: "Record rolled back because not all records were valid"}}})
+		}
+		json.NewEncoder(w).Encode(results)
+	})
+
+	var contacts []*SObject
+	for i := 0; i < 250; i++ {
+		contacts = append(contacts, client.SObject("Contact").Set("LastName", i))
+	}
+	delete(*contacts[150], "LastName")
+
+	results, err := client.saveCollection(http.MethodPost, contacts, true)
+	if !errors.Is(err, ErrRolledBack) {
+		t.Fatalf("expected ErrRolledBack, got %v", err)
+	}
+	if calls != 1 || len(results) != 200 {
+		t.Errorf("expected the following calls to be skipped, got %d calls and %d results", calls, len(results))
+	}
+	var rollbackErr *RollbackError
+	if !errors.As(err, &rollbackErr) || len(rollbackErr.Failures) != 1 || rollbackErr.Failures[0].Index != 150 {
+		t.Fatalf("unexpected error %#v", err)
+	}
+	if !strings.Contains(err.Error(), "record 150 REQUIRED_FIELD_MISSING") {
+		t.Errorf("unexpected message %s", err)
+	}
+}
+
+func TestClient_RetrieveCollection(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/composite/sobjects/Account" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			IDs    []string `json:"ids"`
+			Fields []string `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		if len(req.Fields) != 2 || req.Fields[1] != "Name" {
+			t.Errorf("unexpected fields %v", req.Fields)
+		}
+		records := make([]interface{}, len(req.IDs))
+		for i, id := range req.IDs {
+			if id != "missing" {
+				records[i] = map[string]interface{}{"attributes": map[string]string{"type": "Account"}, "Id": id, "Name": "Account " + id}
+			}
+		}
+		json.NewEncoder(w).Encode(records)
+	})
+
+	ids := make([]string, 2500)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%d", i)
+	}
+	ids[2100] = "missing"
+	records, err := client.RetrieveCollection("Account", ids, []string{"Id", "Name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || len(records) != len(ids) {
+		t.Fatalf("expected 2 calls for %d records, got %d calls and %d records", len(ids), calls, len(records))
+	}
+	if records[2100] != nil || records[2499].StringField("Name") != "Account 2499" || records[0].client() != client {
+		t.Errorf("unexpected records %v %v", records[2100], records[2499])
+	}
+
+	if _, err := client.RetrieveCollection("Account", ids, nil); err == nil {
+		t.Error("expected an error without fields")
+	}
+}
@@ -0,0 +1,463 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxBatchSubrequests is the maximum number of subrequests of a single composite batch call.
+const maxBatchSubrequests = 25
+
+// batchHaltedCode is the error code of the batch subrequests which were not processed because a previous one failed
+// and the batch was halted on error.
+const batchHaltedCode = "BATCH_PROCESSING_HALTED"
+
+// BatchSubrequest is a single request of a composite batch call. URL is relative to /services/data, e.g.
+// "v54.0/query?q=...". RichInput is the body of POST, PATCH and PUT subrequests.
+type BatchSubrequest struct {
+	Method    string      `json:"method"`
+	URL       string      `json:"url"`
+	RichInput interface{} `json:"richInput,omitempty"`
+}
+
+// BatchSubresponse is the outcome of a single subrequest of a composite batch call.
+type BatchSubresponse struct {
+	StatusCode int             `json:"statusCode"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// Succeeded reports whether the subrequest was processed successfully.
+func (resp *BatchSubresponse) Succeeded() bool {
+	return resp.StatusCode >= 200 && resp.StatusCode <= 299
+}
+
+// Err returns nil if the subrequest succeeded, or the SalesforceError it failed with.
+func (resp *BatchSubresponse) Err() error {
+	if resp.Succeeded() {
+		return nil
+	}
+	return ParseSalesforceError(resp.StatusCode, resp.Result)
+}
+
+// Skipped reports whether the subrequest was not processed because a previous one failed and the batch was halted on
+// error.
+func (resp *BatchSubresponse) Skipped() bool {
+	var errs jsonError
+	if resp.Succeeded() || json.Unmarshal(resp.Result, &errs) != nil {
+		return false
+	}
+	return len(errs) > 0 && errs[0].ErrorCode == batchHaltedCode
+}
+
+// executeBatch sends requests through the composite batch resource, splitting them into as many calls as needed.
+// Subresponses are returned in the order of requests.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_batch.htm
+func (client *Client) executeBatch(ctx context.Context, requests []BatchSubrequest, haltOnError bool) ([]BatchSubresponse, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	responses := make([]BatchSubresponse, 0, len(requests))
+	for start := 0; start < len(requests); start += maxBatchSubrequests {
+		end := start + maxBatchSubrequests
+		if end > len(requests) {
+			end = len(requests)
+		}
+
+		reqData, err := json.Marshal(map[string]interface{}{
+			"batchRequests": requests[start:end],
+			"haltOnError":   haltOnError,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		u := client.makeURL("composite/batch")
+		data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+		if err != nil {
+			client.logError("HTTP POST request failed:", u)
+			return nil, err
+		}
+
+		var result struct {
+			HasErrors bool               `json:"hasErrors"`
+			Results   []BatchSubresponse `json:"results"`
+		}
+		err = json.Unmarshal(data, &result)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Results) != end-start {
+			return nil, errors.Errorf("expected %d batch results, got %d", end-start, len(result.Results))
+		}
+		responses = append(responses, result.Results...)
+	}
+
+	return responses, nil
+}
+
+// Batch executes up to 25 independent subrequests in a single composite batch call. Unlike Composite, subrequests
+// can't reference each other and each of them succeeds or fails on its own, unless haltOnError is set, in which case
+// the subrequests following a failed one are skipped. URLs are either relative to the versioned REST API, e.g.
+// "sobjects/Account/001D000000K0fXOIAZ", or start with /services/data. Subresponses are returned in the order of
+// requests as long as the call itself succeeded; use their Err method to find out about failed subrequests.
+func (client *Client) Batch(haltOnError bool, requests ...BatchSubrequest) ([]BatchSubresponse, error) {
+	return client.BatchContext(context.Background(), haltOnError, requests...)
+}
+
+// BatchContext is like Batch, with the request bound to ctx.
+func (client *Client) BatchContext(ctx context.Context, haltOnError bool, requests ...BatchSubrequest) ([]BatchSubresponse, error) {
+	if len(requests) == 0 {
+		return nil, errors.New("no subrequests to execute")
+	}
+	if len(requests) > maxBatchSubrequests {
+		return nil, errors.Errorf("%d subrequests exceed the maximum of %d", len(requests), maxBatchSubrequests)
+	}
+
+	batch := make([]BatchSubrequest, len(requests))
+	for idx, req := range requests {
+		if strings.HasPrefix(req.URL, "/services/data/") {
+			req.URL = strings.TrimPrefix(req.URL, "/services/data/")
+		} else {
+			req.URL = fmt.Sprintf("v%s/%s", client.APIVersion(), strings.TrimPrefix(req.URL, "/"))
+		}
+		batch[idx] = req
+	}
+	return client.executeBatch(ctx, batch, haltOnError)
+}
+
+// batchQueryURL returns the composite batch URL of q, which could either be a SOQL string or a nextRecordsURL.
+func (client *Client) batchQueryURL(q string) string {
+	if strings.HasPrefix(q, "/services/data/") {
+		return strings.TrimPrefix(q, "/services/data/")
+	}
+	resource := "query"
+	if client.useToolingAPI {
+		resource = "tooling/query"
+	}
+	return fmt.Sprintf("v%s/%s?q=%s", client.APIVersion(), resource, url.QueryEscape(q))
+}
+
+// Queries runs several SOQL queries with as few round trips as possible by packing them into composite batch calls
+// of up to 25 queries each. Results are returned in the order of the queries. If some of the queries fail, the
+// results of the successful ones are still returned, the failed ones are nil, and the error of the first failed
+// query is returned.
+func (client *Client) Queries(soql ...string) ([]*QueryResult, error) {
+	return client.QueriesContext(context.Background(), soql...)
+}
+
+// QueriesContext is like Queries, with the requests bound to ctx.
+func (client *Client) QueriesContext(ctx context.Context, soql ...string) ([]*QueryResult, error) {
+	requests := make([]BatchSubrequest, 0, len(soql))
+	for _, q := range soql {
+		requests = append(requests, BatchSubrequest{Method: http.MethodGet, URL: client.batchQueryURL(q)})
+	}
+
+	responses, err := client.executeBatch(ctx, requests, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	results := make([]*QueryResult, len(responses))
+	for idx, resp := range responses {
+		if !resp.Succeeded() {
+			if firstErr == nil {
+				firstErr = errors.Wrapf(resp.Err(), "query %d failed", idx)
+			}
+			continue
+		}
+
+		var result QueryResult
+		err = json.Unmarshal(resp.Result, &result)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "query %d failed", idx)
+			}
+			continue
+		}
+		for i := range result.Records {
+			result.Records[i].setClient(client)
+		}
+		results[idx] = &result
+	}
+
+	return results, firstErr
+}
+
+// processingHaltedCode is the error code of the composite subrequests which were not processed, or rolled back,
+// because another subrequest failed.
+const processingHaltedCode = "PROCESSING_HALTED"
+
+// CompositeSubrequest is a single request of a composite call. URL is the path of the resource, starting with
+// /services/data. Bodies and URLs may reference the results of previous subrequests, e.g. "@{newAccount.id}".
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_composite.htm
+type CompositeSubrequest struct {
+	Method      string            `json:"method"`
+	URL         string            `json:"url"`
+	ReferenceID string            `json:"referenceId"`
+	Body        interface{}       `json:"body,omitempty"`
+	HTTPHeaders map[string]string `json:"httpHeaders,omitempty"`
+}
+
+// CompositeSubresponse is the outcome of a single subrequest of a composite call.
+type CompositeSubresponse struct {
+	Body           json.RawMessage   `json:"body"`
+	HTTPHeaders    map[string]string `json:"httpHeaders"`
+	HTTPStatusCode int               `json:"httpStatusCode"`
+	ReferenceID    string            `json:"referenceId"`
+}
+
+// Succeeded reports whether the subrequest was processed successfully.
+func (resp *CompositeSubresponse) Succeeded() bool {
+	return resp.HTTPStatusCode >= 200 && resp.HTTPStatusCode <= 299
+}
+
+// Err returns nil if the subrequest succeeded, or the SalesforceError it failed with.
+func (resp *CompositeSubresponse) Err() error {
+	if resp.Succeeded() {
+		return nil
+	}
+	return ParseSalesforceError(resp.HTTPStatusCode, resp.Body)
+}
+
+// Decode decodes the body of a successful subresponse into v, e.g. a *SaveResult for a subrequest creating a record.
+func (resp *CompositeSubresponse) Decode(v interface{}) error {
+	if err := resp.Err(); err != nil {
+		return err
+	}
+	return json.Unmarshal(resp.Body, v)
+}
+
+// Skipped reports whether the subrequest was not processed, or rolled back, because another subrequest failed.
+func (resp *CompositeSubresponse) Skipped() bool {
+	var errs jsonError
+	if resp.Succeeded() || json.Unmarshal(resp.Body, &errs) != nil {
+		return false
+	}
+	for _, e := range errs {
+		if e.ErrorCode != processingHaltedCode {
+			return false
+		}
+	}
+	return len(errs) > 0
+}
+
+// CompositeResult holds the subresponses of a composite call, in the order of the subrequests.
+type CompositeResult struct {
+	Responses []CompositeSubresponse
+}
+
+// Response returns the subresponse of the subrequest with the provided reference ID, or nil.
+func (result *CompositeResult) Response(referenceID string) *CompositeSubresponse {
+	for idx := range result.Responses {
+		if result.Responses[idx].ReferenceID == referenceID {
+			return &result.Responses[idx]
+		}
+	}
+	return nil
+}
+
+// Err returns nil if all the subrequests succeeded, or a *CompositeError telling the failed ones apart from the ones
+// which were skipped or rolled back because of them.
+func (result *CompositeResult) Err() error {
+	return compositeError(result.Responses)
+}
+
+// CompositeFailure identifies a failed subrequest.
+type CompositeFailure struct {
+	// Index is the position of the subrequest in the composite call.
+	Index       int
+	ReferenceID string
+	Err         error
+}
+
+// CompositeError is returned when subrequests of a composite call failed. Failures are the subrequests which caused
+// the call to fail; Skipped holds the reference IDs of the subrequests which were not processed or rolled back
+// because of them.
+type CompositeError struct {
+	Failures []CompositeFailure
+	Skipped  []string
+}
+
+func (err *CompositeError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("composite request failed")
+	for i, failure := range err.Failures {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(failure.ReferenceID + ": ")
+		var sfErr SalesforceError
+		if errors.As(failure.Err, &sfErr) && sfErr.ErrorCode != "" {
+			sb.WriteString(sfErr.ErrorCode + " " + sfErr.ErrorMessage)
+		} else {
+			sb.WriteString(failure.Err.Error())
+		}
+	}
+	if len(err.Skipped) > 0 {
+		sb.WriteString(fmt.Sprintf(" (%d subrequests skipped)", len(err.Skipped)))
+	}
+	return sb.String()
+}
+
+// compositeError returns a *CompositeError describing the failures of responses, or nil if they all succeeded.
+func compositeError(responses []CompositeSubresponse) error {
+	compositeErr := &CompositeError{}
+	for idx := range responses {
+		resp := &responses[idx]
+		switch {
+		case resp.Succeeded():
+		case resp.Skipped():
+			compositeErr.Skipped = append(compositeErr.Skipped, resp.ReferenceID)
+		default:
+			compositeErr.Failures = append(compositeErr.Failures, CompositeFailure{
+				Index:       idx,
+				ReferenceID: resp.ReferenceID,
+				Err:         resp.Err(),
+			})
+		}
+	}
+	if len(compositeErr.Failures) == 0 && len(compositeErr.Skipped) == 0 {
+		return nil
+	}
+	return compositeErr
+}
+
+// Composite executes up to 25 subrequests in a single call, in order. Subrequests can reference the results of the
+// previous ones. If allOrNone is set, a failure rolls back all the subrequests. The result is returned as long as the
+// call itself succeeded; use its Err method to find out about failed subrequests.
+func (client *Client) Composite(allOrNone bool, requests []CompositeSubrequest) (*CompositeResult, error) {
+	return client.CompositeContext(context.Background(), allOrNone, requests)
+}
+
+// CompositeContext is like Composite, with the request bound to ctx.
+func (client *Client) CompositeContext(ctx context.Context, allOrNone bool, requests []CompositeSubrequest) (*CompositeResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	reqData, err := json.Marshal(map[string]interface{}{
+		"allOrNone":        allOrNone,
+		"compositeRequest": requests,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("composite")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+
+	var result struct {
+		CompositeResponse []CompositeSubresponse `json:"compositeResponse"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &CompositeResult{Responses: result.CompositeResponse}, nil
+}
+
+// maxCompositeSubrequests is the maximum number of subrequests of a single composite call.
+const maxCompositeSubrequests = 25
+
+// CompositeBuilder builds a composite call subrequest by subrequest:
+//
+//	comp := client.NewComposite().AllOrNone(true)
+//	comp.Add(http.MethodPost, "sobjects/Account", map[string]string{"Name": "Acme"}).Ref("refAccount")
+//	comp.Add(http.MethodPost, "sobjects/Contact", map[string]string{"LastName": "Doe", "AccountId": "@{refAccount.id}"})
+//	result, err := comp.Execute()
+type CompositeBuilder struct {
+	client    *Client
+	allOrNone bool
+	requests  []CompositeSubrequest
+}
+
+// NewComposite creates an empty CompositeBuilder.
+func (client *Client) NewComposite() *CompositeBuilder {
+	return &CompositeBuilder{client: client}
+}
+
+// AllOrNone sets whether a failure rolls back all the subrequests.
+func (b *CompositeBuilder) AllOrNone(allOrNone bool) *CompositeBuilder {
+	b.allOrNone = allOrNone
+	return b
+}
+
+// Add appends a subrequest sending body to url, which is either relative to the versioned REST API, e.g.
+// "sobjects/Account", or starts with /services/data. The subrequest is given the reference ID "ref" followed by its
+// position; use Ref to name it.
+func (b *CompositeBuilder) Add(method, url string, body interface{}) *CompositeBuilder {
+	if !strings.HasPrefix(url, "/services/data/") {
+		url = fmt.Sprintf("/services/data/v%s/%s", b.client.APIVersion(), strings.TrimPrefix(url, "/"))
+	}
+	b.requests = append(b.requests, CompositeSubrequest{
+		Method:      method,
+		URL:         url,
+		ReferenceID: fmt.Sprintf("ref%d", len(b.requests)),
+		Body:        body,
+	})
+	return b
+}
+
+// Ref sets the reference ID of the last added subrequest, by which later subrequests reference its result, e.g.
+// "@{refAccount.id}".
+func (b *CompositeBuilder) Ref(referenceID string) *CompositeBuilder {
+	if len(b.requests) > 0 {
+		b.requests[len(b.requests)-1].ReferenceID = referenceID
+	}
+	return b
+}
+
+// Header sets an HTTP header of the last added subrequest, e.g. If-Match.
+func (b *CompositeBuilder) Header(key, value string) *CompositeBuilder {
+	if len(b.requests) > 0 {
+		last := &b.requests[len(b.requests)-1]
+		if last.HTTPHeaders == nil {
+			last.HTTPHeaders = map[string]string{}
+		}
+		last.HTTPHeaders[key] = value
+	}
+	return b
+}
+
+// Requests returns the subrequests added so far.
+func (b *CompositeBuilder) Requests() []CompositeSubrequest {
+	return b.requests
+}
+
+// Execute sends the subrequests in a single composite call, see Client.Composite.
+func (b *CompositeBuilder) Execute() (*CompositeResult, error) {
+	return b.ExecuteContext(context.Background())
+}
+
+// ExecuteContext is like Execute, with the request bound to ctx.
+func (b *CompositeBuilder) ExecuteContext(ctx context.Context) (*CompositeResult, error) {
+	if len(b.requests) == 0 {
+		return nil, errors.New("no subrequests to execute")
+	}
+	if len(b.requests) > maxCompositeSubrequests {
+		return nil, errors.Errorf("%d subrequests exceed the maximum of %d", len(b.requests), maxCompositeSubrequests)
+	}
+	seen := map[string]bool{}
+	for _, req := range b.requests {
+		if seen[req.ReferenceID] {
+			return nil, errors.Errorf("duplicate reference ID %s", req.ReferenceID)
+		}
+		seen[req.ReferenceID] = true
+	}
+	return b.client.CompositeContext(ctx, b.allOrNone, b.requests)
+}
@@ -0,0 +1,123 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// maxGraphNodes is the maximum number of subrequests, or nodes, of a single graph of a Composite Graph call.
+const maxGraphNodes = 500
+
+// maxGraphs is the maximum number of graphs of a single Composite Graph call.
+const maxGraphs = 75
+
+// CompositeGraph is a graph of a Composite Graph call: its subrequests, or nodes, are processed in a single
+// transaction and can reference the results of the previous ones, e.g. "@{newAccount.id}".
+type CompositeGraph struct {
+	GraphID  string                `json:"graphId"`
+	Requests []CompositeSubrequest `json:"compositeRequest"`
+}
+
+// CompositeGraphResult is the outcome of a graph of a Composite Graph call. Its Responses are in the order of the
+// nodes of the graph; use Response to get the result of a node by reference ID and Err to find out about the failed
+// ones.
+type CompositeGraphResult struct {
+	GraphID      string
+	IsSuccessful bool
+	CompositeResult
+}
+
+// Err returns nil if the graph was processed successfully, or a *CompositeError telling the failed nodes apart from
+// the ones which were rolled back because of them.
+func (result *CompositeGraphResult) Err() error {
+	err := compositeError(result.Responses)
+	if err == nil && !result.IsSuccessful {
+		return errors.Errorf("graph %s failed", result.GraphID)
+	}
+	return err
+}
+
+// Graph returns the subrequests added so far as a graph identified by graphID, to be sent with
+// Client.CompositeGraph.
+func (b *CompositeBuilder) Graph(graphID string) CompositeGraph {
+	requests := make([]CompositeSubrequest, len(b.requests))
+	copy(requests, b.requests)
+	return CompositeGraph{GraphID: graphID, Requests: requests}
+}
+
+// CompositeGraph executes graphs of up to 500 subrequests each in a single call. Each graph is processed in its own
+// transaction: either all of its nodes succeed or none, independently of the other graphs. The results are returned
+// in the order of graphs as long as the call itself succeeded; use their Err method to find out about failed graphs.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_graph.htm
+func (client *Client) CompositeGraph(graphs ...CompositeGraph) ([]CompositeGraphResult, error) {
+	return client.CompositeGraphContext(context.Background(), graphs...)
+}
+
+// CompositeGraphContext is like CompositeGraph, with the request bound to ctx.
+func (client *Client) CompositeGraphContext(ctx context.Context, graphs ...CompositeGraph) ([]CompositeGraphResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	if len(graphs) == 0 {
+		return nil, errors.New("no graphs to execute")
+	}
+	if len(graphs) > maxGraphs {
+		return nil, errors.Errorf("%d graphs exceed the maximum of %d", len(graphs), maxGraphs)
+	}
+	seen := map[string]bool{}
+	for _, graph := range graphs {
+		if seen[graph.GraphID] {
+			return nil, errors.Errorf("duplicate graph ID %s", graph.GraphID)
+		}
+		seen[graph.GraphID] = true
+		if len(graph.Requests) == 0 {
+			return nil, errors.Errorf("graph %s has no subrequests", graph.GraphID)
+		}
+		if len(graph.Requests) > maxGraphNodes {
+			return nil, errors.Errorf("graph %s: %d subrequests exceed the maximum of %d", graph.GraphID, len(graph.Requests), maxGraphNodes)
+		}
+	}
+
+	reqData, err := json.Marshal(map[string]interface{}{"graphs": graphs})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("composite/graph")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+
+	var result struct {
+		Graphs []struct {
+			GraphID       string `json:"graphId"`
+			IsSuccessful  bool   `json:"isSuccessful"`
+			GraphResponse struct {
+				CompositeResponse []CompositeSubresponse `json:"compositeResponse"`
+			} `json:"graphResponse"`
+		} `json:"graphs"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Graphs) != len(graphs) {
+		return nil, errors.Errorf("expected %d graph results, got %d", len(graphs), len(result.Graphs))
+	}
+
+	results := make([]CompositeGraphResult, len(result.Graphs))
+	for idx, graph := range result.Graphs {
+		results[idx] = CompositeGraphResult{
+			GraphID:         graph.GraphID,
+			IsSuccessful:    graph.IsSuccessful,
+			CompositeResult: CompositeResult{Responses: graph.GraphResponse.CompositeResponse},
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,82 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_CompositeGraph(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/composite/graph" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var req struct {
+			Graphs []CompositeGraph `json:"graphs"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Graphs) != 2 || req.Graphs[0].GraphID != "acme" || len(req.Graphs[0].Requests) != 2 ||
+			req.Graphs[0].Requests[0].ReferenceID != "account" || req.Graphs[0].Requests[1].URL != "/services/data/v54.0/sobjects/Contact" {
+			t.Errorf("unexpected graphs %+v", req.Graphs)
+		}
+		w.Write([]byte(`{"graphs":[
+			{"graphId":"acme","isSuccessful":true,"graphResponse":{"compositeResponse":[
+				{"body":{"id":"0013000000Db2wKAAR","success":true,"errors":[]},"httpHeaders":{},"httpStatusCode":201,"referenceId":"account"},
+				{"body":{"id":"0033000000Db2wKAAR","success":true,"errors":[]},"httpHeaders":{},"httpStatusCode":201,"referenceId":"ref1"}]}},
+			{"graphId":"globex","isSuccessful":false,"graphResponse":{"compositeResponse":[
+				{"body":[{"errorCode":"PROCESSING_HALTED","message":"The transaction was rolled back since another operation in the same transaction failed."}],"httpHeaders":{},"httpStatusCode":400,"referenceId":"account"},
+				{"body":[{"errorCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [LastName]","fields":["LastName"]}],"httpHeaders":{},"httpStatusCode":400,"referenceId":"ref1"}]}}
+		]}`))
+	})
+
+	acme := client.NewComposite()
+	acme.Add(http.MethodPost, "sobjects/Account", map[string]string{"Name": "Acme"}).Ref("account")
+	acme.Add(http.MethodPost, "sobjects/Contact", map[string]string{"LastName": "Doe", "AccountId": "@{account.id}"})
+	globex := client.NewComposite()
+	globex.Add(http.MethodPost, "sobjects/Account", map[string]string{"Name": "Globex"}).Ref("account")
+	globex.Add(http.MethodPost, "sobjects/Contact", map[string]string{"AccountId": "@{account.id}"})
+
+	results, err := client.CompositeGraph(acme.Graph("acme"), globex.Graph("globex"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Err() != nil {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	var contact SaveResult
+	if err = results[0].Response("ref1").Decode(&contact); err != nil || contact.ID != "0033000000Db2wKAAR" {
+		t.Errorf("unexpected contact %+v: %v", contact, err)
+	}
+
+	var compositeErr *CompositeError
+	if !errors.As(results[1].Err(), &compositeErr) {
+		t.Fatalf("expected a CompositeError, got %v", results[1].Err())
+	}
+	if len(compositeErr.Failures) != 1 || compositeErr.Failures[0].ReferenceID != "ref1" ||
+		len(compositeErr.Skipped) != 1 || compositeErr.Skipped[0] != "account" {
+		t.Errorf("unexpected error %v", compositeErr)
+	}
+	if err = results[1].Response("account").Decode(&contact); err == nil {
+		t.Error("expected an error decoding a rolled back node")
+	}
+}
+
+func TestClient_CompositeGraphLimits(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	if _, err := client.CompositeGraph(); err == nil {
+		t.Error("expected an error without graphs")
+	}
+	node := CompositeSubrequest{Method: http.MethodGet, URL: "/services/data/v54.0/limits", ReferenceID: "limits"}
+	if _, err := client.CompositeGraph(CompositeGraph{GraphID: "1", Requests: []CompositeSubrequest{node}},
+		CompositeGraph{GraphID: "1", Requests: []CompositeSubrequest{node}}); err == nil {
+		t.Error("expected an error for duplicate graph IDs")
+	}
+	if _, err := client.CompositeGraph(CompositeGraph{GraphID: "1", Requests: make([]CompositeSubrequest, 501)}); err == nil {
+		t.Error("expected an error for more than 500 nodes")
+	}
+}
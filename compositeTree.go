@@ -0,0 +1,152 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// maxTreeRecords is the maximum number of records, across all levels, of a single Composite Tree call.
+const maxTreeRecords = 200
+
+// TreeRecord is a record of a hierarchy created with CreateTree, along with its child records.
+type TreeRecord struct {
+	// ReferenceID identifies the record in the results; it's assigned by CreateTree if empty.
+	ReferenceID string
+	Record      *SObject
+	// Children maps child relationship names, e.g. "Contacts" for an Account, to the child records.
+	Children map[string][]*TreeRecord
+}
+
+// NewTreeRecord creates a TreeRecord for record.
+func NewTreeRecord(record *SObject) *TreeRecord {
+	return &TreeRecord{Record: record}
+}
+
+// AddChildren adds child records through the child relationship relationship.
+func (r *TreeRecord) AddChildren(relationship string, children ...*TreeRecord) *TreeRecord {
+	if r.Children == nil {
+		r.Children = map[string][]*TreeRecord{}
+	}
+	r.Children[relationship] = append(r.Children[relationship], children...)
+	return r
+}
+
+// CreateTree creates record hierarchies of up to 200 records in a single call, e.g. accounts along with their contacts.
+// records are the root records, which are all of type sobject. Either all the records are created or none: on
+// failure a *CompositeError tells which records failed. The IDs of the created records are set on their SObjects, and
+// returned by reference ID.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_sobject_tree.htm
+func (client *Client) CreateTree(sobject string, records []*TreeRecord) (map[string]string, error) {
+	return client.CreateTreeContext(context.Background(), sobject, records)
+}
+
+// CreateTreeContext is like CreateTree, with the request bound to ctx.
+func (client *Client) CreateTreeContext(ctx context.Context, sobject string, records []*TreeRecord) (map[string]string, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	byReference := map[string]*TreeRecord{}
+	payload, err := treePayload(records, byReference)
+	if err != nil {
+		return nil, err
+	}
+	if len(byReference) > maxTreeRecords {
+		return nil, errors.Errorf("%d records exceed the maximum of %d", len(byReference), maxTreeRecords)
+	}
+	reqData, err := json.Marshal(map[string]interface{}{"records": payload})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("composite/tree/" + sobject)
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	// Failures are reported with a 400 status along with the results, which ParseSalesforceError keeps as the message
+	// of the error as they aren't an error list.
+	var sfErr SalesforceError
+	failed := errors.As(err, &sfErr) && sfErr.HttpCode == http.StatusBadRequest
+	if err != nil && !failed {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+	if failed {
+		data = []byte(sfErr.Message)
+	}
+
+	var result struct {
+		HasErrors bool `json:"hasErrors"`
+		Results   []struct {
+			ReferenceID string      `json:"referenceId"`
+			ID          string      `json:"id"`
+			Errors      []SaveError `json:"errors"`
+		} `json:"results"`
+	}
+	if jsonErr := json.Unmarshal(data, &result); jsonErr != nil || (failed && !result.HasErrors) {
+		if failed {
+			return nil, err
+		}
+		return nil, jsonErr
+	}
+
+	if result.HasErrors {
+		compositeErr := &CompositeError{}
+		for idx, res := range result.Results {
+			failure := CompositeFailure{Index: idx, ReferenceID: res.ReferenceID, Err: ErrFailure}
+			if len(res.Errors) > 0 {
+				failure.Err = SaveResult{Errors: res.Errors}.Err()
+			}
+			compositeErr.Failures = append(compositeErr.Failures, failure)
+		}
+		return nil, compositeErr
+	}
+
+	ids := make(map[string]string, len(result.Results))
+	for _, res := range result.Results {
+		ids[res.ReferenceID] = res.ID
+		if record, ok := byReference[res.ReferenceID]; ok {
+			record.Record.setID(res.ID)
+		}
+	}
+	return ids, nil
+}
+
+// treePayload converts records and their children into the representation expected by Composite Tree, assigning the
+// missing reference IDs. All the records are indexed by reference ID into byReference.
+func treePayload(records []*TreeRecord, byReference map[string]*TreeRecord) ([]map[string]interface{}, error) {
+	payload := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		if record.Record == nil || record.Record.Type() == "" {
+			return nil, errors.New("record type is required")
+		}
+		if record.ReferenceID == "" {
+			record.ReferenceID = fmt.Sprintf("ref%d", len(byReference)+1)
+		}
+		if _, ok := byReference[record.ReferenceID]; ok {
+			return nil, errors.Errorf("duplicate reference ID %s", record.ReferenceID)
+		}
+		byReference[record.ReferenceID] = record
+
+		fields := record.Record.makeCopy()
+		fields[sobjectAttributesKey] = map[string]string{"type": record.Record.Type(), "referenceId": record.ReferenceID}
+		relationships := make([]string, 0, len(record.Children))
+		for relationship := range record.Children {
+			relationships = append(relationships, relationship)
+		}
+		sort.Strings(relationships)
+		for _, relationship := range relationships {
+			childPayload, err := treePayload(record.Children[relationship], byReference)
+			if err != nil {
+				return nil, err
+			}
+			fields[relationship] = map[string]interface{}{"records": childPayload}
+		}
+		payload = append(payload, fields)
+	}
+	return payload, nil
+}
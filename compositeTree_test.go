@@ -0,0 +1,91 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_CreateTree(t *testing.T) {
+	fail := false
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/composite/tree/Account" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			Records []struct {
+				Attributes map[string]string `json:"attributes"`
+				Name       string
+				Contacts   struct {
+					Records []map[string]interface{} `json:"records"`
+				}
+			} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Records) != 1 || req.Records[0].Attributes["referenceId"] != "acme" || req.Records[0].Name != "Acme" ||
+			len(req.Records[0].Contacts.Records) != 2 {
+			t.Errorf("unexpected records %+v", req)
+			return
+		}
+		if ref := req.Records[0].Contacts.Records[1]["attributes"].(map[string]interface{})["referenceId"]; ref != "ref3" {
+			t.Errorf("unexpected reference ID %v", ref)
+		}
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"hasErrors":true,"results":[{"referenceId":"ref2","errors":[{"statusCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [LastName]","fields":["LastName"]}]}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"hasErrors":false,"results":[{"referenceId":"acme","id":"0013000000Db2wKAAR"},
+			{"referenceId":"ref2","id":"0033000000Db2wKAAR"},{"referenceId":"ref3","id":"0033000000Db2wLAAR"}]}`))
+	})
+
+	tree := func() (*TreeRecord, *SObject) {
+		contact := client.SObject("Contact").Set("LastName", "Doe")
+		account := &TreeRecord{ReferenceID: "acme", Record: client.SObject("Account").Set("Name", "Acme")}
+		account.AddChildren("Contacts", NewTreeRecord(contact), NewTreeRecord(client.SObject("Contact").Set("LastName", "Roe")))
+		return account, contact
+	}
+
+	account, contact := tree()
+	ids, err := client.CreateTree("Account", []*TreeRecord{account})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids["acme"] != "0013000000Db2wKAAR" || account.Record.ID() != "0013000000Db2wKAAR" ||
+		contact.ID() != "0033000000Db2wKAAR" {
+		t.Errorf("unexpected IDs %v", ids)
+	}
+
+	fail = true
+	account, _ = tree()
+	_, err = client.CreateTree("Account", []*TreeRecord{account})
+	var compositeErr *CompositeError
+	if !errors.As(err, &compositeErr) || len(compositeErr.Failures) != 1 || compositeErr.Failures[0].ReferenceID != "ref2" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	var sfErr SalesforceError
+	if !errors.As(compositeErr.Failures[0].Err, &sfErr) || sfErr.ErrorCode != "REQUIRED_FIELD_MISSING" || sfErr.Fields()[0] != "LastName" {
+		t.Errorf("unexpected failure %v", compositeErr.Failures[0].Err)
+	}
+}
+
+func TestClient_CreateTreeContext(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(HeaderAutoAssign) != "FALSE" {
+			t.Errorf("expected the headers of the context, got %v", r.Header)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`[{"message":"The requested resource does not exist","errorCode":"NOT_FOUND"}]`))
+	})
+
+	ctx := ContextWithAutoAssign(context.Background(), false)
+	_, err := client.CreateTreeContext(ctx, "Account", []*TreeRecord{NewTreeRecord(client.SObject("Account").Set("Name", "Acme"))})
+	var sfErr SalesforceError
+	if !errors.As(err, &sfErr) || sfErr.ErrorCode != "NOT_FOUND" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_Queries(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/services/data/v"+DefaultAPIVersion+"/composite/batch" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var req struct {
+			BatchRequests []BatchSubrequest `json:"batchRequests"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		results := []map[string]interface{}{}
+		for _, sub := range req.BatchRequests {
+			u, _ := url.Parse(sub.URL)
+			q := u.Query().Get("q")
+			if strings.Contains(q, "Invalid") {
+				results = append(results, map[string]interface{}{
+					"statusCode": 400,
+					"result":     []map[string]string{{"errorCode": "INVALID_TYPE", "message": "sObject type 'Invalid' is not supported."}},
+				})
+				continue
+			}
+			results = append(results, map[string]interface{}{
+				"statusCode": 200,
+				"result": map[string]interface{}{
+					"totalSize": 1,
+					"done":      true,
+					"records":   []map[string]interface{}{{"attributes": map[string]string{"type": "Account"}, "Name": q}},
+				},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"hasErrors": false, "results": results})
+	})
+
+	soql := make([]string, 30)
+	for i := range soql {
+		soql[i] = fmt.Sprintf("SELECT Name FROM Account LIMIT %d", i+1)
+	}
+	soql[27] = "SELECT Name FROM Invalid"
+
+	results, err := client.Queries(soql...)
+	if calls != 2 {
+		t.Errorf("expected 2 batch calls, got %d", calls)
+	}
+	var sfErr SalesforceError
+	if !errors.As(err, &sfErr) || sfErr.ErrorCode != "INVALID_TYPE" || !strings.Contains(err.Error(), "query 27") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if len(results) != 30 || results[27] != nil {
+		t.Fatalf("unexpected results %v", results)
+	}
+	for i, result := range results {
+		if i != 27 && result.Records[0].StringField("Name") != soql[i] {
+			t.Errorf("result %d out of order", i)
+		}
+	}
+}
+
+func TestClient_Batch(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/composite/batch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var req struct {
+			BatchRequests []BatchSubrequest `json:"batchRequests"`
+			HaltOnError   bool              `json:"haltOnError"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.HaltOnError || len(req.BatchRequests) != 3 || req.BatchRequests[0].URL != "v54.0/sobjects/Account/001D000000K0fXOIAZ" ||
+			req.BatchRequests[1].URL != "v54.0/sobjects/Contact/003D000000QV9n2IAD" {
+			t.Errorf("unexpected batch %+v", req)
+		}
+		w.Write([]byte(`{"hasErrors":true,"results":[
+			{"statusCode":204,"result":null},
+			{"statusCode":404,"result":[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]},
+			{"statusCode":412,"result":[{"errorCode":"BATCH_PROCESSING_HALTED","message":"Batch processing halted per request"}]}]}`))
+	})
+
+	responses, err := client.Batch(true,
+		BatchSubrequest{Method: http.MethodPatch, URL: "sobjects/Account/001D000000K0fXOIAZ", RichInput: map[string]string{"Name": "Acme"}},
+		BatchSubrequest{Method: http.MethodDelete, URL: "/services/data/v54.0/sobjects/Contact/003D000000QV9n2IAD"},
+		BatchSubrequest{Method: http.MethodGet, URL: "limits"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(responses) != 3 || responses[0].Err() != nil {
+		t.Fatalf("unexpected responses %+v", responses)
+	}
+	var sfErr SalesforceError
+	if err := responses[1].Err(); !errors.As(err, &sfErr) || sfErr.ErrorCode != "NOT_FOUND" || responses[1].Skipped() {
+		t.Errorf("expected the second subrequest to fail with NOT_FOUND, got %v", err)
+	}
+	if !responses[2].Skipped() {
+		t.Errorf("expected the third subrequest to be skipped, got %s", responses[2].Result)
+	}
+
+	if _, err = client.Batch(false, make([]BatchSubrequest, 26)...); err == nil {
+		t.Error("expected an error for more than 25 subrequests")
+	}
+}
+
+func TestClient_Composite(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			AllOrNone        bool                  `json:"allOrNone"`
+			CompositeRequest []CompositeSubrequest `json:"compositeRequest"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/services/data/v54.0/composite" || !req.AllOrNone || len(req.CompositeRequest) != 3 {
+			t.Errorf("unexpected request %s %v", r.URL.Path, req)
+		}
+		w.Write([]byte(`{"compositeResponse":[
+			{"body":[{"errorCode":"PROCESSING_HALTED","message":"The transaction was rolled back since another operation in the same transaction failed."}],"httpHeaders":{},"httpStatusCode":400,"referenceId":"account"},
+			{"body":[{"errorCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [LastName]","fields":["LastName"]}],"httpHeaders":{},"httpStatusCode":400,"referenceId":"contact"},
+			{"body":[{"errorCode":"PROCESSING_HALTED","message":"The transaction was rolled back since another operation in the same transaction failed."}],"httpHeaders":{},"httpStatusCode":400,"referenceId":"case"}
+		]}`))
+	})
+
+	result, err := client.Composite(true, []CompositeSubrequest{
+		{Method: http.MethodPost, URL: "/services/data/v54.0/sobjects/Account", ReferenceID: "account", Body: map[string]string{"Name": "Acme"}},
+		{Method: http.MethodPost, URL: "/services/data/v54.0/sobjects/Contact", ReferenceID: "contact", Body: map[string]string{"AccountId": "@{account.id}"}},
+		{Method: http.MethodPost, URL: "/services/data/v54.0/sobjects/Case", ReferenceID: "case", Body: map[string]string{"ContactId": "@{contact.id}"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Response("account").Skipped() || result.Response("contact").Skipped() {
+		t.Errorf("unexpected statuses %v", result.Responses)
+	}
+
+	var compositeErr *CompositeError
+	if !errors.As(result.Err(), &compositeErr) {
+		t.Fatalf("expected a CompositeError, got %v", result.Err())
+	}
+	if len(compositeErr.Failures) != 1 || compositeErr.Failures[0].ReferenceID != "contact" || compositeErr.Failures[0].Index != 1 {
+		t.Errorf("unexpected failures %v", compositeErr.Failures)
+	}
+	if len(compositeErr.Skipped) != 2 || compositeErr.Skipped[0] != "account" || compositeErr.Skipped[1] != "case" {
+		t.Errorf("unexpected skipped subrequests %v", compositeErr.Skipped)
+	}
+	var sfErr SalesforceError
+	if !errors.As(compositeErr.Failures[0].Err, &sfErr) || sfErr.ErrorCode != "REQUIRED_FIELD_MISSING" {
+		t.Errorf("unexpected failure %v", compositeErr.Failures[0].Err)
+	}
+	if compositeErr.Error() != "composite request failed: contact: REQUIRED_FIELD_MISSING Required fields are missing: [LastName] (2 subrequests skipped)" {
+		t.Errorf("unexpected message %s", compositeErr)
+	}
+}
+
+func TestCompositeBuilder_Execute(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			AllOrNone        bool                  `json:"allOrNone"`
+			CompositeRequest []CompositeSubrequest `json:"compositeRequest"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		if !req.AllOrNone || len(req.CompositeRequest) != 2 {
+			t.Errorf("unexpected request %+v", req)
+			return
+		}
+		account, contact := req.CompositeRequest[0], req.CompositeRequest[1]
+		if account.ReferenceID != "refAccount" || account.URL != "/services/data/v54.0/sobjects/Account" {
+			t.Errorf("unexpected account subrequest %+v", account)
+		}
+		if contact.ReferenceID != "ref1" || contact.HTTPHeaders["Sforce-Auto-Assign"] != "FALSE" ||
+			contact.Body.(map[string]interface{})["AccountId"] != "@{refAccount.id}" {
+			t.Errorf("unexpected contact subrequest %+v", contact)
+		}
+		w.Write([]byte(`{"compositeResponse":[
+			{"body":{"id":"0013000000Db2wKAAR","success":true,"errors":[]},"httpHeaders":{},"httpStatusCode":201,"referenceId":"refAccount"},
+			{"body":{"id":"0033000000Db2wKAAR","success":true,"errors":[]},"httpHeaders":{},"httpStatusCode":201,"referenceId":"ref1"}
+		]}`))
+	})
+
+	comp := client.NewComposite().AllOrNone(true)
+	comp.Add(http.MethodPost, "sobjects/Account", map[string]string{"Name": "Acme"}).Ref("refAccount")
+	comp.Add(http.MethodPost, "/services/data/v54.0/sobjects/Contact", map[string]string{"LastName": "Doe", "AccountId": "@{refAccount.id}"}).
+		Header("Sforce-Auto-Assign", "FALSE")
+	result, err := comp.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Err() != nil || result.Response("refAccount") == nil || !result.Response("ref1").Succeeded() {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	comp = client.NewComposite()
+	comp.Add(http.MethodGet, "sobjects/Account/0013000000Db2wKAAR", nil).Ref("same")
+	comp.Add(http.MethodGet, "sobjects/Account/0013000000Db2wLAAR", nil).Ref("same")
+	if _, err := comp.Execute(); err == nil {
+		t.Error("expected an error for duplicate reference IDs")
+	}
+	if _, err := client.NewComposite().Execute(); err == nil {
+		t.Error("expected an error without subrequests")
+	}
+}
@@ -0,0 +1,78 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ETag returns the entity tag of the record as last read by Get or one of the conditional reads, or an empty string.
+// It identifies a version of the record for GetIfNoneMatch and UpdateIfMatch.
+func (obj *SObject) ETag() string {
+	return obj.StringField(sobjectETagKey)
+}
+
+// GetIfNoneMatch reads the record like Get, unless its entity tag is still etag: ErrNotModified is then returned and
+// the SObject is left as is, which saves transferring the record again, e.g. to refresh a cache. ID is required.
+func (obj *SObject) GetIfNoneMatch(etag string) error {
+	return obj.GetIfNoneMatchContext(context.Background(), etag)
+}
+
+// GetIfNoneMatchContext is like GetIfNoneMatch, with the request bound to ctx.
+func (obj *SObject) GetIfNoneMatchContext(ctx context.Context, etag string) error {
+	return obj.getIfModified(ctx, http.Header{"If-None-Match": {etag}})
+}
+
+// GetIfModifiedSince reads the record like Get, unless it wasn't modified since since, in which case ErrNotModified is
+// returned and the SObject is left as is. ID is required.
+func (obj *SObject) GetIfModifiedSince(since time.Time) error {
+	return obj.GetIfModifiedSinceContext(context.Background(), since)
+}
+
+// GetIfModifiedSinceContext is like GetIfModifiedSince, with the request bound to ctx.
+func (obj *SObject) GetIfModifiedSinceContext(ctx context.Context, since time.Time) error {
+	return obj.getIfModified(ctx, http.Header{"If-Modified-Since": {since.UTC().Format(http.TimeFormat)}})
+}
+
+func (obj *SObject) getIfModified(ctx context.Context, header http.Header) error {
+	if obj.Type() == "" || obj.client() == nil || obj.ID() == "" {
+		// Sanity check.
+		return ErrFailure
+	}
+	return obj.getRecord(ctx, obj.ID(), nil, header)
+}
+
+// UpdateIfMatch updates SObject in place on condition that the entity tag of the record is still etag, usually the
+// ETag of the SObject as read. ErrConflict is returned if the record was modified in the meantime; read the record
+// again and retry the update. ID is required.
+func (obj *SObject) UpdateIfMatch(etag string) error {
+	return obj.UpdateIfMatchContext(context.Background(), etag)
+}
+
+// UpdateIfMatchContext is like UpdateIfMatch, with the request bound to ctx.
+func (obj *SObject) UpdateIfMatchContext(ctx context.Context, etag string) error {
+	if obj.Type() == "" || obj.client() == nil || obj.ID() == "" {
+		// Sanity check.
+		return ErrFailure
+	}
+	if etag == "" {
+		return errors.New("etag is required to detect conflicts")
+	}
+
+	reqData, err := json.Marshal(obj.makeCopy())
+	if err != nil {
+		return err
+	}
+
+	resp, err := obj.client().httpResponseContext(ctx, http.MethodPatch, obj.recordURL(), bytes.NewReader(reqData),
+		http.Header{"If-Match": {etag}})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
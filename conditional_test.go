@@ -0,0 +1,87 @@
+package simpleforce
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestSObject_ConditionalRequests(t *testing.T) {
+	etag := `"gtfIvqwCyjDaXfQfa3vYLkdTHRU93RnFPBltM1CyP8c="`
+	name := "Acme"
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/sobjects/Account/001D000000IqhSLIAZ" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if r.Header.Get("If-None-Match") == etag ||
+				r.Header.Get("If-Modified-Since") == "Fri, 29 Apr 2022 10:00:00 GMT" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			w.Write([]byte(`{"attributes":{"type":"Account"},"Id":"001D000000IqhSLIAZ","Name":"` + name + `"}`))
+		case http.MethodPatch:
+			if r.Header.Get("If-Match") != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				w.Write([]byte(`[{"errorCode":"PRECONDITION_FAILED","message":"The entity has been modified"}]`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	account := client.SObject("Account").Get("001D000000IqhSLIAZ")
+	if account == nil || account.ETag() != etag {
+		t.Fatalf("unexpected account %v", account)
+	}
+	if _, ok := account.makeCopy()[sobjectETagKey]; ok {
+		t.Error("expected the ETag not to be sent in updates")
+	}
+
+	name = "Acme Corp"
+	err := account.GetIfNoneMatch(account.ETag())
+	if !errors.Is(err, ErrNotModified) || account.StringField("Name") != "Acme" {
+		t.Errorf("unexpected result %v, %v", err, account)
+	}
+	err = account.GetIfModifiedSince(time.Date(2022, 4, 29, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600)))
+	if !errors.Is(err, ErrNotModified) {
+		t.Errorf("unexpected error %v", err)
+	}
+	err = account.GetIfNoneMatch(`"stale"`)
+	if err != nil || account.StringField("Name") != "Acme Corp" {
+		t.Errorf("unexpected result %v, %v", err, account)
+	}
+
+	if err = account.Set("Name", "Acme Inc").UpdateIfMatch(account.ETag()); err != nil {
+		t.Error(err)
+	}
+	if err = account.UpdateIfMatch(`"stale"`); !errors.Is(err, ErrConflict) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestSObject_ConditionalRequestsContext(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	account := client.SObject("Account").Set("Id", "001D000000IqhSLIAZ")
+	if err := account.GetIfNoneMatchContext(ctx, `"stale"`); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := account.GetIfModifiedSinceContext(ctx, time.Now()); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected the canceled context to stop the requests, got %d calls", calls)
+	}
+}
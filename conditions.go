@@ -0,0 +1,272 @@
+package simpleforce
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const soqlDateFormat = "2006-01-02"
+
+// soqlIdentifier matches the field names conditions accept: a field or relationship path such as "Account.Name",
+// optionally wrapped in a function such as "CALENDAR_YEAR(CreatedDate)".
+var soqlIdentifier = regexp.MustCompile(`^(?:[A-Za-z]\w*\()?[A-Za-z]\w*(?:\.[A-Za-z]\w*)*(?:\))?$`)
+
+// likeEscaper escapes the wildcards of LIKE patterns, once the pattern is escaped as a string literal.
+var likeEscaper = strings.NewReplacer("%", `\%`, "_", `\_`)
+
+// soqlNumber matches the number literals SOQL accepts, unlike strconv.ParseFloat which also accepts e.g. "NaN", "Inf",
+// "0x1p3" or "1_000".
+var soqlNumber = regexp.MustCompile(`^-?\d+(?:\.\d+)?$`)
+
+// checkIdentifier returns an error unless name is a valid SOQL identifier, so that names coming from user input, e.g.
+// a column to filter by, can't alter the query.
+func checkIdentifier(name string) error {
+	if !soqlIdentifier.MatchString(name) || strings.Count(name, "(") != strings.Count(name, ")") {
+		return errors.Wrap(ErrInvalidQuery, fmt.Sprintf("invalid identifier %q", name))
+	}
+	return nil
+}
+
+// fieldTypes maps lower-cased field names of an object to their describe type, e.g. "amount" => "currency".
+type fieldTypes map[string]string
+
+// newFieldTypes extracts the field types from describe metadata. nil is returned if meta holds no fields.
+func newFieldTypes(meta *SObjectMeta) fieldTypes {
+	if meta == nil {
+		return nil
+	}
+	rawFields, _ := (*meta)["fields"].([]interface{})
+	if len(rawFields) == 0 {
+		return nil
+	}
+
+	types := make(fieldTypes, len(rawFields))
+	for _, rawField := range rawFields {
+		field, ok := rawField.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := field["name"].(string)
+		fieldType, _ := field["type"].(string)
+		if name != "" {
+			types[strings.ToLower(name)] = fieldType
+		}
+	}
+	return types
+}
+
+// lookup returns the describe type of field. ok is false if field is unknown while metadata is present; relationship
+// paths such as "Account.Name" are not validated and have an empty type.
+func (types fieldTypes) lookup(field string) (fieldType string, ok bool) {
+	if types == nil || strings.Contains(field, ".") {
+		return "", true
+	}
+	fieldType, ok = types[strings.ToLower(field)]
+	return fieldType, ok
+}
+
+// format renders value as a literal matching the describe type of field.
+func (types fieldTypes) format(field string, value interface{}) (string, error) {
+	if err := checkIdentifier(field); err != nil {
+		return "", err
+	}
+	fieldType, ok := types.lookup(field)
+	if !ok {
+		return "", errors.Wrap(ErrInvalidQuery, "unknown field "+field)
+	}
+	if value == nil {
+		return "null", nil
+	}
+	if literal, isLiteral := value.(DateLiteral); isLiteral {
+		if fieldType != "" && fieldType != "date" && fieldType != "datetime" {
+			return "", errors.Wrap(ErrInvalidQuery, fmt.Sprintf("date literal used on %s field %s", fieldType, field))
+		}
+		return formatSOQLValue(literal)
+	}
+
+	switch fieldType {
+	case "int", "long", "double", "currency", "percent":
+		if s, isString := value.(string); isString {
+			if !soqlNumber.MatchString(s) {
+				return "", errors.Wrap(ErrInvalidQuery, fmt.Sprintf("%q is not a number for field %s", s, field))
+			}
+			return s, nil
+		}
+	case "boolean":
+		if s, isString := value.(string); isString {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return "", errors.Wrap(ErrInvalidQuery, fmt.Sprintf("%q is not a boolean for field %s", s, field))
+			}
+			return strconv.FormatBool(b), nil
+		}
+	case "date":
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(soqlDateFormat), nil
+		case string:
+			if _, err := time.Parse(soqlDateFormat, v); err != nil {
+				return "", errors.Wrap(ErrInvalidQuery, fmt.Sprintf("%q is not a date for field %s", v, field))
+			}
+			return v, nil
+		}
+	case "datetime":
+		if s, isString := value.(string); isString {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return "", errors.Wrap(ErrInvalidQuery, fmt.Sprintf("%q is not a dateTime for field %s", s, field))
+			}
+			return s, nil
+		}
+	case "string", "textarea", "picklist", "multipicklist", "combobox", "email", "phone", "url", "id", "reference",
+		"encryptedstring":
+		switch value.(type) {
+		case string:
+		case int, int32, int64, float32, float64, bool:
+			// Text fields compare against text, quote the value.
+			return quoteSOQL(fmt.Sprint(value)), nil
+		default:
+			return "", errors.Wrap(ErrInvalidQuery, fmt.Sprintf("%T is not a text value for field %s", value, field))
+		}
+	}
+	return formatSOQLValue(value)
+}
+
+type comparison struct {
+	field    string
+	operator string
+	value    interface{}
+}
+
+func (c comparison) render(types fieldTypes) (string, error) {
+	value, err := types.format(c.field, c.value)
+	if err != nil {
+		return "", err
+	}
+	return c.field + " " + c.operator + " " + value, nil
+}
+
+// Eq matches records whose field equals value.
+func Eq(field string, value interface{}) Condition { return comparison{field, "=", value} }
+
+// Ne matches records whose field doesn't equal value.
+func Ne(field string, value interface{}) Condition { return comparison{field, "!=", value} }
+
+// Gt matches records whose field is greater than value.
+func Gt(field string, value interface{}) Condition { return comparison{field, ">", value} }
+
+// Gte matches records whose field is greater than or equal to value.
+func Gte(field string, value interface{}) Condition { return comparison{field, ">=", value} }
+
+// Lt matches records whose field is less than value.
+func Lt(field string, value interface{}) Condition { return comparison{field, "<", value} }
+
+// Lte matches records whose field is less than or equal to value.
+func Lte(field string, value interface{}) Condition { return comparison{field, "<=", value} }
+
+// IsNull matches records whose field is empty.
+func IsNull(field string) Condition { return comparison{field, "=", nil} }
+
+// IsNotNull matches records whose field is not empty.
+func IsNotNull(field string) Condition { return comparison{field, "!=", nil} }
+
+// Like matches records whose field matches pattern, where % and _ are wildcards.
+func Like(field string, pattern string) Condition { return comparison{field, "LIKE", pattern} }
+
+type substring struct {
+	field  string
+	prefix string
+	value  string
+	suffix string
+}
+
+func (c substring) render(types fieldTypes) (string, error) {
+	if _, err := types.format(c.field, c.value); err != nil {
+		return "", err
+	}
+	return c.field + " LIKE '" + c.prefix + likeEscaper.Replace(soqlEscaper.Replace(c.value)) + c.suffix + "'", nil
+}
+
+// StartsWith matches records whose field starts with value. Unlike with Like, % and _ in value match themselves.
+func StartsWith(field string, value string) Condition { return substring{field, "", value, "%"} }
+
+// EndsWith matches records whose field ends with value, like StartsWith.
+func EndsWith(field string, value string) Condition { return substring{field, "%", value, ""} }
+
+// Contains matches records whose field contains value, like StartsWith.
+func Contains(field string, value string) Condition { return substring{field, "%", value, "%"} }
+
+type membership struct {
+	field    string
+	operator string
+	values   []interface{}
+}
+
+func (c membership) render(types fieldTypes) (string, error) {
+	if len(c.values) == 0 {
+		return "", errors.Wrap(ErrInvalidQuery, "empty list for field "+c.field)
+	}
+	items := make([]string, 0, len(c.values))
+	for _, v := range c.values {
+		item, err := types.format(c.field, v)
+		if err != nil {
+			return "", err
+		}
+		items = append(items, item)
+	}
+	return c.field + " " + c.operator + " (" + strings.Join(items, ", ") + ")", nil
+}
+
+// In matches records whose field equals any of values.
+func In(field string, values ...interface{}) Condition { return membership{field, "IN", values} }
+
+// NotIn matches records whose field equals none of values.
+func NotIn(field string, values ...interface{}) Condition { return membership{field, "NOT IN", values} }
+
+type junction struct {
+	operator   string
+	conditions []Condition
+}
+
+func (c junction) render(types fieldTypes) (string, error) {
+	if len(c.conditions) == 0 {
+		return "", errors.Wrap(ErrInvalidQuery, "empty "+c.operator)
+	}
+	if len(c.conditions) == 1 {
+		return c.conditions[0].render(types)
+	}
+	clauses := make([]string, 0, len(c.conditions))
+	for _, cond := range c.conditions {
+		clause, err := cond.render(types)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, "("+clause+")")
+	}
+	return strings.Join(clauses, " "+c.operator+" "), nil
+}
+
+// And matches records satisfying all conditions.
+func And(conditions ...Condition) Condition { return junction{"AND", conditions} }
+
+// Or matches records satisfying any of conditions.
+func Or(conditions ...Condition) Condition { return junction{"OR", conditions} }
+
+type negation struct {
+	condition Condition
+}
+
+func (c negation) render(types fieldTypes) (string, error) {
+	clause, err := c.condition.render(types)
+	if err != nil {
+		return "", err
+	}
+	return "NOT (" + clause + ")", nil
+}
+
+// Not matches records not satisfying condition.
+func Not(condition Condition) Condition { return negation{condition} }
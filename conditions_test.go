@@ -0,0 +1,110 @@
+package simpleforce
+
+import (
+	"testing"
+	"time"
+)
+
+var opportunityMeta = &SObjectMeta{
+	"name": "Opportunity",
+	"fields": []interface{}{
+		map[string]interface{}{"name": "Id", "type": "id"},
+		map[string]interface{}{"name": "Name", "type": "string"},
+		map[string]interface{}{"name": "Amount", "type": "currency"},
+		map[string]interface{}{"name": "IsWon", "type": "boolean"},
+		map[string]interface{}{"name": "CloseDate", "type": "date"},
+		map[string]interface{}{"name": "CreatedDate", "type": "datetime"},
+		map[string]interface{}{"name": "StageName", "type": "picklist"},
+	},
+}
+
+func TestConditions_Render(t *testing.T) {
+	cond := And(
+		Eq("Name", "O'Brien"),
+		Or(Gt("Amount", 1000), IsNull("Amount")),
+		Not(In("StageName", "Closed Lost", "Prospecting")),
+		Like("Name", "Acme%"),
+		Lte("CreatedDate", time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)),
+	)
+	clause, err := cond.render(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `(Name = 'O\'Brien') AND ((Amount > 1000) OR (Amount = null)) AND (NOT (StageName IN ('Closed Lost', 'Prospecting'))) AND (Name LIKE 'Acme%') AND (CreatedDate <= 2022-01-02T03:04:05Z)`
+	if clause != expected {
+		t.Errorf("unexpected clause:\n%s\n%s", clause, expected)
+	}
+}
+
+func TestConditions_RenderWithDescribe(t *testing.T) {
+	q, err := Select("Id").From("Opportunity").
+		WithDescribe(opportunityMeta).
+		Where(Eq("Name", 42)).
+		Where(Eq("Amount", "1500.50")).
+		Where(Eq("IsWon", "true")).
+		Where(Gte("CloseDate", time.Date(2022, 4, 29, 0, 0, 0, 0, time.UTC))).
+		Where(Lt("CreatedDate", LastNDays(3))).
+		Where(IsNotNull("Account.Name")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "SELECT Id FROM Opportunity WHERE (Name = '42') AND (Amount = 1500.50) AND (IsWon = true) AND (CloseDate >= 2022-04-29) AND (CreatedDate < LAST_N_DAYS:3) AND (Account.Name != null)"
+	if q != expected {
+		t.Errorf("unexpected query:\n%s\n%s", q, expected)
+	}
+
+	invalid := []Condition{
+		Eq("Unknown__c", "x"),
+		Eq("Amount", "1,500"),
+		Eq("Amount", "NaN"),
+		Eq("Amount", "Inf"),
+		Eq("Amount", "0x1p3"),
+		Eq("Amount", "1_000"),
+		Eq("IsWon", "maybe"),
+		Eq("CloseDate", "29/04/2022"),
+		Eq("Name", LastNDays(3)),
+		In("StageName"),
+		And(),
+	}
+	for _, cond := range invalid {
+		if _, err := Select("Id").From("Opportunity").WithDescribe(opportunityMeta).Where(cond).Build(); err == nil {
+			t.Errorf("expected error for %#v", cond)
+		}
+	}
+}
+
+func TestConditions_Substring(t *testing.T) {
+	cond := Or(StartsWith("Name", "50%_off"), EndsWith("Name", `O'Brien`), Contains("Name", `back\slash`))
+	clause, err := cond.render(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `(Name LIKE '50\%\_off%') OR (Name LIKE '%O\'Brien') OR (Name LIKE '%back\\slash%')`
+	if clause != expected {
+		t.Errorf("unexpected clause:\n%s\n%s", clause, expected)
+	}
+
+	if _, err := Select("Id").From("Opportunity").WithDescribe(opportunityMeta).Where(Contains("Unknown__c", "x")).Build(); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestConditions_Identifiers(t *testing.T) {
+	valid := []string{"Name", "Account.Owner.Name", "Custom_Field__c", "CALENDAR_YEAR(CreatedDate)"}
+	for _, field := range valid {
+		if _, err := Select("Id").From("Account").Where(Eq(field, 1)).Build(); err != nil {
+			t.Errorf("unexpected error for %q: %v", field, err)
+		}
+	}
+
+	invalid := []string{"", "Name = 'x' OR Name", "Name)", "(Name", "Account..Name", "Name--", "1Name"}
+	for _, field := range invalid {
+		if _, err := Select("Id").From("Account").Where(Eq(field, 1)).Build(); err == nil {
+			t.Errorf("expected error for %q", field)
+		}
+	}
+	if _, err := Select("Id").From("Account WHERE Name != null").Build(); err == nil {
+		t.Error("expected error for invalid object")
+	}
+}
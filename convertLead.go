@@ -0,0 +1,72 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/xml"
+
+	"github.com/pkg/errors"
+)
+
+// ConvertLeadRequest describes the conversion of a lead into an account, a contact and optionally an opportunity.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api.meta/api/sforce_api_calls_convertlead.htm
+type ConvertLeadRequest struct {
+	// AccountID merges the lead into an existing account, instead of creating one.
+	AccountID string `xml:"accountId,omitempty"`
+	// ContactID merges the lead into an existing contact of the account, instead of creating one.
+	ContactID string `xml:"contactId,omitempty"`
+	// ConvertedStatus is the converted status of the lead, one of the LeadStatus values with IsConverted set.
+	ConvertedStatus        string `xml:"convertedStatus"`
+	DoNotCreateOpportunity bool   `xml:"doNotCreateOpportunity"`
+	LeadID                 string `xml:"leadId"`
+	// OpportunityName names the created opportunity, which defaults to the company of the lead.
+	OpportunityName     string `xml:"opportunityName,omitempty"`
+	OverwriteLeadSource bool   `xml:"overwriteLeadSource"`
+	// OwnerID assigns the created records to a user, instead of the owner of the lead.
+	OwnerID               string `xml:"ownerId,omitempty"`
+	SendNotificationEmail bool   `xml:"sendNotificationEmail"`
+}
+
+// ConvertLeadResult holds the records a lead was converted into.
+type ConvertLeadResult struct {
+	AccountID     string      `xml:"accountId"`
+	ContactID     string      `xml:"contactId"`
+	LeadID        string      `xml:"leadId"`
+	OpportunityID string      `xml:"opportunityId"`
+	Success       bool        `xml:"success"`
+	Errors        []SaveError `xml:"errors"`
+}
+
+// Err returns nil if the lead was converted, or a SalesforceError describing the first error otherwise.
+func (result ConvertLeadResult) Err() error {
+	return SaveResult{ID: result.LeadID, Success: result.Success, Errors: result.Errors}.Err()
+}
+
+// ConvertLead converts a lead through the convertLead SOAP call, which has no REST equivalent. A failed conversion is
+// returned as an error, along with the result.
+func (client *Client) ConvertLead(req ConvertLeadRequest) (*ConvertLeadResult, error) {
+	return client.ConvertLeadContext(context.Background(), req)
+}
+
+// ConvertLeadContext is like ConvertLead, with the request bound to ctx.
+func (client *Client) ConvertLeadContext(ctx context.Context, req ConvertLeadRequest) (*ConvertLeadResult, error) {
+	if req.LeadID == "" || req.ConvertedStatus == "" {
+		return nil, errors.New("lead id and converted status are required")
+	}
+
+	request := struct {
+		XMLName      xml.Name           `xml:"urn:partner.soap.sforce.com convertLead"`
+		LeadConverts ConvertLeadRequest `xml:"leadConverts"`
+	}{LeadConverts: req}
+	var envelope struct {
+		Results []ConvertLeadResult `xml:"Body>convertLeadResponse>result"`
+	}
+	err := client.soapCall(ctx, "convertLead", request, &envelope)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(envelope.Results))
+	}
+	result := &envelope.Results[0]
+	return result, result.Err()
+}
@@ -0,0 +1,83 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_ConvertLead(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/Soap/u/54.0" || r.Header.Get("SOAPAction") != "convertLead" {
+			t.Errorf("unexpected request %s %s", r.URL.Path, r.Header.Get("SOAPAction"))
+			return
+		}
+		var envelope struct {
+			Convert struct {
+				LeadID                 string  `xml:"leadId"`
+				ConvertedStatus        string  `xml:"convertedStatus"`
+				OwnerID                string  `xml:"ownerId"`
+				DoNotCreateOpportunity bool    `xml:"doNotCreateOpportunity"`
+				OpportunityName        string  `xml:"opportunityName"`
+				AccountID              *string `xml:"accountId"`
+			} `xml:"Body>convertLead>leadConverts"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&envelope); err != nil {
+			t.Error(err)
+			return
+		}
+		convert := envelope.Convert
+		if convert.LeadID != "00Q000000000001AAA" || convert.ConvertedStatus != "Closed - Converted" ||
+			convert.OwnerID != "005D0000001KyEIIA0" || convert.DoNotCreateOpportunity ||
+			convert.OpportunityName != "Acme - 100 widgets" || convert.AccountID != nil {
+			t.Errorf("unexpected conversion %+v", convert)
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:partner.soap.sforce.com"><soapenv:Body><convertLeadResponse><result><accountId>001D000000IqhSLIAZ</accountId><contactId>003D000000QqWfNIAV</contactId><leadId>00Q000000000001AAA</leadId><opportunityId>006D000000CrQ2CIAV</opportunityId><success>true</success></result></convertLeadResponse></soapenv:Body></soapenv:Envelope>`))
+	})
+
+	result, err := client.ConvertLead(ConvertLeadRequest{
+		LeadID:          "00Q000000000001AAA",
+		ConvertedStatus: "Closed - Converted",
+		OwnerID:         "005D0000001KyEIIA0",
+		OpportunityName: "Acme - 100 widgets",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.AccountID != "001D000000IqhSLIAZ" || result.ContactID != "003D000000QqWfNIAV" ||
+		result.OpportunityID != "006D000000CrQ2CIAV" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if _, err = client.ConvertLead(ConvertLeadRequest{LeadID: "00Q000000000001AAA"}); err == nil {
+		t.Error("expected error without converted status")
+	}
+}
+
+func TestClient_ConvertLeadFailure(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:partner.soap.sforce.com"><soapenv:Body><convertLeadResponse><result><errors><message>invalid converted status</message><statusCode>INVALID_STATUS</statusCode></errors><leadId>00Q000000000001AAA</leadId><success>false</success></result></convertLeadResponse></soapenv:Body></soapenv:Envelope>`))
+	})
+
+	result, err := client.ConvertLead(ConvertLeadRequest{LeadID: "00Q000000000001AAA", ConvertedStatus: "Open"})
+	if err == nil || result == nil || result.Success || IsRetryable(err) {
+		t.Errorf("unexpected result %+v, %v", result, err)
+	}
+}
+
+func TestClient_ConvertLeadContext(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.ConvertLeadContext(ctx, ConvertLeadRequest{LeadID: "00Q000000000001", ConvertedStatus: "Closed - Converted"})
+	if !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("expected the canceled context to stop the call, got %v after %d calls", err, calls)
+	}
+}
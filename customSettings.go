@@ -0,0 +1,147 @@
+package simpleforce
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// hierarchySystemFields are the fields of hierarchy custom settings which are not inherited between levels.
+var hierarchySystemFields = map[string]bool{
+	"Id":                 true,
+	"Name":               true,
+	"SetupOwnerId":       true,
+	"IsDeleted":          true,
+	"CreatedDate":        true,
+	"CreatedById":        true,
+	"LastModifiedDate":   true,
+	"LastModifiedById":   true,
+	"SystemModstamp":     true,
+	sobjectAttributesKey: true,
+}
+
+// queryAllFields returns every record of object matching cond, selecting all fields reported by describe. cond may
+// be nil.
+func (client *Client) queryAllFields(object string, cond Condition) ([]SObject, error) {
+	meta, err := client.describe(object)
+	if err != nil {
+		return nil, err
+	}
+	qb := Select(meta.fieldNames()...).From(object)
+	if cond != nil {
+		qb.Where(cond)
+	}
+	q, err := qb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []SObject{}
+	err = client.queryEach(q, func(raw json.RawMessage) error {
+		var page []SObject
+		err := json.Unmarshal(raw, &page)
+		for idx := range page {
+			page[idx].setClient(client)
+		}
+		records = append(records, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ListCustomSetting returns the records of a list custom setting keyed by their Name.
+func (client *Client) ListCustomSetting(name string) (map[string]SObject, error) {
+	records, err := client.queryAllFields(name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]SObject, len(records))
+	for _, record := range records {
+		settings[record.StringField("Name")] = record
+	}
+	return settings, nil
+}
+
+// HierarchyCustomSetting returns the values of a hierarchy custom setting that apply to a user, the same way Apex
+// getInstance(userId) does: fields set on the user's record take precedence over the ones of the user's profile,
+// which take precedence over the organization default. Fields not set on any level are absent from the result.
+func (client *Client) HierarchyCustomSetting(name, userID string) (SObject, error) {
+	var user []struct {
+		ProfileID string `json:"ProfileId"`
+	}
+	q, err := Select("ProfileId").From("User").Where(Eq("Id", userID)).Build()
+	if err != nil {
+		return nil, err
+	}
+	err = client.queryEach(q, func(records json.RawMessage) error {
+		return json.Unmarshal(records, &user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(user) == 0 {
+		return nil, errors.New("user not found: " + userID)
+	}
+
+	var org []struct {
+		ID string `json:"Id"`
+	}
+	err = client.queryEach("SELECT Id FROM Organization", func(records json.RawMessage) error {
+		return json.Unmarshal(records, &org)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(org) == 0 {
+		return nil, errors.New("organization not found")
+	}
+
+	records, err := client.queryAllFields(name, In("SetupOwnerId", org[0].ID, user[0].ProfileID, userID))
+	if err != nil {
+		return nil, err
+	}
+	return resolveHierarchySetting(records, org[0].ID, user[0].ProfileID, userID), nil
+}
+
+// resolveHierarchySetting merges the non-null fields of the hierarchy setting records owned by ownerIDs, from the
+// least to the most specific owner.
+func resolveHierarchySetting(records []SObject, ownerIDs ...string) SObject {
+	byOwner := map[string]SObject{}
+	for _, record := range records {
+		byOwner[record.StringField("SetupOwnerId")] = record
+	}
+
+	resolved := SObject{}
+	for _, ownerID := range ownerIDs {
+		record, ok := byOwner[ownerID]
+		if !ok {
+			continue
+		}
+		for key, value := range record {
+			if value == nil || hierarchySystemFields[key] || key == sobjectClientKey {
+				continue
+			}
+			resolved[key] = value
+		}
+	}
+	return resolved
+}
+
+// CustomMetadata returns the records of a Custom Metadata Type, e.g. "Integration_Setting__mdt", keyed by their
+// DeveloperName.
+func (client *Client) CustomMetadata(typeName string) (map[string]SObject, error) {
+	records, err := client.queryAllFields(typeName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]SObject, len(records))
+	for _, record := range records {
+		metadata[record.StringField("DeveloperName")] = record
+	}
+	return metadata, nil
+}
@@ -0,0 +1,101 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func requireSettingsClient(t *testing.T, records map[string][]map[string]interface{}) *Client {
+	return requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/describe") {
+			json.NewEncoder(w).Encode(map[string]interface{}{"fields": []map[string]interface{}{
+				{"name": "Id"}, {"name": "Name"}, {"name": "DeveloperName"}, {"name": "SetupOwnerId"},
+				{"name": "Endpoint__c"}, {"name": "Enabled__c"},
+			}})
+			return
+		}
+		q := r.URL.Query().Get("q")
+		for object, result := range records {
+			if strings.Contains(q, "FROM "+object) {
+				json.NewEncoder(w).Encode(map[string]interface{}{"done": true, "records": result})
+				return
+			}
+		}
+		t.Errorf("unexpected query %s", q)
+	})
+}
+
+func TestClient_ListCustomSetting(t *testing.T) {
+	client := requireSettingsClient(t, map[string][]map[string]interface{}{
+		"Country__c": {
+			{"Name": "US", "Endpoint__c": "https://us.example.com"},
+			{"Name": "FR", "Endpoint__c": "https://fr.example.com"},
+		},
+	})
+
+	settings, err := client.ListCustomSetting("Country__c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(settings) != 2 {
+		t.Fatalf("expected 2 settings, got %d", len(settings))
+	}
+	us := settings["US"]
+	if us.StringField("Endpoint__c") != "https://us.example.com" {
+		t.Errorf("unexpected setting %v", us)
+	}
+}
+
+func TestClient_HierarchyCustomSetting(t *testing.T) {
+	client := requireSettingsClient(t, map[string][]map[string]interface{}{
+		"User":         {{"ProfileId": "00e000000000001"}},
+		"Organization": {{"Id": "00D000000000001"}},
+		"Integration__c": {
+			{"Id": "a00000000000001", "SetupOwnerId": "00D000000000001", "Endpoint__c": "https://default.example.com", "Enabled__c": false},
+			{"Id": "a00000000000002", "SetupOwnerId": "005000000000001", "Endpoint__c": nil, "Enabled__c": true},
+		},
+	})
+
+	setting, err := client.HierarchyCustomSetting("Integration__c", "005000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setting.StringField("Endpoint__c") != "https://default.example.com" {
+		t.Errorf("expected endpoint to be inherited from the org default, got %v", setting["Endpoint__c"])
+	}
+	if setting["Enabled__c"] != true {
+		t.Errorf("expected user value to take precedence, got %v", setting["Enabled__c"])
+	}
+	if _, ok := setting["Id"]; ok {
+		t.Errorf("expected system fields to be dropped, got %v", setting)
+	}
+}
+
+func TestResolveHierarchySetting(t *testing.T) {
+	records := []SObject{
+		{"SetupOwnerId": "user", "Value__c": "user"},
+		{"SetupOwnerId": "org", "Value__c": "org", "Other__c": "org"},
+		{"SetupOwnerId": "profile", "Value__c": "profile", "Other__c": "profile"},
+	}
+	setting := resolveHierarchySetting(records, "org", "profile", "user")
+	if setting["Value__c"] != "user" || setting["Other__c"] != "profile" {
+		t.Errorf("unexpected setting %v", setting)
+	}
+}
+
+func TestClient_CustomMetadata(t *testing.T) {
+	client := requireSettingsClient(t, map[string][]map[string]interface{}{
+		"Feature_Flag__mdt": {{"DeveloperName": "Checkout", "Enabled__c": true}},
+	})
+
+	metadata, err := client.CustomMetadata("Feature_Flag__mdt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flag := metadata["Checkout"]
+	if flag["Enabled__c"] != true {
+		t.Errorf("unexpected metadata %v", metadata)
+	}
+}
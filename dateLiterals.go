@@ -0,0 +1,211 @@
+package simpleforce
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidDateLiteral is returned when a date literal is not part of the documented SOQL date literal set.
+var ErrInvalidDateLiteral = errors.New("invalid date literal")
+
+// DateLiteral is a relative date understood natively by SOQL, such as TODAY or LAST_N_DAYS:30. Date literals are
+// rendered without quotes by the query builder and FormatSOQL.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select_dateformats.htm
+type DateLiteral struct {
+	name string
+	n    int
+	hasN bool
+}
+
+// Date literals without a parameter.
+var (
+	Yesterday         = DateLiteral{name: "YESTERDAY"}
+	Today             = DateLiteral{name: "TODAY"}
+	Tomorrow          = DateLiteral{name: "TOMORROW"}
+	LastWeek          = DateLiteral{name: "LAST_WEEK"}
+	ThisWeek          = DateLiteral{name: "THIS_WEEK"}
+	NextWeek          = DateLiteral{name: "NEXT_WEEK"}
+	LastMonth         = DateLiteral{name: "LAST_MONTH"}
+	ThisMonth         = DateLiteral{name: "THIS_MONTH"}
+	NextMonth         = DateLiteral{name: "NEXT_MONTH"}
+	Last90Days        = DateLiteral{name: "LAST_90_DAYS"}
+	Next90Days        = DateLiteral{name: "NEXT_90_DAYS"}
+	ThisQuarter       = DateLiteral{name: "THIS_QUARTER"}
+	LastQuarter       = DateLiteral{name: "LAST_QUARTER"}
+	NextQuarter       = DateLiteral{name: "NEXT_QUARTER"}
+	ThisYear          = DateLiteral{name: "THIS_YEAR"}
+	LastYear          = DateLiteral{name: "LAST_YEAR"}
+	NextYear          = DateLiteral{name: "NEXT_YEAR"}
+	ThisFiscalQuarter = DateLiteral{name: "THIS_FISCAL_QUARTER"}
+	LastFiscalQuarter = DateLiteral{name: "LAST_FISCAL_QUARTER"}
+	NextFiscalQuarter = DateLiteral{name: "NEXT_FISCAL_QUARTER"}
+	ThisFiscalYear    = DateLiteral{name: "THIS_FISCAL_YEAR"}
+	LastFiscalYear    = DateLiteral{name: "LAST_FISCAL_YEAR"}
+	NextFiscalYear    = DateLiteral{name: "NEXT_FISCAL_YEAR"}
+)
+
+// dateLiterals lists every documented date literal; the value tells whether the literal takes an ":n" parameter.
+var dateLiterals = map[string]bool{
+	"YESTERDAY":              false,
+	"TODAY":                  false,
+	"TOMORROW":               false,
+	"LAST_WEEK":              false,
+	"THIS_WEEK":              false,
+	"NEXT_WEEK":              false,
+	"LAST_MONTH":             false,
+	"THIS_MONTH":             false,
+	"NEXT_MONTH":             false,
+	"LAST_90_DAYS":           false,
+	"NEXT_90_DAYS":           false,
+	"LAST_N_DAYS":            true,
+	"NEXT_N_DAYS":            true,
+	"N_DAYS_AGO":             true,
+	"NEXT_N_WEEKS":           true,
+	"LAST_N_WEEKS":           true,
+	"N_WEEKS_AGO":            true,
+	"NEXT_N_MONTHS":          true,
+	"LAST_N_MONTHS":          true,
+	"N_MONTHS_AGO":           true,
+	"THIS_QUARTER":           false,
+	"LAST_QUARTER":           false,
+	"NEXT_QUARTER":           false,
+	"NEXT_N_QUARTERS":        true,
+	"LAST_N_QUARTERS":        true,
+	"N_QUARTERS_AGO":         true,
+	"THIS_YEAR":              false,
+	"LAST_YEAR":              false,
+	"NEXT_YEAR":              false,
+	"NEXT_N_YEARS":           true,
+	"LAST_N_YEARS":           true,
+	"N_YEARS_AGO":            true,
+	"THIS_FISCAL_QUARTER":    false,
+	"LAST_FISCAL_QUARTER":    false,
+	"NEXT_FISCAL_QUARTER":    false,
+	"NEXT_N_FISCAL_QUARTERS": true,
+	"LAST_N_FISCAL_QUARTERS": true,
+	"N_FISCAL_QUARTERS_AGO":  true,
+	"THIS_FISCAL_YEAR":       false,
+	"LAST_FISCAL_YEAR":       false,
+	"NEXT_FISCAL_YEAR":       false,
+	"NEXT_N_FISCAL_YEARS":    true,
+	"LAST_N_FISCAL_YEARS":    true,
+	"N_FISCAL_YEARS_AGO":     true,
+}
+
+func nDateLiteral(name string, n int) DateLiteral {
+	return DateLiteral{name: name, n: n, hasN: true}
+}
+
+// LastNDays returns the LAST_N_DAYS:n date literal.
+func LastNDays(n int) DateLiteral { return nDateLiteral("LAST_N_DAYS", n) }
+
+// NextNDays returns the NEXT_N_DAYS:n date literal.
+func NextNDays(n int) DateLiteral { return nDateLiteral("NEXT_N_DAYS", n) }
+
+// NDaysAgo returns the N_DAYS_AGO:n date literal.
+func NDaysAgo(n int) DateLiteral { return nDateLiteral("N_DAYS_AGO", n) }
+
+// LastNWeeks returns the LAST_N_WEEKS:n date literal.
+func LastNWeeks(n int) DateLiteral { return nDateLiteral("LAST_N_WEEKS", n) }
+
+// NextNWeeks returns the NEXT_N_WEEKS:n date literal.
+func NextNWeeks(n int) DateLiteral { return nDateLiteral("NEXT_N_WEEKS", n) }
+
+// NWeeksAgo returns the N_WEEKS_AGO:n date literal.
+func NWeeksAgo(n int) DateLiteral { return nDateLiteral("N_WEEKS_AGO", n) }
+
+// LastNMonths returns the LAST_N_MONTHS:n date literal.
+func LastNMonths(n int) DateLiteral { return nDateLiteral("LAST_N_MONTHS", n) }
+
+// NextNMonths returns the NEXT_N_MONTHS:n date literal.
+func NextNMonths(n int) DateLiteral { return nDateLiteral("NEXT_N_MONTHS", n) }
+
+// NMonthsAgo returns the N_MONTHS_AGO:n date literal.
+func NMonthsAgo(n int) DateLiteral { return nDateLiteral("N_MONTHS_AGO", n) }
+
+// LastNQuarters returns the LAST_N_QUARTERS:n date literal.
+func LastNQuarters(n int) DateLiteral { return nDateLiteral("LAST_N_QUARTERS", n) }
+
+// NextNQuarters returns the NEXT_N_QUARTERS:n date literal.
+func NextNQuarters(n int) DateLiteral { return nDateLiteral("NEXT_N_QUARTERS", n) }
+
+// NQuartersAgo returns the N_QUARTERS_AGO:n date literal.
+func NQuartersAgo(n int) DateLiteral { return nDateLiteral("N_QUARTERS_AGO", n) }
+
+// LastNYears returns the LAST_N_YEARS:n date literal.
+func LastNYears(n int) DateLiteral { return nDateLiteral("LAST_N_YEARS", n) }
+
+// NextNYears returns the NEXT_N_YEARS:n date literal.
+func NextNYears(n int) DateLiteral { return nDateLiteral("NEXT_N_YEARS", n) }
+
+// NYearsAgo returns the N_YEARS_AGO:n date literal.
+func NYearsAgo(n int) DateLiteral { return nDateLiteral("N_YEARS_AGO", n) }
+
+// LastNFiscalQuarters returns the LAST_N_FISCAL_QUARTERS:n date literal.
+func LastNFiscalQuarters(n int) DateLiteral { return nDateLiteral("LAST_N_FISCAL_QUARTERS", n) }
+
+// NextNFiscalQuarters returns the NEXT_N_FISCAL_QUARTERS:n date literal.
+func NextNFiscalQuarters(n int) DateLiteral { return nDateLiteral("NEXT_N_FISCAL_QUARTERS", n) }
+
+// NFiscalQuartersAgo returns the N_FISCAL_QUARTERS_AGO:n date literal.
+func NFiscalQuartersAgo(n int) DateLiteral { return nDateLiteral("N_FISCAL_QUARTERS_AGO", n) }
+
+// LastNFiscalYears returns the LAST_N_FISCAL_YEARS:n date literal.
+func LastNFiscalYears(n int) DateLiteral { return nDateLiteral("LAST_N_FISCAL_YEARS", n) }
+
+// NextNFiscalYears returns the NEXT_N_FISCAL_YEARS:n date literal.
+func NextNFiscalYears(n int) DateLiteral { return nDateLiteral("NEXT_N_FISCAL_YEARS", n) }
+
+// NFiscalYearsAgo returns the N_FISCAL_YEARS_AGO:n date literal.
+func NFiscalYearsAgo(n int) DateLiteral { return nDateLiteral("N_FISCAL_YEARS_AGO", n) }
+
+// ParseDateLiteral parses a literal such as "TODAY" or "LAST_N_DAYS:30". ErrInvalidDateLiteral is returned if the
+// literal is not part of the documented set or its parameter is missing or malformed.
+func ParseDateLiteral(s string) (DateLiteral, error) {
+	name, param := strings.ToUpper(strings.TrimSpace(s)), ""
+	if idx := strings.Index(name, ":"); idx != -1 {
+		name, param = name[:idx], name[idx+1:]
+	}
+
+	takesN, ok := dateLiterals[name]
+	if !ok {
+		return DateLiteral{}, errors.Wrap(ErrInvalidDateLiteral, s)
+	}
+	if !takesN {
+		if param != "" {
+			return DateLiteral{}, errors.Wrap(ErrInvalidDateLiteral, s)
+		}
+		return DateLiteral{name: name}, nil
+	}
+
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return DateLiteral{}, errors.Wrap(ErrInvalidDateLiteral, s)
+	}
+	literal := nDateLiteral(name, n)
+	return literal, literal.Validate()
+}
+
+// Validate checks the literal against the documented set. Literals created with the constructors of this package
+// only fail validation if a negative n was provided.
+func (d DateLiteral) Validate() error {
+	takesN, ok := dateLiterals[d.name]
+	if !ok || takesN != d.hasN {
+		return errors.Wrap(ErrInvalidDateLiteral, d.String())
+	}
+	if d.hasN && d.n < 0 {
+		return errors.Wrap(ErrInvalidDateLiteral, d.String())
+	}
+	return nil
+}
+
+// String renders the literal as it appears in SOQL.
+func (d DateLiteral) String() string {
+	if d.hasN {
+		return fmt.Sprintf("%s:%d", d.name, d.n)
+	}
+	return d.name
+}
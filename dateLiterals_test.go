@@ -0,0 +1,49 @@
+package simpleforce
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseDateLiteral(t *testing.T) {
+	valid := map[string]string{
+		"TODAY":                    "TODAY",
+		"last_n_days:30":           "LAST_N_DAYS:30",
+		" THIS_FISCAL_QUARTER ":    "THIS_FISCAL_QUARTER",
+		"N_FISCAL_YEARS_AGO:2":     "N_FISCAL_YEARS_AGO:2",
+		"NEXT_N_FISCAL_QUARTERS:0": "NEXT_N_FISCAL_QUARTERS:0",
+	}
+	for input, expected := range valid {
+		literal, err := ParseDateLiteral(input)
+		if err != nil {
+			t.Errorf("failed to parse %q: %s", input, err)
+			continue
+		}
+		if literal.String() != expected {
+			t.Errorf("expected %s, got %s", expected, literal)
+		}
+	}
+
+	invalid := []string{"", "NOW", "TODAY:3", "LAST_N_DAYS", "LAST_N_DAYS:x", "LAST_N_DAYS:-1"}
+	for _, input := range invalid {
+		if _, err := ParseDateLiteral(input); errors.Cause(err) != ErrInvalidDateLiteral {
+			t.Errorf("expected %q to be rejected, got %v", input, err)
+		}
+	}
+}
+
+func TestDateLiteral_Validate(t *testing.T) {
+	if Today.Validate() != nil || LastNDays(7).Validate() != nil {
+		t.Fail()
+	}
+	if LastNDays(-7).Validate() == nil {
+		t.Fail()
+	}
+	if (DateLiteral{name: "LAST_N_DAYS"}).Validate() == nil {
+		t.Fail()
+	}
+	if LastNFiscalYears(3).String() != "LAST_N_FISCAL_YEARS:3" {
+		t.Fail()
+	}
+}
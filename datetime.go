@@ -0,0 +1,54 @@
+package simpleforce
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// salesforceDateTimeFormat is the format Salesforce uses for dateTime values in JSON payloads.
+const salesforceDateTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// DateTime is a time.Time that encodes to and decodes from the date and dateTime formats used in Salesforce JSON
+// payloads, e.g. "2022-04-29T10:30:00.000+0000". A JSON null decodes to the zero time.
+type DateTime struct {
+	time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *DateTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return errors.Errorf("invalid dateTime %s", data)
+	}
+
+	parsed, err := parseDateTime(string(data[1 : len(data)-1]))
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// parseDateTime parses a date or dateTime value of a Salesforce payload.
+func parseDateTime(value string) (time.Time, error) {
+	for _, layout := range []string{salesforceDateTimeFormat, time.RFC3339Nano, soqlDateFormat} {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid dateTime %q", value)
+}
+
+// MarshalJSON implements json.Marshaler. The zero time is encoded as null.
+func (t DateTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.Format(salesforceDateTimeFormat) + `"`), nil
+}
@@ -0,0 +1,37 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateTime_JSON(t *testing.T) {
+	var record struct {
+		Created  DateTime `json:"created"`
+		Modified DateTime `json:"modified"`
+		Closed   DateTime `json:"closed"`
+		Deleted  DateTime `json:"deleted"`
+	}
+	data := `{"created": "2022-04-29T10:30:00.000+0000", "modified": "2022-04-29T12:30:00.5+02:00", "closed": "2022-04-29", "deleted": null}`
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := time.Date(2022, 4, 29, 10, 30, 0, 0, time.UTC)
+	if !record.Created.Equal(expected) || !record.Modified.Equal(expected.Add(500*time.Millisecond)) {
+		t.Errorf("unexpected times %s, %s", record.Created, record.Modified)
+	}
+	if !record.Closed.Equal(time.Date(2022, 4, 29, 0, 0, 0, 0, time.UTC)) || !record.Deleted.IsZero() {
+		t.Errorf("unexpected times %s, %s", record.Closed, record.Deleted)
+	}
+
+	encoded, _ := json.Marshal(record)
+	if string(encoded) != `{"created":"2022-04-29T10:30:00.000+0000","modified":"2022-04-29T12:30:00.500+0200","closed":"2022-04-29T00:00:00.000+0000","deleted":null}` {
+		t.Errorf("unexpected encoding %s", encoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"created": "yesterday"}`), &record); err == nil {
+		t.Error("expected error for invalid dateTime")
+	}
+}
@@ -0,0 +1,266 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// describeCacheTTL is how long describe metadata is cached.
+const describeCacheTTL = 10 * time.Minute
+
+// describeCache caches describe metadata by API version, API and object.
+type describeCache struct {
+	mu      sync.Mutex
+	entries map[string]describeCacheEntry
+}
+
+type describeCacheEntry struct {
+	meta      *SObjectMeta
+	fetchedAt time.Time
+}
+
+// describe queries the metadata of the SObject type name using the "describe" API, of the Tooling API if the client
+// uses it. Results are cached for ten minutes; see ResetDescribeCache.
+func (client *Client) describe(name string) (*SObjectMeta, error) {
+	return client.describeContext(context.Background(), name)
+}
+
+// describeContext is like describe, with the request bound to ctx.
+func (client *Client) describeContext(ctx context.Context, name string) (*SObjectMeta, error) {
+	return client.describeResource(ctx, client.sobjectsResource(), name)
+}
+
+// DescribeToolingSObject queries the metadata of a Tooling API object, such as ApexTrigger, FlowDefinition or
+// EntityDefinition, whether or not the client uses the Tooling API. Results are cached like the ones of
+// SObject.Describe.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_tooling.meta/api_tooling/intro_rest_resources.htm
+func (client *Client) DescribeToolingSObject(name string) (*SObjectMeta, error) {
+	return client.DescribeToolingSObjectContext(context.Background(), name)
+}
+
+// DescribeToolingSObjectContext is like DescribeToolingSObject, with the request bound to ctx.
+func (client *Client) DescribeToolingSObjectContext(ctx context.Context, name string) (*SObjectMeta, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	return client.describeResource(ctx, "tooling/sobjects/", name)
+}
+
+// describeResource queries the describe metadata of name under resource, through the cache.
+func (client *Client) describeResource(ctx context.Context, resource, name string) (*SObjectMeta, error) {
+	key := client.APIVersion() + "/" + resource + strings.ToLower(name)
+	cache := client.describeCache
+	if cache != nil {
+		cache.mu.Lock()
+		entry, ok := cache.entries[key]
+		cache.mu.Unlock()
+		if ok && time.Since(entry.fetchedAt) < describeCacheTTL {
+			return entry.meta, nil
+		}
+	}
+
+	url := client.makeURL(resource + name + "/describe")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta SObjectMeta
+	err = json.Unmarshal(data, &meta)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		cache.mu.Lock()
+		cache.entries[key] = describeCacheEntry{meta: &meta, fetchedAt: time.Now()}
+		cache.mu.Unlock()
+	}
+	return &meta, nil
+}
+
+// ResetDescribeCache drops the cached describe metadata of the provided objects, or of all objects if none is
+// provided, e.g. after deploying new fields.
+func (client *Client) ResetDescribeCache(names ...string) {
+	cache := client.describeCache
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if len(names) == 0 {
+		cache.entries = map[string]describeCacheEntry{}
+		return
+	}
+	for key := range cache.entries {
+		for _, name := range names {
+			if strings.HasSuffix(key, "/"+strings.ToLower(name)) {
+				delete(cache.entries, key)
+			}
+		}
+	}
+}
+
+// DescribeSObjectResult is the typed describe metadata of an object.
+type DescribeSObjectResult struct {
+	Name               string              `json:"name"`
+	Label              string              `json:"label"`
+	LabelPlural        string              `json:"labelPlural"`
+	KeyPrefix          string              `json:"keyPrefix"`
+	Custom             bool                `json:"custom"`
+	Createable         bool                `json:"createable"`
+	Updateable         bool                `json:"updateable"`
+	Deletable          bool                `json:"deletable"`
+	Queryable          bool                `json:"queryable"`
+	Searchable         bool                `json:"searchable"`
+	Fields             []DescribeField     `json:"fields"`
+	ChildRelationships []ChildRelationship `json:"childRelationships"`
+	RecordTypeInfos    []RecordTypeInfo    `json:"recordTypeInfos"`
+}
+
+// Field returns the field named name, compared case-insensitively, or nil.
+func (result *DescribeSObjectResult) Field(name string) *DescribeField {
+	for idx := range result.Fields {
+		if strings.EqualFold(result.Fields[idx].Name, name) {
+			return &result.Fields[idx]
+		}
+	}
+	return nil
+}
+
+// DescribeField describes a field of an object.
+type DescribeField struct {
+	Name              string          `json:"name"`
+	Label             string          `json:"label"`
+	Type              string          `json:"type"`
+	SOAPType          string          `json:"soapType"`
+	Length            int             `json:"length"`
+	Precision         int             `json:"precision"`
+	Scale             int             `json:"scale"`
+	Custom            bool            `json:"custom"`
+	Nillable          bool            `json:"nillable"`
+	Createable        bool            `json:"createable"`
+	Updateable        bool            `json:"updateable"`
+	DefaultedOnCreate bool            `json:"defaultedOnCreate"`
+	Unique            bool            `json:"unique"`
+	ExternalID        bool            `json:"externalId"`
+	IDLookup          bool            `json:"idLookup"`
+	Calculated        bool            `json:"calculated"`
+	Permissionable    bool            `json:"permissionable"`
+	ReferenceTo       []string        `json:"referenceTo"`
+	RelationshipName  string          `json:"relationshipName"`
+	PicklistValues    []PicklistValue `json:"picklistValues"`
+	// DefaultValue is of the type of the field, if any.
+	DefaultValue interface{} `json:"defaultValue"`
+}
+
+// PicklistValue is a value of a picklist field.
+type PicklistValue struct {
+	Value        string `json:"value"`
+	Label        string `json:"label"`
+	Active       bool   `json:"active"`
+	DefaultValue bool   `json:"defaultValue"`
+	// ValidFor is the base64 encoded bitmap of the values of the controlling field this value is valid for, if the
+	// picklist is dependent.
+	ValidFor string `json:"validFor"`
+}
+
+// ChildRelationship describes a relationship from another object to the object.
+type ChildRelationship struct {
+	ChildSObject     string `json:"childSObject"`
+	Field            string `json:"field"`
+	RelationshipName string `json:"relationshipName"`
+	CascadeDelete    bool   `json:"cascadeDelete"`
+}
+
+// RecordTypeInfo describes a record type of the object, available to the user or not.
+type RecordTypeInfo struct {
+	RecordTypeID             string `json:"recordTypeId"`
+	Name                     string `json:"name"`
+	DeveloperName            string `json:"developerName"`
+	Active                   bool   `json:"active"`
+	Available                bool   `json:"available"`
+	DefaultRecordTypeMapping bool   `json:"defaultRecordTypeMapping"`
+	Master                   bool   `json:"master"`
+}
+
+// DescribeSObject returns the typed describe metadata of the object name. Metadata is cached, like the one of
+// SObject.Describe.
+func (client *Client) DescribeSObject(name string) (*DescribeSObjectResult, error) {
+	return client.DescribeSObjectContext(context.Background(), name)
+}
+
+// DescribeSObjectContext is like DescribeSObject, with the request bound to ctx.
+func (client *Client) DescribeSObjectContext(ctx context.Context, name string) (*DescribeSObjectResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	meta, err := client.describeContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	var result DescribeSObjectResult
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DescribeGlobalResult lists the objects available to the user.
+type DescribeGlobalResult struct {
+	Encoding     string                  `json:"encoding"`
+	MaxBatchSize int                     `json:"maxBatchSize"`
+	SObjects     []DescribeGlobalSObject `json:"sobjects"`
+}
+
+// DescribeGlobalSObject summarizes an object; see DescribeSObject for its fields.
+type DescribeGlobalSObject struct {
+	Name          string `json:"name"`
+	Label         string `json:"label"`
+	LabelPlural   string `json:"labelPlural"`
+	KeyPrefix     string `json:"keyPrefix"`
+	Custom        bool   `json:"custom"`
+	CustomSetting bool   `json:"customSetting"`
+	Createable    bool   `json:"createable"`
+	Updateable    bool   `json:"updateable"`
+	Deletable     bool   `json:"deletable"`
+	Queryable     bool   `json:"queryable"`
+	Searchable    bool   `json:"searchable"`
+}
+
+// DescribeGlobalSObjects returns the typed list of the objects available to the user.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_describeGlobal.htm
+func (client *Client) DescribeGlobalSObjects() (*DescribeGlobalResult, error) {
+	return client.DescribeGlobalSObjectsContext(context.Background())
+}
+
+// DescribeGlobalSObjectsContext is like DescribeGlobalSObjects, with the request bound to ctx.
+func (client *Client) DescribeGlobalSObjectsContext(ctx context.Context) (*DescribeGlobalResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("sobjects")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var result DescribeGlobalResult
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,96 @@
+package simpleforce
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClient_DescribeCache(t *testing.T) {
+	calls := map[string]int{}
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/services/data/v54.0/sobjects/Account/describe":
+			w.Write([]byte(`{"name":"Account","fields":[{"name":"Id"},{"name":"Name"}]}`))
+		case "/services/data/v54.0/tooling/sobjects/ApexTrigger/describe":
+			w.Write([]byte(`{"name":"ApexTrigger","fields":[{"name":"Id"},{"name":"Body"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		meta := client.SObject("Account").Describe()
+		if meta == nil || (*meta)["name"] != "Account" {
+			t.Fatalf("unexpected metadata %v", meta)
+		}
+		meta, err := client.DescribeToolingSObject("ApexTrigger")
+		if err != nil || (*meta)["name"] != "ApexTrigger" {
+			t.Fatalf("unexpected tooling metadata %v, %v", meta, err)
+		}
+	}
+	if calls["/services/data/v54.0/sobjects/Account/describe"] != 1 || calls["/services/data/v54.0/tooling/sobjects/ApexTrigger/describe"] != 1 {
+		t.Errorf("expected describe results to be cached, got %v", calls)
+	}
+
+	// The Tooling API flag selects the tooling describe, sharing its cache.
+	if meta := client.ToolingClient().SObject("ApexTrigger").Describe(); meta == nil || (*meta)["name"] != "ApexTrigger" {
+		t.Errorf("unexpected metadata %v", meta)
+	}
+	if calls["/services/data/v54.0/tooling/sobjects/ApexTrigger/describe"] != 1 {
+		t.Errorf("expected the tooling describe to be cached, got %v", calls)
+	}
+
+	client.ResetDescribeCache("account")
+	client.SObject("Account").Describe()
+	client.DescribeToolingSObject("ApexTrigger")
+	if calls["/services/data/v54.0/sobjects/Account/describe"] != 2 || calls["/services/data/v54.0/tooling/sobjects/ApexTrigger/describe"] != 1 {
+		t.Errorf("expected only Account to be described again, got %v", calls)
+	}
+}
+
+func TestClient_DescribeSObject(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v54.0/sobjects/Case/describe":
+			w.Write([]byte(`{"name":"Case","label":"Case","keyPrefix":"500","queryable":true,
+				"fields":[
+					{"name":"Id","type":"id","nillable":false},
+					{"name":"Status","type":"picklist","picklistValues":[{"value":"New","label":"New","active":true,"defaultValue":true},{"value":"Closed","label":"Closed","active":true}]},
+					{"name":"AccountId","type":"reference","referenceTo":["Account"],"relationshipName":"Account"}
+				],
+				"childRelationships":[{"childSObject":"CaseComment","field":"ParentId","relationshipName":"CaseComments","cascadeDelete":true}],
+				"recordTypeInfos":[{"recordTypeId":"012000000000000AAA","name":"Master","developerName":"Master","active":true,"available":true,"master":true}]}`))
+		case "/services/data/v54.0/sobjects":
+			w.Write([]byte(`{"encoding":"UTF-8","maxBatchSize":200,"sobjects":[{"name":"Account","keyPrefix":"001","queryable":true},{"name":"Case","keyPrefix":"500"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	result, err := client.DescribeSObject("Case")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != "Case" || result.KeyPrefix != "500" || !result.Queryable || len(result.Fields) != 3 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	status := result.Field("status")
+	if status == nil || len(status.PicklistValues) != 2 || !status.PicklistValues[0].DefaultValue {
+		t.Errorf("unexpected status field %+v", status)
+	}
+	if account := result.Field("AccountId"); account == nil || account.ReferenceTo[0] != "Account" || account.RelationshipName != "Account" {
+		t.Errorf("unexpected account field %+v", account)
+	}
+	if len(result.ChildRelationships) != 1 || result.ChildRelationships[0].RelationshipName != "CaseComments" ||
+		len(result.RecordTypeInfos) != 1 || !result.RecordTypeInfos[0].Master {
+		t.Errorf("unexpected relationships or record types %+v", result)
+	}
+
+	global, err := client.DescribeGlobalSObjects()
+	if err != nil || global.MaxBatchSize != 200 || len(global.SObjects) != 2 || global.SObjects[0].KeyPrefix != "001" {
+		t.Errorf("unexpected global describe %+v, %v", global, err)
+	}
+}
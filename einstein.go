@@ -0,0 +1,127 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// RecordInsight is a prediction made by Einstein on a record, such as a Prediction Builder score.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.object_reference.meta/object_reference/sforce_api_objects_airecordinsight.htm
+type RecordInsight struct {
+	ID                string   `json:"Id"`
+	TargetID          string   `json:"TargetId"`
+	TargetSObjectType string   `json:"TargetSobjectType"`
+	PredictionField   string   `json:"PredictionField"`
+	Type              string   `json:"Type"`
+	Confidence        float64  `json:"Confidence"`
+	RunStartTime      DateTime `json:"RunStartTime"`
+	ValidUntil        DateTime `json:"ValidUntil"`
+}
+
+// RecordInsights returns the Einstein predictions made on the records targetIDs.
+func (client *Client) RecordInsights(targetIDs ...string) ([]RecordInsight, error) {
+	insights := []RecordInsight{}
+	for _, chunk := range ChunkIDs(targetIDs, maxIDsPerQuery) {
+		values := make([]interface{}, len(chunk))
+		for i, id := range chunk {
+			values[i] = id
+		}
+		q, err := Select("Id", "TargetId", "TargetSobjectType", "PredictionField", "Type", "Confidence",
+			"RunStartTime", "ValidUntil").From("AIRecordInsight").Where(In("TargetId", values...)).Build()
+		if err != nil {
+			return nil, err
+		}
+
+		err = client.queryEach(q, func(records json.RawMessage) error {
+			var page []RecordInsight
+			err := json.Unmarshal(records, &page)
+			insights = append(insights, page...)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return insights, nil
+}
+
+// PredictionFactor is a combination of field values contributing to a prediction.
+type PredictionFactor struct {
+	Columns []struct {
+		Name  string `json:"columnName"`
+		Value string `json:"columnValue"`
+	} `json:"columns"`
+	Value float64 `json:"value"`
+}
+
+// Prediction is the outcome predicted by an Einstein Discovery model for a record.
+type Prediction struct {
+	RecordID string
+	Status   string
+	// Score is the predicted value.
+	Score float64
+	// Factors are the strongest contributors to Score.
+	Factors []PredictionFactor
+}
+
+// Predict scores records with the Einstein Discovery prediction definition predictionDefinitionID, where the org has
+// Einstein Discovery enabled. Predictions are returned in the order of recordIDs.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.bi_dev_guide_rest.meta/bi_dev_guide_rest/bi_resources_smartdatadiscovery_predict.htm
+func (client *Client) Predict(predictionDefinitionID string, recordIDs []string) ([]Prediction, error) {
+	return client.PredictContext(context.Background(), predictionDefinitionID, recordIDs)
+}
+
+// PredictContext is like Predict, with the request bound to ctx.
+func (client *Client) PredictContext(ctx context.Context, predictionDefinitionID string, recordIDs []string) ([]Prediction, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	reqData, err := json.Marshal(map[string]interface{}{
+		"predictionDefinition": predictionDefinitionID,
+		"type":                 "Records",
+		"records":              recordIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("smartdatadiscovery/predict")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+
+	var result struct {
+		Predictions []struct {
+			Status     string `json:"status"`
+			Prediction struct {
+				Total        float64            `json:"total"`
+				MiddleValues []PredictionFactor `json:"middleValues"`
+			} `json:"prediction"`
+		} `json:"predictions"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Predictions) != len(recordIDs) {
+		return nil, errors.Errorf("expected %d predictions, got %d", len(recordIDs), len(result.Predictions))
+	}
+
+	predictions := make([]Prediction, len(recordIDs))
+	for i, p := range result.Predictions {
+		predictions[i] = Prediction{
+			RecordID: recordIDs[i],
+			Status:   p.Status,
+			Score:    p.Prediction.Total,
+			Factors:  p.Prediction.MiddleValues,
+		}
+	}
+	return predictions, nil
+}
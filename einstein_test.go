@@ -0,0 +1,57 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_RecordInsights(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		if !strings.Contains(q, "FROM AIRecordInsight WHERE TargetId IN ('0063000000D8cuIAAR')") {
+			t.Errorf("unexpected query %s", q)
+		}
+		w.Write([]byte(`{"totalSize":1,"done":true,"records":[{"attributes":{"type":"AIRecordInsight"},"Id":"0ML30000000001AGAQ","TargetId":"0063000000D8cuIAAR","TargetSobjectType":"Opportunity","PredictionField":"Win_Score__c","Type":"Prediction","Confidence":0.82,"RunStartTime":"2022-04-29T10:30:00.000+0000","ValidUntil":null}]}`))
+	})
+
+	insights, err := client.RecordInsights("0063000000D8cuIAAR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(insights) != 1 || insights[0].PredictionField != "Win_Score__c" || insights[0].Confidence != 0.82 ||
+		insights[0].RunStartTime.IsZero() || !insights[0].ValidUntil.IsZero() {
+		t.Errorf("unexpected insights %+v", insights)
+	}
+}
+
+func TestClient_Predict(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/smartdatadiscovery/predict" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			PredictionDefinition string   `json:"predictionDefinition"`
+			Type                 string   `json:"type"`
+			Records              []string `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.PredictionDefinition != "1ORB000000000bOOAQ" || req.Type != "Records" || len(req.Records) != 2 {
+			t.Errorf("unexpected request %+v", req)
+		}
+		w.Write([]byte(`{"predictionDefinition":"1ORB000000000bOOAQ","predictions":[
+			{"status":"Success","prediction":{"total":71.5,"middleValues":[{"columns":[{"columnName":"Industry","columnValue":"Energy"}],"value":12.5}]}},
+			{"status":"Success","prediction":{"total":12,"middleValues":[]}}
+		]}`))
+	})
+
+	predictions, err := client.Predict("1ORB000000000bOOAQ", []string{"0063000000D8cuIAAR", "0063000000D8cuJAAR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(predictions) != 2 || predictions[0].RecordID != "0063000000D8cuIAAR" || predictions[0].Score != 71.5 ||
+		len(predictions[0].Factors) != 1 || predictions[0].Factors[0].Columns[0].Value != "Energy" || predictions[1].Score != 12 {
+		t.Errorf("unexpected predictions %+v", predictions)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -14,11 +16,53 @@ var (
 
 	// ErrAuthentication is returned when authentication failed.
 	ErrAuthentication = errors.New("authentication failure")
+
+	// ErrQueryTimeout matches a SalesforceError with the QUERY_TIMEOUT code, e.g. when a FOR UPDATE query waited too
+	// long for a lock. The request can be retried.
+	ErrQueryTimeout = errors.New("query timeout")
+
+	// ErrRecordLocked matches a SalesforceError with the UNABLE_TO_LOCK_ROW code. The request can be retried.
+	ErrRecordLocked = errors.New("record locked")
+
+	// ErrAPIVersionRetired matches a SalesforceError caused by a request to an API version which is retired or not
+	// supported by the org. See Client.SetAutoUpgradeAPIVersion.
+	ErrAPIVersionRetired = errors.New("API version retired")
+
+	// ErrConflict matches a SalesforceError caused by a failed precondition, e.g. when a record was modified by
+	// someone else since it was read. See SObject.UpdateIfUnmodified.
+	ErrConflict = errors.New("conflict")
+
+	// ErrNotModified matches the SalesforceError of a conditional request whose record wasn't modified, see
+	// SObject.GetIfNoneMatch and SObject.GetIfModifiedSince.
+	ErrNotModified = errors.New("not modified")
+
+	// ErrSessionExpired matches a SalesforceError caused by an expired or invalid session. See
+	// Client.SetAutoRenewSession.
+	ErrSessionExpired = errors.New("session expired")
+
+	// ErrDuplicate matches a SalesforceError caused by a duplicate value in a unique field, or a record blocked by a
+	// duplicate rule.
+	ErrDuplicate = errors.New("duplicate")
 )
 
+// errorCodeSentinels maps Salesforce error codes to the sentinel errors they match with errors.Is.
+var errorCodeSentinels = map[string]error{
+	"QUERY_TIMEOUT":           ErrQueryTimeout,
+	"UNABLE_TO_LOCK_ROW":      ErrRecordLocked,
+	"UNSUPPORTED_API_VERSION": ErrAPIVersionRetired,
+	"INVALID_SESSION_ID":      ErrSessionExpired,
+	"INVALID_LOGIN":           ErrAuthentication,
+	"DUPLICATE_VALUE":         ErrDuplicate,
+	"DUPLICATES_DETECTED":     ErrDuplicate,
+	"invalid_grant":           ErrAuthentication,
+	"invalid_client":          ErrAuthentication,
+	"invalid_client_id":       ErrAuthentication,
+}
+
 type jsonError []struct {
-	Message   string `json:"message"`
-	ErrorCode string `json:"errorCode"`
+	Message   string   `json:"message"`
+	ErrorCode string   `json:"errorCode"`
+	Fields    []string `json:"fields"`
 }
 
 type xmlError struct {
@@ -26,22 +70,77 @@ type xmlError struct {
 	ErrorCode string `xml:"Body>Fault>faultcode"`
 }
 
+// SalesforceError is an error returned by Salesforce, from a REST error body or a SOAP fault. Branch on ErrorCode, or
+// match the sentinel errors of common codes with errors.Is, rather than on messages.
 type SalesforceError struct {
 	Message      string
 	HttpCode     int
 	ErrorCode    string
 	ErrorMessage string
+	// fields is held by a pointer so that SalesforceError stays comparable, see Fields.
+	fields *[]string
 }
 
 func (err SalesforceError) Error() string {
 	return err.Message
 }
 
+// Fields returns the fields which caused the error, if any, e.g. the missing required fields of a record.
+func (err SalesforceError) Fields() []string {
+	if err.fields == nil {
+		return nil
+	}
+	return *err.fields
+}
+
+// errorFields returns fields to be held by a SalesforceError, or nil if there are none.
+func errorFields(fields []string) *[]string {
+	if len(fields) == 0 {
+		return nil
+	}
+	return &fields
+}
+
+// Is reports whether the error code of err corresponds to target, allowing errors.Is(err, ErrRecordLocked).
+func (err SalesforceError) Is(target error) bool {
+	if target == ErrAPIVersionRetired && err.HttpCode == http.StatusGone {
+		return true
+	}
+	if target == ErrConflict && err.HttpCode == http.StatusPreconditionFailed {
+		return true
+	}
+	if target == ErrNotModified && err.HttpCode == http.StatusNotModified {
+		return true
+	}
+	if target == ErrSessionExpired && err.HttpCode == http.StatusUnauthorized {
+		return true
+	}
+	sentinel, ok := errorCodeSentinels[err.ErrorCode]
+	return ok && sentinel == target
+}
+
+// IsRetryable reports whether err is a transient Salesforce error, such as a lock contention or query timeout, after
+// which the same request may succeed.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRecordLocked) || errors.Is(err, ErrQueryTimeout)
+}
+
+// IsSessionExpired reports whether err is caused by an expired or invalid session, after which a new login is
+// required.
+func IsSessionExpired(err error) bool {
+	return errors.Is(err, ErrSessionExpired)
+}
+
+// IsDuplicate reports whether err is caused by a duplicate value or a duplicate rule.
+func IsDuplicate(err error) bool {
+	return errors.Is(err, ErrDuplicate)
+}
+
 //Need to get information out of this package.
 func ParseSalesforceError(statusCode int, responseBody []byte) (err error) {
 	jsonError := jsonError{}
 	err = json.Unmarshal(responseBody, &jsonError)
-	if err == nil {
+	if err == nil && len(jsonError) > 0 {
 		return SalesforceError{
 			Message: fmt.Sprintf(
 				logPrefix+" Error. http code: %v Error Message:  %v Error Code: %v",
@@ -50,12 +149,17 @@ func ParseSalesforceError(statusCode int, responseBody []byte) (err error) {
 			HttpCode:     statusCode,
 			ErrorCode:    jsonError[0].ErrorCode,
 			ErrorMessage: jsonError[0].Message,
+			fields:       errorFields(jsonError[0].Fields),
 		}
 	}
 
 	xmlError := xmlError{}
 	err = xml.Unmarshal(responseBody, &xmlError)
 	if err == nil {
+		// Fault codes are qualified by a namespace prefix, e.g. sf:INVALID_LOGIN.
+		if idx := strings.LastIndex(xmlError.ErrorCode, ":"); idx >= 0 {
+			xmlError.ErrorCode = xmlError.ErrorCode[idx+1:]
+		}
 		return SalesforceError{
 			Message: fmt.Sprintf(
 				logPrefix+" Error. http code: %v Error Message:  %v Error Code: %v",
@@ -1,7 +1,11 @@
 package simpleforce
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/pkg/errors"
 )
 
 var expectedError SalesforceError = SalesforceError{
@@ -42,6 +46,16 @@ func TestSuccessfulXMLParse(t *testing.T) {
 	}
 }
 
+func TestXMLParse_FaultCodePrefix(t *testing.T) {
+	response := `<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:sf="urn:fault.partner.soap.sforce.com">
+		<soapenv:Body><soapenv:Fault><faultcode>sf:INVALID_LOGIN</faultcode><faultstring>INVALID_LOGIN: Invalid username, password, security token; or user locked out.</faultstring></soapenv:Fault></soapenv:Body>
+	</soapenv:Envelope>`
+	err := ParseSalesforceError(500, []byte(response)).(SalesforceError)
+	if err.ErrorCode != "INVALID_LOGIN" || strings.Contains(err.Message, "sf:") {
+		t.Errorf("expected the namespace prefix to be dropped from the fault code, got %q: %s", err.ErrorCode, err.Message)
+	}
+}
+
 func TestUnsuccessfulParse(t *testing.T) {
 	response := "surprise!"
 	unknownError := SalesforceError{
@@ -54,3 +68,58 @@ func TestUnsuccessfulParse(t *testing.T) {
 		t.Errorf("failed to parse unknown error, got %s", err)
 	}
 }
+
+func TestSalesforceError_Is(t *testing.T) {
+	response := `[{"message": "unable to obtain exclusive access to this record", "errorCode": "UNABLE_TO_LOCK_ROW"}]`
+	err := ParseSalesforceError(400, []byte(response))
+	if !errors.Is(err, ErrRecordLocked) || errors.Is(err, ErrQueryTimeout) || !IsRetryable(err) {
+		t.Errorf("failed to match lock error, got %s", err)
+	}
+
+	response = `[{"message": "Your query request was running for too long.", "errorCode": "QUERY_TIMEOUT"}]`
+	err = ParseSalesforceError(400, []byte(response))
+	if !errors.Is(errors.Wrap(err, "query failed"), ErrQueryTimeout) || !IsRetryable(err) {
+		t.Errorf("failed to match timeout error, got %s", err)
+	}
+
+	if IsRetryable(expectedError) || IsRetryable(ErrFailure) {
+		t.Error("unexpected retryable error")
+	}
+}
+
+func TestEmptyJSONParse(t *testing.T) {
+	err := ParseSalesforceError(400, []byte("[]"))
+	if sfErr, ok := err.(SalesforceError); !ok || sfErr.HttpCode != 400 || sfErr.Message != "[]" {
+		t.Errorf("failed to parse empty JSON error, got %v", err)
+	}
+}
+
+func TestSalesforceError_Fields(t *testing.T) {
+	response := `[{"message":"Required fields are missing: [LastName]","errorCode":"REQUIRED_FIELD_MISSING","fields":["LastName"]}]`
+	err := ParseSalesforceError(400, []byte(response))
+	var sfErr SalesforceError
+	if !errors.As(err, &sfErr) || sfErr.ErrorCode != "REQUIRED_FIELD_MISSING" || !reflect.DeepEqual(sfErr.Fields(), []string{"LastName"}) {
+		t.Errorf("failed to parse fields, got %#v", err)
+	}
+	// Comparing the error along with its fields must not panic.
+	if err == error(SalesforceError{}) {
+		t.Error("expected errors with different fields to differ")
+	}
+
+	response = `[{"message":"duplicate value found: Ref__c duplicates value on record with id: 0013000000Db2wK","errorCode":"DUPLICATE_VALUE","fields":[]}]`
+	err = ParseSalesforceError(400, []byte(response))
+	if !IsDuplicate(err) || IsSessionExpired(err) {
+		t.Errorf("failed to match duplicate error, got %s", err)
+	}
+
+	response = `<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:sf="urn:fault.partner.soap.sforce.com">
+		<soapenv:Body><soapenv:Fault><faultcode>sf:INVALID_SESSION_ID</faultcode><faultstring>INVALID_SESSION_ID: Invalid Session ID found</faultstring></soapenv:Fault></soapenv:Body>
+	</soapenv:Envelope>`
+	err = ParseSalesforceError(500, []byte(response))
+	if !IsSessionExpired(err) || err.(SalesforceError).ErrorCode != "INVALID_SESSION_ID" {
+		t.Errorf("failed to match session error, got %s", err)
+	}
+	if !IsSessionExpired(ParseSalesforceError(401, []byte("Unauthorized"))) {
+		t.Error("expected 401 to match ErrSessionExpired")
+	}
+}
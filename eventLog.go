@@ -0,0 +1,96 @@
+package simpleforce
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EventLogFile describes an Event Monitoring log file. The content of the log is retrieved with DownloadEventLogFile.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.object_reference.meta/object_reference/sforce_api_objects_eventlogfile.htm
+type EventLogFile struct {
+	ID                 string   `json:"Id"`
+	EventType          string   `json:"EventType"`
+	LogDate            DateTime `json:"LogDate"`
+	LogFileLength      float64  `json:"LogFileLength"`
+	LogFileContentType string   `json:"LogFileContentType"`
+	Interval           string   `json:"Interval"`
+	Sequence           int      `json:"Sequence"`
+	APIVersion         float64  `json:"ApiVersion"`
+	CreatedDate        DateTime `json:"CreatedDate"`
+}
+
+// EventLogFiles lists the event log files of eventType with a LogDate in [since, until). An empty eventType matches
+// all event types and zero times leave the respective end of the range open. Files are returned by ascending LogDate.
+func (client *Client) EventLogFiles(eventType string, since, until time.Time) ([]EventLogFile, error) {
+	return client.EventLogFilesContext(context.Background(), eventType, since, until)
+}
+
+// EventLogFilesContext is like EventLogFiles, with the requests bound to ctx.
+func (client *Client) EventLogFilesContext(ctx context.Context, eventType string, since, until time.Time) ([]EventLogFile, error) {
+	qb := Select("Id", "EventType", "LogDate", "LogFileLength", "LogFileContentType", "Interval", "Sequence",
+		"ApiVersion", "CreatedDate").From("EventLogFile").OrderBy("LogDate", "Sequence")
+	if eventType != "" {
+		qb.Where(Eq("EventType", eventType))
+	}
+	if !since.IsZero() {
+		qb.Where(Gte("LogDate", since))
+	}
+	if !until.IsZero() {
+		qb.Where(Lt("LogDate", until))
+	}
+	q, err := qb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	files := []EventLogFile{}
+	err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+		var page []EventLogFile
+		err := json.Unmarshal(records, &page)
+		files = append(files, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// DownloadEventLogFile streams the CSV content of an event log file to w. The content is requested gzip-compressed
+// and transparently decompressed, which considerably reduces the transfer size of large logs.
+func (client *Client) DownloadEventLogFile(id string, w io.Writer) error {
+	return client.DownloadEventLogFileContext(context.Background(), id, w)
+}
+
+// DownloadEventLogFileContext is like DownloadEventLogFile, with the request bound to ctx.
+func (client *Client) DownloadEventLogFileContext(ctx context.Context, id string, w io.Writer) error {
+	if !client.isLoggedIn() {
+		return ErrAuthentication
+	}
+
+	u := client.makeURL("sobjects/EventLogFile/" + id + "/LogFile")
+	resp, err := client.httpResponseContext(ctx, http.MethodGet, u, nil, http.Header{"Accept-Encoding": {"gzip"}})
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return err
+	}
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	_, err = io.Copy(w, body)
+	return err
+}
@@ -0,0 +1,61 @@
+package simpleforce
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const eventLogCSV = "\"EVENT_TYPE\",\"TIMESTAMP\",\"USER_ID\"\n\"Login\",\"20220429103000.000\",\"005000000000001\"\n"
+
+func TestClient_EventLogFiles(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/query"):
+			q := r.URL.Query().Get("q")
+			if !strings.Contains(q, "EventType = 'Login'") || !strings.Contains(q, "LogDate >= 2022-04-01T00:00:00Z") {
+				t.Errorf("unexpected query %s", q)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"done":           false,
+				"nextRecordsUrl": "/services/data/v" + DefaultAPIVersion + "/query/01g-2000",
+				"records":        []map[string]interface{}{{"Id": "0AT000000000001", "EventType": "Login", "LogDate": "2022-04-28T00:00:00.000+0000"}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/query/01g-2000"):
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"done":    true,
+				"records": []map[string]interface{}{{"Id": "0AT000000000002", "EventType": "Login", "LogDate": "2022-04-29T00:00:00.000+0000"}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/sobjects/EventLogFile/0AT000000000001/LogFile"):
+			if r.Header.Get("Accept-Encoding") != "gzip" {
+				t.Error("gzip encoding not requested")
+			}
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			gz.Write([]byte(eventLogCSV))
+			gz.Close()
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	files, err := client.EventLogFiles("Login", time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[1].ID != "0AT000000000002" || files[1].LogDate.Day() != 29 {
+		t.Errorf("unexpected files %v", files)
+	}
+
+	var buf bytes.Buffer
+	if err = client.DownloadEventLogFile(files[0].ID, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != eventLogCSV {
+		t.Errorf("unexpected content %q", buf.String())
+	}
+}
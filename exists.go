@@ -0,0 +1,64 @@
+package simpleforce
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Exists reports whether a record of sobject with the given ID exists, through a HEAD request on its record resource
+// which doesn't transfer the record. Deleted records in the recycle bin don't exist.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_sobject_retrieve.htm
+func (client *Client) Exists(sobject, id string) (bool, error) {
+	return client.ExistsContext(context.Background(), sobject, id)
+}
+
+// ExistsContext is like Exists, with the request bound to ctx.
+func (client *Client) ExistsContext(ctx context.Context, sobject, id string) (bool, error) {
+	if sobject == "" || id == "" {
+		return false, errors.New("object and id are required")
+	}
+	return client.recordExists(ctx, client.makeURL("sobjects/"+sobject+"/"+url.PathEscape(id)))
+}
+
+// ExistsByExternalID reports whether a record of sobject has value in its external ID field, through a HEAD request
+// on the record resource by external ID. It also returns true if several records share the value, in which case an
+// upsert on that value would fail.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/dome_upsert.htm
+func (client *Client) ExistsByExternalID(sobject, field, value string) (bool, error) {
+	return client.ExistsByExternalIDContext(context.Background(), sobject, field, value)
+}
+
+// ExistsByExternalIDContext is like ExistsByExternalID, with the request bound to ctx.
+func (client *Client) ExistsByExternalIDContext(ctx context.Context, sobject, field, value string) (bool, error) {
+	if sobject == "" || field == "" || value == "" {
+		return false, errors.New("object, external ID field and value are required")
+	}
+	return client.recordExists(ctx, client.makeURL("sobjects/"+sobject+"/"+field+"/"+url.PathEscape(value)))
+}
+
+// recordExists sends a HEAD request to the record resource u.
+func (client *Client) recordExists(ctx context.Context, u string) (bool, error) {
+	if !client.isLoggedIn() {
+		return false, ErrAuthentication
+	}
+
+	resp, err := client.httpResponseContext(ctx, http.MethodHead, u, nil, nil)
+	if err != nil {
+		var sfErr SalesforceError
+		if errors.As(err, &sfErr) {
+			switch sfErr.HttpCode {
+			case http.StatusNotFound:
+				return false, nil
+			case http.StatusMultipleChoices:
+				return true, nil
+			}
+		}
+		client.logError("HTTP HEAD request failed:", u)
+		return false, err
+	}
+	resp.Body.Close()
+	return true, nil
+}
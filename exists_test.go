@@ -0,0 +1,55 @@
+package simpleforce
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClient_Exists(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		switch r.URL.EscapedPath() {
+		case "/services/data/v54.0/sobjects/Account/0013000000Db2wKAAR":
+			w.WriteHeader(http.StatusOK)
+		case "/services/data/v54.0/sobjects/Account/External_Id__c/A%2F1":
+			w.WriteHeader(http.StatusOK)
+		case "/services/data/v54.0/sobjects/Account/External_Id__c/dup":
+			w.WriteHeader(http.StatusMultipleChoices)
+		case "/services/data/v54.0/sobjects/Account/0013000000Db2wLAAR", "/services/data/v54.0/sobjects/Account/External_Id__c/none":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	for _, tc := range []struct {
+		field, value string
+		want         bool
+	}{
+		{"", "0013000000Db2wKAAR", true},
+		{"", "0013000000Db2wLAAR", false},
+		{"External_Id__c", "A/1", true},
+		{"External_Id__c", "dup", true},
+		{"External_Id__c", "none", false},
+	} {
+		var exists bool
+		var err error
+		if tc.field == "" {
+			exists, err = client.Exists("Account", tc.value)
+		} else {
+			exists, err = client.ExistsByExternalID("Account", tc.field, tc.value)
+		}
+		if err != nil || exists != tc.want {
+			t.Errorf("%s: expected %v, got %v, %v", tc.value, tc.want, exists, err)
+		}
+	}
+
+	if _, err := client.ExistsByExternalID("Account", "External_Id__c", "boom"); err == nil {
+		t.Error("expected an error on server failure")
+	}
+	if _, err := client.Exists("Account", ""); err == nil {
+		t.Error("expected an error without id")
+	}
+}
@@ -0,0 +1,92 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Community is an Experience Cloud site of the org.
+type Community struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	URLPathPrefix string `json:"urlPathPrefix"`
+	// SiteURL is the URL of the site, e.g. "https://acme.my.site.com/partners". See WithSiteURL.
+	SiteURL string `json:"siteUrl"`
+}
+
+// Communities lists the Experience Cloud sites available to the user of the client.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.chatterapi.meta/chatterapi/connect_resources_communities_list.htm
+func (client *Client) Communities() ([]Community, error) {
+	return client.CommunitiesContext(context.Background())
+}
+
+// CommunitiesContext is like Communities, with the request bound to ctx.
+func (client *Client) CommunitiesContext(ctx context.Context) ([]Community, error) {
+	data, err := client.connectRequest(ctx, http.MethodGet, "connect/communities", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Communities []Community `json:"communities"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Communities, nil
+}
+
+// WithCommunity returns a copy of the client whose Connect calls act within the context of the Experience Cloud site
+// communityID, i.e. are routed through /connect/communities/{communityID}/. The copy shares the session of the client.
+func (client *Client) WithCommunity(communityID string) *Client {
+	override := client.clone()
+	override.communityID = communityID
+	return override
+}
+
+// WithSiteURL returns a copy of the client sending all its requests to the URL of an Experience Cloud site, e.g.
+// "https://acme.my.site.com/partners", instead of the instance URL. This is required for sessions of site users and
+// for site scoped resources. The copy shares the session of the client.
+func (client *Client) WithSiteURL(siteURL string) *Client {
+	override := client.clone()
+	override.siteURL = strings.TrimRight(siteURL, "/")
+	return override
+}
+
+// Connect executes a Connect REST API request, such as Chatter feeds, managed content or navigation menus. path is
+// relative to the connect resource, e.g. "managed-content/delivery/contents"; for clients created by WithCommunity it
+// is relative to the resource of the site instead.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.chatterapi.meta/chatterapi/intro_what_is_chatter_connect.htm
+func (client *Client) Connect(method, path string, requestBody io.Reader) ([]byte, error) {
+	return client.ConnectContext(context.Background(), method, path, requestBody)
+}
+
+// ConnectContext is like Connect, with the request bound to ctx.
+func (client *Client) ConnectContext(ctx context.Context, method, path string, requestBody io.Reader) ([]byte, error) {
+	resource := "connect/"
+	if client.communityID != "" {
+		resource = fmt.Sprintf("connect/communities/%s/", client.communityID)
+	}
+	return client.connectRequest(ctx, method, resource+strings.TrimLeft(path, "/"), requestBody)
+}
+
+// connectRequest sends a request to the versioned REST resource path.
+func (client *Client) connectRequest(ctx context.Context, method, path string, requestBody io.Reader) ([]byte, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL(path)
+	data, err := client.httpRequestContext(ctx, method, u, requestBody)
+	if err != nil {
+		client.logError(fmt.Sprintf("HTTP %s request failed:", method), u)
+		return nil, err
+	}
+	return data, nil
+}
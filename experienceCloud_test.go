@@ -0,0 +1,68 @@
+package simpleforce
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClient_Connect(t *testing.T) {
+	var paths []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/services/data/v54.0/connect/communities" {
+			w.Write([]byte(`{"communities":[{"id":"0DB30000000072LGAQ","name":"Partners","status":"Live","urlPathPrefix":"partners","siteUrl":"https://acme.my.site.com/partners"}],"total":1}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	communities, err := client.Communities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(communities) != 1 || communities[0].ID != "0DB30000000072LGAQ" || communities[0].SiteURL != "https://acme.my.site.com/partners" {
+		t.Fatalf("unexpected communities %+v", communities)
+	}
+
+	if _, err := client.Connect(http.MethodGet, "/managed-content/delivery/contents", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.WithCommunity(communities[0].ID).Connect(http.MethodGet, "navigation-menu/navigation-menu-items", nil); err != nil {
+		t.Fatal(err)
+	}
+	// The original client is unaffected.
+	if _, err := client.Connect(http.MethodGet, "user-profiles/me", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"/services/data/v54.0/connect/communities",
+		"/services/data/v54.0/connect/managed-content/delivery/contents",
+		"/services/data/v54.0/connect/communities/0DB30000000072LGAQ/navigation-menu/navigation-menu-items",
+		"/services/data/v54.0/connect/user-profiles/me",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("expected path %s, got %s", want[i], paths[i])
+		}
+	}
+}
+
+func TestClient_WithSiteURL(t *testing.T) {
+	var path string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	})
+
+	site := client.WithSiteURL(client.getInstanceURL() + "/partners/")
+	if _, err := site.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/partners/services/data/v54.0/query" {
+		t.Errorf("unexpected path %s", path)
+	}
+}
@@ -0,0 +1,256 @@
+// Package factory mints Salesforce records from registered templates, for seeding sandboxes and driving integration
+// tests through the real API.
+package factory
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/simpleforce/simpleforce"
+)
+
+// Sequence returns the value of a field for the n-th record built from a template, starting at 1.
+type Sequence func(n int) interface{}
+
+// Sequencef returns a Sequence formatting n with format, e.g. Sequencef("Account %d").
+func Sequencef(format string) Sequence {
+	return func(n int) interface{} {
+		return fmt.Sprintf(format, n)
+	}
+}
+
+// Relationship resolves the ID of the record a lookup field of a template points to.
+type Relationship interface {
+	// resolve is called while the templates of chain are being created, the last one holding the lookup field.
+	resolve(f *Factory, persist bool, chain []string) (string, error)
+}
+
+// existing points to a record created outside of the factory.
+type existing string
+
+func (r existing) resolve(f *Factory, persist bool, chain []string) (string, error) {
+	return string(r), nil
+}
+
+// Existing makes the lookup field point to the record id.
+func Existing(id string) Relationship {
+	return existing(id)
+}
+
+// roundRobin cycles through a pool of existing records.
+type roundRobin struct {
+	mu   sync.Mutex
+	ids  []string
+	next int
+}
+
+func (r *roundRobin) resolve(f *Factory, persist bool, chain []string) (string, error) {
+	if len(r.ids) == 0 {
+		return "", errors.New("no record to pick from")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := r.ids[r.next%len(r.ids)]
+	r.next++
+	return id, nil
+}
+
+// RoundRobin spreads the records built from the template over the records ids, in turn.
+func RoundRobin(ids ...string) Relationship {
+	return &roundRobin{ids: ids}
+}
+
+// parent creates the related record from another template.
+type parent struct {
+	template string
+	shared   bool
+
+	mu sync.Mutex
+	id string
+}
+
+func (r *parent) resolve(f *Factory, persist bool, chain []string) (string, error) {
+	if !persist {
+		// Records which are only built have no parent to point to.
+		return "", nil
+	}
+	if r.shared {
+		r.mu.Lock()
+		id := r.id
+		r.mu.Unlock()
+		if id != "" {
+			return id, nil
+		}
+	}
+
+	// The mutex isn't held while the parent is created, which may resolve other relationships.
+	obj, err := f.create(r.template, nil, chain)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create %s parent", r.template)
+	}
+	if !r.shared {
+		return obj.ID(), nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.id == "" {
+		// Records created concurrently before the shared parent existed may have created another one; the first one
+		// created is shared.
+		r.id = obj.ID()
+	}
+	return r.id, nil
+}
+
+// Parent creates a new record from template for every record created from the template, and points the lookup field
+// to it.
+func Parent(template string) Relationship {
+	return &parent{template: template}
+}
+
+// SharedParent creates a single record from template the first time it is needed, and points the lookup field of all
+// the records created from the template to it.
+func SharedParent(template string) Relationship {
+	return &parent{template: template, shared: true}
+}
+
+// Template describes how the records of an object are built.
+type Template struct {
+	// Object is the API name of the sObject, e.g. "Account".
+	Object string
+	// Defaults are the values of fields shared by all the records.
+	Defaults map[string]interface{}
+	// Sequences generate values unique to each record, such as names or external IDs.
+	Sequences map[string]Sequence
+	// Relationships resolve the lookup fields of the records.
+	Relationships map[string]Relationship
+}
+
+// Factory builds records from registered templates. It is safe for concurrent use.
+type Factory struct {
+	client *simpleforce.Client
+
+	mu        sync.Mutex
+	templates map[string]*Template
+	counters  map[string]int
+}
+
+// New creates a Factory creating records through client. client may be nil if records are only built.
+func New(client *simpleforce.Client) *Factory {
+	return &Factory{
+		client:    client,
+		templates: map[string]*Template{},
+		counters:  map[string]int{},
+	}
+}
+
+// Register registers a template under name, replacing any template registered under the same name. Several templates
+// may target the same object, e.g. "customer" and "partner" Accounts.
+func (f *Factory) Register(name string, template Template) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.templates[name] = &template
+}
+
+// Build builds a record from the template name, without creating it. Fields of overrides take precedence over the
+// ones of the template. Lookups to parents created by the factory are left unset.
+func (f *Factory) Build(name string, overrides map[string]interface{}) (*simpleforce.SObject, error) {
+	return f.build(name, overrides, false, nil)
+}
+
+// BuildBatch builds n records from the template name, without creating them.
+func (f *Factory) BuildBatch(name string, n int, overrides map[string]interface{}) ([]*simpleforce.SObject, error) {
+	records := make([]*simpleforce.SObject, 0, n)
+	for i := 0; i < n; i++ {
+		obj, err := f.Build(name, overrides)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, obj)
+	}
+	return records, nil
+}
+
+// Create builds a record from the template name and creates it in Salesforce, creating its parents first if needed.
+// Templates whose parents lead back to themselves, directly or through other templates, fail to create.
+func (f *Factory) Create(name string, overrides map[string]interface{}) (*simpleforce.SObject, error) {
+	return f.create(name, overrides, nil)
+}
+
+// create creates a record from the template name as a parent of the records of the templates of chain being created.
+func (f *Factory) create(name string, overrides map[string]interface{}, chain []string) (*simpleforce.SObject, error) {
+	if f.client == nil {
+		return nil, errors.New("factory has no client to create records with")
+	}
+	for _, ancestor := range chain {
+		if ancestor == name {
+			return nil, errors.Errorf("template %s is its own parent through %s", name, strings.Join(append(chain, name), " -> "))
+		}
+	}
+	obj, err := f.build(name, overrides, true, append(chain[:len(chain):len(chain)], name))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = f.client.CreateMultiple([]*simpleforce.SObject{obj}, true); err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s record from template %s", obj.Type(), name)
+	}
+	return obj, nil
+}
+
+// CreateBatch creates n records from the template name. The records created until a failure are returned along with
+// the error.
+func (f *Factory) CreateBatch(name string, n int, overrides map[string]interface{}) ([]*simpleforce.SObject, error) {
+	records := make([]*simpleforce.SObject, 0, n)
+	for i := 0; i < n; i++ {
+		obj, err := f.Create(name, overrides)
+		if err != nil {
+			return records, err
+		}
+		records = append(records, obj)
+	}
+	return records, nil
+}
+
+// build builds a record from the template name, the last one of chain. Parents are only created when the record is
+// persisted.
+func (f *Factory) build(name string, overrides map[string]interface{}, persist bool, chain []string) (*simpleforce.SObject, error) {
+	f.mu.Lock()
+	template, ok := f.templates[name]
+	f.counters[name]++
+	n := f.counters[name]
+	f.mu.Unlock()
+	if !ok {
+		return nil, errors.Errorf("template %s is not registered", name)
+	}
+
+	var obj *simpleforce.SObject
+	if f.client != nil {
+		obj = f.client.SObject(template.Object)
+	} else {
+		obj = &simpleforce.SObject{"attributes": simpleforce.SObjectAttributes{Type: template.Object}}
+	}
+
+	for key, value := range template.Defaults {
+		obj.Set(key, value)
+	}
+	for key, seq := range template.Sequences {
+		obj.Set(key, seq(n))
+	}
+	for key, rel := range template.Relationships {
+		if _, ok := overrides[key]; ok {
+			continue
+		}
+		id, err := rel.resolve(f, persist, chain)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve %s.%s", template.Object, key)
+		}
+		if id != "" {
+			obj.Set(key, id)
+		}
+	}
+	for key, value := range overrides {
+		obj.Set(key, value)
+	}
+	return obj, nil
+}
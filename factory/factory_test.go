@@ -0,0 +1,172 @@
+package factory
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/simpleforce/simpleforce"
+)
+
+func TestFactory_Build(t *testing.T) {
+	f := New(nil)
+	f.Register("account", Template{
+		Object:    "Account",
+		Defaults:  map[string]interface{}{"Industry": "Banking"},
+		Sequences: map[string]Sequence{"Name": Sequencef("Account %d")},
+	})
+	f.Register("contact", Template{
+		Object:        "Contact",
+		Sequences:     map[string]Sequence{"LastName": Sequencef("Contact %d")},
+		Relationships: map[string]Relationship{"AccountId": RoundRobin("001A", "001B"), "ReportsToId": Parent("contact")},
+	})
+
+	accounts, err := f.BuildBatch("account", 2, map[string]interface{}{"Rating": "Hot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accounts[1].Type() != "Account" || accounts[1].StringField("Name") != "Account 2" ||
+		accounts[1].StringField("Industry") != "Banking" || accounts[1].StringField("Rating") != "Hot" {
+		t.Errorf("unexpected account %v", *accounts[1])
+	}
+
+	contacts, err := f.BuildBatch("contact", 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []string{"001A", "001B", "001A"} {
+		if id := contacts[i].StringField("AccountId"); id != expected {
+			t.Errorf("contact %d: expected account %s, got %s", i, expected, id)
+		}
+		if _, ok := (*contacts[i])["ReportsToId"]; ok {
+			t.Errorf("contact %d: expected parent lookup to be unset", i)
+		}
+	}
+
+	_, err = f.Build("unknown", nil)
+	if err == nil {
+		t.Error("expected an error for an unregistered template")
+	}
+}
+
+func TestFactory_Create(t *testing.T) {
+	var mu sync.Mutex
+	created := map[string][]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Records []map[string]interface{} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		record := req.Records[0]
+		object := record["attributes"].(map[string]interface{})["type"].(string)
+		delete(record, "attributes")
+
+		mu.Lock()
+		created[object] = append(created[object], record)
+		id := fmt.Sprintf("%s%d", object, len(created[object]))
+		mu.Unlock()
+
+		fmt.Fprintf(w, `[{"id":%q,"success":true,"errors":[]}]`, id)
+	}))
+	defer server.Close()
+	client := simpleforce.NewClient(server.URL, simpleforce.DefaultClientID, simpleforce.DefaultAPIVersion)
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	f := New(client)
+	f.Register("account", Template{Object: "Account", Sequences: map[string]Sequence{"Name": Sequencef("Account %d")}})
+	f.Register("opportunity", Template{
+		Object:        "Opportunity",
+		Sequences:     map[string]Sequence{"Name": Sequencef("Deal %d")},
+		Relationships: map[string]Relationship{"AccountId": SharedParent("account")},
+	})
+	f.Register("case", Template{
+		Object:        "Case",
+		Relationships: map[string]Relationship{"AccountId": Parent("account")},
+	})
+
+	opportunities, err := f.CreateBatch("opportunity", 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opportunities) != 3 || opportunities[2].ID() != "Opportunity3" {
+		t.Errorf("unexpected opportunities %v", opportunities)
+	}
+	if len(created["Account"]) != 1 {
+		t.Errorf("expected a single shared account, got %d", len(created["Account"]))
+	}
+	for _, opportunity := range created["Opportunity"] {
+		if opportunity["AccountId"] != "Account1" {
+			t.Errorf("unexpected opportunity %v", opportunity)
+		}
+	}
+
+	_, err = f.CreateBatch("case", 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created["Account"]) != 3 || created["Case"][1]["AccountId"] != "Account3" {
+		t.Errorf("expected a new account per case, got %v", created)
+	}
+}
+
+func TestFactory_CreateCycle(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `[{"id":"001000000000001","success":true,"errors":[]}]`)
+	}))
+	defer server.Close()
+	client := simpleforce.NewClient(server.URL, simpleforce.DefaultClientID, simpleforce.DefaultAPIVersion)
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	f := New(client)
+	f.Register("account", Template{Object: "Account", Relationships: map[string]Relationship{"ParentId": Parent("account")}})
+	f.Register("contact", Template{Object: "Contact", Relationships: map[string]Relationship{"ReportsToId": SharedParent("manager")}})
+	f.Register("manager", Template{Object: "Contact", Relationships: map[string]Relationship{"ReportsToId": SharedParent("contact")}})
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := f.Create("account", nil)
+		done <- err
+	}()
+	go func() {
+		_, err := f.Create("contact", nil)
+		done <- err
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			if err == nil || !strings.Contains(err.Error(), "is its own parent") {
+				t.Errorf("expected a cycle error, got %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("cyclic templates never returned")
+		}
+	}
+	if requests != 0 {
+		t.Errorf("expected no record to be created, got %d", requests)
+	}
+}
+
+func TestFactory_CreateFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"success":false,"errors":[{"statusCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [Name]","fields":["Name"]}]}]`)
+	}))
+	defer server.Close()
+	client := simpleforce.NewClient(server.URL, simpleforce.DefaultClientID, simpleforce.DefaultAPIVersion)
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	f := New(client)
+	f.Register("account", Template{Object: "Account"})
+	_, err := f.Create("account", nil)
+	var rollbackErr *simpleforce.RollbackError
+	if !errors.As(err, &rollbackErr) || rollbackErr.Failures[0].Errors[0].StatusCode != "REQUIRED_FIELD_MISSING" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,359 @@
+package simpleforce
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"math"
+	"math/rand"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// fixtureBaseTime is the reference time around which fixture dates are generated.
+var fixtureBaseTime = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// describedField is the subset of a describe field used to generate fixtures.
+type describedField struct {
+	Name              string   `json:"name"`
+	Label             string   `json:"label"`
+	Type              string   `json:"type"`
+	Length            int      `json:"length"`
+	Precision         int      `json:"precision"`
+	Scale             int      `json:"scale"`
+	Nillable          bool     `json:"nillable"`
+	Createable        bool     `json:"createable"`
+	DefaultedOnCreate bool     `json:"defaultedOnCreate"`
+	ReferenceTo       []string `json:"referenceTo"`
+	PicklistValues    []struct {
+		Value        string `json:"value"`
+		Active       bool   `json:"active"`
+		DefaultValue bool   `json:"defaultValue"`
+	} `json:"picklistValues"`
+}
+
+// required reports whether a value must be provided for the field when creating a record.
+func (f *describedField) required() bool {
+	return f.Createable && !f.Nillable && !f.DefaultedOnCreate && f.Type != "boolean"
+}
+
+// describedFields decodes the fields of meta.
+func describedFields(meta *SObjectMeta) ([]describedField, error) {
+	if meta == nil {
+		return nil, errors.New("describe metadata is required")
+	}
+	data, err := json.Marshal((*meta)["fields"])
+	if err != nil {
+		return nil, err
+	}
+	var fields []describedField
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// FixtureGenerator generates records of an object whose field values match the types, lengths and picklist values
+// found in its describe metadata. Generated records are deterministic for a given seed, which keeps test fixtures
+// stable between runs.
+type FixtureGenerator struct {
+	object    string
+	keyPrefix string
+	fields    []describedField
+	rand      *rand.Rand
+	seq       int
+}
+
+// NewFixtureGenerator creates a FixtureGenerator from the describe metadata of an object.
+func NewFixtureGenerator(meta *SObjectMeta, seed int64) (*FixtureGenerator, error) {
+	fields, err := describedFields(meta)
+	if err != nil {
+		return nil, err
+	}
+	object, _ := (*meta)["name"].(string)
+	if object == "" {
+		return nil, errors.New("describe metadata has no object name")
+	}
+	keyPrefix, _ := (*meta)["keyPrefix"].(string)
+	if len(keyPrefix) != 3 {
+		keyPrefix = "a00"
+	}
+
+	return &FixtureGenerator{
+		object:    object,
+		keyPrefix: keyPrefix,
+		fields:    fields,
+		rand:      rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// Record generates a record with a value for every field of the object, overridden by the values of overrides.
+func (g *FixtureGenerator) Record(overrides map[string]interface{}) SObject {
+	g.seq++
+	record := SObject{}
+	record.setType(g.object)
+	for i := range g.fields {
+		value := g.value(&g.fields[i])
+		if value != nil {
+			record[g.fields[i].Name] = value
+		}
+	}
+	for key, value := range overrides {
+		record[key] = value
+	}
+	return record
+}
+
+// RequiredRecord generates a record with values for the fields required on creation only, overridden by the values
+// of overrides.
+func (g *FixtureGenerator) RequiredRecord(overrides map[string]interface{}) SObject {
+	g.seq++
+	record := SObject{}
+	record.setType(g.object)
+	for i := range g.fields {
+		if !g.fields[i].required() {
+			continue
+		}
+		if value := g.value(&g.fields[i]); value != nil {
+			record[g.fields[i].Name] = value
+		}
+	}
+	for key, value := range overrides {
+		record[key] = value
+	}
+	return record
+}
+
+// Records generates n records.
+func (g *FixtureGenerator) Records(n int) []SObject {
+	records := make([]SObject, n)
+	for i := range records {
+		records[i] = g.Record(nil)
+	}
+	return records
+}
+
+// QueryResultJSON returns n generated records encoded as the response of a query, ready to be served by a fake
+// server.
+func (g *FixtureGenerator) QueryResultJSON(n int) ([]byte, error) {
+	return json.Marshal(QueryResult{TotalSize: n, Done: true, Records: g.Records(n)})
+}
+
+// id generates a record ID starting with keyPrefix.
+func (g *FixtureGenerator) id(keyPrefix string) string {
+	return fmt.Sprintf("%s%012d", keyPrefix, g.seq)
+}
+
+// value generates a value for field, or nil for compound and binary fields.
+func (g *FixtureGenerator) value(field *describedField) interface{} {
+	switch field.Type {
+	case "id":
+		return g.id(g.keyPrefix)
+	case "reference":
+		// Key prefixes of referenced objects are unknown from this describe, use a placeholder one.
+		return g.id("000")
+	case "boolean":
+		return g.rand.Intn(2) == 1
+	case "int":
+		return g.rand.Intn(g.maxNumber(field, 1000))
+	case "double", "currency", "percent":
+		scale := math.Pow(10, float64(field.Scale))
+		return math.Round(g.rand.Float64()*float64(g.maxNumber(field, 10000))*scale) / scale
+	case "date":
+		return fixtureBaseTime.AddDate(0, 0, g.rand.Intn(365)).Format(soqlDateFormat)
+	case "datetime":
+		return fixtureBaseTime.Add(time.Duration(g.rand.Intn(365*24*3600)) * time.Second).Format(soqlDateTimeFormat)
+	case "time":
+		return fmt.Sprintf("%02d:%02d:00.000Z", g.rand.Intn(24), g.rand.Intn(60))
+	case "picklist", "multipicklist", "combobox":
+		return g.picklistValue(field)
+	case "email":
+		return g.truncate(field, fmt.Sprintf("user%d@example.com", g.seq))
+	case "phone":
+		return fmt.Sprintf("(555) 01%02d-%04d", g.seq%100, g.rand.Intn(10000))
+	case "url":
+		return g.truncate(field, fmt.Sprintf("https://example.com/%d", g.seq))
+	case "string", "textarea", "encryptedstring":
+		label := field.Label
+		if label == "" {
+			label = field.Name
+		}
+		return g.truncate(field, fmt.Sprintf("%s %d", label, g.seq))
+	}
+	return nil
+}
+
+// maxNumber returns the bound of generated numbers for field, limited by its precision.
+func (g *FixtureGenerator) maxNumber(field *describedField, bound int) int {
+	digits := field.Precision - field.Scale
+	if digits > 0 && digits < 10 {
+		limit := int(math.Pow(10, float64(digits)))
+		if limit < bound {
+			return limit
+		}
+	}
+	return bound
+}
+
+// picklistValue picks the default value of field, or a random active one.
+func (g *FixtureGenerator) picklistValue(field *describedField) interface{} {
+	var active []string
+	for _, value := range field.PicklistValues {
+		if value.DefaultValue && value.Active {
+			return value.Value
+		}
+		if value.Active {
+			active = append(active, value.Value)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	return active[g.rand.Intn(len(active))]
+}
+
+// truncate cuts s to the length of field.
+func (g *FixtureGenerator) truncate(field *describedField, s string) string {
+	if field.Length > 0 && len(s) > field.Length {
+		return s[:field.Length]
+	}
+	return s
+}
+
+// fixtureBuilderTemplate renders the source of a test builder.
+var fixtureBuilderTemplate = template.Must(template.New("builder").Parse(`// Code generated by simpleforce from the describe metadata of {{.Object}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import "github.com/simpleforce/simpleforce"
+
+// {{.Type}} builds {{.Object}} records for tests.
+type {{.Type}} struct {
+	record simpleforce.SObject
+}
+
+// New{{.Type}} returns a {{.Type}} with fixture values set for the fields required on creation.
+func New{{.Type}}() *{{.Type}} {
+	return &{{.Type}}{record: simpleforce.SObject{
+		"attributes": simpleforce.SObjectAttributes{Type: {{printf "%q" .Object}}},
+{{- range .Defaults}}
+		{{printf "%q" .Name}}: {{.Value}},
+{{- end}}
+	}}
+}
+{{range .Setters}}
+// {{.Method}} sets the {{.Name}} field.
+func (b *{{$.Type}}) {{.Method}}(value {{.GoType}}) *{{$.Type}} {
+	b.record[{{printf "%q" .Name}}] = value
+	return b
+}
+{{end}}
+// Build returns a copy of the record being built.
+func (b *{{.Type}}) Build() simpleforce.SObject {
+	record := make(simpleforce.SObject, len(b.record))
+	for key, value := range b.record {
+		record[key] = value
+	}
+	return record
+}
+`))
+
+// GenerateFixtureBuilder generates the Go source of a test builder for the records of the object described by meta,
+// in package pkg. The builder has a setter per field, typed after the field, and starts with fixture values for the
+// fields required on creation.
+func GenerateFixtureBuilder(meta *SObjectMeta, pkg string) ([]byte, error) {
+	generator, err := NewFixtureGenerator(meta, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	type fieldData struct {
+		Name   string
+		Method string
+		GoType string
+		Value  string
+	}
+	data := struct {
+		Package  string
+		Object   string
+		Type     string
+		Defaults []fieldData
+		Setters  []fieldData
+	}{
+		Package: pkg,
+		Object:  generator.object,
+		Type:    goIdentifier(generator.object) + "Builder",
+	}
+
+	defaults := generator.RequiredRecord(nil)
+	methods := map[string]bool{"Build": true}
+	for _, field := range generator.fields {
+		fd := fieldData{Name: field.Name, GoType: fixtureGoType(field.Type)}
+		if value, ok := defaults[field.Name]; ok && value != nil {
+			fd.Value = fmt.Sprintf("%#v", value)
+			if f, ok := value.(float64); ok {
+				fd.Value = fmt.Sprintf("float64(%v)", f)
+			}
+			data.Defaults = append(data.Defaults, fd)
+		}
+
+		fd.Method = goIdentifier(field.Name)
+		for i := 2; methods[fd.Method]; i++ {
+			fd.Method = fmt.Sprintf("%s%d", goIdentifier(field.Name), i)
+		}
+		methods[fd.Method] = true
+		data.Setters = append(data.Setters, fd)
+	}
+
+	var buf bytes.Buffer
+	err = fixtureBuilderTemplate.Execute(&buf, data)
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// fixtureGoType returns the Go type of the values of a describe field type.
+func fixtureGoType(fieldType string) string {
+	switch fieldType {
+	case "boolean":
+		return "bool"
+	case "int":
+		return "int"
+	case "double", "currency", "percent":
+		return "float64"
+	case "address", "location", "base64", "anyType":
+		return "interface{}"
+	}
+	return "string"
+}
+
+// goIdentifier turns a Salesforce API name such as "Billing_Code__c" into an exported Go identifier ("BillingCode").
+func goIdentifier(name string) string {
+	for _, suffix := range []string{"__c", "__r", "__mdt", "__e", "__b"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 || unicode.IsDigit([]rune(b.String())[0]) {
+		return "F" + b.String()
+	}
+	return b.String()
+}
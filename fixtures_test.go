@@ -0,0 +1,112 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var caseMeta = &SObjectMeta{
+	"name":      "Case",
+	"keyPrefix": "500",
+	"fields": []interface{}{
+		map[string]interface{}{"name": "Id", "type": "id"},
+		map[string]interface{}{"name": "Subject", "label": "Subject", "type": "string", "length": 10.0, "createable": true},
+		map[string]interface{}{"name": "Status", "type": "picklist", "createable": true, "picklistValues": []interface{}{
+			map[string]interface{}{"value": "New", "active": true},
+			map[string]interface{}{"value": "Legacy", "active": false},
+		}},
+		map[string]interface{}{"name": "Priority", "type": "picklist", "createable": true, "nillable": true, "picklistValues": []interface{}{
+			map[string]interface{}{"value": "High", "active": true},
+			map[string]interface{}{"value": "Medium", "active": true, "defaultValue": true},
+		}},
+		map[string]interface{}{"name": "IsEscalated", "type": "boolean", "createable": true},
+		map[string]interface{}{"name": "Hours__c", "type": "double", "precision": 3.0, "scale": 1.0, "createable": true, "nillable": true},
+		map[string]interface{}{"name": "ContactId", "type": "reference", "createable": true, "nillable": true},
+		map[string]interface{}{"name": "ClosedDate", "type": "datetime", "nillable": true},
+	},
+}
+
+func TestFixtureGenerator_Record(t *testing.T) {
+	g, err := NewFixtureGenerator(caseMeta, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := g.Record(map[string]interface{}{"IsEscalated": true})
+	if record.Type() != "Case" || !strings.HasPrefix(record.ID(), "500") || len(record.ID()) != 15 {
+		t.Errorf("unexpected record %v", record)
+	}
+	if subject := record.StringField("Subject"); subject != "Subject 1" {
+		t.Errorf("unexpected subject %q", subject)
+	}
+	if record["Status"] != "New" || record["Priority"] != "Medium" {
+		t.Errorf("unexpected picklist values %v %v", record["Status"], record["Priority"])
+	}
+	if record["IsEscalated"] != true {
+		t.Errorf("expected override to be applied")
+	}
+	if hours, ok := record["Hours__c"].(float64); !ok || hours < 0 || hours >= 100 {
+		t.Errorf("unexpected hours %v", record["Hours__c"])
+	}
+
+	required := g.RequiredRecord(nil)
+	if len(required) != 3 || required["Subject"] != "Subject 2" || required["Status"] != "New" {
+		t.Errorf("unexpected required record %v", required)
+	}
+
+	other, _ := NewFixtureGenerator(caseMeta, 42)
+	again := other.Record(map[string]interface{}{"IsEscalated": true})
+	if again["Hours__c"] != record["Hours__c"] || again["ClosedDate"] != record["ClosedDate"] {
+		t.Errorf("expected generation to be deterministic")
+	}
+}
+
+func TestFixtureGenerator_QueryResultJSON(t *testing.T) {
+	g, _ := NewFixtureGenerator(caseMeta, 1)
+	data, err := g.QueryResultJSON(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result QueryResult
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalSize != 3 || len(result.Records) != 3 || result.Records[2].ID() != "500000000000003" {
+		t.Errorf("unexpected result %s", data)
+	}
+}
+
+func TestGenerateFixtureBuilder(t *testing.T) {
+	src, err := GenerateFixtureBuilder(caseMeta, "fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"package fixtures",
+		"type CaseBuilder struct",
+		`"Subject 1",`,
+		"func (b *CaseBuilder) Hours(value float64) *CaseBuilder {",
+		"func (b *CaseBuilder) IsEscalated(value bool) *CaseBuilder {",
+		"func (b *CaseBuilder) Build() simpleforce.SObject {",
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("expected generated source to contain %q:\n%s", expected, src)
+		}
+	}
+}
+
+func TestGoIdentifier(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Name":            "Name",
+		"Billing_Code__c": "BillingCode",
+		"ns__Amount__c":   "NsAmount",
+		"Flag__mdt":       "Flag",
+		"1st_Contact__c":  "F1stContact",
+	} {
+		if actual := goIdentifier(name); actual != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, actual)
+		}
+	}
+}
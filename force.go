@@ -2,31 +2,34 @@ package simpleforce
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"html"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"time"
+
+	"github.com/pkg/errors"
 )
 
 const (
 	DefaultAPIVersion = "54.0"
 	DefaultClientID   = "simpleforce"
 	DefaultURL        = "https://login.salesforce.com"
+	SandboxURL        = "https://test.salesforce.com"
 
 	logPrefix = "[simpleforce]"
 )
 
 // Client is the main instance to access salesforce.
 type Client struct {
-	sessionID string
-	user      struct {
+	user struct {
 		id       string
 		name     string
 		fullName string
@@ -35,9 +38,28 @@ type Client struct {
 	clientID      string
 	apiVersion    string
 	baseURL       string
-	instanceURL   string
+	loginURL      string
+	siteURL       string
 	useToolingAPI bool
+	communityID   string
 	httpClient    *http.Client
+
+	permissionCache *permissionCache
+	describeCache   *describeCache
+	redactor        *redactor
+	responses       *responseRecorder
+
+	session *sessionRenewal
+
+	autoUpgradeAPIVersion bool
+	autoRenewSession      bool
+	requestHook           RequestHook
+	retry                 retryPolicy
+	limiter               *rateLimiter
+	apiBudget             float64
+	logger                Logger
+	logLevel              LogLevel
+	middlewares           []Middleware
 }
 
 // QueryResult holds the response data from an SOQL query.
@@ -50,43 +72,41 @@ type QueryResult struct {
 
 // Expose sid to save in admin settings
 func (client *Client) GetSid() (sid string) {
-        return client.sessionID
+        return client.getSessionID()
 }
 
 //Expose Loc to save in admin settings
 func (client *Client) GetLoc() (loc string) {
-	return client.instanceURL
+	return client.getInstanceURL()
 }
 
-// Set SID and Loc as a means to log in without LoginPassword
+// SetSidLoc sets the session ID, or OAuth access token, and the instance URL of a session obtained outside of the
+// client, e.g. by the OAuth web server flow of an application, as a means to log in without LoginPassword.
 func (client *Client) SetSidLoc(sid string, loc string) {
-        client.sessionID = sid
-        client.instanceURL = loc
+        client.setSessionID(sid)
+        client.setInstanceURL(strings.TrimRight(loc, "/"))
+        client.setSessionRenewal(nil)
 }
 
 // Query runs an SOQL query. q could either be the SOQL string or the nextRecordsURL.
 func (client *Client) Query(q string) (*QueryResult, error) {
+	return client.QueryContext(context.Background(), q)
+}
+
+// QueryContext is like Query, with the request bound to ctx.
+func (client *Client) QueryContext(ctx context.Context, q string) (*QueryResult, error) {
 	if !client.isLoggedIn() {
 		return nil, ErrAuthentication
 	}
 
-	var u string
-	if strings.HasPrefix(q, "/services/data") {
-		// q is nextRecordsURL.
-		u = fmt.Sprintf("%s%s", client.instanceURL, q)
-	} else {
-		// q is SOQL.
-		formatString := "%s/services/data/v%s/query?q=%s"
-		baseURL := client.instanceURL
-		if client.useToolingAPI {
-			formatString = strings.Replace(formatString, "query", "tooling/query", -1)
-		}
-		u = fmt.Sprintf(formatString, baseURL, client.apiVersion, url.QueryEscape(q))
-	}
+	return client.queryPage(ctx, client.queryURL(q))
+}
 
-	data, err := client.httpRequest("GET", u, nil)
+// queryPage retrieves the page of query results at u.
+func (client *Client) queryPage(ctx context.Context, u string) (*QueryResult, error) {
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
-		log.Println(logPrefix, "HTTP GET request failed:", u)
+		client.logError("HTTP GET request failed:", u)
 		return nil, err
 	}
 
@@ -104,17 +124,138 @@ func (client *Client) Query(q string) (*QueryResult, error) {
 	return &result, nil
 }
 
+// queryURL returns the URL to run q, which could either be the SOQL string or the nextRecordsURL.
+func (client *Client) queryURL(q string) string {
+	if strings.HasPrefix(q, "/services/data") {
+		// q is nextRecordsURL.
+		return fmt.Sprintf("%s%s", client.getInstanceURL(), q)
+	}
+
+	// q is SOQL.
+	formatString := "%s/services/data/v%s/query?q=%s"
+	baseURL := client.getInstanceURL()
+	if client.useToolingAPI {
+		formatString = strings.Replace(formatString, "query", "tooling/query", -1)
+	}
+	return fmt.Sprintf(formatString, baseURL, client.APIVersion(), url.QueryEscape(q))
+}
+
+// queryEach runs an SOQL query and follows nextRecordsUrl until all records are retrieved. The raw JSON array of
+// records of every page is passed to fn, which is typically decoding it into typed records.
+func (client *Client) queryEach(q string, fn func(records json.RawMessage) error) error {
+	return client.queryEachContext(context.Background(), q, fn)
+}
+
+// queryEachContext is like queryEach, with the requests bound to ctx.
+func (client *Client) queryEachContext(ctx context.Context, q string, fn func(records json.RawMessage) error) error {
+	if !client.isLoggedIn() {
+		return ErrAuthentication
+	}
+	return client.queryPagesContext(ctx, client.queryURL(q), fn)
+}
+
+// queryPagesContext retrieves the records of the query at u, then of its following pages, passing the raw JSON array
+// of records of every page to fn.
+func (client *Client) queryPagesContext(ctx context.Context, u string, fn func(records json.RawMessage) error) error {
+	for u != "" {
+		data, err := client.httpRequestContext(ctx, "GET", u, nil)
+		if err != nil {
+			client.logError("HTTP GET request failed:", u)
+			return err
+		}
+
+		var page struct {
+			Done           bool            `json:"done"`
+			NextRecordsURL string          `json:"nextRecordsUrl"`
+			Records        json.RawMessage `json:"records"`
+		}
+		err = json.Unmarshal(data, &page)
+		if err != nil {
+			return err
+		}
+		err = fn(page.Records)
+		if err != nil {
+			return err
+		}
+
+		u = ""
+		if !page.Done && page.NextRecordsURL != "" {
+			u = client.queryURL(page.NextRecordsURL)
+		}
+	}
+	return nil
+}
+
+// RunQuery builds the query of qb and runs it with Query.
+// If the offset of qb exceeds MaxQueryOffset, the query is answered with ID-keyset pagination instead: the records
+// before the offset are skipped by repeatedly querying "WHERE Id > :lastId ORDER BY Id", which requires that qb is
+// either unordered or ordered by Id only.
+func (client *Client) RunQuery(qb *QueryBuilder) (*QueryResult, error) {
+	if qb.offset > MaxQueryOffset {
+		return client.runKeysetQuery(qb)
+	}
+
+	q, err := qb.Build()
+	if err != nil {
+		return nil, err
+	}
+	return client.Query(q)
+}
+
+// runKeysetQuery emulates a deep OFFSET with ID-keyset pagination.
+func (client *Client) runKeysetQuery(qb *QueryBuilder) (*QueryResult, error) {
+	if len(qb.orderBy) > 1 || (len(qb.orderBy) == 1 && !strings.EqualFold(qb.orderBy[0], "Id")) {
+		return nil, errors.Wrap(ErrInvalidQuery, "offsets beyond the maximum require ordering by Id")
+	}
+	if qb.forClause != "" {
+		return nil, errors.Wrap(ErrInvalidQuery, "offsets beyond the maximum can't be combined with "+qb.forClause)
+	}
+
+	lastID := ""
+	for skip := qb.offset; skip > 0; {
+		batch := skip
+		if batch > MaxQueryOffset {
+			batch = MaxQueryOffset
+		}
+		q, err := qb.keysetPage([]string{"Id"}, lastID, batch).Build()
+		if err != nil {
+			return nil, err
+		}
+		result, err := client.Query(q)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Records) == 0 {
+			// Fewer records than the offset, nothing left to return.
+			return &QueryResult{Done: true, Records: []SObject{}}, nil
+		}
+		lastID = result.Records[len(result.Records)-1].ID()
+		skip -= len(result.Records)
+	}
+
+	q, err := qb.keysetPage(qb.fields, lastID, qb.limit).Build()
+	if err != nil {
+		return nil, err
+	}
+	return client.Query(q)
+}
+
 // ApexREST executes a custom rest request with the provided method, path, and body. The path is relative to the domain.
 func (client *Client) ApexREST(method, path string, requestBody io.Reader) ([]byte, error) {
+	return client.ApexRESTContext(context.Background(), method, path, requestBody)
+}
+
+// ApexRESTContext is like ApexREST, with the request bound to ctx.
+func (client *Client) ApexRESTContext(ctx context.Context, method, path string, requestBody io.Reader) ([]byte, error) {
 	if !client.isLoggedIn() {
 		return nil, ErrAuthentication
 	}
 
-	u := fmt.Sprintf("%s/%s", client.instanceURL, path)
+	u := fmt.Sprintf("%s/%s", client.getInstanceURL(), path)
 
-	data, err := client.httpRequest(method, u, requestBody)
+	data, err := client.httpRequestContext(ctx, method, u, requestBody)
 	if err != nil {
-		log.Println(logPrefix, fmt.Sprintf("HTTP %s request failed:", method), u)
+		client.logError(fmt.Sprintf("HTTP %s request failed:", method), u)
 		return nil, err
 	}
 
@@ -133,13 +274,38 @@ func (client *Client) SObject(typeName ...string) *SObject {
 
 // isLoggedIn returns if the login to salesforce is successful.
 func (client *Client) isLoggedIn() bool {
-	return client.sessionID != ""
+	return client.getSessionID() != ""
 }
 
-// LoginPassword signs into salesforce using password. token is optional if trusted IP is configured.
+// LoginPassword signs into salesforce using password. token is optional if trusted IP is configured. If automatic
+// session renewal is enabled beforehand, see SetAutoRenewSession, the credentials are kept to log in again once the
+// session expires.
 // Ref: https://developer.salesforce.com/docs/atlas.en-us.214.0.api_rest.meta/api_rest/intro_understanding_username_password_oauth_flow.htm
 // Ref: https://developer.salesforce.com/docs/atlas.en-us.214.0.api.meta/api/sforce_api_calls_login.htm
 func (client *Client) LoginPassword(username, password, token string) error {
+	return client.LoginPasswordContext(context.Background(), username, password, token)
+}
+
+// LoginPasswordContext is like LoginPassword, with the request bound to ctx.
+func (client *Client) LoginPasswordContext(ctx context.Context, username, password, token string) error {
+	err := client.loginPassword(ctx, username, password, token)
+	if err != nil {
+		return err
+	}
+
+	// The credentials are only kept in memory if the client is to renew the session with them.
+	if !client.autoRenewSession {
+		client.setSessionRenewal(nil)
+		return nil
+	}
+	client.setSessionRenewal(func(ctx context.Context, client *Client) error {
+		return client.loginPassword(ctx, username, password, token)
+	})
+	return nil
+}
+
+// loginPassword obtains a session using password through the SOAP login call.
+func (client *Client) loginPassword(ctx context.Context, username, password, token string) error {
 	// Use the SOAP interface to acquire session ID with username, password, and token.
 	// Do not use REST interface here as REST interface seems to have strong checking against client_id, while the SOAP
 	// interface allows a non-exist placeholder client_id to be used.
@@ -163,38 +329,40 @@ func (client *Client) LoginPassword(username, password, token string) error {
             </env:Body>
         </env:Envelope>`
 	soapBody = fmt.Sprintf(soapBody, client.clientID, username, html.EscapeString(password), token)
+	client.redactor.addSecret(password)
+	client.redactor.addSecret(token)
 
-	url := fmt.Sprintf("%s/services/Soap/u/%s", client.baseURL, client.apiVersion)
-	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(soapBody))
+	url := fmt.Sprintf("%s/services/Soap/u/%s", client.loginBaseURL(), client.APIVersion())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(soapBody))
 	if err != nil {
-		log.Println(logPrefix, "error occurred creating request,", err)
+		client.logError("error occurred creating request,", err)
 		return err
 	}
 	req.Header.Add("Content-Type", "text/xml")
 	req.Header.Add("charset", "UTF-8")
 	req.Header.Add("SOAPAction", "login")
 
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.do(req)
 	if err != nil {
-		log.Println(logPrefix, "error occurred submitting request,", err)
+		client.logError("error occurred submitting request,", err)
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		log.Println(logPrefix, "request failed,", resp.StatusCode)
+		client.logError("request failed,", resp.StatusCode)
 		buf := new(bytes.Buffer)
 		buf.ReadFrom(resp.Body)
-		newStr := buf.String()
-		log.Println(logPrefix, "Failed resp.body: ", newStr)
+		newStr := client.Redact(buf.String())
+		client.logDebug("Failed resp.body: ", newStr)
 		theError := ParseSalesforceError(resp.StatusCode, buf.Bytes())
-		return theError
+		return client.redactError(theError)
 	}
 
 	respData, err := ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		log.Println(logPrefix, "error occurred reading response data,", err)
+		client.logError("error occurred reading response data,", err)
 	}
 
 	var loginResponse struct {
@@ -209,108 +377,255 @@ func (client *Client) LoginPassword(username, password, token string) error {
 
 	err = xml.Unmarshal(respData, &loginResponse)
 	if err != nil {
-		log.Println(logPrefix, "error occurred parsing login response,", err)
+		client.logError("error occurred parsing login response,", err)
 		return err
 	}
 
 	// Now we should all be good and the sessionID can be used to talk to salesforce further.
-	client.sessionID = loginResponse.SessionID
-	client.instanceURL = parseHost(loginResponse.ServerURL)
-	client.user.id = loginResponse.UserID
-	client.user.name = loginResponse.UserName
-	client.user.email = loginResponse.UserEmail
-	client.user.fullName = loginResponse.UserFullName
-
-	log.Println(logPrefix, "User", client.user.name, "authenticated.")
+	client.setSessionID(loginResponse.SessionID)
+	client.setInstanceURL(parseHost(loginResponse.ServerURL))
+	setIfChanged(&client.user.id, loginResponse.UserID)
+	setIfChanged(&client.user.name, loginResponse.UserName)
+	setIfChanged(&client.user.email, loginResponse.UserEmail)
+	setIfChanged(&client.user.fullName, loginResponse.UserFullName)
+
+	client.logDebug("User", client.user.name, "authenticated.")
 	return nil
 }
 
 // httpRequest executes an HTTP request to the salesforce server and returns the response data in byte buffer.
 func (client *Client) httpRequest(method, url string, body io.Reader) ([]byte, error) {
-	req, err := http.NewRequest(method, url, body)
+	return client.httpRequestContext(context.Background(), method, url, body)
+}
+
+// httpRequestContext is like httpRequest, with the request bound to ctx.
+func (client *Client) httpRequestContext(ctx context.Context, method, url string, body io.Reader) ([]byte, error) {
+	resp, err := client.httpResponseContext(ctx, method, url, body, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", client.sessionID))
+	return ioutil.ReadAll(resp.Body)
+}
+
+// httpResponse executes an HTTP request to the salesforce server and returns the response, whose body must be closed
+// by the caller. header is added to the request, overriding the default headers. Responses with a non-2xx status
+// are converted into errors.
+func (client *Client) httpResponse(method, url string, body io.Reader, header http.Header) (*http.Response, error) {
+	return client.httpResponseContext(context.Background(), method, url, body, header)
+}
+
+// httpResponseContext is like httpResponse, with the request bound to ctx.
+func (client *Client) httpResponseContext(ctx context.Context, method, url string, body io.Reader, header http.Header) (*http.Response, error) {
+	err := client.checkAPIBudget()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", client.getSessionID()))
 	req.Header.Add("Content-Type", "application/json")
+	for key, values := range headersOf(ctx) {
+		req.Header[key] = values
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
 
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.sendRequest(req)
+	if err == nil {
+		return resp, nil
+	}
+
+	// Send the request again with a new session if the session expired.
+	if client.renewSession(req, err) {
+		retry, replayErr := replayRequest(req, req.URL.String())
+		if replayErr != nil {
+			client.logError("request can't be sent again,", replayErr)
+			return nil, err
+		}
+		retry.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.getSessionID()))
+		return client.sendRequest(retry)
+	}
+
+	// Send the request again if it failed because of a retired API version or an instance which moved.
+	retryURL, ok := client.retryURL(req, err)
+	if !ok {
+		return nil, err
+	}
+	retry, replayErr := replayRequest(req, retryURL)
+	if replayErr != nil {
+		client.logError("request can't be sent again,", replayErr)
+		return nil, err
+	}
+	return client.sendRequest(retry)
+}
+
+// retryURL returns the URL to send req to again after it failed with err, and false if it shouldn't be retried.
+func (client *Client) retryURL(req *http.Request, err error) (string, bool) {
+	if client.autoUpgradeAPIVersion && errors.Is(err, ErrAPIVersionRetired) {
+		return client.upgradeAPIVersion(req.Context(), req.URL.String())
+	}
+	var hookErr *requestHookError
+	if !errors.As(err, &hookErr) && req.Context().Err() == nil {
+		return client.instanceMoved(req.Context(), req.URL.String(), err)
+	}
+	return "", false
+}
+
+// replayRequest returns a copy of req to be sent to url, with a fresh body.
+func replayRequest(req *http.Request, url string) (*http.Request, error) {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return nil, errors.New("request body can't be read again")
+	}
+
+	retry := req.Clone(req.Context())
+	parsed, err := retry.URL.Parse(url)
+	if err != nil {
+		return nil, err
+	}
+	retry.URL = parsed
+	retry.Host = ""
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return retry, nil
+}
+
+// sendOnce sends a single HTTP request to the salesforce server, see httpResponse.
+func (client *Client) sendOnce(req *http.Request) (*http.Response, error) {
+	resp, err := client.do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		log.Println(logPrefix, "request failed,", resp.StatusCode)
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotModified {
+			// The expected outcome of conditional requests, not a failure.
+			return nil, SalesforceError{Message: logPrefix + " Not Modified", HttpCode: resp.StatusCode}
+		}
+		client.logError("request failed,", resp.StatusCode)
 		buf := new(bytes.Buffer)
 		buf.ReadFrom(resp.Body)
-		newStr := buf.String()
+		newStr := client.Redact(buf.String())
 		theError := ParseSalesforceError(resp.StatusCode, buf.Bytes())
-		log.Println(logPrefix, "Failed resp.body: ", newStr)
-		return nil, theError
+		client.logDebug("Failed resp.body: ", newStr)
+		return nil, client.redactError(theError)
 	}
 
-	return ioutil.ReadAll(resp.Body)
+	return resp, nil
+}
+
+// do waits for the rate limiter, applies the request hook to req, sends it through the middlewares and records the
+// metadata of the response. Every request of the client goes through do.
+func (client *Client) do(req *http.Request) (*http.Response, error) {
+	if client.limiter != nil {
+		err := client.limiter.wait(req.Context())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if client.requestHook != nil {
+		// Hook a copy, so that requests sent again are built from the headers set by the client, and hooked anew.
+		hooked := req.Clone(req.Context())
+		err := client.requestHook(hooked)
+		if err != nil {
+			return nil, &requestHookError{err: err}
+		}
+		req = hooked
+	}
+
+	start := time.Now()
+	resp, err := client.doer().Do(req)
+	if err != nil {
+		return nil, client.redactError(err)
+	}
+	client.responses.record(req, resp, time.Since(start))
+	return resp, nil
 }
 
 // makeURL generates a REST API URL based on baseURL, APIVersion of the client.
 func (client *Client) makeURL(req string) string {
-	client.apiVersion = strings.Replace(client.apiVersion, "v", "", -1)
-	retURL := fmt.Sprintf("%s/services/data/v%s/%s", client.instanceURL, client.apiVersion, req)
+	retURL := fmt.Sprintf("%s/services/data/v%s/%s", client.getInstanceURL(), client.APIVersion(), req)
 	return retURL
 }
 
-// NewClient creates a new instance of the client.
-func NewClient(url, clientID, apiVersion string) *Client {
+// NewClient creates a new instance of the client, configured by opts.
+func NewClient(url, clientID, apiVersion string, opts ...ClientOption) *Client {
 	client := &Client{
-		apiVersion: apiVersion,
+		apiVersion: strings.Replace(apiVersion, "v", "", -1),
 		baseURL:    url,
 		clientID:   clientID,
 		httpClient: &http.Client{},
+
+		permissionCache: &permissionCache{users: map[string]*UserPermissions{}},
+		describeCache:   &describeCache{entries: map[string]describeCacheEntry{}},
+		redactor:        newRedactor(),
+		responses:       &responseRecorder{},
+		session:         &sessionRenewal{},
+		logger:          stdLogger{},
+		logLevel:        LogLevelInfo,
 	}
 
 	// Remove trailing "/" from base url to prevent "//" when paths are appended
 	if strings.HasSuffix(client.baseURL, "/") {
 		client.baseURL = client.baseURL[:len(client.baseURL)-1]
 	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
 	return client
 }
 
+// NewClientFromToken creates a client using an access token obtained outside of the client, for the org at
+// instanceURL, such as the instance_url returned along with the token.
+func NewClientFromToken(instanceURL, accessToken, apiVersion string, opts ...ClientOption) *Client {
+	client := NewClient(instanceURL, DefaultClientID, apiVersion, opts...)
+	client.SetSidLoc(accessToken, instanceURL)
+	return client
+}
+
+// loginBaseURL returns the URL the client logs in at: the one set by WithLoginURL, or else the URL given to NewClient.
+func (client *Client) loginBaseURL() string {
+	if client.loginURL != "" {
+		return client.loginURL
+	}
+	return client.baseURL
+}
+
+// SetHttpClient makes the client send its requests with c, see WithHTTPClient.
 func (client *Client) SetHttpClient(c *http.Client) {
 	client.httpClient = c
 }
 
-// DownloadFile downloads a file based on the REST API path given. Saves to filePath.
+// DownloadFile downloads the content of a ContentVersion. Saves to filePath.
 func (client *Client) DownloadFile(contentVersionID string, filepath string) error {
-	apiPath := fmt.Sprintf("/services/data/v%s/sobjects/ContentVersion/%s/VersionData", client.apiVersion, contentVersionID)
-	return client.download(apiPath, filepath)
+	body, err := client.ContentVersionData(contentVersionID)
+	return client.download(filepath, body, err)
 }
 
+// DownloadAttachment downloads the content of an Attachment. Saves to filePath.
 func (client *Client) DownloadAttachment(attachmentId string, filepath string) error {
-	apiPath := fmt.Sprintf("/services/data/v%s/sobjects/Attachment/%s/Body", client.apiVersion, attachmentId)
-	return client.download(apiPath, filepath)
+	body, err := client.AttachmentBody(attachmentId)
+	return client.download(filepath, body, err)
 }
 
-func (client *Client) download(apiPath string, filepath string) error {
-	// Get the data
-	httpClient := client.httpClient
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s", strings.TrimRight(client.instanceURL, "/"), apiPath), nil)
-	req.Header.Add("Content-Type", "application/json; charset=UTF-8")
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Authorization", "Bearer "+client.sessionID)
-
-	resp, err := httpClient.Do(req)
+// download saves body to filepath, unless err is set.
+func (client *Client) download(filepath string, body io.ReadCloser, err error) error {
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		buf := new(bytes.Buffer)
-		buf.ReadFrom(resp.Body)
-		return fmt.Errorf("ERROR: statuscode: %d, body: %s", resp.StatusCode, buf.String())
-	}
+	defer body.Close()
 
 	// Create the file
 	out, err := os.Create(filepath)
@@ -320,7 +635,7 @@ func (client *Client) download(apiPath string, filepath string) error {
 	defer out.Close()
 
 	// Write the body to file
-	_, err = io.Copy(out, resp.Body)
+	_, err = io.Copy(out, body)
 	return err
 }
 
@@ -334,17 +649,21 @@ func parseHost(input string) string {
 
 //Get the List of all available objects and their metadata for your organization's data
 func (client *Client) DescribeGlobal() (*SObjectMeta, error) {
-	apiPath := fmt.Sprintf("/services/data/v%s/sobjects", client.apiVersion)
-	baseURL := strings.TrimRight(client.baseURL, "/")
+	return client.DescribeGlobalContext(context.Background())
+}
+
+// DescribeGlobalContext is like DescribeGlobal, with the request bound to ctx.
+func (client *Client) DescribeGlobalContext(ctx context.Context) (*SObjectMeta, error) {
+	apiPath := fmt.Sprintf("/services/data/v%s/sobjects", client.APIVersion())
+	baseURL := strings.TrimRight(client.getInstanceURL(), "/")
 	url := fmt.Sprintf("%s%s", baseURL, apiPath) // Get the objects
-	httpClient := client.httpClient
-	req, err := http.NewRequest("GET", url, nil)
-	req.Header.Add("Content-Type", "application/json; charset=UTF-8")
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Authorization", "Bearer "+client.sessionID)
-	// resp, err := http.Get(url)
-	resp, err := httpClient.Do(req)
+	header := http.Header{
+		"Content-Type": {"application/json; charset=UTF-8"},
+		"Accept":       {"application/json"},
+	}
+	resp, err := client.httpResponseContext(ctx, http.MethodGet, url, nil, header)
 	if err != nil {
+		client.logError("HTTP GET request failed:", url)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -352,9 +671,8 @@ func (client *Client) DescribeGlobal() (*SObjectMeta, error) {
 	var meta SObjectMeta
 
 	respData, err := ioutil.ReadAll(resp.Body)
-	log.Println(logPrefix, fmt.Sprintf("status code %d", resp.StatusCode))
 	if err != nil {
-		log.Println(logPrefix, "error while reading all body")
+		return nil, err
 	}
 
 	err = json.Unmarshal(respData, &meta)
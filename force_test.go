@@ -1,8 +1,16 @@
 package simpleforce
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -43,6 +51,16 @@ func requireClient(t *testing.T, skippable bool) *Client {
 	return client
 }
 
+// requireMockClient returns a logged in client which sends all requests to a local server backed by handler.
+func requireMockClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL, DefaultClientID, DefaultAPIVersion)
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+	return client
+}
+
 func TestClient_LoginPassword(t *testing.T) {
 	checkCredentialsAndSkip(t)
 
@@ -56,7 +74,7 @@ func TestClient_LoginPassword(t *testing.T) {
 	if err != nil {
 		t.Fail()
 	} else {
-		log.Println(logPrefix, "sessionID:", client.sessionID)
+		log.Println(logPrefix, "sessionID:", client.getSessionID())
 	}
 
 	err = client.LoginPassword("__INVALID_USER__", "__INVALID_PASS__", "__INVALID_TOKEN__")
@@ -78,7 +96,7 @@ func TestClient_LoginPasswordNoToken(t *testing.T) {
 	if err != nil {
 		t.FailNow()
 	} else {
-		log.Println(logPrefix, "sessionID:", client.sessionID)
+		log.Println(logPrefix, "sessionID:", client.getSessionID())
 	}
 }
 
@@ -147,6 +165,118 @@ func TestClient_Query3(t *testing.T) {
 	}
 }
 
+func TestClient_QueryMock(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v"+DefaultAPIVersion+"/query" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("q") == "SELECT Bad FROM Account" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`[{"errorCode":"INVALID_FIELD","message":"No such column 'Bad' on entity 'Account'."}]`))
+			return
+		}
+		w.Write([]byte(`{"totalSize":3,"done":false,"nextRecordsUrl":"/services/data/v54.0/query/01gD0000002HU6KIAW-2000","records":[
+			{"attributes":{"type":"Account","url":"/services/data/v54.0/sobjects/Account/0013000000Db2wKAAR"},"Id":"0013000000Db2wKAAR","Name":"Acme"}
+		]}`))
+	})
+
+	result, err := client.Query("SELECT Id, Name FROM Account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalSize != 3 || result.Done || result.NextRecordsURL != "/services/data/v54.0/query/01gD0000002HU6KIAW-2000" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(result.Records) != 1 || result.Records[0].Type() != "Account" || result.Records[0].StringField("Name") != "Acme" ||
+		result.Records[0].client() != client {
+		t.Errorf("unexpected records %+v", result.Records)
+	}
+
+	_, err = client.Query("SELECT Bad FROM Account")
+	sfErr, ok := err.(SalesforceError)
+	if !ok || sfErr.ErrorCode != "INVALID_FIELD" || sfErr.HttpCode != http.StatusBadRequest {
+		t.Errorf("unexpected error %#v", err)
+	}
+}
+
+func TestNewClientFromToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer 00D000000000062!AQ0AQ" {
+			t.Errorf("unexpected authorization %s", r.Header.Get("Authorization"))
+		}
+		if !strings.HasPrefix(r.URL.Path, "/services/data/v54.0/") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClientFromToken(server.URL+"/", "00D000000000062!AQ0AQ", DefaultAPIVersion)
+	if client.GetSid() != "00D000000000062!AQ0AQ" || client.GetLoc() != server.URL {
+		t.Errorf("unexpected session %s %s", client.GetSid(), client.GetLoc())
+	}
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewClientFromToken_Sandbox(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClientFromToken(server.URL, "00D000000000062!AQ0AQ", DefaultAPIVersion, WithSandbox())
+	if client.InstanceURL() != server.URL {
+		t.Errorf("expected instance URL %s, got %s", server.URL, client.InstanceURL())
+	}
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_Context(t *testing.T) {
+	requests := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.QueryContext(ctx, "SELECT Id FROM Account"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from QueryContext, got %v", err)
+	}
+	if obj := client.SObject("Account").GetContext(ctx, "0013000000Db2wKAAR"); obj != nil {
+		t.Errorf("expected GetContext to fail, got %v", obj)
+	}
+	if err := client.SObject("Account").DeleteContext(ctx, "0013000000Db2wKAAR"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from DeleteContext, got %v", err)
+	}
+	if err := client.LoginPasswordContext(ctx, "user@example.com", "password", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from LoginPasswordContext, got %v", err)
+	}
+	if _, err := client.DescribeGlobalContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from DescribeGlobalContext, got %v", err)
+	}
+	if _, err := client.APIVersionsContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from APIVersionsContext, got %v", err)
+	}
+	if err := client.RefreshInstanceURLContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from RefreshInstanceURLContext, got %v", err)
+	}
+	if _, err := client.ExecuteAnonymousContext(ctx, "System.debug(1);"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from ExecuteAnonymousContext, got %v", err)
+	}
+	obj := client.SObject("Account").Set("Id", "0013000000Db2wKAAR").Set("LastModifiedDate", "2022-04-29T00:00:00.000+0000")
+	if err := obj.UpdateIfUnmodifiedContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from UpdateIfUnmodifiedContext, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request to be sent, got %d", requests)
+	}
+}
+
 func TestClient_ApexREST(t *testing.T) {
 	client := requireClient(t, true)
 
@@ -179,3 +309,71 @@ func TestClient_QueryLike(t *testing.T) {
 func TestMain(m *testing.M) {
 	m.Run()
 }
+
+func TestClient_RunQueryKeyset(t *testing.T) {
+	// The mock org holds 4500 accounts with IDs "00000" to "04499".
+	const total = 4500
+	idPattern := regexp.MustCompile(`Id > '(\d+)'`)
+	limitPattern := regexp.MustCompile(`LIMIT (\d+)`)
+	var queries []string
+
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		queries = append(queries, q)
+
+		start, limit := 0, total
+		if m := idPattern.FindStringSubmatch(q); m != nil {
+			start, _ = strconv.Atoi(m[1])
+			start++
+		}
+		if m := limitPattern.FindStringSubmatch(q); m != nil {
+			limit, _ = strconv.Atoi(m[1])
+		}
+
+		records := []map[string]interface{}{}
+		for i := start; i < total && len(records) < limit; i++ {
+			records = append(records, map[string]interface{}{
+				"attributes": map[string]string{"type": "Account"},
+				"Id":         fmt.Sprintf("%05d", i),
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"totalSize": len(records),
+			"done":      true,
+			"records":   records,
+		})
+	})
+
+	result, err := client.RunQuery(Select("Id", "Name").From("Account").Limit(5).Offset(4100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Records) != 5 || result.Records[0].ID() != "04100" || result.Records[4].ID() != "04104" {
+		t.Errorf("unexpected records %v", result.Records)
+	}
+	if len(queries) != 4 || !strings.HasPrefix(queries[3], "SELECT Id, Name FROM Account WHERE Id > '04099' ORDER BY Id LIMIT 5") {
+		t.Errorf("unexpected queries %v", queries)
+	}
+
+	result, err = client.RunQuery(Select("Id").From("Account").Offset(5000))
+	if err != nil || len(result.Records) != 0 {
+		t.Errorf("expected no records beyond the end, got %v, %v", result, err)
+	}
+
+	if _, err = client.RunQuery(Select("Id").From("Account").OrderBy("Name").Offset(5000)); err == nil {
+		t.Error("expected error for deep offset with custom ordering")
+	}
+}
+
+func TestClient_DescribeGlobalError(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`[{"message":"The REST API is not enabled for this Organization.","errorCode":"API_DISABLED_FOR_ORG"}]`))
+	})
+
+	meta, err := client.DescribeGlobal()
+	var sfErr SalesforceError
+	if meta != nil || !errors.As(err, &sfErr) || sfErr.HttpCode != http.StatusForbidden {
+		t.Errorf("expected the status to be reported, got %v, %v", meta, err)
+	}
+}
@@ -0,0 +1,106 @@
+package simpleforce
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// GetOption configures how GetWith reads a record.
+type GetOption func(*getOptions)
+
+type getOptions struct {
+	fields    []string
+	forClause string
+}
+
+// WithFields reads only fields, rather than all the fields of the record, which cuts the size of the response.
+func WithFields(fields ...string) GetOption {
+	return func(o *getOptions) {
+		o.fields = append(o.fields, fields...)
+	}
+}
+
+// WithForView updates the LastViewedDate of the record and adds it to the recent items of the user, as viewing it
+// in the user interface does.
+func WithForView() GetOption {
+	return func(o *getOptions) {
+		o.forClause = "FOR VIEW"
+	}
+}
+
+// WithForReference updates the LastReferencedDate of the record and adds it to the recent items of the user.
+func WithForReference() GetOption {
+	return func(o *getOptions) {
+		o.forClause = "FOR REFERENCE"
+	}
+}
+
+// GetWith retrieves the fields of an SObject like Get, configured by opts. The existing ID of the SObject is used if id
+// is empty. WithForView and WithForReference read the record with a SOQL query, selecting all the described fields
+// unless WithFields is set.
+func (obj *SObject) GetWith(id string, opts ...GetOption) *SObject {
+	return obj.GetWithContext(context.Background(), id, opts...)
+}
+
+// GetWithContext is like GetWith, with the requests bound to ctx.
+func (obj *SObject) GetWithContext(ctx context.Context, id string, opts ...GetOption) *SObject {
+	if obj.Type() == "" || obj.client() == nil {
+		// Sanity check.
+		return nil
+	}
+	if id == "" {
+		id = obj.ID()
+	}
+	if id == "" {
+		obj.client().logError("object id not found.")
+		return nil
+	}
+
+	options := getOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var err error
+	if options.forClause == "" {
+		err = obj.getRecord(ctx, id, options.fields, nil)
+	} else {
+		err = obj.queryRecord(ctx, id, options)
+	}
+	if err != nil {
+		obj.client().logError("failed to get record,", err)
+		return nil
+	}
+	return obj
+}
+
+// queryRecord reads the record id into the SObject with a SOQL query using the FOR clause of options.
+func (obj *SObject) queryRecord(ctx context.Context, id string, options getOptions) error {
+	fields := options.fields
+	if len(fields) == 0 {
+		meta, err := obj.client().describeContext(ctx, obj.Type())
+		if err != nil {
+			return err
+		}
+		fields = meta.fieldNames()
+	}
+
+	qb := Select(fields...).From(obj.Type()).Where(Eq(sobjectIDKey, id)).Limit(1)
+	qb.forClause = options.forClause
+	q, err := qb.Build()
+	if err != nil {
+		return err
+	}
+	result, err := obj.client().QueryContext(ctx, q)
+	if err != nil {
+		return err
+	}
+	if len(result.Records) == 0 {
+		return errors.Errorf("%s %s not found", obj.Type(), id)
+	}
+	for key, value := range result.Records[0] {
+		(*obj)[key] = value
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package simpleforce
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSObject_GetWith(t *testing.T) {
+	var requests []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		switch r.URL.Path {
+		case "/services/data/v54.0/sobjects/Account/001D000000IqhSLIAZ":
+			w.Write([]byte(`{"attributes":{"type":"Account"},"Id":"001D000000IqhSLIAZ","Name":"Acme","Industry":"Retail"}`))
+		case "/services/data/v54.0/query":
+			w.Write([]byte(`{"totalSize":1,"done":true,"records":[{"attributes":{"type":"Account"},"Id":"001D000000IqhSLIAZ","Name":"Acme"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	account := client.SObject("Account").GetWith("001D000000IqhSLIAZ", WithFields("Name", "Industry"))
+	if account == nil || account.StringField("Industry") != "Retail" {
+		t.Fatalf("unexpected account %v", account)
+	}
+	if account.GetWith("", WithFields("Name"), WithForView()) == nil || account.ID() != "001D000000IqhSLIAZ" {
+		t.Fatalf("unexpected account %v", account)
+	}
+
+	expected := []string{
+		"/services/data/v54.0/sobjects/Account/001D000000IqhSLIAZ?fields=Name%2CIndustry",
+		"/services/data/v54.0/query?q=SELECT+Name+FROM+Account+WHERE+Id+%3D+%27001D000000IqhSLIAZ%27+LIMIT+1+FOR+VIEW",
+	}
+	if len(requests) != 2 || requests[0] != expected[0] || requests[1] != expected[1] {
+		t.Errorf("unexpected requests %v", requests)
+	}
+
+	if client.SObject("Account").GetWith("") != nil {
+		t.Error("expected nil without id")
+	}
+}
@@ -4,4 +4,6 @@ go 1.17
 
 require github.com/pkg/errors v0.9.1
 
-require github.com/google/uuid v1.3.0 // indirect
+require github.com/google/uuid v1.3.0
+
+require gopkg.in/yaml.v3 v3.0.1
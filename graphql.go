@@ -0,0 +1,167 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GraphQLError is an error reported by the GraphQL API.
+type GraphQLError struct {
+	Message   string `json:"message"`
+	Locations []struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"locations"`
+	// Path is the path of the field which failed, made of field names and list indexes.
+	Path       []interface{} `json:"path"`
+	Extensions struct {
+		// Classification is e.g. "ValidationError", "InvalidSyntax" or "DataFetchingException".
+		Classification string `json:"classification"`
+	} `json:"extensions"`
+}
+
+// GraphQLErrors are the errors of a GraphQL response. They match ErrInvalidQuery if the query was rejected.
+type GraphQLErrors []GraphQLError
+
+func (errs GraphQLErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Message
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// Is makes errors.Is(err, ErrInvalidQuery) hold for syntax and validation errors.
+func (errs GraphQLErrors) Is(target error) bool {
+	if target != ErrInvalidQuery {
+		return false
+	}
+	for _, err := range errs {
+		switch err.Extensions.Classification {
+		case "ValidationError", "InvalidSyntax":
+			return true
+		}
+	}
+	return false
+}
+
+// GraphQLResponse is the response of a GraphQL query.
+type GraphQLResponse struct {
+	// Data is the result of the query; it may be partial if some fields failed.
+	Data   json.RawMessage `json:"data"`
+	Errors GraphQLErrors   `json:"errors"`
+}
+
+// Decode decodes the data of the response into v.
+func (resp *GraphQLResponse) Decode(v interface{}) error {
+	return json.Unmarshal(resp.Data, v)
+}
+
+// GraphQL runs a query of the GraphQL API, e.g. "query { uiapi { query { Account { edges { node { Name { value }
+// } } } } } }", with variables, which may be nil. The GraphQL API reads related records of several levels in a single
+// request. If the response holds errors, they're returned as GraphQLErrors along with the response, whose data may be
+// partial.
+// Ref: https://developer.salesforce.com/docs/platform/graphql/guide/graphql-about.html
+func (client *Client) GraphQL(query string, variables map[string]interface{}) (*GraphQLResponse, error) {
+	return client.GraphQLContext(context.Background(), query, variables)
+}
+
+// GraphQLContext is like GraphQL, with the request bound to ctx.
+func (client *Client) GraphQLContext(ctx context.Context, query string, variables map[string]interface{}) (*GraphQLResponse, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	body := map[string]interface{}{"query": query}
+	if len(variables) > 0 {
+		body["variables"] = variables
+	}
+	reqData, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("graphql")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+
+	var resp GraphQLResponse
+	err = json.Unmarshal(data, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Errors) > 0 {
+		client.logError("GraphQL query failed:", resp.Errors.Error())
+		return &resp, resp.Errors
+	}
+	return &resp, nil
+}
+
+// GraphQLEach runs a query whose connection at path, e.g. "uiapi.query.Account", is paginated by cursor, calling fn
+// with the edges of each page until the last page. The query declares an $after variable, passed as the after
+// argument of the connection along with first, and selects its pageInfo, e.g.:
+//
+//	query accounts($after: String) { uiapi { query { Account(first: 200, after: $after) {
+//		edges { node { Id Name { value } } }
+//		pageInfo { hasNextPage endCursor }
+//	} } } }
+func (client *Client) GraphQLEach(query string, variables map[string]interface{}, path string, fn func(edges json.RawMessage) error) error {
+	return client.GraphQLEachContext(context.Background(), query, variables, path, fn)
+}
+
+// GraphQLEachContext is like GraphQLEach, with the requests bound to ctx.
+func (client *Client) GraphQLEachContext(ctx context.Context, query string, variables map[string]interface{}, path string, fn func(edges json.RawMessage) error) error {
+	pageVariables := make(map[string]interface{}, len(variables)+1)
+	for name, value := range variables {
+		pageVariables[name] = value
+	}
+
+	for {
+		resp, err := client.GraphQLContext(ctx, query, pageVariables)
+		if err != nil {
+			return err
+		}
+
+		connection := resp.Data
+		for _, key := range strings.Split(path, ".") {
+			var fields map[string]json.RawMessage
+			err = json.Unmarshal(connection, &fields)
+			if err != nil {
+				return errors.Wrapf(err, "invalid data at %s", key)
+			}
+			var ok bool
+			connection, ok = fields[key]
+			if !ok {
+				return errors.Errorf("no %s in data at %s", key, path)
+			}
+		}
+		var page struct {
+			Edges    json.RawMessage `json:"edges"`
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+		}
+		err = json.Unmarshal(connection, &page)
+		if err != nil {
+			return err
+		}
+
+		err = fn(page.Edges)
+		if err != nil {
+			return err
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return nil
+		}
+		pageVariables["after"] = page.PageInfo.EndCursor
+	}
+}
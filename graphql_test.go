@@ -0,0 +1,126 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_GraphQL(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/graphql" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables["name"] != "Acme" {
+			t.Errorf("unexpected variables %v", req.Variables)
+		}
+		w.Write([]byte(`{"data":{"uiapi":{"query":{"Account":{"edges":[{"node":{"Id":"0013000000Db2wKAAR",
+			"Name":{"value":"Acme"},"Contacts":{"edges":[{"node":{"Name":{"value":"Jane Doe"}}}]}}}]}}}},"errors":[]}`))
+	})
+
+	resp, err := client.GraphQL(`query accounts($name: String) { uiapi { query { Account(where: {Name: {eq: $name}}) {
+		edges { node { Id Name { value } Contacts { edges { node { Name { value } } } } } } } } } }`,
+		map[string]interface{}{"name": "Acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data struct {
+		UIAPI struct {
+			Query struct {
+				Account struct {
+					Edges []struct {
+						Node struct {
+							Contacts struct {
+								Edges []struct {
+									Node struct {
+										Name struct{ Value string }
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		} `json:"uiapi"`
+	}
+	if err = resp.Decode(&data); err != nil {
+		t.Fatal(err)
+	}
+	accounts := data.UIAPI.Query.Account.Edges
+	if len(accounts) != 1 || accounts[0].Node.Contacts.Edges[0].Node.Name.Value != "Jane Doe" {
+		t.Errorf("unexpected data %s", resp.Data)
+	}
+}
+
+func TestClient_GraphQLErrors(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":null,"errors":[{"message":"Validation error (FieldUndefined@[uiapi/query/Account/edges/node/Foo]) : Field 'Foo' in type 'Account' is undefined",
+			"locations":[{"line":1,"column":50}],"path":null,"extensions":{"ErrorType":"ValidationError","classification":"ValidationError"}}]}`))
+	})
+
+	resp, err := client.GraphQL(`query { uiapi { query { Account { edges { node { Foo } } } } } }`, nil)
+	var graphQLErrs GraphQLErrors
+	if !errors.As(err, &graphQLErrs) || len(graphQLErrs) != 1 || graphQLErrs[0].Locations[0].Column != 50 {
+		t.Fatalf("expected GraphQL errors, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("expected %v to match ErrInvalidQuery", err)
+	}
+	if resp == nil || len(resp.Errors) != 1 {
+		t.Errorf("expected the response along with the errors, got %+v", resp)
+	}
+}
+
+func TestClient_GraphQLEach(t *testing.T) {
+	var cursors []interface{}
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		cursors = append(cursors, req.Variables["after"])
+		if req.Variables["after"] == nil {
+			w.Write([]byte(`{"data":{"uiapi":{"query":{"Account":{"edges":[{"node":{"Id":"0013000000Db2wKAAR"}}],
+				"pageInfo":{"hasNextPage":true,"endCursor":"djE6MQ=="}}}}}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"uiapi":{"query":{"Account":{"edges":[{"node":{"Id":"0013000000Db2wLAAR"}}],
+			"pageInfo":{"hasNextPage":false,"endCursor":"djE6Mg=="}}}}}}`))
+	})
+
+	variables := map[string]interface{}{"industry": "Energy"}
+	var ids []string
+	err := client.GraphQLEach(`query accounts($after: String) { ... }`, variables, "uiapi.query.Account", func(edges json.RawMessage) error {
+		var page []struct {
+			Node struct {
+				ID string `json:"Id"`
+			} `json:"node"`
+		}
+		err := json.Unmarshal(edges, &page)
+		for _, edge := range page {
+			ids = append(ids, edge.Node.ID)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[1] != "0013000000Db2wLAAR" || len(cursors) != 2 || cursors[0] != nil || cursors[1] != "djE6MQ==" {
+		t.Errorf("unexpected ids %v after cursors %v", ids, cursors)
+	}
+	if _, ok := variables["after"]; ok {
+		t.Error("expected the variables of the caller to be left unchanged")
+	}
+
+	err = client.GraphQLEach(`query { ... }`, nil, "uiapi.query.Contact", func(json.RawMessage) error { return nil })
+	if err == nil {
+		t.Error("expected an error for a missing connection")
+	}
+}
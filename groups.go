@@ -0,0 +1,320 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Group types.
+const (
+	GroupTypeRegular                     = "Regular"
+	GroupTypeQueue                       = "Queue"
+	GroupTypeRole                        = "Role"
+	GroupTypeRoleAndSubordinates         = "RoleAndSubordinates"
+	GroupTypeRoleAndSubordinatesInternal = "RoleAndSubordinatesInternal"
+)
+
+// Group is a public group, a queue, or a group implicitly created for a role.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.object_reference.meta/object_reference/sforce_api_objects_group.htm
+type Group struct {
+	ID                string `json:"Id"`
+	Name              string `json:"Name"`
+	DeveloperName     string `json:"DeveloperName"`
+	Type              string `json:"Type"`
+	RelatedID         string `json:"RelatedId"`
+	Email             string `json:"Email"`
+	DoesIncludeBosses bool   `json:"DoesIncludeBosses"`
+}
+
+// GroupMember is the membership of a user or a group in a group.
+type GroupMember struct {
+	ID            string `json:"Id"`
+	GroupID       string `json:"GroupId"`
+	UserOrGroupID string `json:"UserOrGroupId"`
+}
+
+var groupFields = []string{"Id", "Name", "DeveloperName", "Type", "RelatedId", "Email", "DoesIncludeBosses"}
+
+// CreateGroup creates a public group and returns its ID.
+func (client *Client) CreateGroup(name, developerName string) (string, error) {
+	return client.CreateGroupContext(context.Background(), name, developerName)
+}
+
+// CreateGroupContext is like CreateGroup, with the request bound to ctx.
+func (client *Client) CreateGroupContext(ctx context.Context, name, developerName string) (string, error) {
+	group := client.SObject("Group").
+		Set("Name", name).
+		Set("DeveloperName", developerName).
+		Set("Type", GroupTypeRegular)
+	results, err := client.saveCollectionContext(ctx, http.MethodPost, []*SObject{group}, true)
+	if err != nil {
+		return "", err
+	}
+	return results[0].ID, results[0].Err()
+}
+
+// CreateQueue creates a queue supporting records of the provided objects, e.g. "Case" or "Lead", and returns its ID.
+func (client *Client) CreateQueue(name, developerName string, sobjectTypes ...string) (string, error) {
+	return client.CreateQueueContext(context.Background(), name, developerName, sobjectTypes...)
+}
+
+// CreateQueueContext is like CreateQueue, with the requests bound to ctx.
+func (client *Client) CreateQueueContext(ctx context.Context, name, developerName string, sobjectTypes ...string) (string, error) {
+	queue := client.SObject("Group").
+		Set("Name", name).
+		Set("DeveloperName", developerName).
+		Set("Type", GroupTypeQueue)
+	results, err := client.saveCollectionContext(ctx, http.MethodPost, []*SObject{queue}, true)
+	if err != nil {
+		return "", err
+	}
+	if err = results[0].Err(); err != nil {
+		return "", err
+	}
+
+	queueID := results[0].ID
+	if len(sobjectTypes) == 0 {
+		return queueID, nil
+	}
+	queueObjects := make([]*SObject, 0, len(sobjectTypes))
+	for _, sobjectType := range sobjectTypes {
+		queueObjects = append(queueObjects, client.SObject("QueueSobject").
+			Set("QueueId", queueID).
+			Set("SobjectType", sobjectType))
+	}
+	results, err = client.saveCollectionContext(ctx, http.MethodPost, queueObjects, true)
+	if err != nil {
+		return queueID, err
+	}
+	for _, result := range results {
+		if err = result.Err(); err != nil {
+			return queueID, errors.Wrap(err, "queue created without all of its objects")
+		}
+	}
+	return queueID, nil
+}
+
+// GroupMembers lists the direct members of a group.
+func (client *Client) GroupMembers(groupID string) ([]GroupMember, error) {
+	return client.GroupMembersContext(context.Background(), groupID)
+}
+
+// GroupMembersContext is like GroupMembers, with the requests bound to ctx.
+func (client *Client) GroupMembersContext(ctx context.Context, groupID string) ([]GroupMember, error) {
+	q, err := Select("Id", "GroupId", "UserOrGroupId").From("GroupMember").Where(Eq("GroupId", groupID)).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	members := []GroupMember{}
+	err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+		var page []GroupMember
+		err := json.Unmarshal(records, &page)
+		members = append(members, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddGroupMembers adds users or groups to a group. Each member succeeds or fails on its own; the results are
+// returned in the order of userOrGroupIDs.
+func (client *Client) AddGroupMembers(groupID string, userOrGroupIDs ...string) ([]SaveResult, error) {
+	return client.AddGroupMembersContext(context.Background(), groupID, userOrGroupIDs...)
+}
+
+// AddGroupMembersContext is like AddGroupMembers, with the requests bound to ctx.
+func (client *Client) AddGroupMembersContext(ctx context.Context, groupID string, userOrGroupIDs ...string) ([]SaveResult, error) {
+	members := make([]*SObject, 0, len(userOrGroupIDs))
+	for _, id := range userOrGroupIDs {
+		members = append(members, client.SObject("GroupMember").
+			Set("GroupId", groupID).
+			Set("UserOrGroupId", id))
+	}
+	return client.saveCollectionContext(ctx, http.MethodPost, members, false)
+}
+
+// RemoveGroupMembers removes users or groups from a group. IDs which aren't members of the group are ignored.
+func (client *Client) RemoveGroupMembers(groupID string, userOrGroupIDs ...string) error {
+	return client.RemoveGroupMembersContext(context.Background(), groupID, userOrGroupIDs...)
+}
+
+// RemoveGroupMembersContext is like RemoveGroupMembers, with the requests bound to ctx.
+func (client *Client) RemoveGroupMembersContext(ctx context.Context, groupID string, userOrGroupIDs ...string) error {
+	members, err := client.GroupMembersContext(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	remove := map[string]bool{}
+	for _, id := range userOrGroupIDs {
+		remove[id] = true
+	}
+	var memberIDs []string
+	for _, member := range members {
+		if remove[member.UserOrGroupID] {
+			memberIDs = append(memberIDs, member.ID)
+		}
+	}
+	if len(memberIDs) == 0 {
+		return nil
+	}
+
+	results, err := client.deleteCollectionContext(ctx, memberIDs, false)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err = result.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UserGroups returns the groups and queues a user effectively belongs to: the groups of the user's role and of the
+// roles above it in the hierarchy, the groups the user was added to, and, transitively, the groups containing any of
+// these groups.
+func (client *Client) UserGroups(userID string) ([]Group, error) {
+	return client.UserGroupsContext(context.Background(), userID)
+}
+
+// UserGroupsContext is like UserGroups, with the requests bound to ctx.
+func (client *Client) UserGroupsContext(ctx context.Context, userID string) ([]Group, error) {
+	roleGroupIDs, err := client.roleGroupIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	pending := append([]string{userID}, roleGroupIDs...)
+	for _, id := range roleGroupIDs {
+		found[id] = true
+	}
+	for len(pending) > 0 {
+		values := make([]interface{}, 0, len(pending))
+		for _, id := range pending {
+			values = append(values, id)
+		}
+		pending = nil
+
+		q, err := Select("GroupId").From("GroupMember").Where(In("UserOrGroupId", values...)).Build()
+		if err != nil {
+			return nil, err
+		}
+		err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+			var page []GroupMember
+			err := json.Unmarshal(records, &page)
+			for _, member := range page {
+				if !found[member.GroupID] {
+					found[member.GroupID] = true
+					pending = append(pending, member.GroupID)
+				}
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	groups := []Group{}
+	if len(found) == 0 {
+		return groups, nil
+	}
+	ids := make([]interface{}, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	q, err := Select(groupFields...).From("Group").Where(In("Id", ids...)).OrderBy("Name").Build()
+	if err != nil {
+		return nil, err
+	}
+	err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+		var page []Group
+		err := json.Unmarshal(records, &page)
+		groups = append(groups, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// roleGroupIDs returns the IDs of the groups a user implicitly belongs to through the role hierarchy: the Role group
+// of the user's role, and the RoleAndSubordinates groups of the user's role and all of its ancestors.
+func (client *Client) roleGroupIDs(ctx context.Context, userID string) ([]string, error) {
+	q, err := Select("UserRoleId").From("User").Where(Eq("Id", userID)).Build()
+	if err != nil {
+		return nil, err
+	}
+	var roleID string
+	err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+		var page []struct {
+			UserRoleID string `json:"UserRoleId"`
+		}
+		err := json.Unmarshal(records, &page)
+		if len(page) > 0 {
+			roleID = page[0].UserRoleID
+		}
+		return err
+	})
+	if err != nil || roleID == "" {
+		return nil, err
+	}
+
+	// Walk up the role hierarchy.
+	parents := map[string]string{}
+	q, err = Select("Id", "ParentRoleId").From("UserRole").Build()
+	if err != nil {
+		return nil, err
+	}
+	err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+		var page []struct {
+			ID           string `json:"Id"`
+			ParentRoleID string `json:"ParentRoleId"`
+		}
+		err := json.Unmarshal(records, &page)
+		for _, role := range page {
+			parents[role.ID] = role.ParentRoleID
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	ancestors := []interface{}{}
+	for id := roleID; id != "" && len(ancestors) <= len(parents); id = parents[id] {
+		ancestors = append(ancestors, id)
+	}
+
+	q, err = Select("Id", "Type", "RelatedId").From("Group").
+		Where(In("RelatedId", ancestors...)).
+		Where(In("Type", GroupTypeRole, GroupTypeRoleAndSubordinates, GroupTypeRoleAndSubordinatesInternal)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+		var page []Group
+		err := json.Unmarshal(records, &page)
+		for _, group := range page {
+			// The Role group only contains users of exactly that role.
+			if group.Type != GroupTypeRole || group.RelatedID == roleID {
+				ids = append(ids, group.ID)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
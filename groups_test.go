@@ -0,0 +1,109 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_UserGroups(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		var records []map[string]interface{}
+		switch {
+		case strings.HasPrefix(q, "SELECT UserRoleId FROM User"):
+			records = []map[string]interface{}{{"UserRoleId": "00E000000000003"}}
+		case strings.HasPrefix(q, "SELECT Id, ParentRoleId FROM UserRole"):
+			records = []map[string]interface{}{
+				{"Id": "00E000000000001"},
+				{"Id": "00E000000000002", "ParentRoleId": "00E000000000001"},
+				{"Id": "00E000000000003", "ParentRoleId": "00E000000000002"},
+			}
+		case strings.HasPrefix(q, "SELECT Id, Type, RelatedId FROM Group"):
+			if !strings.Contains(q, "RelatedId IN ('00E000000000003', '00E000000000002', '00E000000000001')") {
+				t.Errorf("unexpected role query %s", q)
+			}
+			records = []map[string]interface{}{
+				{"Id": "00G00000000000R", "Type": "Role", "RelatedId": "00E000000000003"},
+				{"Id": "00G00000000001R", "Type": "Role", "RelatedId": "00E000000000001"},
+				{"Id": "00G00000000001S", "Type": "RoleAndSubordinates", "RelatedId": "00E000000000001"},
+			}
+		case strings.HasPrefix(q, "SELECT GroupId FROM GroupMember"):
+			// The user is in a public group, which is in a queue; the role group is in another public group.
+			if strings.Contains(q, "'005000000000001'") {
+				records = []map[string]interface{}{{"GroupId": "00G000000000001"}, {"GroupId": "00G000000000002"}}
+			} else if strings.Contains(q, "'00G000000000001'") {
+				records = []map[string]interface{}{{"GroupId": "00G00000000000Q"}}
+			}
+		case strings.HasPrefix(q, "SELECT Id, Name, DeveloperName"):
+			for _, id := range []string{"00G00000000000R", "00G00000000001S", "00G000000000001", "00G000000000002", "00G00000000000Q"} {
+				if !strings.Contains(q, id) {
+					t.Errorf("group %s missing from %s", id, q)
+				}
+			}
+			if strings.Contains(q, "00G00000000001R") {
+				t.Errorf("unexpected role group of an ancestor in %s", q)
+			}
+			records = []map[string]interface{}{{"Id": "00G00000000000Q", "Name": "Support", "Type": "Queue"}}
+		default:
+			t.Errorf("unexpected query %s", q)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"done": true, "records": records})
+	})
+
+	groups, err := client.UserGroups("005000000000001")
+	if err != nil || len(groups) != 1 || groups[0].Type != GroupTypeQueue {
+		t.Errorf("unexpected groups %v, %v", groups, err)
+	}
+}
+
+func TestClient_CreateQueue(t *testing.T) {
+	var types []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Records []map[string]interface{} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		results := []SaveResult{}
+		for _, record := range req.Records {
+			types = append(types, record["attributes"].(map[string]interface{})["type"].(string))
+			results = append(results, SaveResult{ID: "00G000000000001", Success: true})
+		}
+		json.NewEncoder(w).Encode(results)
+	})
+
+	id, err := client.CreateQueue("Support", "Support", "Case", "Lead")
+	if err != nil || id != "00G000000000001" || strings.Join(types, ",") != "Group,QueueSobject,QueueSobject" {
+		t.Errorf("unexpected queue %s with %v, %v", id, types, err)
+	}
+}
+
+func TestClient_CreateGroupMissingResult(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	})
+
+	if _, err := client.CreateGroup("Sales", "Sales"); err == nil {
+		t.Error("expected error for a group response without results")
+	}
+	if _, err := client.CreateQueue("Support", "Support", "Case"); err == nil {
+		t.Error("expected error for a queue response without results")
+	}
+}
+
+func TestClient_UserGroupsContext(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.UserGroupsContext(ctx, "005000000000001"); !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("expected the canceled context to stop the queries, got %v after %d calls", err, calls)
+	}
+}
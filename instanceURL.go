@@ -0,0 +1,130 @@
+package simpleforce
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// InstanceURL returns the URL of the Salesforce instance REST requests are sent to, as returned by the last login. It
+// may differ from the login URL given to NewClient, and changes if the org is migrated to another instance.
+func (client *Client) InstanceURL() string {
+	return client.getInstanceURL()
+}
+
+// RefreshInstanceURL asks the login URL which instance currently hosts the org through the OAuth userinfo endpoint,
+// and switches the client to it. This is needed after an org migration or instance refresh, when the previous
+// instance stops answering.
+// Ref: https://help.salesforce.com/s/articleView?id=sf.remoteaccess_using_userinfo_endpoint.htm
+func (client *Client) RefreshInstanceURL() error {
+	return client.RefreshInstanceURLContext(context.Background())
+}
+
+// RefreshInstanceURLContext is like RefreshInstanceURL, with the request bound to ctx.
+func (client *Client) RefreshInstanceURLContext(ctx context.Context) error {
+	if !client.isLoggedIn() {
+		return ErrAuthentication
+	}
+
+	u := client.loginBaseURL() + "/services/oauth2/userinfo"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Authorization", "Bearer "+client.getSessionID())
+	resp, err := client.sendRequest(req)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return err
+	}
+	defer resp.Body.Close()
+
+	var userInfo struct {
+		URLs struct {
+			REST string `json:"rest"`
+		} `json:"urls"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&userInfo)
+	if err != nil {
+		return err
+	}
+	if userInfo.URLs.REST == "" {
+		return errors.New("userinfo response has no REST URL")
+	}
+
+	instanceURL := parseHost(userInfo.URLs.REST)
+	if previous := client.swapInstanceURL(instanceURL); previous != instanceURL {
+		client.logInfo("org moved from", previous, "to", instanceURL)
+	}
+	return nil
+}
+
+// instanceMoved checks whether the org moved to another instance after a request to url failed with err, and returns
+// url rewritten for the new instance if it did. Only errors telling the current instance is gone are checked, see
+// instanceGone; sites set by WithSiteURL don't move with the instance.
+func (client *Client) instanceMoved(ctx context.Context, url string, err error) (string, bool) {
+	if client.siteURL != "" || !instanceGone(err) {
+		return "", false
+	}
+	previous := client.getInstanceURL()
+	if previous == "" || previous == client.loginBaseURL() || !strings.HasPrefix(url, previous+"/") {
+		return "", false
+	}
+
+	if client.RefreshInstanceURLContext(ctx) != nil {
+		return "", false
+	}
+	current := client.getInstanceURL()
+	if current == previous {
+		return "", false
+	}
+	return current + strings.TrimPrefix(url, previous), true
+}
+
+// redirectingHTTPClient returns the HTTP client of the client, made not to follow redirects to another host unless the
+// application set a redirect policy of its own. Such a redirect tells the org moved, see instanceGone, and following
+// it would send the request without its Authorization header, which isn't forwarded to other hosts.
+func (client *Client) redirectingHTTPClient() *http.Client {
+	if client.httpClient.CheckRedirect != nil {
+		return client.httpClient
+	}
+	httpClient := *client.httpClient
+	httpClient.CheckRedirect = checkRedirect
+	return &httpClient
+}
+
+// checkRedirect follows the redirects of a request within its host, up to 10 as the default policy of http.Client.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Host != via[0].URL.Host {
+		return http.ErrUseLastResponse
+	}
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	return nil
+}
+
+// instanceGone reports whether err tells the instance a request was sent to doesn't serve the org anymore: a redirect
+// to another host, which the client doesn't follow, a host name which doesn't resolve or a certificate which doesn't
+// match it. Timeouts and other network errors don't, as they're usually transient.
+func instanceGone(err error) bool {
+	var sfErr SalesforceError
+	if errors.As(err, &sfErr) {
+		switch sfErr.HttpCode {
+		case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+			return true
+		}
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsNotFound
+	}
+	var hostErr x509.HostnameError
+	return errors.As(err, &hostErr)
+}
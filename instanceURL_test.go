@@ -0,0 +1,103 @@
+package simpleforce
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_InstanceMoved(t *testing.T) {
+	newInstance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/sobjects/Account/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"001000000000001","success":true}`))
+	}))
+	defer newInstance.Close()
+
+	userInfos := 0
+	login := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/oauth2/userinfo" || r.Header.Get("Authorization") != "Bearer __SESSION_ID__" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		userInfos++
+		fmt.Fprintf(w, `{"urls":{"rest":"%s/services/data/v{version}/"}}`, newInstance.URL)
+	}))
+	defer login.Close()
+
+	// The old instance no longer resolves; an unreachable one may just be down.
+	oldInstance := httptest.NewServer(http.NotFoundHandler())
+	oldInstance.Close()
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "na1.salesforce.invalid" {
+			return nil, &net.DNSError{Err: "no such host", Name: req.URL.Host, IsNotFound: true}
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client := NewClient(login.URL, DefaultClientID, DefaultAPIVersion, WithTransport(transport))
+
+	client.SetSidLoc("__SESSION_ID__", oldInstance.URL)
+	body := `{"Name":"Acme"}`
+	_, err := client.httpRequest(http.MethodPost, client.makeURL("sobjects/Account/"), strings.NewReader(body))
+	if err == nil || userInfos != 0 || client.InstanceURL() != oldInstance.URL {
+		t.Fatalf("expected an unreachable instance to be kept, got %v, %d userinfo calls", err, userInfos)
+	}
+
+	client.SetSidLoc("__SESSION_ID__", "http://na1.salesforce.invalid")
+	data, err := client.httpRequest(http.MethodPost, client.makeURL("sobjects/Account/"), strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "001000000000001") {
+		t.Errorf("unexpected response %s", data)
+	}
+	if client.InstanceURL() != newInstance.URL || userInfos != 1 {
+		t.Errorf("expected instance URL to be %s, got %s", newInstance.URL, client.InstanceURL())
+	}
+}
+
+func TestClient_DescribeGlobalUsesInstanceURL(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sobjects":[]}`))
+	})
+	client.baseURL = "http://127.0.0.1:1"
+
+	_, err := client.DescribeGlobal()
+	if err != nil {
+		t.Errorf("expected describe global to go to the instance, got %v", err)
+	}
+}
+
+func TestClient_InstanceMovedRedirect(t *testing.T) {
+	newInstance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer __SESSION_ID__" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer newInstance.Close()
+
+	oldRequests := 0
+	oldInstance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		oldRequests++
+		http.Redirect(w, r, newInstance.URL+r.URL.RequestURI(), http.StatusMovedPermanently)
+	}))
+	defer oldInstance.Close()
+
+	login := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"urls":{"rest":"%s/services/data/v{version}/"}}`, newInstance.URL)
+	}))
+	defer login.Close()
+
+	client := NewClient(login.URL, DefaultClientID, DefaultAPIVersion)
+	client.SetSidLoc("__SESSION_ID__", oldInstance.URL)
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	if client.InstanceURL() != newInstance.URL || oldRequests != 1 {
+		t.Errorf("expected the redirect to move the client to %s, got %s", newInstance.URL, client.InstanceURL())
+	}
+}
@@ -0,0 +1,57 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Names of common org limits.
+const (
+	LimitDailyAPIRequests   = "DailyApiRequests"
+	LimitDailyBulkV2Query   = "DailyBulkV2QueryJobs"
+	LimitDataStorageMB      = "DataStorageMB"
+	LimitFileStorageMB      = "FileStorageMB"
+	LimitDailyAsyncApex     = "DailyAsyncApexExecutions"
+	LimitSingleEmail        = "SingleEmail"
+	LimitHourlyPublishEvent = "HourlyPublishedPlatformEvents"
+)
+
+// Limit is the maximum and remaining allocation of an org limit.
+type Limit struct {
+	Max       int `json:"Max"`
+	Remaining int `json:"Remaining"`
+}
+
+// Used returns the consumed allocation of the limit.
+func (limit Limit) Used() int {
+	return limit.Max - limit.Remaining
+}
+
+// Limits returns the limits of the org by name, e.g. Limits()[LimitDailyAPIRequests]. The breakdown of some limits
+// by connected app is left out.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_limits.htm
+func (client *Client) Limits() (map[string]Limit, error) {
+	return client.LimitsContext(context.Background())
+}
+
+// LimitsContext is like Limits, with the request bound to ctx.
+func (client *Client) LimitsContext(ctx context.Context) (map[string]Limit, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("limits")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var limits map[string]Limit
+	err = json.Unmarshal(data, &limits)
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
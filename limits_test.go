@@ -0,0 +1,36 @@
+package simpleforce
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClient_Limits(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/limits" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Sforce-Limit-Info", "api-usage=25/15000")
+		w.Write([]byte(`{
+			"DailyApiRequests":{"Max":15000,"Remaining":14975,"Ant Migration Tool":{"Max":0,"Remaining":0}},
+			"DataStorageMB":{"Max":5,"Remaining":3}
+		}`))
+	})
+
+	if _, _, ok := client.APIUsage(); ok {
+		t.Error("expected no API usage before any response")
+	}
+	limits, err := client.Limits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api := limits[LimitDailyAPIRequests]; api.Max != 15000 || api.Used() != 25 {
+		t.Errorf("unexpected API limit %+v", api)
+	}
+	if storage := limits[LimitDataStorageMB]; storage.Remaining != 3 {
+		t.Errorf("unexpected storage limit %+v", storage)
+	}
+	if used, max, ok := client.APIUsage(); !ok || used != 25 || max != 15000 {
+		t.Errorf("unexpected API usage %d/%d %v", used, max, ok)
+	}
+}
@@ -0,0 +1,105 @@
+package simpleforce
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger receives the log messages of a client. Messages are formatted like the arguments of log.Println, then
+// redacted and passed as a single argument: they never hold passwords, tokens or the values of the redacted fields,
+// see SetRedactedFields. Debug messages may hold user names, record IDs and response bodies.
+type Logger interface {
+	Debug(args ...interface{})
+	Info(args ...interface{})
+	Error(args ...interface{})
+}
+
+// LogLevel is the minimum level of the messages logged by a client.
+type LogLevel int
+
+// Log levels, from the most verbose.
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelError
+	// LogLevelNone silences the client.
+	LogLevelNone
+)
+
+// stdLogger writes messages through the standard log package, prefixed with logPrefix. It is the logger of clients
+// created without WithLogger.
+type stdLogger struct{}
+
+// Debug implements Logger.
+func (stdLogger) Debug(args ...interface{}) {
+	log.Println(append([]interface{}{logPrefix}, args...)...)
+}
+
+// Info implements Logger.
+func (stdLogger) Info(args ...interface{}) {
+	log.Println(append([]interface{}{logPrefix}, args...)...)
+}
+
+// Error implements Logger.
+func (stdLogger) Error(args ...interface{}) {
+	log.Println(append([]interface{}{logPrefix}, args...)...)
+}
+
+// WithLogger makes the client log its messages with logger instead of the standard log package. A nil logger silences
+// the client.
+func WithLogger(logger Logger) ClientOption {
+	return func(client *Client) {
+		client.logger = logger
+		if logger == nil {
+			client.logLevel = LogLevelNone
+		}
+	}
+}
+
+// WithLogLevel makes the client log the messages of level and above only. Clients log from LogLevelInfo by default.
+func WithLogLevel(level LogLevel) ClientOption {
+	return func(client *Client) {
+		client.logLevel = level
+	}
+}
+
+// logDebug logs a debug message. Like the other log methods, it can be called on SObjects without client.
+func (client *Client) logDebug(args ...interface{}) {
+	if logger := client.loggerFor(LogLevelDebug); logger != nil {
+		logger.Debug(client.logMessage(args))
+	}
+}
+
+// logInfo logs an informational message.
+func (client *Client) logInfo(args ...interface{}) {
+	if logger := client.loggerFor(LogLevelInfo); logger != nil {
+		logger.Info(client.logMessage(args))
+	}
+}
+
+// logError logs an error message.
+func (client *Client) logError(args ...interface{}) {
+	if logger := client.loggerFor(LogLevelError); logger != nil {
+		logger.Error(client.logMessage(args))
+	}
+}
+
+// logMessage formats args like log.Println and redacts the message, once for all the arguments.
+func (client *Client) logMessage(args []interface{}) string {
+	return client.Redact(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+// loggerFor returns the logger receiving the messages of level, or nil if they are filtered out.
+func (client *Client) loggerFor(level LogLevel) Logger {
+	if client == nil {
+		if level < LogLevelInfo {
+			return nil
+		}
+		return stdLogger{}
+	}
+	if level < client.logLevel || client.logger == nil {
+		return nil
+	}
+	return client.logger
+}
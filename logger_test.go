@@ -0,0 +1,101 @@
+package simpleforce
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// recordingLogger keeps the messages logged, prefixed with their level.
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debug(args ...interface{}) {
+	l.messages = append(l.messages, "DEBUG "+strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (l *recordingLogger) Info(args ...interface{}) {
+	l.messages = append(l.messages, "INFO "+strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (l *recordingLogger) Error(args ...interface{}) {
+	l.messages = append(l.messages, "ERROR "+strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/Soap/u/54.0" {
+			w.Write([]byte(`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><loginResponse><result>
+<serverUrl>http://` + r.Host + `/services/Soap/u/54.0/00D000000000062</serverUrl><sessionId>__SESSION_ID__</sessionId>
+<userInfo><userName>user@example.com</userName></userInfo></result></loginResponse></soapenv:Body></soapenv:Envelope>`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`[{"message":"unexpected token: FORM","errorCode":"MALFORMED_QUERY"}]`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithLogger(logger))
+	if err := client.LoginPassword("user@example.com", "password", ""); err != nil {
+		t.Fatal(err)
+	}
+	client.Query("SELECT Id FORM Account")
+	for _, msg := range logger.messages {
+		if strings.HasPrefix(msg, "DEBUG") || strings.Contains(msg, "user@example.com") {
+			t.Errorf("unexpected message %q", msg)
+		}
+	}
+	if len(logger.messages) == 0 || !strings.HasPrefix(logger.messages[len(logger.messages)-1], "ERROR HTTP GET request failed:") {
+		t.Errorf("unexpected messages %q", logger.messages)
+	}
+
+	logger.messages = nil
+	client = NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithLogger(logger), WithLogLevel(LogLevelDebug))
+	client.LoginPassword("user@example.com", "password", "")
+	if len(logger.messages) != 1 || logger.messages[0] != "DEBUG User user@example.com authenticated." {
+		t.Errorf("unexpected messages %q", logger.messages)
+	}
+
+	logger.messages = nil
+	client = NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithLogger(logger), WithLogLevel(LogLevelNone))
+	client.LoginPassword("user@example.com", "password", "")
+	client.Query("SELECT Id FORM Account")
+	if len(logger.messages) != 0 {
+		t.Errorf("unexpected messages %q", logger.messages)
+	}
+	// A nil logger silences the client as well.
+	client = NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithLogger(nil))
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+	if _, err := client.Query("SELECT Id FORM Account"); err == nil {
+		t.Error("expected the query to fail")
+	}
+}
+
+func TestClient_LogRedaction(t *testing.T) {
+	logger := &recordingLogger{}
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`[{"message":"unexpected token: FORM","errorCode":"MALFORMED_QUERY"}]`))
+	})
+	client.logger = logger
+	client.SetRedactedFields("Email")
+
+	if _, err := client.Query("SELECT Id FORM Contact WHERE Email = 'jdoe@example.com'"); err == nil {
+		t.Fatal("expected the query to fail")
+	}
+	if len(logger.messages) == 0 {
+		t.Fatal("expected the failure to be logged")
+	}
+	for _, msg := range logger.messages {
+		if strings.Contains(msg, "jdoe") || strings.Contains(msg, "__SESSION_ID__") {
+			t.Errorf("unredacted message %q", msg)
+		}
+	}
+	if !strings.Contains(logger.messages[len(logger.messages)-1], "Email+%3D+%27[REDACTED]%27") {
+		t.Errorf("unexpected messages %q", logger.messages)
+	}
+}
@@ -0,0 +1,157 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// HistoryFilter narrows down the security history records returned by LoginHistory, LoginGeos and
+// VerificationHistory. Zero values leave the respective filter out.
+type HistoryFilter struct {
+	// Since and Until select records in the time range [Since, Until).
+	Since time.Time
+	Until time.Time
+	// UserID selects records of a single user. It isn't supported by LoginGeos.
+	UserID string
+	// Limit caps the number of records returned; all pages of records are retrieved otherwise.
+	Limit int
+}
+
+// apply adds the filter conditions on timeField to qb.
+func (filter HistoryFilter) apply(qb *QueryBuilder, timeField string) *QueryBuilder {
+	if !filter.Since.IsZero() {
+		qb.Where(Gte(timeField, filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		qb.Where(Lt(timeField, filter.Until))
+	}
+	if filter.UserID != "" {
+		qb.Where(Eq("UserId", filter.UserID))
+	}
+	return qb.OrderBy(timeField).Limit(filter.Limit)
+}
+
+// LoginHistoryEntry is a login attempt of a user.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.object_reference.meta/object_reference/sforce_api_objects_loginhistory.htm
+type LoginHistoryEntry struct {
+	ID                  string   `json:"Id"`
+	UserID              string   `json:"UserId"`
+	LoginTime           DateTime `json:"LoginTime"`
+	LoginType           string   `json:"LoginType"`
+	LoginSubType        string   `json:"LoginSubType"`
+	LoginURL            string   `json:"LoginUrl"`
+	SourceIP            string   `json:"SourceIp"`
+	Status              string   `json:"Status"`
+	Application         string   `json:"Application"`
+	Browser             string   `json:"Browser"`
+	Platform            string   `json:"Platform"`
+	ClientVersion       string   `json:"ClientVersion"`
+	APIType             string   `json:"ApiType"`
+	APIVersion          string   `json:"ApiVersion"`
+	CountryISO          string   `json:"CountryIso"`
+	LoginGeoID          string   `json:"LoginGeoId"`
+	TLSProtocol         string   `json:"TlsProtocol"`
+	CipherSuite         string   `json:"CipherSuite"`
+	AuthMethodReference string   `json:"AuthMethodReference"`
+}
+
+// LoginGeo is the geographic location of a login.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.object_reference.meta/object_reference/sforce_api_objects_logingeo.htm
+type LoginGeo struct {
+	ID          string   `json:"Id"`
+	LoginTime   DateTime `json:"LoginTime"`
+	City        string   `json:"City"`
+	Country     string   `json:"Country"`
+	CountryISO  string   `json:"CountryIso"`
+	Subdivision string   `json:"Subdivision"`
+	PostalCode  string   `json:"PostalCode"`
+	Latitude    float64  `json:"Latitude"`
+	Longitude   float64  `json:"Longitude"`
+}
+
+// VerificationHistoryEntry is an identity verification attempt of a user.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.object_reference.meta/object_reference/sforce_api_objects_verificationhistory.htm
+type VerificationHistoryEntry struct {
+	ID                 string   `json:"Id"`
+	UserID             string   `json:"UserId"`
+	VerificationTime   DateTime `json:"VerificationTime"`
+	VerificationMethod string   `json:"VerificationMethod"`
+	EventGroup         int      `json:"EventGroup"`
+	Activity           string   `json:"Activity"`
+	Status             string   `json:"Status"`
+	Policy             string   `json:"Policy"`
+	SourceIP           string   `json:"SourceIp"`
+	LoginHistoryID     string   `json:"LoginHistoryId"`
+	ResourceID         string   `json:"ResourceId"`
+	Remarks            string   `json:"Remarks"`
+}
+
+// LoginHistory returns the login attempts matching filter, by ascending LoginTime.
+func (client *Client) LoginHistory(filter HistoryFilter) ([]LoginHistoryEntry, error) {
+	q, err := filter.apply(Select("Id", "UserId", "LoginTime", "LoginType", "LoginSubType", "LoginUrl", "SourceIp",
+		"Status", "Application", "Browser", "Platform", "ClientVersion", "ApiType", "ApiVersion", "CountryIso",
+		"LoginGeoId", "TlsProtocol", "CipherSuite", "AuthMethodReference").From("LoginHistory"), "LoginTime").Build()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []LoginHistoryEntry{}
+	err = client.queryEach(q, func(records json.RawMessage) error {
+		var page []LoginHistoryEntry
+		err := json.Unmarshal(records, &page)
+		entries = append(entries, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// LoginGeos returns the login locations matching filter, by ascending LoginTime.
+func (client *Client) LoginGeos(filter HistoryFilter) ([]LoginGeo, error) {
+	if filter.UserID != "" {
+		return nil, errors.Wrap(ErrInvalidQuery, "LoginGeo can't be filtered by user, filter LoginHistory by LoginGeoId instead")
+	}
+	q, err := filter.apply(Select("Id", "LoginTime", "City", "Country", "CountryIso", "Subdivision", "PostalCode",
+		"Latitude", "Longitude").From("LoginGeo"), "LoginTime").Build()
+	if err != nil {
+		return nil, err
+	}
+
+	geos := []LoginGeo{}
+	err = client.queryEach(q, func(records json.RawMessage) error {
+		var page []LoginGeo
+		err := json.Unmarshal(records, &page)
+		geos = append(geos, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return geos, nil
+}
+
+// VerificationHistory returns the identity verification attempts matching filter, by ascending VerificationTime.
+func (client *Client) VerificationHistory(filter HistoryFilter) ([]VerificationHistoryEntry, error) {
+	q, err := filter.apply(Select("Id", "UserId", "VerificationTime", "VerificationMethod", "EventGroup", "Activity",
+		"Status", "Policy", "SourceIp", "LoginHistoryId", "ResourceId", "Remarks").From("VerificationHistory"),
+		"VerificationTime").Build()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []VerificationHistoryEntry{}
+	err = client.queryEach(q, func(records json.RawMessage) error {
+		var page []VerificationHistoryEntry
+		err := json.Unmarshal(records, &page)
+		entries = append(entries, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,51 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_LoginHistory(t *testing.T) {
+	var queries []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"done": true,
+			"records": []map[string]interface{}{{
+				"Id": "0Ya000000000001", "UserId": "005000000000001", "LoginTime": "2022-04-29T10:30:00.000+0000",
+				"Status": "Success", "SourceIp": "203.0.113.7", "VerificationTime": "2022-04-29T10:31:00.000+0000",
+				"Latitude": 48.85, "Longitude": 2.35,
+			}},
+		})
+	})
+
+	filter := HistoryFilter{
+		Since:  time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+		Until:  time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC),
+		UserID: "005000000000001",
+		Limit:  100,
+	}
+	logins, err := client.LoginHistory(filter)
+	if err != nil || len(logins) != 1 || logins[0].SourceIP != "203.0.113.7" || logins[0].LoginTime.Hour() != 10 {
+		t.Errorf("unexpected logins %v, %v", logins, err)
+	}
+	expected := "FROM LoginHistory WHERE (LoginTime >= 2022-04-01T00:00:00Z) AND (LoginTime < 2022-05-01T00:00:00Z) AND (UserId = '005000000000001') ORDER BY LoginTime LIMIT 100"
+	if len(queries) != 1 || queries[0][len(queries[0])-len(expected):] != expected {
+		t.Errorf("unexpected queries %v", queries)
+	}
+
+	verifications, err := client.VerificationHistory(HistoryFilter{UserID: "005000000000001"})
+	if err != nil || len(verifications) != 1 || verifications[0].VerificationTime.Minute() != 31 {
+		t.Errorf("unexpected verifications %v, %v", verifications, err)
+	}
+
+	geos, err := client.LoginGeos(HistoryFilter{Since: filter.Since})
+	if err != nil || len(geos) != 1 || geos[0].Latitude != 48.85 {
+		t.Errorf("unexpected geos %v, %v", geos, err)
+	}
+	if _, err = client.LoginGeos(filter); err == nil {
+		t.Error("expected error for LoginGeo filtered by user")
+	}
+}
@@ -0,0 +1,237 @@
+package simpleforce
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// lockedRowCode is the error code of the records which couldn't be saved because they were locked.
+const lockedRowCode = "UNABLE_TO_LOCK_ROW"
+
+// defaultLockRetries is how many times UpdateByQuery retries locked records by default.
+const defaultLockRetries = 3
+
+// lockRetryDelay is the delay before the first retry of locked records; it doubles with every retry.
+var lockRetryDelay = time.Second
+
+// MassOptions controls DeleteByQuery and UpdateByQuery.
+type MassOptions struct {
+	// BatchSize is the number of records per SObject Collections call, up to and by default 200. Smaller batches
+	// help with records whose triggers hit governor limits.
+	BatchSize int
+	// DryRun only counts the matching records, without changing them.
+	DryRun bool
+	// Progress is called after every batch with the number of records processed so far and the number of matching
+	// records.
+	Progress func(processed, total int)
+	// LockRetries is how many times UpdateByQuery updates again the records of a batch which failed because they
+	// were locked by another transaction, 3 if zero. A negative value disables retries.
+	LockRetries int
+}
+
+// batchSize returns the effective batch size of opts.
+func (opts *MassOptions) batchSize() int {
+	if opts.BatchSize <= 0 || opts.BatchSize > maxCollectionRecords {
+		return maxCollectionRecords
+	}
+	return opts.BatchSize
+}
+
+// MassFailure describes a record which couldn't be processed by a mass operation.
+type MassFailure struct {
+	ID     string
+	Errors []SaveError
+}
+
+// MassResult is the report of a mass operation.
+type MassResult struct {
+	// Matched is the number of records matching the query.
+	Matched int
+	// Succeeded is the number of records processed successfully.
+	Succeeded int
+	// Failures are the records which couldn't be processed.
+	Failures []MassFailure
+}
+
+// record adds results to the report.
+func (result *MassResult) record(ids []string, results []SaveResult) {
+	for idx, saved := range results {
+		if saved.Success {
+			result.Succeeded++
+			continue
+		}
+		id := saved.ID
+		if id == "" && idx < len(ids) {
+			id = ids[idx]
+		}
+		result.Failures = append(result.Failures, MassFailure{ID: id, Errors: saved.Errors})
+	}
+}
+
+// eachQueriedRecord runs soql, which must select Id, and passes the matching records to fn by batches of size. total
+// is the number of matching records.
+func (client *Client) eachQueriedRecord(ctx context.Context, soql string, size int, fn func(records []SObject, total int) error) error {
+	var pending []SObject
+	for q := soql; q != ""; {
+		result, err := client.QueryContext(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, record := range result.Records {
+			if record.ID() == "" {
+				return errors.Wrap(ErrInvalidQuery, "query must select Id")
+			}
+			pending = append(pending, record)
+		}
+		q = ""
+		if !result.Done {
+			q = result.NextRecordsURL
+		}
+
+		for len(pending) >= size || (q == "" && len(pending) > 0) {
+			n := size
+			if n > len(pending) {
+				n = len(pending)
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			err = fn(pending[:n], result.TotalSize)
+			if err != nil {
+				return err
+			}
+			pending = pending[n:]
+		}
+	}
+	return nil
+}
+
+// DeleteByQuery deletes every record matching soql, which must select Id, through SObject Collections calls. Records
+// failing to be deleted don't prevent the others from being deleted; they are listed in the failures of the result.
+// If the deletion stops early, e.g. because ctx is cancelled, the result so far is returned along with the error.
+func (client *Client) DeleteByQuery(ctx context.Context, soql string, opts MassOptions) (*MassResult, error) {
+	result := &MassResult{}
+	if opts.DryRun {
+		counted, err := client.QueryContext(ctx, soql)
+		if err != nil {
+			return nil, err
+		}
+		result.Matched = counted.TotalSize
+		return result, nil
+	}
+
+	processed := 0
+	err := client.eachQueriedRecord(ctx, soql, opts.batchSize(), func(records []SObject, total int) error {
+		result.Matched = total
+		ids := make([]string, len(records))
+		for i := range records {
+			ids[i] = records[i].ID()
+		}
+		results, err := client.deleteCollectionContext(ctx, ids, false)
+		if err != nil {
+			return err
+		}
+		result.record(ids, results)
+		processed += len(ids)
+		if opts.Progress != nil {
+			opts.Progress(processed, total)
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// UpdateByQuery sets the fields of set on every record matching soql, which must select Id, through SObject
+// Collections calls: the "UPDATE ... SET ... WHERE" SOQL lacks. Records of a batch failing because they are locked
+// are updated again after a delay, see MassOptions.LockRetries. Other failures don't prevent the remaining records
+// from being updated; they are listed in the failures of the result. If the update stops early, e.g. because ctx is
+// cancelled, the result so far is returned along with the error.
+func (client *Client) UpdateByQuery(ctx context.Context, soql string, set map[string]interface{}, opts MassOptions) (*MassResult, error) {
+	if len(set) == 0 {
+		return nil, errors.New("no field to update")
+	}
+	result := &MassResult{}
+	if opts.DryRun {
+		counted, err := client.QueryContext(ctx, soql)
+		if err != nil {
+			return nil, err
+		}
+		result.Matched = counted.TotalSize
+		return result, nil
+	}
+
+	retries := opts.LockRetries
+	if retries == 0 {
+		retries = defaultLockRetries
+	}
+
+	processed := 0
+	err := client.eachQueriedRecord(ctx, soql, opts.batchSize(), func(records []SObject, total int) error {
+		result.Matched = total
+		updates := make([]*SObject, len(records))
+		for i := range records {
+			updates[i] = client.SObject(records[i].Type())
+			updates[i].setID(records[i].ID())
+			for field, value := range set {
+				updates[i].Set(field, value)
+			}
+		}
+
+		ids, results, err := client.updateRetryingLocks(ctx, updates, retries)
+		if err != nil {
+			return err
+		}
+		result.record(ids, results)
+		processed += len(records)
+		if opts.Progress != nil {
+			opts.Progress(processed, total)
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// updateRetryingLocks updates records, then updates again up to retries times those which failed because they were
+// locked. The IDs and final results of the records are returned, in no particular order.
+func (client *Client) updateRetryingLocks(ctx context.Context, records []*SObject, retries int) ([]string, []SaveResult, error) {
+	var ids []string
+	var results []SaveResult
+	delay := lockRetryDelay
+	for attempt := 0; len(records) > 0; attempt++ {
+		saved, err := client.saveCollectionContext(ctx, http.MethodPatch, records, false)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var locked []*SObject
+		for idx, result := range saved {
+			if attempt < retries && !result.Success && len(result.Errors) > 0 && result.Errors[0].StatusCode == lockedRowCode {
+				locked = append(locked, records[idx])
+				continue
+			}
+			ids = append(ids, records[idx].ID())
+			results = append(results, result)
+		}
+		if len(locked) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		records = locked
+	}
+	return ids, results, nil
+}
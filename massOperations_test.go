@@ -0,0 +1,140 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// massQueryHandler serves the query of n Account records in pages of pageSize, and passes other requests to handle.
+func massQueryHandler(n, pageSize int, handle func(w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/query") {
+			handle(w, r)
+			return
+		}
+		start := 0
+		if strings.HasSuffix(r.URL.Path, "/query/01gD0000002HU6KIAW-page") {
+			start = pageSize
+		}
+		end := start + pageSize
+		if end > n {
+			end = n
+		}
+		var records []string
+		for i := start; i < end; i++ {
+			records = append(records, fmt.Sprintf(`{"attributes":{"type":"Account"},"Id":"001%012d"}`, i))
+		}
+		next := ""
+		if end < n {
+			next = `,"nextRecordsUrl":"/services/data/v54.0/query/01gD0000002HU6KIAW-page"`
+		}
+		fmt.Fprintf(w, `{"totalSize":%d,"done":%v%s,"records":[%s]}`, n, end == n, next, strings.Join(records, ","))
+	}
+}
+
+func TestClient_DeleteByQuery(t *testing.T) {
+	var deleted []string
+	client := requireMockClient(t, massQueryHandler(5, 3, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		var results []string
+		for _, id := range ids {
+			if id == "001000000000003" {
+				results = append(results, `{"id":"001000000000003","success":false,"errors":[{"statusCode":"ENTITY_IS_DELETED","message":"entity is deleted"}]}`)
+				continue
+			}
+			deleted = append(deleted, id)
+			results = append(results, fmt.Sprintf(`{"id":"%s","success":true,"errors":[]}`, id))
+		}
+		w.Write([]byte("[" + strings.Join(results, ",") + "]"))
+	}))
+
+	var progress []int
+	result, err := client.DeleteByQuery(context.Background(), "SELECT Id FROM Account", MassOptions{
+		BatchSize: 2,
+		Progress:  func(processed, total int) { progress = append(progress, processed, total) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Matched != 5 || result.Succeeded != 4 || len(deleted) != 4 {
+		t.Errorf("unexpected result %+v, deleted %v", result, deleted)
+	}
+	if len(result.Failures) != 1 || result.Failures[0].ID != "001000000000003" || result.Failures[0].Errors[0].StatusCode != "ENTITY_IS_DELETED" {
+		t.Errorf("unexpected failures %+v", result.Failures)
+	}
+	if fmt.Sprint(progress) != "[2 5 4 5 5 5]" {
+		t.Errorf("unexpected progress %v", progress)
+	}
+
+	deleted = nil
+	result, err = client.DeleteByQuery(context.Background(), "SELECT Id FROM Account", MassOptions{DryRun: true})
+	if err != nil || result.Matched != 5 || len(deleted) != 0 {
+		t.Errorf("unexpected dry run %+v, %v, deleted %v", result, err, deleted)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.DeleteByQuery(ctx, "SELECT Id FROM Account", MassOptions{}); err == nil {
+		t.Error("expected an error with a cancelled context")
+	}
+}
+
+func TestClient_UpdateByQuery(t *testing.T) {
+	lockRetryDelay = time.Millisecond
+	defer func() { lockRetryDelay = time.Second }()
+
+	attempts := map[string]int{}
+	client := requireMockClient(t, massQueryHandler(3, 3, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		var req struct {
+			Records []map[string]interface{} `json:"records"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		var results []string
+		for _, record := range req.Records {
+			id, _ := record["Id"].(string)
+			attempts[id]++
+			if record["Rating"] != "Hot" {
+				t.Errorf("unexpected record %v", record)
+			}
+			switch {
+			// The second record is locked once, the third one always.
+			case id == "001000000000001" && attempts[id] == 1, id == "001000000000002":
+				results = append(results, fmt.Sprintf(`{"id":"%s","success":false,"errors":[{"statusCode":"UNABLE_TO_LOCK_ROW","message":"unable to obtain exclusive access to this record"}]}`, id))
+			default:
+				results = append(results, fmt.Sprintf(`{"id":"%s","success":true,"errors":[]}`, id))
+			}
+		}
+		w.Write([]byte("[" + strings.Join(results, ",") + "]"))
+	}))
+
+	result, err := client.UpdateByQuery(context.Background(), "SELECT Id FROM Account", map[string]interface{}{"Rating": "Hot"}, MassOptions{LockRetries: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Matched != 3 || result.Succeeded != 2 || len(result.Failures) != 1 || result.Failures[0].ID != "001000000000002" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if attempts["001000000000000"] != 1 || attempts["001000000000001"] != 2 || attempts["001000000000002"] != 3 {
+		t.Errorf("unexpected attempts %v", attempts)
+	}
+
+	if _, err := client.UpdateByQuery(context.Background(), "SELECT Id FROM Account", nil, MassOptions{}); err == nil {
+		t.Error("expected an error without fields to update")
+	}
+}
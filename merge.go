@@ -0,0 +1,81 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/xml"
+
+	"github.com/pkg/errors"
+)
+
+// maxMergeDuplicates is the maximum number of records merged into a master record by a single merge call.
+const maxMergeDuplicates = 2
+
+// mergeableTypes maps the key prefixes of the objects supporting merge to their type.
+var mergeableTypes = map[string]string{
+	"001": "Account",
+	"003": "Contact",
+	"00Q": "Lead",
+	"500": "Case",
+}
+
+// MergeResult describes the outcome of a merge.
+type MergeResult struct {
+	// ID is the ID of the master record.
+	ID string `xml:"id"`
+	// MergedRecordIDs are the IDs of the duplicates, which were deleted.
+	MergedRecordIDs []string `xml:"mergedRecordIds"`
+	// UpdatedRelatedIDs are the IDs of the child records reparented to the master record, e.g. contacts or
+	// opportunities of merged accounts.
+	UpdatedRelatedIDs []string    `xml:"updatedRelatedIds"`
+	Success           bool        `xml:"success"`
+	Errors            []SaveError `xml:"errors"`
+}
+
+// Err returns nil if the records were merged, or a SalesforceError describing the first error otherwise.
+func (result MergeResult) Err() error {
+	return SaveResult{ID: result.ID, Success: result.Success, Errors: result.Errors}.Err()
+}
+
+// Merge merges up to two duplicateIDs into the record masterID through the merge SOAP call: the child records of the
+// duplicates are reparented to the master record, and the duplicates are deleted. overrides are set on the master
+// record, e.g. to keep the Phone of a duplicate; nil values clear fields. Accounts, contacts, leads and cases can be
+// merged, and all records must be of the same type. A failed merge is returned as an error, along with the result.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api.meta/api/sforce_api_calls_merge.htm
+func (client *Client) Merge(masterID string, duplicateIDs []string, overrides map[string]interface{}) (*MergeResult, error) {
+	return client.MergeContext(context.Background(), masterID, duplicateIDs, overrides)
+}
+
+// MergeContext is like Merge, with the request bound to ctx.
+func (client *Client) MergeContext(ctx context.Context, masterID string, duplicateIDs []string, overrides map[string]interface{}) (*MergeResult, error) {
+	if len(duplicateIDs) == 0 || len(duplicateIDs) > maxMergeDuplicates {
+		return nil, errors.Errorf("1 to %d duplicates can be merged, got %d", maxMergeDuplicates, len(duplicateIDs))
+	}
+	sobjectType := ""
+	if len(masterID) >= 3 {
+		sobjectType = mergeableTypes[masterID[:3]]
+	}
+	if sobjectType == "" {
+		return nil, errors.Errorf("records of %s can't be merged", masterID)
+	}
+
+	request := struct {
+		XMLName      xml.Name    `xml:"urn:partner.soap.sforce.com merge"`
+		MasterRecord soapSObject `xml:"request>masterRecord"`
+		RecordIDs    []string    `xml:"request>recordToMergeIds"`
+	}{
+		MasterRecord: soapSObject{Type: sobjectType, ID: masterID, Fields: overrides},
+		RecordIDs:    duplicateIDs,
+	}
+	var envelope struct {
+		Results []MergeResult `xml:"Body>mergeResponse>result"`
+	}
+	err := client.soapCall(ctx, "merge", request, &envelope)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(envelope.Results))
+	}
+	result := &envelope.Results[0]
+	return result, result.Err()
+}
@@ -0,0 +1,73 @@
+package simpleforce
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_Merge(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("SOAPAction") != "merge" {
+			t.Errorf("unexpected action %s", r.Header.Get("SOAPAction"))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		expected := `<Body><merge xmlns="urn:partner.soap.sforce.com"><request><masterRecord>` +
+			`<type xmlns="urn:sobject.partner.soap.sforce.com">Account</type>` +
+			`<fieldsToNull xmlns="urn:sobject.partner.soap.sforce.com">Fax</fieldsToNull>` +
+			`<Id xmlns="urn:sobject.partner.soap.sforce.com">001D000000IqhSLIAZ</Id>` +
+			`<NumberOfEmployees xmlns="urn:sobject.partner.soap.sforce.com">120</NumberOfEmployees>` +
+			`<Phone xmlns="urn:sobject.partner.soap.sforce.com">+1 415 &amp; 555</Phone>` +
+			`</masterRecord><recordToMergeIds>001D000000IqhSMIAZ</recordToMergeIds><recordToMergeIds>001D000000IqhSNIAZ</recordToMergeIds></request></merge></Body>`
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("unexpected request %s", body)
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:partner.soap.sforce.com"><soapenv:Body><mergeResponse><result><id>001D000000IqhSLIAZ</id><mergedRecordIds>001D000000IqhSMIAZ</mergedRecordIds><mergedRecordIds>001D000000IqhSNIAZ</mergedRecordIds><success>true</success><updatedRelatedIds>003D000000QqWfNIAV</updatedRelatedIds></result></mergeResponse></soapenv:Body></soapenv:Envelope>`))
+	})
+
+	result, err := client.Merge("001D000000IqhSLIAZ", []string{"001D000000IqhSMIAZ", "001D000000IqhSNIAZ"},
+		map[string]interface{}{"Phone": "+1 415 & 555", "NumberOfEmployees": 120, "Fax": nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ID != "001D000000IqhSLIAZ" || !reflect.DeepEqual(result.MergedRecordIDs, []string{"001D000000IqhSMIAZ", "001D000000IqhSNIAZ"}) ||
+		!reflect.DeepEqual(result.UpdatedRelatedIDs, []string{"003D000000QqWfNIAV"}) {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	invalid := []struct {
+		master     string
+		duplicates []string
+		overrides  map[string]interface{}
+	}{
+		{"001D000000IqhSLIAZ", nil, nil},
+		{"001D000000IqhSLIAZ", []string{"a", "b", "c"}, nil},
+		{"006D000000CrQ2CIAV", []string{"006D000000CrQ2DIAV"}, nil},
+		{"001D000000IqhSLIAZ", []string{"001D000000IqhSMIAZ"}, map[string]interface{}{"<Name>": "x"}},
+	}
+	for _, tc := range invalid {
+		if _, err := client.Merge(tc.master, tc.duplicates, tc.overrides); err == nil {
+			t.Errorf("expected error for %+v", tc)
+		}
+	}
+}
+
+func TestClient_MergeContext(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.MergeContext(ctx, "001000000000001", []string{"001000000000002"}, nil)
+	if !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("expected the canceled context to stop the call, got %v after %d calls", err, calls)
+	}
+}
@@ -0,0 +1,288 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/simpleforce/simpleforce"
+)
+
+// maxCRUDComponents is the maximum number of components of a single createMetadata, updateMetadata, deleteMetadata
+// or readMetadata call.
+const maxCRUDComponents = 10
+
+// xsiNamespace is the namespace of the xsi:type attribute typing the components of CRUD calls.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// Metadata is a metadata component sent and read by CRUD calls, e.g. a CustomObject.
+type Metadata interface {
+	// MetadataType returns the name of the metadata type of the component, e.g. "CustomObject".
+	MetadataType() string
+}
+
+// CustomObject is a custom object, e.g. Invoice__c. Its FullName is the API name of the object. Fields are in the
+// order of the WSDL, which the Metadata API requires.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/customobject.htm
+type CustomObject struct {
+	FullName string `xml:"fullName"`
+	// DeploymentStatus is "Deployed" or "InDevelopment".
+	DeploymentStatus string           `xml:"deploymentStatus,omitempty"`
+	Description      string           `xml:"description,omitempty"`
+	EnableActivities bool             `xml:"enableActivities,omitempty"`
+	EnableHistory    bool             `xml:"enableHistory,omitempty"`
+	EnableReports    bool             `xml:"enableReports,omitempty"`
+	EnableSearch     bool             `xml:"enableSearch,omitempty"`
+	Fields           []CustomField    `xml:"fields,omitempty"`
+	Label            string           `xml:"label,omitempty"`
+	NameField        *CustomField     `xml:"nameField,omitempty"`
+	PluralLabel      string           `xml:"pluralLabel,omitempty"`
+	SharingModel     string           `xml:"sharingModel,omitempty"`
+	ValidationRules  []ValidationRule `xml:"validationRules,omitempty"`
+}
+
+// MetadataType implements Metadata.
+func (CustomObject) MetadataType() string { return "CustomObject" }
+
+// CustomField is a custom field, or the name field of a custom object. Its FullName is qualified by the object when
+// created on its own, e.g. "Account.Rating__c".
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/customfield.htm
+type CustomField struct {
+	// FullName is left empty for the name field of an object.
+	FullName         string `xml:"fullName,omitempty"`
+	DefaultValue     string `xml:"defaultValue,omitempty"`
+	DeleteConstraint string `xml:"deleteConstraint,omitempty"`
+	Description      string `xml:"description,omitempty"`
+	ExternalID       bool   `xml:"externalId,omitempty"`
+	Formula          string `xml:"formula,omitempty"`
+	InlineHelpText   string `xml:"inlineHelpText,omitempty"`
+	Label            string `xml:"label,omitempty"`
+	Length           int    `xml:"length,omitempty"`
+	Precision        int    `xml:"precision,omitempty"`
+	ReferenceTo      string `xml:"referenceTo,omitempty"`
+	RelationshipName string `xml:"relationshipName,omitempty"`
+	Required         bool   `xml:"required,omitempty"`
+	// Scale is a pointer, as 0 is a valid scale of Number fields.
+	Scale        *int `xml:"scale,omitempty"`
+	TrackHistory bool `xml:"trackHistory,omitempty"`
+	// Type is the field type, e.g. "Text", "Number", "Picklist" or "Lookup".
+	Type         string    `xml:"type,omitempty"`
+	Unique       bool      `xml:"unique,omitempty"`
+	ValueSet     *ValueSet `xml:"valueSet,omitempty"`
+	VisibleLines int       `xml:"visibleLines,omitempty"`
+}
+
+// MetadataType implements Metadata.
+func (CustomField) MetadataType() string { return "CustomField" }
+
+// ValueSet holds the values of a picklist field.
+type ValueSet struct {
+	// Restricted limits the values of the field to the values of the set.
+	Restricted bool          `xml:"restricted,omitempty"`
+	Values     []CustomValue `xml:"valueSetDefinition>value"`
+}
+
+// CustomValue is a value of a picklist field.
+type CustomValue struct {
+	FullName string `xml:"fullName"`
+	Default  bool   `xml:"default"`
+	Label    string `xml:"label,omitempty"`
+}
+
+// ValidationRule is a validation rule of an object. Its FullName is qualified by the object when created on its own,
+// e.g. "Account.Phone_Required".
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/customobject.htm#validation_rule
+type ValidationRule struct {
+	FullName              string `xml:"fullName"`
+	Active                bool   `xml:"active"`
+	Description           string `xml:"description,omitempty"`
+	ErrorConditionFormula string `xml:"errorConditionFormula"`
+	ErrorDisplayField     string `xml:"errorDisplayField,omitempty"`
+	ErrorMessage          string `xml:"errorMessage"`
+}
+
+// MetadataType implements Metadata.
+func (ValidationRule) MetadataType() string { return "ValidationRule" }
+
+// SaveResult is the outcome for a single component of a create, update or delete.
+type SaveResult struct {
+	FullName string                  `xml:"fullName"`
+	Success  bool                    `xml:"success"`
+	Errors   []simpleforce.SaveError `xml:"errors"`
+}
+
+// Err returns nil if the component was saved, or a SalesforceError describing the first error otherwise.
+func (result SaveResult) Err() error {
+	return simpleforce.SaveResult{ID: result.FullName, Success: result.Success, Errors: result.Errors}.Err()
+}
+
+// typedComponent writes a component as a metadata element typed by xsi:type, as CRUD calls expect.
+type typedComponent struct {
+	Metadata
+}
+
+func (c typedComponent) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Space: xsiNamespace, Local: "type"}, Value: c.MetadataType()})
+	return e.EncodeElement(c.Metadata, start)
+}
+
+// CreateMetadata creates components, which may be of different types, splitting them into calls of up to 10
+// components. Results are returned in the order of components; use their Err to find the components which failed.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_createMetadata.htm
+func (c *Client) CreateMetadata(ctx context.Context, components ...Metadata) ([]SaveResult, error) {
+	return c.saveMetadata(ctx, "createMetadata", components)
+}
+
+// UpdateMetadata updates components identified by their FullName, like CreateMetadata. Fields left empty are cleared,
+// so components are usually read with ReadMetadata before being updated.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_updateMetadata.htm
+func (c *Client) UpdateMetadata(ctx context.Context, components ...Metadata) ([]SaveResult, error) {
+	return c.saveMetadata(ctx, "updateMetadata", components)
+}
+
+// saveMetadata sends the CRUD call action, createMetadata or updateMetadata, for components.
+func (c *Client) saveMetadata(ctx context.Context, action string, components []Metadata) ([]SaveResult, error) {
+	results := make([]SaveResult, 0, len(components))
+	for start := 0; start < len(components); start += maxCRUDComponents {
+		end := start + maxCRUDComponents
+		if end > len(components) {
+			end = len(components)
+		}
+
+		typed := make([]typedComponent, 0, end-start)
+		for _, component := range components[start:end] {
+			typed = append(typed, typedComponent{component})
+		}
+		request := struct {
+			XMLName    xml.Name
+			Components []typedComponent `xml:"metadata"`
+		}{
+			XMLName:    xml.Name{Space: Namespace, Local: action},
+			Components: typed,
+		}
+		page, err := c.crudCall(ctx, action, request)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, page...)
+	}
+	return results, nil
+}
+
+// DeleteMetadata deletes the components of metadataType with the provided full names, splitting them into calls of
+// up to 10 components. Results are returned in the order of fullNames.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_deleteMetadata.htm
+func (c *Client) DeleteMetadata(ctx context.Context, metadataType string, fullNames ...string) ([]SaveResult, error) {
+	results := make([]SaveResult, 0, len(fullNames))
+	for start := 0; start < len(fullNames); start += maxCRUDComponents {
+		end := start + maxCRUDComponents
+		if end > len(fullNames) {
+			end = len(fullNames)
+		}
+
+		request := struct {
+			XMLName   xml.Name `xml:"http://soap.sforce.com/2006/04/metadata deleteMetadata"`
+			Type      string   `xml:"type"`
+			FullNames []string `xml:"fullNames"`
+		}{Type: metadataType, FullNames: fullNames[start:end]}
+		page, err := c.crudCall(ctx, "deleteMetadata", request)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, page...)
+	}
+	return results, nil
+}
+
+// crudCall sends a CRUD call and returns the results of its response.
+func (c *Client) crudCall(ctx context.Context, action string, request interface{}) ([]SaveResult, error) {
+	var envelope struct {
+		Body struct {
+			Response struct {
+				Results []SaveResult `xml:"result"`
+			} `xml:",any"`
+		}
+	}
+	err := c.call(ctx, action, request, &envelope)
+	if err != nil {
+		return nil, err
+	}
+	return envelope.Body.Response.Results, nil
+}
+
+// ReadMetadata reads the components with the provided full names into dest, a pointer to a slice of the type of the
+// components, e.g. *[]CustomObject, splitting them into calls of up to 10 components. Components which don't exist
+// are left out.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_readMetadata.htm
+func (c *Client) ReadMetadata(ctx context.Context, dest interface{}, fullNames ...string) error {
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Ptr || slice.IsNil() || slice.Elem().Kind() != reflect.Slice {
+		return errors.Errorf("dest must be a pointer to a slice of components, got %T", dest)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	component, ok := reflect.Zero(elemType).Interface().(Metadata)
+	if !ok || elemType.Kind() != reflect.Struct {
+		return errors.Errorf("dest must be a pointer to a slice of components, got %T", dest)
+	}
+	slice.Set(slice.Slice(0, 0))
+
+	for start := 0; start < len(fullNames); start += maxCRUDComponents {
+		end := start + maxCRUDComponents
+		if end > len(fullNames) {
+			end = len(fullNames)
+		}
+
+		request := struct {
+			XMLName   xml.Name `xml:"http://soap.sforce.com/2006/04/metadata readMetadata"`
+			Type      string   `xml:"type"`
+			FullNames []string `xml:"fullNames"`
+		}{Type: component.MetadataType(), FullNames: fullNames[start:end]}
+		var envelope struct {
+			Body struct {
+				Result struct {
+					Records []byte `xml:",innerxml"`
+				} `xml:"readMetadataResponse>result"`
+			}
+		}
+		err := c.call(ctx, "readMetadata", request, &envelope)
+		if err != nil {
+			return err
+		}
+		err = decodeRecords(envelope.Body.Result.Records, slice)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeRecords appends the records elements of data to slice, leaving out the empty records of components which
+// don't exist.
+func decodeRecords(data []byte, slice reflect.Value) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "records" {
+			continue
+		}
+		record := reflect.New(slice.Type().Elem())
+		err = decoder.DecodeElement(record.Interface(), &start)
+		if err != nil {
+			return errors.Wrap(err, "invalid component")
+		}
+		if !record.Elem().IsZero() {
+			slice.Set(reflect.Append(slice, record.Elem()))
+		}
+	}
+}
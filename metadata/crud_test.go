@@ -0,0 +1,122 @@
+package metadata
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/simpleforce/simpleforce"
+)
+
+func TestClient_CreateMetadata(t *testing.T) {
+	c, bodies := mockMetadataAPI(t, map[string][]string{
+		"createMetadata": {`<createMetadataResponse>` +
+			`<result><fullName>Invoice__c</fullName><success>true</success></result>` +
+			`<result><errors><message>Field Rating__c already exists</message><statusCode>DUPLICATE_DEVELOPER_NAME</statusCode></errors>` +
+			`<fullName>Account.Rating__c</fullName><success>false</success></result>` +
+			`</createMetadataResponse>`},
+	})
+
+	scale := 0
+	results, err := c.CreateMetadata(context.Background(),
+		CustomObject{
+			FullName:         "Invoice__c",
+			DeploymentStatus: "Deployed",
+			Label:            "Invoice",
+			NameField:        &CustomField{Label: "Invoice Number", Type: "AutoNumber"},
+			PluralLabel:      "Invoices",
+			SharingModel:     "ReadWrite",
+		},
+		CustomField{FullName: "Account.Rating__c", Label: "Rating", Precision: 3, Scale: &scale, Type: "Number"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Err() != nil {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	sfErr, ok := results[1].Err().(simpleforce.SalesforceError)
+	if !ok || sfErr.ErrorCode != "DUPLICATE_DEVELOPER_NAME" || sfErr.ErrorMessage != "Field Rating__c already exists" {
+		t.Errorf("unexpected error %v", results[1].Err())
+	}
+
+	body := (*bodies)[0]
+	for _, expected := range []string{
+		`<createMetadata xmlns="http://soap.sforce.com/2006/04/metadata"><metadata xmlns:_XMLSchema-instance="http://www.w3.org/2001/XMLSchema-instance" _XMLSchema-instance:type="CustomObject">`,
+		`<fullName>Invoice__c</fullName><deploymentStatus>Deployed</deploymentStatus><label>Invoice</label>` +
+			`<nameField><label>Invoice Number</label><type>AutoNumber</type></nameField>` +
+			`<pluralLabel>Invoices</pluralLabel><sharingModel>ReadWrite</sharingModel></metadata>`,
+		`_XMLSchema-instance:type="CustomField"><fullName>Account.Rating__c</fullName><label>Rating</label>` +
+			`<precision>3</precision><scale>0</scale><type>Number</type></metadata>`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected %s in %s", expected, body)
+		}
+	}
+}
+
+func TestClient_DeleteMetadata(t *testing.T) {
+	calls := make([]string, 2)
+	for i := range calls {
+		calls[i] = `<deleteMetadataResponse><result><fullName>Account.Rule</fullName><success>true</success></result></deleteMetadataResponse>`
+	}
+	c, bodies := mockMetadataAPI(t, map[string][]string{"deleteMetadata": calls})
+
+	fullNames := make([]string, 12)
+	for i := range fullNames {
+		fullNames[i] = "Account.Rule"
+	}
+	results, err := c.DeleteMetadata(context.Background(), "ValidationRule", fullNames...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || len(*bodies) != 2 {
+		t.Fatalf("expected 2 calls, got %d calls and results %+v", len(*bodies), results)
+	}
+	if strings.Count((*bodies)[0], "<fullNames>") != 10 || strings.Count((*bodies)[1], "<fullNames>") != 2 {
+		t.Errorf("expected calls of up to 10 components, got %v", *bodies)
+	}
+	if !strings.Contains((*bodies)[0], `<deleteMetadata xmlns="http://soap.sforce.com/2006/04/metadata"><type>ValidationRule</type>`) {
+		t.Errorf("unexpected body %s", (*bodies)[0])
+	}
+}
+
+func TestClient_ReadMetadata(t *testing.T) {
+	c, bodies := mockMetadataAPI(t, map[string][]string{
+		"readMetadata": {`<readMetadataResponse><result>` +
+			`<records xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CustomField"><fullName>Account.Tier__c</fullName>` +
+			`<label>Tier</label><type>Picklist</type><valueSet><restricted>true</restricted><valueSetDefinition>` +
+			`<value><fullName>Gold</fullName><default>true</default><label>Gold</label></value>` +
+			`<value><fullName>Silver</fullName><default>false</default><label>Silver</label></value>` +
+			`</valueSetDefinition></valueSet></records>` +
+			`<records xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CustomField"/>` +
+			`</result></readMetadataResponse>`},
+	})
+
+	var fields []CustomField
+	err := c.ReadMetadata(context.Background(), &fields, "Account.Tier__c", "Account.Missing__c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []CustomField{{
+		FullName: "Account.Tier__c",
+		Label:    "Tier",
+		Type:     "Picklist",
+		ValueSet: &ValueSet{Restricted: true, Values: []CustomValue{
+			{FullName: "Gold", Default: true, Label: "Gold"},
+			{FullName: "Silver", Label: "Silver"},
+		}},
+	}}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected %+v, got %+v", expected, fields)
+	}
+	if !strings.Contains((*bodies)[0], `<type>CustomField</type><fullNames>Account.Tier__c</fullNames><fullNames>Account.Missing__c</fullNames>`) {
+		t.Errorf("unexpected body %s", (*bodies)[0])
+	}
+
+	var invalid []string
+	if err = c.ReadMetadata(context.Background(), &invalid, "Foo"); err == nil {
+		t.Error("expected an error reading into a slice of strings")
+	}
+}
@@ -0,0 +1,366 @@
+// Package metadata deploys and retrieves metadata through the SOAP Metadata API, so that pipelines can ship
+// metadata from Go without the Ant Migration Tool. Deployments and retrievals are asynchronous: Deploy and Retrieve
+// poll their status until they complete. CRUD calls, such as CreateMetadata, create and change a few components at
+// once, e.g. the custom objects and fields provisioned by a tool, without a package.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_intro.htm
+package metadata
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/simpleforce/simpleforce"
+)
+
+// DefaultPollInterval is the default delay between two checks of the status of a deployment or retrieval.
+const DefaultPollInterval = 2 * time.Second
+
+// Namespace is the XML namespace of the Metadata API.
+const Namespace = "http://soap.sforce.com/2006/04/metadata"
+
+// Client sends Metadata API calls with the session of a simpleforce client.
+type Client struct {
+	client *simpleforce.Client
+	// PollInterval is the delay between two checks of the status of a deployment or retrieval.
+	PollInterval time.Duration
+}
+
+// New creates a Metadata API client from a logged in simpleforce client.
+func New(client *simpleforce.Client) *Client {
+	return &Client{client: client, PollInterval: DefaultPollInterval}
+}
+
+type envelope struct {
+	XMLName xml.Name `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope"`
+	Header  struct {
+		SessionHeader struct {
+			XMLName   xml.Name `xml:"http://soap.sforce.com/2006/04/metadata SessionHeader"`
+			SessionID string   `xml:"sessionId"`
+		}
+	} `xml:"Header"`
+	// Body holds the call, named by its own XMLName.
+	Body interface{} `xml:"Body>call"`
+}
+
+// call sends the Metadata API call action and decodes the response envelope into response. request must set its
+// XMLName in Namespace.
+func (c *Client) call(ctx context.Context, action string, request, response interface{}) error {
+	data, err := c.client.SOAPCall(ctx, "m", action, func(sessionID string) ([]byte, error) {
+		env := envelope{Body: request}
+		env.Header.SessionHeader.SessionID = sessionID
+		reqData, err := xml.Marshal(env)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(xml.Header), reqData...), nil
+	})
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(data, response)
+}
+
+// wait calls check every PollInterval until it reports done, or ctx is done.
+func (c *Client) wait(ctx context.Context, check func() (bool, error)) error {
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		timer := time.NewTimer(c.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Test levels of deployments.
+const (
+	NoTestRun         = "NoTestRun"
+	RunSpecifiedTests = "RunSpecifiedTests"
+	RunLocalTests     = "RunLocalTests"
+	RunAllTestsInOrg  = "RunAllTestsInOrg"
+)
+
+// DeployOptions controls a deployment. Fields are in the order of the WSDL.
+type DeployOptions struct {
+	AllowMissingFiles bool `xml:"allowMissingFiles"`
+	AutoUpdatePackage bool `xml:"autoUpdatePackage"`
+	// CheckOnly validates the deployment, running the tests, without saving it.
+	CheckOnly       bool `xml:"checkOnly"`
+	IgnoreWarnings  bool `xml:"ignoreWarnings"`
+	PerformRetrieve bool `xml:"performRetrieve"`
+	// PurgeOnDelete deletes the components of destructiveChanges.xml immediately, rather than to the Recycle Bin.
+	PurgeOnDelete   bool `xml:"purgeOnDelete"`
+	RollbackOnError bool `xml:"rollbackOnError"`
+	// RunTests are the test classes run with the RunSpecifiedTests test level.
+	RunTests []string `xml:"runTests,omitempty"`
+	// SinglePackage is set if the zip holds a single package at its root, rather than directories of packages.
+	SinglePackage bool `xml:"singlePackage"`
+	// TestLevel is one of the test level constants; the default depends on the org.
+	TestLevel string `xml:"testLevel,omitempty"`
+}
+
+// Statuses of deployments and retrievals.
+const (
+	StatusPending          = "Pending"
+	StatusInProgress       = "InProgress"
+	StatusSucceeded        = "Succeeded"
+	StatusSucceededPartial = "SucceededPartial"
+	StatusFailed           = "Failed"
+	StatusCanceling        = "Canceling"
+	StatusCanceled         = "Canceled"
+)
+
+// ComponentFailure describes why a component of a deployment failed.
+type ComponentFailure struct {
+	ComponentType string `xml:"componentType"`
+	FullName      string `xml:"fullName"`
+	FileName      string `xml:"fileName"`
+	Problem       string `xml:"problem"`
+	ProblemType   string `xml:"problemType"`
+	LineNumber    int    `xml:"lineNumber"`
+	ColumnNumber  int    `xml:"columnNumber"`
+}
+
+// TestFailure describes a test method which failed during a deployment.
+type TestFailure struct {
+	Name       string `xml:"name"`
+	MethodName string `xml:"methodName"`
+	Message    string `xml:"message"`
+	StackTrace string `xml:"stackTrace"`
+}
+
+// DeployResult is the status of a deployment.
+type DeployResult struct {
+	ID                       string             `xml:"id"`
+	Done                     bool               `xml:"done"`
+	Status                   string             `xml:"status"`
+	Success                  bool               `xml:"success"`
+	CheckOnly                bool               `xml:"checkOnly"`
+	ErrorMessage             string             `xml:"errorMessage"`
+	ErrorStatusCode          string             `xml:"errorStatusCode"`
+	NumberComponentsDeployed int                `xml:"numberComponentsDeployed"`
+	NumberComponentErrors    int                `xml:"numberComponentErrors"`
+	NumberComponentsTotal    int                `xml:"numberComponentsTotal"`
+	NumberTestsCompleted     int                `xml:"numberTestsCompleted"`
+	NumberTestErrors         int                `xml:"numberTestErrors"`
+	NumberTestsTotal         int                `xml:"numberTestsTotal"`
+	ComponentFailures        []ComponentFailure `xml:"details>componentFailures"`
+	TestFailures             []TestFailure      `xml:"details>runTestResult>failures"`
+}
+
+// DeployError is returned when a deployment doesn't succeed.
+type DeployError struct {
+	Result *DeployResult
+}
+
+func (err *DeployError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("deployment " + err.Result.ID + " " + strings.ToLower(err.Result.Status))
+	if err.Result.ErrorMessage != "" {
+		sb.WriteString(": " + err.Result.ErrorMessage)
+	}
+	for _, failure := range err.Result.ComponentFailures {
+		sb.WriteString("; " + failure.ComponentType + " " + failure.FullName + ": " + failure.Problem)
+	}
+	for _, failure := range err.Result.TestFailures {
+		sb.WriteString("; test " + failure.Name + "." + failure.MethodName + ": " + failure.Message)
+	}
+	return sb.String()
+}
+
+// StartDeploy starts deploying the zip of a package and returns the ID of the deployment, whose status is checked
+// with CheckDeployStatus.
+func (c *Client) StartDeploy(ctx context.Context, zip []byte, opts DeployOptions) (string, error) {
+	request := struct {
+		XMLName xml.Name      `xml:"http://soap.sforce.com/2006/04/metadata deploy"`
+		ZipFile string        `xml:"ZipFile"`
+		Options DeployOptions `xml:"DeployOptions"`
+	}{ZipFile: base64.StdEncoding.EncodeToString(zip), Options: opts}
+	var response struct {
+		ID string `xml:"Body>deployResponse>result>id"`
+	}
+	err := c.call(ctx, "deploy", request, &response)
+	if err != nil {
+		return "", err
+	}
+	if response.ID == "" {
+		return "", errors.New("no deployment id in response")
+	}
+	return response.ID, nil
+}
+
+// CheckDeployStatus returns the status of the deployment id. The failures of components and tests are only reported
+// if includeDetails is set.
+func (c *Client) CheckDeployStatus(ctx context.Context, id string, includeDetails bool) (*DeployResult, error) {
+	request := struct {
+		XMLName        xml.Name `xml:"http://soap.sforce.com/2006/04/metadata checkDeployStatus"`
+		ID             string   `xml:"asyncProcessId"`
+		IncludeDetails bool     `xml:"includeDetails"`
+	}{ID: id, IncludeDetails: includeDetails}
+	var response struct {
+		Result DeployResult `xml:"Body>checkDeployStatusResponse>result"`
+	}
+	err := c.call(ctx, "checkDeployStatus", request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Result, nil
+}
+
+// Deploy deploys the zip of a package and waits for the deployment to complete. A DeployError is returned along with
+// the result if the deployment doesn't succeed.
+func (c *Client) Deploy(ctx context.Context, zip []byte, opts DeployOptions) (*DeployResult, error) {
+	id, err := c.StartDeploy(ctx, zip, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *DeployResult
+	err = c.wait(ctx, func() (bool, error) {
+		var err error
+		result, err = c.CheckDeployStatus(ctx, id, false)
+		return err == nil && result.Done, err
+	})
+	if err != nil {
+		return result, err
+	}
+	if !result.Success {
+		// Fetch the failures, which are only reported with details.
+		detailed, err := c.CheckDeployStatus(ctx, id, true)
+		if err == nil {
+			result = detailed
+		}
+		return result, &DeployError{Result: result}
+	}
+	return result, nil
+}
+
+// PackageTypeMembers lists the components of a metadata type in a package manifest.
+type PackageTypeMembers struct {
+	Members []string `xml:"members"`
+	Name    string   `xml:"name"`
+}
+
+// Package is a package manifest, the content of a package.xml file.
+type Package struct {
+	Types   []PackageTypeMembers `xml:"types"`
+	Version string               `xml:"version"`
+}
+
+// ParsePackage decodes a package.xml manifest.
+func ParsePackage(manifest []byte) (*Package, error) {
+	var pkg Package
+	err := xml.Unmarshal(manifest, &pkg)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid package manifest")
+	}
+	return &pkg, nil
+}
+
+// RetrieveMessage is a warning or error about a file of a retrieval.
+type RetrieveMessage struct {
+	FileName string `xml:"fileName"`
+	Problem  string `xml:"problem"`
+}
+
+// RetrieveResult is the status of a retrieval.
+type RetrieveResult struct {
+	ID              string            `xml:"id"`
+	Done            bool              `xml:"done"`
+	Status          string            `xml:"status"`
+	Success         bool              `xml:"success"`
+	ErrorMessage    string            `xml:"errorMessage"`
+	ErrorStatusCode string            `xml:"errorStatusCode"`
+	Messages        []RetrieveMessage `xml:"messages"`
+	// ZipFile is the zip of the retrieved components, along with their package.xml.
+	ZipFile []byte `xml:"-"`
+	// EncodedZipFile is the base64 encoding of ZipFile.
+	EncodedZipFile string `xml:"zipFile"`
+}
+
+// StartRetrieve starts retrieving the components listed by pkg as a single package, and returns the ID of the
+// retrieval, whose status is checked with CheckRetrieveStatus.
+func (c *Client) StartRetrieve(ctx context.Context, pkg *Package) (string, error) {
+	type retrieveRequest struct {
+		APIVersion    string   `xml:"apiVersion"`
+		SinglePackage bool     `xml:"singlePackage"`
+		Unpackaged    *Package `xml:"unpackaged"`
+	}
+	request := struct {
+		XMLName xml.Name        `xml:"http://soap.sforce.com/2006/04/metadata retrieve"`
+		Request retrieveRequest `xml:"retrieveRequest"`
+	}{Request: retrieveRequest{APIVersion: c.client.APIVersion(), SinglePackage: true, Unpackaged: pkg}}
+	var response struct {
+		ID string `xml:"Body>retrieveResponse>result>id"`
+	}
+	err := c.call(ctx, "retrieve", request, &response)
+	if err != nil {
+		return "", err
+	}
+	if response.ID == "" {
+		return "", errors.New("no retrieval id in response")
+	}
+	return response.ID, nil
+}
+
+// CheckRetrieveStatus returns the status of the retrieval id, along with the zip of the components once it succeeded
+// if includeZip is set.
+func (c *Client) CheckRetrieveStatus(ctx context.Context, id string, includeZip bool) (*RetrieveResult, error) {
+	request := struct {
+		XMLName    xml.Name `xml:"http://soap.sforce.com/2006/04/metadata checkRetrieveStatus"`
+		ID         string   `xml:"asyncProcessId"`
+		IncludeZip bool     `xml:"includeZip"`
+	}{ID: id, IncludeZip: includeZip}
+	var response struct {
+		Result RetrieveResult `xml:"Body>checkRetrieveStatusResponse>result"`
+	}
+	err := c.call(ctx, "checkRetrieveStatus", request, &response)
+	if err != nil {
+		return nil, err
+	}
+	result := &response.Result
+	if result.EncodedZipFile != "" {
+		result.ZipFile, err = base64.StdEncoding.DecodeString(result.EncodedZipFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid zip file")
+		}
+	}
+	return result, nil
+}
+
+// Retrieve retrieves the components listed by the package.xml manifest and waits for the retrieval to complete. The
+// zip of the components is in the ZipFile of the result.
+func (c *Client) Retrieve(ctx context.Context, manifest []byte) (*RetrieveResult, error) {
+	pkg, err := ParsePackage(manifest)
+	if err != nil {
+		return nil, err
+	}
+	id, err := c.StartRetrieve(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *RetrieveResult
+	err = c.wait(ctx, func() (bool, error) {
+		var err error
+		result, err = c.CheckRetrieveStatus(ctx, id, true)
+		return err == nil && result.Done, err
+	})
+	if err != nil {
+		return result, err
+	}
+	if result.Status != StatusSucceeded {
+		return result, errors.Errorf("retrieval %s %s: %s", id, strings.ToLower(result.Status), result.ErrorMessage)
+	}
+	return result, nil
+}
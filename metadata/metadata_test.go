@@ -0,0 +1,167 @@
+package metadata
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simpleforce/simpleforce"
+)
+
+// mockMetadataAPI answers the Metadata API calls with the envelopes of responses, keyed by SOAPAction, in turn.
+func mockMetadataAPI(t *testing.T, responses map[string][]string) (*Client, *[]string) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/Soap/m/"+simpleforce.DefaultAPIVersion {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		action := r.Header.Get("SOAPAction")
+		if len(responses[action]) == 0 {
+			t.Errorf("unexpected call %s", action)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		response := responses[action][0]
+		responses[action] = responses[action][1:]
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope ` +
+			`xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns="http://soap.sforce.com/2006/04/metadata">` +
+			`<soapenv:Body>` + response + `</soapenv:Body></soapenv:Envelope>`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := simpleforce.NewClient(server.URL, simpleforce.DefaultClientID, simpleforce.DefaultAPIVersion)
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+	c := New(client)
+	c.PollInterval = time.Millisecond
+	return c, &bodies
+}
+
+func TestClient_Deploy(t *testing.T) {
+	c, bodies := mockMetadataAPI(t, map[string][]string{
+		"deploy": {`<deployResponse><result><id>0Af000000000001</id><done>false</done></result></deployResponse>`},
+		"checkDeployStatus": {
+			`<checkDeployStatusResponse><result><id>0Af000000000001</id><done>false</done><status>InProgress</status></result></checkDeployStatusResponse>`,
+			`<checkDeployStatusResponse><result><id>0Af000000000001</id><done>true</done><status>Succeeded</status>` +
+				`<success>true</success><numberComponentsDeployed>2</numberComponentsDeployed></result></checkDeployStatusResponse>`,
+		},
+	})
+
+	result, err := c.Deploy(context.Background(), []byte("zip"), DeployOptions{
+		RollbackOnError: true,
+		TestLevel:       RunSpecifiedTests,
+		RunTests:        []string{"AccountTest"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success || result.Status != StatusSucceeded || result.NumberComponentsDeployed != 2 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(*bodies) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(*bodies))
+	}
+	deploy := (*bodies)[0]
+	for _, expected := range []string{
+		`<sessionId>__SESSION_ID__</sessionId>`,
+		`<deploy xmlns="http://soap.sforce.com/2006/04/metadata"><ZipFile>` + base64.StdEncoding.EncodeToString([]byte("zip")) + `</ZipFile>`,
+		`<rollbackOnError>true</rollbackOnError><runTests>AccountTest</runTests><singlePackage>false</singlePackage><testLevel>RunSpecifiedTests</testLevel>`,
+	} {
+		if !strings.Contains(deploy, expected) {
+			t.Errorf("expected %s in %s", expected, deploy)
+		}
+	}
+	if !strings.Contains((*bodies)[1], `<asyncProcessId>0Af000000000001</asyncProcessId><includeDetails>false</includeDetails>`) {
+		t.Errorf("unexpected status check %s", (*bodies)[1])
+	}
+}
+
+func TestClient_DeployFailure(t *testing.T) {
+	c, _ := mockMetadataAPI(t, map[string][]string{
+		"deploy": {`<deployResponse><result><id>0Af000000000002</id></result></deployResponse>`},
+		"checkDeployStatus": {
+			`<checkDeployStatusResponse><result><id>0Af000000000002</id><done>true</done><status>Failed</status></result></checkDeployStatusResponse>`,
+			`<checkDeployStatusResponse><result><id>0Af000000000002</id><done>true</done><status>Failed</status><details>` +
+				`<componentFailures><componentType>ApexClass</componentType><fullName>Foo</fullName><problem>Missing ';'</problem></componentFailures>` +
+				`<runTestResult><failures><name>FooTest</name><methodName>testBar</methodName><message>Assertion failed</message></failures></runTestResult>` +
+				`</details></result></checkDeployStatusResponse>`,
+		},
+	})
+
+	result, err := c.Deploy(context.Background(), []byte("zip"), DeployOptions{CheckOnly: true})
+	var deployErr *DeployError
+	if !errors.As(err, &deployErr) {
+		t.Fatalf("expected a DeployError, got %v", err)
+	}
+	if len(result.ComponentFailures) != 1 || result.ComponentFailures[0].FullName != "Foo" ||
+		len(result.TestFailures) != 1 || result.TestFailures[0].MethodName != "testBar" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	expected := "deployment 0Af000000000002 failed; ApexClass Foo: Missing ';'; test FooTest.testBar: Assertion failed"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestClient_Retrieve(t *testing.T) {
+	c, bodies := mockMetadataAPI(t, map[string][]string{
+		"retrieve": {`<retrieveResponse><result><id>09S000000000001</id></result></retrieveResponse>`},
+		"checkRetrieveStatus": {
+			`<checkRetrieveStatusResponse><result><id>09S000000000001</id><done>false</done><status>InProgress</status></result></checkRetrieveStatusResponse>`,
+			`<checkRetrieveStatusResponse><result><id>09S000000000001</id><done>true</done><status>Succeeded</status><success>true</success>` +
+				`<messages><fileName>unpackaged/package.xml</fileName><problem>Entity of type 'ApexClass' named 'Missing' cannot be found</problem></messages>` +
+				`<zipFile>` + base64.StdEncoding.EncodeToString([]byte("retrieved")) + `</zipFile></result></checkRetrieveStatusResponse>`,
+		},
+	})
+
+	manifest := `<?xml version="1.0" encoding="UTF-8"?>
+<Package xmlns="http://soap.sforce.com/2006/04/metadata">
+    <types>
+        <members>Foo</members>
+        <members>Missing</members>
+        <name>ApexClass</name>
+    </types>
+    <version>54.0</version>
+</Package>`
+	result, err := c.Retrieve(context.Background(), []byte(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result.ZipFile) != "retrieved" || len(result.Messages) != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	expected := `<retrieveRequest><apiVersion>` + simpleforce.DefaultAPIVersion + `</apiVersion><singlePackage>true</singlePackage>` +
+		`<unpackaged><types><members>Foo</members><members>Missing</members><name>ApexClass</name></types><version>54.0</version></unpackaged>`
+	if !strings.Contains((*bodies)[0], expected) {
+		t.Errorf("expected %s in %s", expected, (*bodies)[0])
+	}
+	if !strings.Contains((*bodies)[1], `<includeZip>true</includeZip>`) {
+		t.Errorf("unexpected status check %s", (*bodies)[1])
+	}
+}
+
+func TestClient_WaitCanceled(t *testing.T) {
+	c, _ := mockMetadataAPI(t, map[string][]string{
+		"checkDeployStatus": {`<checkDeployStatusResponse><result><done>false</done></result></checkDeployStatusResponse>`},
+	})
+	c.PollInterval = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := c.wait(ctx, func() (bool, error) {
+		result, err := c.CheckDeployStatus(ctx, "0Af000000000003", false)
+		return err == nil && result.Done, err
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package simpleforce
+
+import "net/http"
+
+// Doer sends an HTTP request and returns its response, like http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do implements Doer.
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer sending the requests of a client, e.g. to trace requests, add headers or audit the calls
+// made to Salesforce:
+//
+//	client.Use(func(next simpleforce.Doer) simpleforce.Doer {
+//		return simpleforce.DoerFunc(func(req *http.Request) (*http.Response, error) {
+//			req.Header.Set("X-Request-Id", uuid.NewString())
+//			return next.Do(req)
+//		})
+//	})
+//
+// Middlewares see every request sent, including logins and the attempts of retried requests, once the request hook
+// ran. Errors they return are handled as network errors.
+type Middleware func(next Doer) Doer
+
+// Use adds middlewares around the requests of the client. The first middleware added is the outermost, i.e. sees the
+// requests first and the responses last. Copies of the client made after Use share its middlewares.
+func (client *Client) Use(middlewares ...Middleware) {
+	// Never append to the slice of the client, which may be shared with copies of the client.
+	chain := make([]Middleware, 0, len(client.middlewares)+len(middlewares))
+	chain = append(chain, client.middlewares...)
+	client.middlewares = append(chain, middlewares...)
+}
+
+// doer returns the Doer sending the requests of the client through its middlewares.
+func (client *Client) doer() Doer {
+	var doer Doer = client.redirectingHTTPClient()
+	for i := len(client.middlewares) - 1; i >= 0; i-- {
+		doer = client.middlewares[i](doer)
+	}
+	return doer
+}
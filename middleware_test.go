@@ -0,0 +1,61 @@
+package simpleforce
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_Use(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") != "req-1" {
+			t.Errorf("unexpected request ID %q", r.Header.Get("X-Request-Id"))
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+req.Method)
+				resp, err := next.Do(req)
+				if err == nil {
+					calls = append(calls, name+" "+resp.Status)
+				}
+				return resp, err
+			})
+		}
+	}
+	client.Use(trace("outer"), func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Request-Id", "req-1")
+			return next.Do(req)
+		})
+	})
+	client.Use(trace("inner"))
+
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"outer GET", "inner GET", "inner 200 OK", "outer 200 OK"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("unexpected calls %v", calls)
+	}
+
+	// Middlewares added to a copy don't affect the client.
+	copied := client.WithAPIVersion("55.0")
+	copied.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("blocked")
+		})
+	})
+	if _, err := copied.Query("SELECT Id FROM Account"); err == nil {
+		t.Error("expected the copy to be blocked")
+	}
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,227 @@
+package simpleforce
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// jwtLifetime is the validity of the JWT assertions, Salesforce accepts up to 3 minutes.
+const jwtLifetime = 3 * time.Minute
+
+// tokenResponse is the response of the OAuth token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	InstanceURL string `json:"instance_url"`
+	ID          string `json:"id"`
+	TokenType   string `json:"token_type"`
+}
+
+// LoginJWT signs into salesforce with the OAuth 2.0 JWT bearer flow, for server-to-server integrations: the assertion
+// is signed with privateKey, whose certificate is uploaded to the connected app of clientID, and username must be
+// pre-authorized for the app. The audience of the assertion is the login URL of the client, e.g. DefaultURL or
+// SandboxURL, or the one matching a My Domain URL. The key is kept to renew the session later, see SetAutoRenewSession.
+// Ref: https://help.salesforce.com/s/articleView?id=sf.remoteaccess_oauth_jwt_flow.htm
+func (client *Client) LoginJWT(clientID, username string, privateKey *rsa.PrivateKey) error {
+	return client.LoginJWTContext(context.Background(), clientID, username, privateKey)
+}
+
+// LoginJWTContext is like LoginJWT, with the request bound to ctx.
+func (client *Client) LoginJWTContext(ctx context.Context, clientID, username string, privateKey *rsa.PrivateKey) error {
+	assertion, err := client.jwtAssertion(clientID, username, privateKey)
+	if err != nil {
+		return err
+	}
+	err = client.jwtToken(ctx, assertion)
+	if err != nil {
+		return err
+	}
+	client.setSessionRenewal(func(ctx context.Context, client *Client) error {
+		assertion, err := client.jwtAssertion(clientID, username, privateKey)
+		if err != nil {
+			return err
+		}
+		return client.jwtToken(ctx, assertion)
+	})
+	return nil
+}
+
+// jwtToken requests an access token with a JWT assertion.
+func (client *Client) jwtToken(ctx context.Context, assertion string) error {
+	return client.oauthToken(ctx, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	})
+}
+
+// LoginRefreshToken signs into salesforce with the OAuth 2.0 refresh token flow, using a refresh token obtained by
+// an earlier authorization of the connected app of clientID. clientSecret may be empty if the app doesn't require it.
+// The refresh token is kept to renew the session later, see SetAutoRenewSession.
+// Ref: https://help.salesforce.com/s/articleView?id=sf.remoteaccess_oauth_refresh_token_flow.htm
+func (client *Client) LoginRefreshToken(clientID, clientSecret, refreshToken string) error {
+	return client.LoginRefreshTokenContext(context.Background(), clientID, clientSecret, refreshToken)
+}
+
+// LoginRefreshTokenContext is like LoginRefreshToken, with the request bound to ctx.
+func (client *Client) LoginRefreshTokenContext(ctx context.Context, clientID, clientSecret, refreshToken string) error {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {clientID},
+		"refresh_token": {refreshToken},
+	}
+	if clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+	client.redactor.addSecret(clientSecret)
+	client.redactor.addSecret(refreshToken)
+
+	err := client.oauthToken(ctx, form)
+	if err != nil {
+		return err
+	}
+	client.setSessionRenewal(func(ctx context.Context, client *Client) error {
+		return client.oauthToken(ctx, form)
+	})
+	return nil
+}
+
+// ParsePrivateKeyPEM decodes a PEM encoded RSA private key, in PKCS #1 or PKCS #8 form, such as the key generated for
+// the certificate of a connected app.
+func ParsePrivateKeyPEM(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private key")
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA private key")
+	}
+	return rsaKey, nil
+}
+
+// jwtAudience returns the audience of JWT assertions: the login URL of the client, except for My Domain URLs, which
+// aren't accepted as audience in place of the login URLs of production orgs and sandboxes.
+func (client *Client) jwtAudience() string {
+	loginURL := client.loginBaseURL()
+	switch {
+	case strings.HasSuffix(loginURL, ".sandbox.my.salesforce.com"):
+		return SandboxURL
+	case strings.HasSuffix(loginURL, ".my.salesforce.com"):
+		return DefaultURL
+	}
+	return loginURL
+}
+
+// jwtAssertion builds the JWT assertion of username signed with privateKey.
+func (client *Client) jwtAssertion(clientID, username string, privateKey *rsa.PrivateKey) (string, error) {
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": clientID,
+		"sub": username,
+		"aud": client.jwtAudience(),
+		"exp": time.Now().Add(jwtLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString([]byte(`{"alg":"RS256"}`)) + "." + encoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign JWT assertion")
+	}
+	return unsigned + "." + encoding.EncodeToString(signature), nil
+}
+
+// oauthToken requests an access token from the OAuth token endpoint with form, and signs the client in with it.
+func (client *Client) oauthToken(ctx context.Context, form url.Values) error {
+	u := fmt.Sprintf("%s/services/oauth2/token", client.loginBaseURL())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		client.logError("error occurred creating request,", err)
+		return err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := client.do(req)
+	if err != nil {
+		client.logError("error occurred submitting request,", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	respData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		client.logError("error occurred reading response data,", err)
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		client.logError("request failed,", resp.StatusCode)
+		return client.redactError(parseOAuthError(resp.StatusCode, respData))
+	}
+
+	var token tokenResponse
+	err = json.Unmarshal(respData, &token)
+	if err != nil {
+		client.logError("error occurred parsing token response,", err)
+		return err
+	}
+	if token.AccessToken == "" || token.InstanceURL == "" {
+		return errors.Wrap(ErrAuthentication, "no access token in the response")
+	}
+
+	client.redactor.addSecret(token.AccessToken)
+	client.setSessionID(token.AccessToken)
+	client.setInstanceURL(strings.TrimRight(token.InstanceURL, "/"))
+	// The identity URL ends with the IDs of the org and the user.
+	if idx := strings.LastIndex(token.ID, "/"); idx >= 0 {
+		setIfChanged(&client.user.id, token.ID[idx+1:])
+	}
+
+	client.logDebug("User", client.user.id, "authenticated.")
+	return nil
+}
+
+// parseOAuthError converts the error response of the OAuth token endpoint, such as
+// {"error":"invalid_grant","error_description":"user hasn't approved this consumer"}, into a SalesforceError.
+func parseOAuthError(statusCode int, respData []byte) error {
+	var oauthErr struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	if json.Unmarshal(respData, &oauthErr) != nil || oauthErr.Error == "" {
+		return ParseSalesforceError(statusCode, respData)
+	}
+	return SalesforceError{
+		Message: fmt.Sprintf(
+			logPrefix+" Error. http code: %v Error Message:  %v Error Code: %v",
+			statusCode, oauthErr.Description, oauthErr.Error,
+		),
+		HttpCode:     statusCode,
+		ErrorCode:    oauthErr.Error,
+		ErrorMessage: oauthErr.Description,
+	}
+}
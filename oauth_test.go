@@ -0,0 +1,143 @@
+package simpleforce
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_LoginJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var baseURL string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/oauth2/token" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if grant := r.FormValue("grant_type"); grant != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			t.Errorf("unexpected grant type %s", grant)
+		}
+		parts := strings.Split(r.FormValue("assertion"), ".")
+		if len(parts) != 3 {
+			t.Errorf("unexpected assertion %s", r.FormValue("assertion"))
+			return
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			t.Errorf("invalid signature, %v", err)
+		}
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		var claims struct {
+			Iss, Sub, Aud string
+			Exp           int64
+		}
+		json.Unmarshal(payload, &claims)
+		if claims.Iss != "3MVG9_consumer_key" || claims.Aud != baseURL || claims.Exp == 0 {
+			t.Errorf("unexpected claims %s", payload)
+		}
+		if claims.Sub != "integration@example.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant","error_description":"user hasn't approved this consumer"}`))
+			return
+		}
+		w.Write([]byte(`{"access_token":"00D000000000062!AQ0AQ","instance_url":"https://example.my.salesforce.com",
+			"id":"https://login.salesforce.com/id/00D000000000062EAA/005000000000001AAA","token_type":"Bearer"}`))
+	})
+	baseURL = client.baseURL
+
+	if err := client.LoginJWT("3MVG9_consumer_key", "integration@example.com", key); err != nil {
+		t.Fatal(err)
+	}
+	if client.getSessionID() != "00D000000000062!AQ0AQ" || client.getInstanceURL() != "https://example.my.salesforce.com" ||
+		client.user.id != "005000000000001AAA" {
+		t.Errorf("unexpected session %s %s %s", client.getSessionID(), client.getInstanceURL(), client.user.id)
+	}
+
+	err = client.LoginJWT("3MVG9_consumer_key", "unknown@example.com", key)
+	var sfErr SalesforceError
+	if !errors.As(err, &sfErr) || sfErr.ErrorCode != "invalid_grant" || !errors.Is(err, ErrAuthentication) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, block := range []*pem.Block{
+		{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		{Type: "PRIVATE KEY", Bytes: pkcs8},
+	} {
+		parsed, err := ParsePrivateKeyPEM(pem.EncodeToMemory(block))
+		if err != nil || !parsed.Equal(key) {
+			t.Errorf("failed to parse %s, %v", block.Type, err)
+		}
+	}
+	if _, err := ParsePrivateKeyPEM([]byte("not a key")); err == nil {
+		t.Error("expected an error without PEM data")
+	}
+}
+
+func TestClient_LoginRefreshToken(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "refresh_token" || r.FormValue("client_id") != "3MVG9_consumer_key" ||
+			r.FormValue("client_secret") != "" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		if r.FormValue("refresh_token") != "5Aep861_refresh_token" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant","error_description":"expired access/refresh token"}`))
+			return
+		}
+		w.Write([]byte(`{"access_token":"00D000000000062!AQ0AQ","instance_url":"https://example.my.salesforce.com",
+			"id":"https://login.salesforce.com/id/00D000000000062EAA/005000000000001AAA","token_type":"Bearer"}`))
+	})
+
+	if err := client.LoginRefreshToken("3MVG9_consumer_key", "", "5Aep861_refresh_token"); err != nil {
+		t.Fatal(err)
+	}
+	if client.getSessionID() != "00D000000000062!AQ0AQ" || client.session.renew == nil {
+		t.Errorf("unexpected session %s", client.getSessionID())
+	}
+
+	err := client.LoginRefreshToken("3MVG9_consumer_key", "", "5Aep861_revoked")
+	if !errors.Is(err, ErrAuthentication) || strings.Contains(err.Error(), "5Aep861_revoked") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestClient_jwtAudience(t *testing.T) {
+	tests := map[string]string{
+		DefaultURL:                       DefaultURL,
+		SandboxURL:                       SandboxURL,
+		"https://acme.my.salesforce.com": DefaultURL,
+		"https://acme--uat.sandbox.my.salesforce.com": SandboxURL,
+		"https://acme.my.site.com/customers":          "https://acme.my.site.com/customers",
+	}
+	for loginURL, expected := range tests {
+		client := NewClient(loginURL, DefaultClientID, DefaultAPIVersion)
+		if audience := client.jwtAudience(); audience != expected {
+			t.Errorf("expected audience %s for %s, got %s", expected, loginURL, audience)
+		}
+	}
+}
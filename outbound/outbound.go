@@ -0,0 +1,186 @@
+// Package outbound receives Workflow Outbound Messages: Salesforce posts SOAP notifications carrying the fields of
+// records to an endpoint, and sends them again until the endpoint acknowledges them.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api.meta/api/sforce_api_om_outboundmessaging.htm
+package outbound
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/simpleforce/simpleforce"
+)
+
+const (
+	logPrefix = "[simpleforce/outbound]"
+
+	// maxMessageSize bounds the size of the notifications read, which hold up to 100 records.
+	maxMessageSize = 10 << 20
+
+	ackResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><notificationsResponse xmlns="http://soap.sforce.com/2005/09/outbound"><Ack>true</Ack></notificationsResponse></soapenv:Body></soapenv:Envelope>`
+
+	faultResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><soapenv:Fault><faultcode>soapenv:%s</faultcode><faultstring>%s</faultstring></soapenv:Fault></soapenv:Body></soapenv:Envelope>`
+)
+
+// Message is an Outbound Message, holding one or more notifications of the same action.
+type Message struct {
+	OrganizationID string `xml:"OrganizationId"`
+	ActionID       string `xml:"ActionId"`
+	// SessionID is only set if the outbound message is configured to send the session ID of its user, which can be
+	// used with the EnterpriseURL or PartnerURL to call back Salesforce.
+	SessionID     string         `xml:"SessionId"`
+	EnterpriseURL string         `xml:"EnterpriseUrl"`
+	PartnerURL    string         `xml:"PartnerUrl"`
+	Notifications []Notification `xml:"Notification"`
+}
+
+// Notification is the notification of a single record. Salesforce may deliver a notification more than once, so
+// handlers must be idempotent; ID identifies the notification across deliveries.
+type Notification struct {
+	ID     string
+	Record simpleforce.SObject
+}
+
+// UnmarshalXML decodes a notification with the fields of its record. Field values are strings, or nil for fields
+// sent as nil.
+func (n *Notification) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		ID     string `xml:"Id"`
+		Object struct {
+			Type   string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+			Fields []struct {
+				XMLName xml.Name
+				Nil     bool   `xml:"http://www.w3.org/2001/XMLSchema-instance nil,attr"`
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"sObject"`
+	}
+	err := d.DecodeElement(&raw, &start)
+	if err != nil {
+		return err
+	}
+
+	objectType := raw.Object.Type
+	if idx := strings.Index(objectType, ":"); idx >= 0 {
+		objectType = objectType[idx+1:]
+	}
+	n.ID = raw.ID
+	n.Record = simpleforce.SObject{"attributes": simpleforce.SObjectAttributes{Type: objectType}}
+	for _, field := range raw.Object.Fields {
+		if field.Nil {
+			n.Record[field.XMLName.Local] = nil
+		} else {
+			n.Record[field.XMLName.Local] = field.Value
+		}
+	}
+	return nil
+}
+
+// ParseMessage decodes the SOAP envelope of an Outbound Message.
+func ParseMessage(r io.Reader) (*Message, error) {
+	var envelope struct {
+		Message *Message `xml:"Body>notifications"`
+	}
+	err := xml.NewDecoder(r).Decode(&envelope)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid outbound message")
+	}
+	if envelope.Message == nil {
+		return nil, errors.New("invalid outbound message: no notifications")
+	}
+	return envelope.Message, nil
+}
+
+// HandlerFunc processes an Outbound Message. Returning an error makes Salesforce deliver the message again later.
+type HandlerFunc func(ctx context.Context, msg *Message) error
+
+// Handler is an http.Handler receiving Outbound Messages, which acknowledges them once Handle succeeded.
+type Handler struct {
+	// OrganizationID is the 15 or 18 characters ID of the org allowed to send messages. Messages from other orgs are
+	// rejected. It is required, as the endpoint of outbound messages is usually public.
+	OrganizationID string
+	// VerifyCertificate, if set, checks the client certificate presented by Salesforce, which requires a server
+	// requesting client certificates (tls.Config.ClientAuth). Requests without certificate are rejected.
+	VerifyCertificate func(cert *x509.Certificate) error
+	// Handle processes the messages.
+	Handle HandlerFunc
+	// Logger, if set, receives the log messages of the handler instead of the standard log package.
+	Logger simpleforce.Logger
+	// Client, if set, redacts its credentials and redacted fields from the log messages, see
+	// simpleforce.Client.SetRedactedFields. Passwords and tokens are redacted regardless.
+	Client *simpleforce.Client
+}
+
+// NewHandler creates a Handler accepting the messages of the org organizationID and passing them to handle.
+func NewHandler(organizationID string, handle HandlerFunc) *Handler {
+	return &Handler{OrganizationID: organizationID, Handle: handle}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeFault(w, http.StatusMethodNotAllowed, "Client", "method not allowed")
+		return
+	}
+	if h.VerifyCertificate != nil {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			writeFault(w, http.StatusUnauthorized, "Client", "client certificate required")
+			return
+		}
+		err := h.VerifyCertificate(r.TLS.PeerCertificates[0])
+		if err != nil {
+			h.logError("client certificate rejected,", err)
+			writeFault(w, http.StatusForbidden, "Client", "client certificate rejected")
+			return
+		}
+	}
+
+	msg, err := ParseMessage(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		writeFault(w, http.StatusBadRequest, "Client", err.Error())
+		return
+	}
+	if h.OrganizationID == "" || !simpleforce.SameID(msg.OrganizationID, h.OrganizationID) {
+		h.logError("message of unexpected organization rejected:", msg.OrganizationID)
+		writeFault(w, http.StatusForbidden, "Client", "unexpected organization")
+		return
+	}
+
+	err = h.Handle(r.Context(), msg)
+	if err != nil {
+		h.logError("failed to handle message of action", msg.ActionID+",", err)
+		writeFault(w, http.StatusInternalServerError, "Server", "message not processed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	io.WriteString(w, ackResponse)
+}
+
+// logError logs an error message with the logger of the handler.
+func (h *Handler) logError(args ...interface{}) {
+	msg := h.Client.Redact(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+	if h.Logger != nil {
+		h.Logger.Error(msg)
+		return
+	}
+	log.Println(logPrefix, msg)
+}
+
+// writeFault replies with a SOAP fault.
+func writeFault(w http.ResponseWriter, status int, code, message string) {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(message))
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, faultResponse, code, escaped.String())
+}
@@ -0,0 +1,180 @@
+package outbound
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/simpleforce/simpleforce"
+)
+
+const testMessage = `<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+ <soapenv:Body>
+  <notifications xmlns="http://soap.sforce.com/2005/09/outbound">
+   <OrganizationId>00D000000000062EAA</OrganizationId>
+   <ActionId>04k000000000001AAA</ActionId>
+   <SessionId xsi:nil="true"/>
+   <EnterpriseUrl>https://example.my.salesforce.com/services/Soap/c/54.0/00D000000000062</EnterpriseUrl>
+   <PartnerUrl>https://example.my.salesforce.com/services/Soap/u/54.0/00D000000000062</PartnerUrl>
+   <Notification>
+    <Id>04l000000000001AAA</Id>
+    <sObject xsi:type="sf:Account" xmlns:sf="urn:sobject.enterprise.soap.sforce.com">
+     <sf:Id>0013000000Db2wKAAR</sf:Id>
+     <sf:Name>Acme &amp; Co</sf:Name>
+     <sf:Website xsi:nil="true"/>
+    </sObject>
+   </Notification>
+   <Notification>
+    <Id>04l000000000002AAA</Id>
+    <sObject xsi:type="sf:Account" xmlns:sf="urn:sobject.enterprise.soap.sforce.com">
+     <sf:Id>0013000000Db2wLAAR</sf:Id>
+     <sf:Name>Globex</sf:Name>
+    </sObject>
+   </Notification>
+  </notifications>
+ </soapenv:Body>
+</soapenv:Envelope>`
+
+func TestParseMessage(t *testing.T) {
+	msg, err := ParseMessage(strings.NewReader(testMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.OrganizationID != "00D000000000062EAA" || msg.ActionID != "04k000000000001AAA" || msg.SessionID != "" ||
+		!strings.HasSuffix(msg.PartnerURL, "/Soap/u/54.0/00D000000000062") {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	if len(msg.Notifications) != 2 {
+		t.Fatalf("unexpected notifications %+v", msg.Notifications)
+	}
+	n := msg.Notifications[0]
+	if n.ID != "04l000000000001AAA" || n.Record.Type() != "Account" || n.Record.ID() != "0013000000Db2wKAAR" ||
+		n.Record.StringField("Name") != "Acme & Co" {
+		t.Errorf("unexpected notification %+v", n)
+	}
+	if website, ok := n.Record["Website"]; !ok || website != nil {
+		t.Errorf("expected a nil Website, got %v", n.Record["Website"])
+	}
+
+	if _, err := ParseMessage(strings.NewReader(`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body/></soapenv:Envelope>`)); err == nil {
+		t.Error("expected an error without notifications")
+	}
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	var handled *Message
+	var failure error
+	handler := NewHandler("00D000000000062", func(ctx context.Context, msg *Message) error {
+		handled = msg
+		return failure
+	})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/outbound", strings.NewReader(body)))
+		return w
+	}
+
+	w := post(testMessage)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "<Ack>true</Ack>") {
+		t.Errorf("unexpected response %d %s", w.Code, w.Body)
+	}
+	if handled == nil || len(handled.Notifications) != 2 {
+		t.Errorf("unexpected handled message %+v", handled)
+	}
+
+	failure = errors.New("database unavailable")
+	w = post(testMessage)
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "<Ack>") ||
+		strings.Contains(w.Body.String(), "database unavailable") {
+		t.Errorf("unexpected response %d %s", w.Code, w.Body)
+	}
+	failure = nil
+
+	handled = nil
+	w = post(strings.Replace(testMessage, "00D000000000062EAA", "00D000000000099EAA", 1))
+	if w.Code != http.StatusForbidden || handled != nil {
+		t.Errorf("unexpected response %d %s", w.Code, w.Body)
+	}
+
+	if w = post("<notifications"); w.Code != http.StatusBadRequest {
+		t.Errorf("unexpected response %d %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/outbound", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected response %d", w.Code)
+	}
+}
+
+// recordingLogger keeps the error messages logged.
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debug(args ...interface{}) {}
+
+func (l *recordingLogger) Info(args ...interface{}) {}
+
+func (l *recordingLogger) Error(args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprint(args...))
+}
+
+func TestHandler_logError(t *testing.T) {
+	logger := &recordingLogger{}
+	client := simpleforce.NewClient(simpleforce.DefaultURL, simpleforce.DefaultClientID, simpleforce.DefaultAPIVersion)
+	client.SetRedactedFields("SSN__c")
+	handler := NewHandler("00D000000000062", func(ctx context.Context, msg *Message) error {
+		return errors.New(`upsert failed: {"SSN__c":"123-45-6789","password":"hunter2"}`)
+	})
+	handler.Logger = logger
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/outbound", strings.NewReader(testMessage)))
+	handler.Client = client
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/outbound", strings.NewReader(testMessage)))
+
+	if len(logger.messages) != 2 || strings.Contains(logger.messages[0], "hunter2") || !strings.Contains(logger.messages[0], "123-45-6789") ||
+		strings.Contains(logger.messages[1], "123-45-6789") {
+		t.Errorf("unexpected messages %q", logger.messages)
+	}
+}
+
+func TestHandler_VerifyCertificate(t *testing.T) {
+	handler := NewHandler("00D000000000062EAA", func(ctx context.Context, msg *Message) error {
+		return nil
+	})
+	handler.VerifyCertificate = func(cert *x509.Certificate) error {
+		if cert.Subject.CommonName != "proxy.salesforce.com" {
+			return errors.New("unexpected certificate")
+		}
+		return nil
+	}
+
+	post := func(state *tls.ConnectionState) int {
+		r := httptest.NewRequest(http.MethodPost, "/outbound", strings.NewReader(testMessage))
+		r.TLS = state
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := post(nil); code != http.StatusUnauthorized {
+		t.Errorf("expected a missing certificate to be rejected, got %d", code)
+	}
+	cert := &x509.Certificate{}
+	cert.Subject.CommonName = "attacker.example.com"
+	if code := post(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}); code != http.StatusForbidden {
+		t.Errorf("expected an unexpected certificate to be rejected, got %d", code)
+	}
+	cert.Subject.CommonName = "proxy.salesforce.com"
+	if code := post(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}); code != http.StatusOK {
+		t.Errorf("expected the certificate to be accepted, got %d", code)
+	}
+}
@@ -0,0 +1,197 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrDuplicateAssignment is returned when a user is already assigned the permission set or group.
+	ErrDuplicateAssignment = errors.New("duplicate permission set assignment")
+
+	// ErrLicenseMismatch is returned when the license of the permission set doesn't match the license of the user.
+	ErrLicenseMismatch = errors.New("permission set license mismatch")
+)
+
+// PermissionSetAssignment assigns a permission set, or a permission set group, to a user.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.object_reference.meta/object_reference/sforce_api_objects_permissionsetassignment.htm
+type PermissionSetAssignment struct {
+	ID                   string `json:"Id,omitempty"`
+	AssigneeID           string `json:"AssigneeId"`
+	PermissionSetID      string `json:"PermissionSetId,omitempty"`
+	PermissionSetGroupID string `json:"PermissionSetGroupId,omitempty"`
+	PermissionSet        *struct {
+		Name             string `json:"Name"`
+		Label            string `json:"Label"`
+		IsOwnedByProfile bool   `json:"IsOwnedByProfile"`
+	} `json:"PermissionSet,omitempty"`
+}
+
+// AssignmentResult is the outcome of a single assignment or removal. Err is nil on success and matches
+// ErrDuplicateAssignment or ErrLicenseMismatch with errors.Is for these common failures.
+type AssignmentResult struct {
+	ID  string
+	Err error
+}
+
+// PermissionSetAssignments lists the permission sets and permission set groups assigned to a user. The permission
+// sets owned by profiles are left out as they can't be assigned or removed individually.
+func (client *Client) PermissionSetAssignments(userID string) ([]PermissionSetAssignment, error) {
+	return client.PermissionSetAssignmentsContext(context.Background(), userID)
+}
+
+// PermissionSetAssignmentsContext is like PermissionSetAssignments, with the requests bound to ctx.
+func (client *Client) PermissionSetAssignmentsContext(ctx context.Context, userID string) ([]PermissionSetAssignment, error) {
+	q, err := Select("Id", "AssigneeId", "PermissionSetId", "PermissionSetGroupId", "PermissionSet.Name",
+		"PermissionSet.Label", "PermissionSet.IsOwnedByProfile").
+		From("PermissionSetAssignment").
+		Where(Eq("AssigneeId", userID)).
+		Where(Eq("PermissionSet.IsOwnedByProfile", false)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := []PermissionSetAssignment{}
+	err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+		var page []PermissionSetAssignment
+		err := json.Unmarshal(records, &page)
+		assignments = append(assignments, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// AssignPermissionSet assigns a permission set to a user and returns the ID of the assignment.
+func (client *Client) AssignPermissionSet(userID, permissionSetID string) (string, error) {
+	return client.AssignPermissionSetContext(context.Background(), userID, permissionSetID)
+}
+
+// AssignPermissionSetContext is like AssignPermissionSet, with the request bound to ctx.
+func (client *Client) AssignPermissionSetContext(ctx context.Context, userID, permissionSetID string) (string, error) {
+	return client.assignOne(ctx, PermissionSetAssignment{AssigneeID: userID, PermissionSetID: permissionSetID})
+}
+
+// AssignPermissionSetGroup assigns a permission set group to a user and returns the ID of the assignment.
+func (client *Client) AssignPermissionSetGroup(userID, permissionSetGroupID string) (string, error) {
+	return client.AssignPermissionSetGroupContext(context.Background(), userID, permissionSetGroupID)
+}
+
+// AssignPermissionSetGroupContext is like AssignPermissionSetGroup, with the request bound to ctx.
+func (client *Client) AssignPermissionSetGroupContext(ctx context.Context, userID, permissionSetGroupID string) (string, error) {
+	return client.assignOne(ctx, PermissionSetAssignment{AssigneeID: userID, PermissionSetGroupID: permissionSetGroupID})
+}
+
+func (client *Client) assignOne(ctx context.Context, assignment PermissionSetAssignment) (string, error) {
+	results, err := client.AssignPermissionSetsContext(ctx, []PermissionSetAssignment{assignment}, false)
+	if err != nil {
+		return "", err
+	}
+	return results[0].ID, results[0].Err
+}
+
+// AssignPermissionSets creates several assignments at once, 200 per API call. The results are returned in the order
+// of assignments. If allOrNone is false, each assignment succeeds or fails on its own; otherwise a RollbackError
+// identifies the assignments which prevented the others from being created.
+func (client *Client) AssignPermissionSets(assignments []PermissionSetAssignment, allOrNone bool) ([]AssignmentResult, error) {
+	return client.AssignPermissionSetsContext(context.Background(), assignments, allOrNone)
+}
+
+// AssignPermissionSetsContext is like AssignPermissionSets, with the requests bound to ctx.
+func (client *Client) AssignPermissionSetsContext(ctx context.Context, assignments []PermissionSetAssignment, allOrNone bool) ([]AssignmentResult, error) {
+	records := make([]*SObject, 0, len(assignments))
+	for _, assignment := range assignments {
+		if (assignment.PermissionSetID == "") == (assignment.PermissionSetGroupID == "") {
+			return nil, errors.New("either a permission set or a permission set group is required")
+		}
+		obj := client.SObject("PermissionSetAssignment").Set("AssigneeId", assignment.AssigneeID)
+		if assignment.PermissionSetID != "" {
+			obj.Set("PermissionSetId", assignment.PermissionSetID)
+		} else {
+			obj.Set("PermissionSetGroupId", assignment.PermissionSetGroupID)
+		}
+		records = append(records, obj)
+	}
+
+	saveResults, err := client.saveCollectionContext(ctx, http.MethodPost, records, allOrNone)
+	if err != nil && !errors.Is(err, ErrRolledBack) {
+		return nil, err
+	}
+	return assignmentResults(saveResults), err
+}
+
+// RemovePermissionSetAssignments deletes assignments by ID, 200 per API call. The results are returned in the order
+// of assignmentIDs.
+func (client *Client) RemovePermissionSetAssignments(assignmentIDs ...string) ([]AssignmentResult, error) {
+	return client.RemovePermissionSetAssignmentsContext(context.Background(), assignmentIDs...)
+}
+
+// RemovePermissionSetAssignmentsContext is like RemovePermissionSetAssignments, with the requests bound to ctx.
+func (client *Client) RemovePermissionSetAssignmentsContext(ctx context.Context, assignmentIDs ...string) ([]AssignmentResult, error) {
+	saveResults, err := client.deleteCollectionContext(ctx, assignmentIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	return assignmentResults(saveResults), nil
+}
+
+// RemovePermissionSet removes a permission set, or a permission set group, from a user. Nothing happens if the user
+// isn't assigned the permission set.
+func (client *Client) RemovePermissionSet(userID, permissionSetOrGroupID string) error {
+	return client.RemovePermissionSetContext(context.Background(), userID, permissionSetOrGroupID)
+}
+
+// RemovePermissionSetContext is like RemovePermissionSet, with the requests bound to ctx.
+func (client *Client) RemovePermissionSetContext(ctx context.Context, userID, permissionSetOrGroupID string) error {
+	assignments, err := client.PermissionSetAssignmentsContext(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, assignment := range assignments {
+		if assignment.PermissionSetID == permissionSetOrGroupID || assignment.PermissionSetGroupID == permissionSetOrGroupID {
+			ids = append(ids, assignment.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	results, err := client.RemovePermissionSetAssignmentsContext(ctx, ids...)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+// assignmentResults converts save results, mapping the common assignment failures to friendly errors.
+func assignmentResults(saveResults []SaveResult) []AssignmentResult {
+	results := make([]AssignmentResult, 0, len(saveResults))
+	for _, saveResult := range saveResults {
+		result := AssignmentResult{ID: saveResult.ID, Err: saveResult.Err()}
+		if result.Err != nil && len(saveResult.Errors) > 0 {
+			first := saveResult.Errors[0]
+			switch {
+			case first.StatusCode == "DUPLICATE_VALUE":
+				result.Err = errors.Wrap(ErrDuplicateAssignment, first.Message)
+			case strings.Contains(strings.ToLower(first.Message), "license"):
+				result.Err = errors.Wrap(ErrLicenseMismatch, first.Message)
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,78 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_AssignPermissionSets(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Records []map[string]interface{} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		json.NewEncoder(w).Encode([]SaveResult{
+			{ID: "0Pa000000000001", Success: true},
+			{Errors: []SaveError{{StatusCode: "DUPLICATE_VALUE", Message: "duplicate value found: <unknown> duplicates value on record with id: <unknown>"}}},
+			{Errors: []SaveError{{StatusCode: "FIELD_INTEGRITY_EXCEPTION", Message: "The user license doesn't match the user license of the permission set"}}},
+		})
+		if len(req.Records) != 3 || req.Records[2]["PermissionSetGroupId"] != "0PG000000000001" {
+			t.Errorf("unexpected records %v", req.Records)
+		}
+	})
+
+	results, err := client.AssignPermissionSets([]PermissionSetAssignment{
+		{AssigneeID: "005000000000001", PermissionSetID: "0PS000000000001"},
+		{AssigneeID: "005000000000002", PermissionSetID: "0PS000000000001"},
+		{AssigneeID: "005000000000003", PermissionSetGroupID: "0PG000000000001"},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].ID != "0Pa000000000001" || results[0].Err != nil {
+		t.Errorf("unexpected result %v", results[0])
+	}
+	if !errors.Is(results[1].Err, ErrDuplicateAssignment) || !errors.Is(results[2].Err, ErrLicenseMismatch) {
+		t.Errorf("unexpected errors %v, %v", results[1].Err, results[2].Err)
+	}
+
+	if _, err = client.AssignPermissionSets([]PermissionSetAssignment{{AssigneeID: "005000000000001"}}, false); err == nil {
+		t.Error("expected error for assignment without permission set")
+	}
+}
+
+func TestClient_AssignPermissionSetMissingResult(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	})
+
+	if _, err := client.AssignPermissionSet("005000000000001", "0PS000000000001"); err == nil {
+		t.Error("expected error for a response without results")
+	}
+}
+
+func TestClient_RemovePermissionSet(t *testing.T) {
+	deleted := ""
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"done": true,
+				"records": []map[string]interface{}{
+					{"Id": "0Pa000000000001", "AssigneeId": "005000000000001", "PermissionSetId": "0PS000000000001"},
+					{"Id": "0Pa000000000002", "AssigneeId": "005000000000001", "PermissionSetGroupId": "0PG000000000001"},
+				},
+			})
+		case http.MethodDelete:
+			deleted = r.URL.Query().Get("ids")
+			json.NewEncoder(w).Encode([]SaveResult{{ID: deleted, Success: true}})
+		}
+	})
+
+	if err := client.RemovePermissionSet("005000000000001", "0PG000000000001"); err != nil || deleted != "0Pa000000000002" {
+		t.Errorf("unexpected removal %s, %v", deleted, err)
+	}
+}
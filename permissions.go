@@ -0,0 +1,223 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// permissionCacheTTL is how long the effective permissions of a user are cached.
+const permissionCacheTTL = 10 * time.Minute
+
+// ObjectAccess is the access a user has on an object, combined over all of their permission sets and profile.
+type ObjectAccess struct {
+	Read      bool
+	Create    bool
+	Edit      bool
+	Delete    bool
+	ViewAll   bool
+	ModifyAll bool
+}
+
+// FieldAccess is the access a user has on a field, combined over all of their permission sets and profile.
+type FieldAccess struct {
+	Read bool
+	Edit bool
+}
+
+// UserPermissions holds the effective object and field permissions of a user.
+type UserPermissions struct {
+	UserID    string
+	fetchedAt time.Time
+	objects   map[string]ObjectAccess
+	fields    map[string]FieldAccess
+}
+
+// Object returns the access on object, e.g. "Account".
+func (p *UserPermissions) Object(object string) ObjectAccess {
+	return p.objects[strings.ToLower(object)]
+}
+
+// Field returns the access on field of object, e.g. ("Account", "Industry"), as granted by FieldPermissions.
+// Salesforce deletes the FieldPermissions of a field once its access is revoked, so a field without them has no
+// access. Fields that aren't permissionable, such as required fields, have no FieldPermissions either: CanReadField
+// and CanEditField tell them apart through the describe metadata of the object.
+func (p *UserPermissions) Field(object, field string) FieldAccess {
+	return p.fields[strings.ToLower(object+"."+field)]
+}
+
+// hasField reports whether FieldPermissions were found for field of object.
+func (p *UserPermissions) hasField(object, field string) bool {
+	_, ok := p.fields[strings.ToLower(object+"."+field)]
+	return ok
+}
+
+// permissionCache caches UserPermissions by user ID.
+type permissionCache struct {
+	mu    sync.Mutex
+	users map[string]*UserPermissions
+}
+
+// UserPermissions returns the effective permissions of a user, combining the ObjectPermissions and FieldPermissions
+// of every permission set assigned to the user, including the one owned by the profile. Results are cached for
+// ten minutes; see ResetPermissionCache.
+func (client *Client) UserPermissions(userID string) (*UserPermissions, error) {
+	cache := client.permissionCache
+	if cache != nil {
+		cache.mu.Lock()
+		cached := cache.users[userID]
+		cache.mu.Unlock()
+		if cached != nil && time.Since(cached.fetchedAt) < permissionCacheTTL {
+			return cached, nil
+		}
+	}
+
+	assigned := Raw("ParentId IN (SELECT PermissionSetId FROM PermissionSetAssignment WHERE AssigneeId = ?)", userID)
+	perms := &UserPermissions{
+		UserID:    userID,
+		fetchedAt: time.Now(),
+		objects:   map[string]ObjectAccess{},
+		fields:    map[string]FieldAccess{},
+	}
+
+	q, err := Select("SobjectType", "PermissionsRead", "PermissionsCreate", "PermissionsEdit", "PermissionsDelete",
+		"PermissionsViewAllRecords", "PermissionsModifyAllRecords").From("ObjectPermissions").Where(assigned).Build()
+	if err != nil {
+		return nil, err
+	}
+	err = client.queryEach(q, func(records json.RawMessage) error {
+		var page []struct {
+			SobjectType                 string
+			PermissionsRead             bool
+			PermissionsCreate           bool
+			PermissionsEdit             bool
+			PermissionsDelete           bool
+			PermissionsViewAllRecords   bool
+			PermissionsModifyAllRecords bool
+		}
+		err := json.Unmarshal(records, &page)
+		for _, row := range page {
+			key := strings.ToLower(row.SobjectType)
+			access := perms.objects[key]
+			access.Read = access.Read || row.PermissionsRead
+			access.Create = access.Create || row.PermissionsCreate
+			access.Edit = access.Edit || row.PermissionsEdit
+			access.Delete = access.Delete || row.PermissionsDelete
+			access.ViewAll = access.ViewAll || row.PermissionsViewAllRecords
+			access.ModifyAll = access.ModifyAll || row.PermissionsModifyAllRecords
+			perms.objects[key] = access
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q, err = Select("Field", "PermissionsRead", "PermissionsEdit").From("FieldPermissions").Where(assigned).Build()
+	if err != nil {
+		return nil, err
+	}
+	err = client.queryEach(q, func(records json.RawMessage) error {
+		var page []struct {
+			Field           string
+			PermissionsRead bool
+			PermissionsEdit bool
+		}
+		err := json.Unmarshal(records, &page)
+		for _, row := range page {
+			key := strings.ToLower(row.Field)
+			access := perms.fields[key]
+			access.Read = access.Read || row.PermissionsRead
+			access.Edit = access.Edit || row.PermissionsEdit
+			perms.fields[key] = access
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		cache.mu.Lock()
+		cache.users[userID] = perms
+		cache.mu.Unlock()
+	}
+	return perms, nil
+}
+
+// ResetPermissionCache drops the cached permissions of the provided users, or of all users if none is provided.
+func (client *Client) ResetPermissionCache(userIDs ...string) {
+	cache := client.permissionCache
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if len(userIDs) == 0 {
+		cache.users = map[string]*UserPermissions{}
+		return
+	}
+	for _, userID := range userIDs {
+		delete(cache.users, userID)
+	}
+}
+
+// CanReadObject reports whether the user can read records of object.
+func (client *Client) CanReadObject(userID, object string) (bool, error) {
+	perms, err := client.UserPermissions(userID)
+	if err != nil {
+		return false, err
+	}
+	return perms.Object(object).Read, nil
+}
+
+// CanEditObject reports whether the user can edit records of object.
+func (client *Client) CanEditObject(userID, object string) (bool, error) {
+	perms, err := client.UserPermissions(userID)
+	if err != nil {
+		return false, err
+	}
+	return perms.Object(object).Edit, nil
+}
+
+// CanReadField reports whether the user can read field of object.
+func (client *Client) CanReadField(userID, object, field string) (bool, error) {
+	objectAccess, access, err := client.fieldAccess(userID, object, field, func(access ObjectAccess) bool { return access.Read })
+	if err != nil {
+		return false, err
+	}
+	return objectAccess.Read && access.Read, nil
+}
+
+// CanEditField reports whether the user can edit field of object.
+func (client *Client) CanEditField(userID, object, field string) (bool, error) {
+	objectAccess, access, err := client.fieldAccess(userID, object, field, func(access ObjectAccess) bool { return access.Edit })
+	if err != nil {
+		return false, err
+	}
+	return objectAccess.Edit && access.Edit, nil
+}
+
+// fieldAccess returns the access of the user on object and on field of it. A field without FieldPermissions follows
+// the access on the object if the describe metadata marks it as not permissionable; it has no access otherwise. The
+// object is only described if needed, i.e. if allowed reports the object access is enough.
+func (client *Client) fieldAccess(userID, object, field string, allowed func(ObjectAccess) bool) (ObjectAccess, FieldAccess, error) {
+	perms, err := client.UserPermissions(userID)
+	if err != nil {
+		return ObjectAccess{}, FieldAccess{}, err
+	}
+	objectAccess := perms.Object(object)
+	if perms.hasField(object, field) || !allowed(objectAccess) {
+		return objectAccess, perms.Field(object, field), nil
+	}
+
+	meta, err := client.DescribeSObject(object)
+	if err != nil {
+		return ObjectAccess{}, FieldAccess{}, err
+	}
+	if describeField := meta.Field(field); describeField != nil && !describeField.Permissionable {
+		return objectAccess, FieldAccess{Read: objectAccess.Read, Edit: objectAccess.Edit}, nil
+	}
+	return objectAccess, FieldAccess{}, nil
+}
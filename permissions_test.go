@@ -0,0 +1,78 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_UserPermissions(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/describe") {
+			if r.URL.Path != "/services/data/v54.0/sobjects/Account/describe" {
+				t.Errorf("unexpected describe %s", r.URL.Path)
+			}
+			w.Write([]byte(`{"name":"Account","fields":[{"name":"Name","permissionable":false},{"name":"Rating","permissionable":true}]}`))
+			return
+		}
+		calls++
+		q := r.URL.Query().Get("q")
+		if !strings.Contains(q, "WHERE ParentId IN (SELECT PermissionSetId FROM PermissionSetAssignment WHERE AssigneeId = '005000000000001')") {
+			t.Errorf("unexpected query %s", q)
+		}
+		var records []map[string]interface{}
+		if strings.Contains(q, "FROM ObjectPermissions") {
+			records = []map[string]interface{}{
+				{"SobjectType": "Account", "PermissionsRead": true},
+				{"SobjectType": "Account", "PermissionsRead": true, "PermissionsEdit": true},
+				{"SobjectType": "Case", "PermissionsRead": true},
+			}
+		} else {
+			records = []map[string]interface{}{
+				{"Field": "Account.Industry", "PermissionsRead": true, "PermissionsEdit": true},
+				{"Field": "Case.Subject", "PermissionsRead": true, "PermissionsEdit": true},
+				{"Field": "Account.AnnualRevenue", "PermissionsRead": false, "PermissionsEdit": false},
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"done": true, "records": records})
+	})
+
+	checks := []struct {
+		check    func() (bool, error)
+		expected bool
+	}{
+		{func() (bool, error) { return client.CanEditObject("005000000000001", "account") }, true},
+		{func() (bool, error) { return client.CanEditObject("005000000000001", "Case") }, false},
+		{func() (bool, error) { return client.CanReadObject("005000000000001", "Case") }, true},
+		{func() (bool, error) { return client.CanReadObject("005000000000001", "Lead") }, false},
+		{func() (bool, error) { return client.CanEditField("005000000000001", "Account", "Industry") }, true},
+		{func() (bool, error) { return client.CanEditField("005000000000001", "Case", "Subject") }, false},
+		{func() (bool, error) { return client.CanReadField("005000000000001", "Case", "Subject") }, true},
+		{func() (bool, error) { return client.CanReadField("005000000000001", "Account", "AnnualRevenue") }, false},
+		// Fields that aren't permissionable, such as required fields, follow the access on the object.
+		{func() (bool, error) { return client.CanReadField("005000000000001", "Account", "Name") }, true},
+		{func() (bool, error) { return client.CanEditField("005000000000001", "Account", "Name") }, true},
+		{func() (bool, error) { return client.CanEditField("005000000000001", "Case", "CaseNumber") }, false},
+		{func() (bool, error) { return client.CanReadField("005000000000001", "Lead", "LastName") }, false},
+		// Permissionable fields without FieldPermissions have had their access revoked.
+		{func() (bool, error) { return client.CanReadField("005000000000001", "Account", "Rating") }, false},
+		{func() (bool, error) { return client.CanEditField("005000000000001", "Account", "Rating") }, false},
+	}
+	for i, c := range checks {
+		allowed, err := c.check()
+		if err != nil || allowed != c.expected {
+			t.Errorf("check %d: expected %v, got %v, %v", i, c.expected, allowed, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected permissions to be cached, got %d calls", calls)
+	}
+
+	client.ResetPermissionCache("005000000000001")
+	client.CanReadObject("005000000000001", "Case")
+	if calls != 4 {
+		t.Errorf("expected permissions to be fetched again, got %d calls", calls)
+	}
+}
@@ -0,0 +1,205 @@
+package simpleforce
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// PersonalDataSource describes where the personal data of a data subject (a Contact, Lead or User) is stored.
+type PersonalDataSource struct {
+	// Object is the API name of the object holding the data, e.g. "Case".
+	Object string
+	// LookupField is the field of Object pointing to the data subject, e.g. "ContactId", or "Id" for the record of the
+	// data subject itself.
+	LookupField string
+	// Fields are exported for right-to-access requests.
+	Fields []string
+	// PIIFields are anonymized for right-to-be-forgotten requests.
+	PIIFields []string
+}
+
+// defaultPersonalDataSources are the personal data sources of data subjects by object.
+var defaultPersonalDataSources = map[string]PersonalDataSource{
+	"Contact": {
+		Object:      "Contact",
+		LookupField: "Id",
+		Fields:      []string{"Id", "FirstName", "LastName", "Email", "Phone", "MobilePhone", "MailingStreet", "MailingCity", "MailingPostalCode", "MailingCountry", "Birthdate"},
+		PIIFields:   []string{"FirstName", "LastName", "Email", "Phone", "MobilePhone", "MailingStreet", "MailingCity", "MailingPostalCode", "Birthdate"},
+	},
+	"Lead": {
+		Object:      "Lead",
+		LookupField: "Id",
+		Fields:      []string{"Id", "FirstName", "LastName", "Email", "Phone", "MobilePhone", "Company", "Street", "City", "PostalCode", "Country"},
+		PIIFields:   []string{"FirstName", "LastName", "Email", "Phone", "MobilePhone", "Street", "City", "PostalCode"},
+	},
+	"User": {
+		Object:      "User",
+		LookupField: "Id",
+		Fields:      []string{"Id", "Username", "FirstName", "LastName", "Email", "Phone", "MobilePhone", "Street", "City", "PostalCode", "Country"},
+		PIIFields:   []string{"FirstName", "LastName", "Phone", "MobilePhone", "Street", "City", "PostalCode"},
+	},
+}
+
+// subjectKeyPrefixes maps the key prefixes of IDs to the objects of data subjects.
+var subjectKeyPrefixes = map[string]string{
+	"003": "Contact",
+	"00Q": "Lead",
+	"005": "User",
+}
+
+// DefaultPersonalDataSources returns the standard personal data fields of the record of a data subject, based on the
+// key prefix of subjectID. Sources for related objects are specific to each org and must be added by the caller.
+func DefaultPersonalDataSources(subjectID string) ([]PersonalDataSource, error) {
+	if len(subjectID) < 3 {
+		return nil, errors.New("invalid data subject id: " + subjectID)
+	}
+	object, ok := subjectKeyPrefixes[subjectID[:3]]
+	if !ok {
+		return nil, errors.New("data subject must be a Contact, Lead or User: " + subjectID)
+	}
+	source := defaultPersonalDataSources[object]
+	return []PersonalDataSource{source}, nil
+}
+
+// PersonalDataExport holds the personal data of a data subject, by object.
+type PersonalDataExport struct {
+	SubjectID string               `json:"subjectId"`
+	Records   map[string][]SObject `json:"records"`
+}
+
+// WriteJSON writes the export as indented JSON.
+func (export *PersonalDataExport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+// personalDataRecords queries the records of source belonging to the data subject, selecting fields.
+func (client *Client) personalDataRecords(ctx context.Context, subjectID string, source PersonalDataSource, fields []string) ([]SObject, error) {
+	q, err := Select(fields...).From(source.Object).Where(Eq(source.LookupField, subjectID)).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []SObject{}
+	err = client.queryEachContext(ctx, q, func(raw json.RawMessage) error {
+		var page []SObject
+		err := json.Unmarshal(raw, &page)
+		for _, record := range page {
+			delete(record, sobjectAttributesKey)
+		}
+		records = append(records, page...)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", source.Object)
+	}
+	return records, nil
+}
+
+// ExportPersonalData collects the personal data of a data subject across sources, for right-to-access requests.
+func (client *Client) ExportPersonalData(subjectID string, sources []PersonalDataSource) (*PersonalDataExport, error) {
+	return client.ExportPersonalDataContext(context.Background(), subjectID, sources)
+}
+
+// ExportPersonalDataContext is like ExportPersonalData, with the requests bound to ctx.
+func (client *Client) ExportPersonalDataContext(ctx context.Context, subjectID string, sources []PersonalDataSource) (*PersonalDataExport, error) {
+	export := &PersonalDataExport{SubjectID: subjectID, Records: map[string][]SObject{}}
+	for _, source := range sources {
+		if len(source.Fields) == 0 {
+			continue
+		}
+		records, err := client.personalDataRecords(ctx, subjectID, source, source.Fields)
+		if err != nil {
+			return nil, err
+		}
+		export.Records[source.Object] = append(export.Records[source.Object], records...)
+	}
+	return export, nil
+}
+
+// Anonymizer returns the anonymized value of a field.
+type Anonymizer func(object, field string, value interface{}) interface{}
+
+// scrambledDateLayouts are the layouts of the date, datetime and time values of records, which are cleared by
+// ScrambleAnonymizer as no scrambled text would be a valid value.
+var scrambledDateLayouts = []string{"2006-01-02", "2006-01-02T15:04:05.000-0700", "2006-01-02T15:04:05.000Z", "15:04:05.000Z"}
+
+// ScrambleAnonymizer returns an Anonymizer replacing text values by an HMAC of their value keyed by secret, keeping
+// e-mail addresses valid, and clearing the other values, e.g. dates and numbers. The same value is always scrambled
+// the same way for a secret, which keeps anonymized records distinguishable; without the secret, scrambled values
+// can't be reversed by hashing candidate values, even for phone numbers or postal codes.
+func ScrambleAnonymizer(secret []byte) Anonymizer {
+	return func(object, field string, value interface{}) interface{} {
+		s, ok := value.(string)
+		if !ok || s == "" {
+			return nil
+		}
+		for _, layout := range scrambledDateLayouts {
+			if _, err := time.Parse(layout, s); err == nil {
+				return nil
+			}
+		}
+
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(s))
+		scrambled := "anon-" + hex.EncodeToString(mac.Sum(nil)[:8])
+		if strings.Contains(s, "@") {
+			return scrambled + "@anonymized.invalid"
+		}
+		return scrambled
+	}
+}
+
+// AnonymizePersonalData overwrites the PII fields of the records of a data subject across sources with the values
+// returned by anonymize, for right-to-be-forgotten requests. If anonymize is nil, a ScrambleAnonymizer with a random
+// secret is used, which scrambles values differently from one call to the next. Records are updated in batches and
+// the result of every update is returned; a record failing to update doesn't prevent the others from being
+// anonymized.
+func (client *Client) AnonymizePersonalData(subjectID string, sources []PersonalDataSource, anonymize Anonymizer) ([]SaveResult, error) {
+	return client.AnonymizePersonalDataContext(context.Background(), subjectID, sources, anonymize)
+}
+
+// AnonymizePersonalDataContext is like AnonymizePersonalData, with the requests bound to ctx.
+func (client *Client) AnonymizePersonalDataContext(ctx context.Context, subjectID string, sources []PersonalDataSource, anonymize Anonymizer) ([]SaveResult, error) {
+	if anonymize == nil {
+		secret := make([]byte, sha256.Size)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		anonymize = ScrambleAnonymizer(secret)
+	}
+
+	var updates []*SObject
+	for _, source := range sources {
+		if len(source.PIIFields) == 0 {
+			continue
+		}
+		records, err := client.personalDataRecords(ctx, subjectID, source, append([]string{sobjectIDKey}, source.PIIFields...))
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			update := client.SObject(source.Object)
+			update.setID(record.ID())
+			for _, field := range source.PIIFields {
+				update.Set(field, anonymize(source.Object, field, record[field]))
+			}
+			updates = append(updates, update)
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return client.saveCollectionContext(ctx, http.MethodPatch, updates, false)
+}
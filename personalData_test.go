@@ -0,0 +1,115 @@
+package simpleforce
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var contactSources = []PersonalDataSource{
+	{Object: "Contact", LookupField: "Id", Fields: []string{"Id", "Email"}, PIIFields: []string{"Email", "Birthdate"}},
+	{Object: "Case", LookupField: "ContactId", Fields: []string{"Id", "SuppliedPhone"}, PIIFields: []string{"SuppliedPhone"}},
+}
+
+func requirePersonalDataClient(t *testing.T, updates *[]map[string]interface{}) *Client {
+	return requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			var body struct {
+				Records []map[string]interface{} `json:"records"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			*updates = append(*updates, body.Records...)
+			w.Write([]byte(`[{"id":"003000000000001","success":true},{"id":"500000000000001","success":true}]`))
+			return
+		}
+
+		q := r.URL.Query().Get("q")
+		var records []map[string]interface{}
+		switch {
+		case strings.Contains(q, "FROM Contact WHERE Id = '003000000000001'"):
+			records = []map[string]interface{}{{"Id": "003000000000001", "Email": "jdoe@example.com", "Birthdate": "1980-01-01"}}
+		case strings.Contains(q, "FROM Case WHERE ContactId = '003000000000001'"):
+			records = []map[string]interface{}{{"Id": "500000000000001", "SuppliedPhone": "555-0100"}}
+		default:
+			t.Errorf("unexpected query %s", q)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"done": true, "records": records})
+	})
+}
+
+func TestClient_ExportPersonalData(t *testing.T) {
+	client := requirePersonalDataClient(t, nil)
+
+	export, err := client.ExportPersonalData("003000000000001", contactSources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.Records["Contact"]) != 1 || len(export.Records["Case"]) != 1 {
+		t.Fatalf("unexpected export %v", export.Records)
+	}
+
+	var buf bytes.Buffer
+	err = export.WriteJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"Email": "jdoe@example.com"`) || strings.Contains(buf.String(), "__client__") {
+		t.Errorf("unexpected json %s", buf.String())
+	}
+}
+
+func TestClient_AnonymizePersonalData(t *testing.T) {
+	var updates []map[string]interface{}
+	client := requirePersonalDataClient(t, &updates)
+
+	results, err := client.AnonymizePersonalData("003000000000001", contactSources, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || len(updates) != 2 {
+		t.Fatalf("unexpected results %v, updates %v", results, updates)
+	}
+	contact := updates[0]
+	email, _ := contact["Email"].(string)
+	if contact["Id"] != "003000000000001" || !strings.HasSuffix(email, "@anonymized.invalid") || strings.Contains(email, "jdoe") {
+		t.Errorf("unexpected contact update %v", contact)
+	}
+	if birthdate, ok := contact["Birthdate"]; !ok || birthdate != nil {
+		t.Errorf("expected the birthdate to be cleared, got %v", contact)
+	}
+	if updates[1]["SuppliedPhone"] == "555-0100" {
+		t.Errorf("expected case phone to be anonymized, got %v", updates[1])
+	}
+}
+
+func TestDefaultPersonalDataSources(t *testing.T) {
+	sources, err := DefaultPersonalDataSources("00Q000000000001")
+	if err != nil || len(sources) != 1 || sources[0].Object != "Lead" {
+		t.Errorf("unexpected sources %v, %v", sources, err)
+	}
+	_, err = DefaultPersonalDataSources("001000000000001")
+	if err == nil {
+		t.Error("expected accounts to be rejected")
+	}
+}
+
+func TestScrambleAnonymizer(t *testing.T) {
+	scramble := ScrambleAnonymizer([]byte("secret"))
+	if scramble("Contact", "Email", "a@b.c") != scramble("Lead", "Email", "a@b.c") {
+		t.Error("expected scrambling to be deterministic")
+	}
+	if scramble("Contact", "Phone", "555-0100") == ScrambleAnonymizer([]byte("other"))("Contact", "Phone", "555-0100") {
+		t.Error("expected scrambling to depend on the secret")
+	}
+	if email, _ := scramble("Contact", "Email", "jdoe@example.com").(string); !strings.HasSuffix(email, "@anonymized.invalid") ||
+		strings.Contains(email, "jdoe") {
+		t.Errorf("unexpected email %v", email)
+	}
+	for _, value := range []interface{}{"1980-01-01", "2022-05-02T10:00:00.000+0000", 42.0, true, "", nil} {
+		if scrambled := scramble("Contact", "Birthdate", value); scrambled != nil {
+			t.Errorf("expected %v to be cleared, got %v", value, scrambled)
+		}
+	}
+}
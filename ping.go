@@ -0,0 +1,63 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Health describes the outcome of a Ping.
+type Health struct {
+	// Latency is the round trip time of the request.
+	Latency time.Duration
+	// SessionValid is false if the session expired or was revoked and the client must log in again.
+	SessionValid bool
+	// APIRequestsMax and APIRequestsRemaining are the daily API request allocation of the org and what is left of it.
+	APIRequestsMax       int
+	APIRequestsRemaining int
+}
+
+// Ping checks that Salesforce can be reached with the session of the client through the cheap "limits" resource,
+// which also reports the API usage of the org. The returned Health is filled as far as the check went,
+// even when an error is returned, so that readiness probes can report it.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_limits.htm
+func (client *Client) Ping(ctx context.Context) (*Health, error) {
+	health := &Health{}
+	if !client.isLoggedIn() {
+		return health, ErrAuthentication
+	}
+
+	u := client.makeURL("limits")
+	start := time.Now()
+	resp, err := client.httpResponseContext(ctx, http.MethodGet, u, nil, nil)
+	health.Latency = time.Since(start)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		var sfErr SalesforceError
+		if errors.As(err, &sfErr) && sfErr.HttpCode == http.StatusUnauthorized {
+			return health, errors.Wrap(ErrAuthentication, sfErr.Error())
+		}
+		// Any other answer from Salesforce means that the session went through authentication.
+		health.SessionValid = errors.As(err, &sfErr)
+		return health, err
+	}
+	defer resp.Body.Close()
+	health.SessionValid = true
+
+	var limits struct {
+		DailyAPIRequests struct {
+			Max       int `json:"Max"`
+			Remaining int `json:"Remaining"`
+		} `json:"DailyApiRequests"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&limits)
+	if err != nil {
+		return health, err
+	}
+	health.APIRequestsMax = limits.DailyAPIRequests.Max
+	health.APIRequestsRemaining = limits.DailyAPIRequests.Remaining
+	return health, nil
+}
@@ -0,0 +1,52 @@
+package simpleforce
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_Ping(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/limits" || r.Header.Get("Authorization") != "Bearer __SESSION_ID__" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		w.Write([]byte(`{"DailyApiRequests":{"Max":15000,"Remaining":14998}}`))
+	})
+
+	health, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !health.SessionValid || health.APIRequestsMax != 15000 || health.APIRequestsRemaining != 14998 || health.Latency <= 0 {
+		t.Errorf("unexpected health %+v", health)
+	}
+}
+
+func TestClient_PingInvalidSession(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`[{"errorCode":"INVALID_SESSION_ID","message":"Session expired or invalid"}]`))
+	})
+
+	health, err := client.Ping(context.Background())
+	if !errors.Is(err, ErrAuthentication) || health.SessionValid {
+		t.Errorf("expected invalid session, got %+v, %v", health, err)
+	}
+}
+
+func TestClient_PingContext(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Ping(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline to be exceeded, got %v", err)
+	}
+}
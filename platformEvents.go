@@ -0,0 +1,129 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// platformEventPrefix is the prefix of the channels of platform events.
+const platformEventPrefix = "/event/"
+
+// PublishEvent publishes a platform event of type eventType, e.g. "Order_Shipped__e", with the values of its fields,
+// and returns the ID of the event. Subscribers receive it once the transaction of the publication succeeded, or
+// immediately, depending on the publish behavior of the event. Several events are published at once with
+// CreateMultiple.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.platform_events.meta/platform_events/platform_events_publish_api.htm
+func (client *Client) PublishEvent(eventType string, fields map[string]interface{}) (string, error) {
+	return client.PublishEventContext(context.Background(), eventType, fields)
+}
+
+// PublishEventContext is like PublishEvent, with the request bound to ctx.
+func (client *Client) PublishEventContext(ctx context.Context, eventType string, fields map[string]interface{}) (string, error) {
+	if !client.isLoggedIn() {
+		return "", ErrAuthentication
+	}
+
+	reqData, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	u := client.makeURL("sobjects/" + eventType + "/")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return "", err
+	}
+
+	var result SaveResult
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return "", err
+	}
+	return result.ID, result.Err()
+}
+
+// ReplayStore persists the replay ID of the last event processed on every channel, so that subscriptions resume where
+// they stopped after a restart.
+type ReplayStore interface {
+	// Load returns the replay ID saved for channel, and false if none was saved.
+	Load(ctx context.Context, channel string) (replayID int64, ok bool, err error)
+	Save(ctx context.Context, channel string, replayID int64) error
+}
+
+// MemoryReplayStore keeps replay IDs in memory, for tests and long running processes.
+type MemoryReplayStore struct {
+	mu        sync.Mutex
+	replayIDs map[string]int64
+}
+
+// Load implements ReplayStore.
+func (s *MemoryReplayStore) Load(ctx context.Context, channel string) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	replayID, ok := s.replayIDs[channel]
+	return replayID, ok, nil
+}
+
+// Save implements ReplayStore.
+func (s *MemoryReplayStore) Save(ctx context.Context, channel string, replayID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.replayIDs == nil {
+		s.replayIDs = map[string]int64{}
+	}
+	s.replayIDs[channel] = replayID
+	return nil
+}
+
+// SetReplayStore makes the streaming client checkpoint the replay ID of every event once its handler returned, and
+// resume the channels subscribed to afterwards from the replay IDs saved in store. Failures to save replay IDs are
+// logged, the events being delivered again after a restart in the worst case, so handlers should be idempotent.
+func (s *StreamingClient) SetReplayStore(store ReplayStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store = store
+}
+
+// SubscribeEvent subscribes to the platform events of type eventType, e.g. "Order_Shipped__e", like Subscribe.
+func (s *StreamingClient) SubscribeEvent(eventType string, handler EventHandler) error {
+	return s.Subscribe(platformEventPrefix+eventType, handler)
+}
+
+// loadReplayID returns the replay ID saved for channel, or replayID if the streaming client has no replay store or
+// nothing was saved.
+func (s *StreamingClient) loadReplayID(ctx context.Context, channel string, replayID int64) (int64, error) {
+	s.mu.Lock()
+	store := s.store
+	s.mu.Unlock()
+	if store == nil {
+		return replayID, nil
+	}
+
+	saved, ok, err := store.Load(ctx, channel)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return replayID, nil
+	}
+	return saved, nil
+}
+
+// saveReplayID checkpoints the replay ID of an event processed on channel.
+func (s *StreamingClient) saveReplayID(channel string, replayID int64) {
+	s.mu.Lock()
+	store := s.store
+	s.mu.Unlock()
+	if store == nil || replayID == 0 {
+		return
+	}
+
+	err := store.Save(context.Background(), channel, replayID)
+	if err != nil {
+		s.client.logError("failed to save replay ID of", channel+",", err)
+	}
+}
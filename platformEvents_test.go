@@ -0,0 +1,93 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_PublishEvent(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.URL.Path != "/services/data/v54.0/sobjects/Order_Shipped__e/" ||
+			!strings.HasPrefix(string(body), `{"Order_Number__c":`) {
+			t.Errorf("unexpected request %s %s %s", r.Method, r.URL.Path, body)
+		}
+		if strings.Contains(string(body), "1043") {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"","success":false,"errors":[{"statusCode":"LIMIT_EXCEEDED","message":"too many events"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"e00xx0000000001AAA","success":true,"errors":[]}`))
+	})
+
+	id, err := client.PublishEvent("Order_Shipped__e", map[string]interface{}{"Order_Number__c": "1042"})
+	if err != nil || id != "e00xx0000000001AAA" {
+		t.Errorf("unexpected result %s, %v", id, err)
+	}
+	_, err = client.PublishEvent("Order_Shipped__e", map[string]interface{}{"Order_Number__c": "1043"})
+	var sfErr SalesforceError
+	if !errors.As(err, &sfErr) || sfErr.ErrorCode != "LIMIT_EXCEEDED" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestStreamingClient_ReplayStore(t *testing.T) {
+	var subscribeExt string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var messages []map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&messages)
+		switch messages[0]["channel"] {
+		case "/meta/handshake":
+			w.Write([]byte(`[{"channel":"/meta/handshake","clientId":"client1","successful":true}]`))
+		case "/meta/subscribe":
+			ext, _ := json.Marshal(messages[0]["ext"])
+			subscribeExt = string(ext)
+			w.Write([]byte(`[{"channel":"/meta/subscribe","subscription":"/event/Order_Shipped__e","successful":true}]`))
+		case "/meta/connect":
+			w.Write([]byte(`[
+				{"channel":"/event/Order_Shipped__e","data":{"schema":"abc","payload":{"Order_Number__c":"1042","CreatedDate":"2022-04-29T10:35:00.000Z"},"event":{"replayId":43}}},
+				{"channel":"/meta/connect","successful":true}
+			]`))
+		default:
+			w.Write([]byte(`[{"channel":"/meta/disconnect","successful":true}]`))
+		}
+	})
+
+	store := &MemoryReplayStore{}
+	store.Save(context.Background(), "/event/Order_Shipped__e", 42)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var payload struct {
+		OrderNumber string `json:"Order_Number__c"`
+	}
+	streaming := client.NewStreamingClient()
+	streaming.SetReplayStore(store)
+	err := streaming.SubscribeEvent("Order_Shipped__e", func(event Event) {
+		json.Unmarshal(event.Payload, &payload)
+		cancel()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = streaming.Run(ctx); err != context.Canceled {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if subscribeExt != `{"replay":{"/event/Order_Shipped__e":42}}` {
+		t.Errorf("unexpected subscribe extension %s", subscribeExt)
+	}
+	if payload.OrderNumber != "1042" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+	if replayID, ok, _ := store.Load(context.Background(), "/event/Order_Shipped__e"); !ok || replayID != 43 {
+		t.Errorf("unexpected saved replay ID %d", replayID)
+	}
+}
@@ -0,0 +1,35 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// QueryAll runs an SOQL query like Query, including the records which were deleted, e.g. soft deleted Tasks in the
+// Recycle Bin, or archived, e.g. Events and Tasks older than a year. Deleted records have IsDeleted set. The following
+// pages are retrieved with QueryMore or Next, as with Query.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_queryall.htm
+func (client *Client) QueryAll(q string) (*QueryResult, error) {
+	return client.QueryAllContext(context.Background(), q)
+}
+
+// queryAllEachContext is like queryEachContext, including deleted and archived records, see QueryAll.
+func (client *Client) queryAllEachContext(ctx context.Context, q string, fn func(records json.RawMessage) error) error {
+	if !client.isLoggedIn() {
+		return ErrAuthentication
+	}
+	return client.queryPagesContext(ctx, client.makeURL("queryAll?q="+url.QueryEscape(q)), fn)
+}
+
+// QueryAllContext is like QueryAll, with the request bound to ctx.
+func (client *Client) QueryAllContext(ctx context.Context, q string) (*QueryResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	if strings.HasPrefix(q, "/services/data") {
+		return client.queryPage(ctx, client.queryURL(q))
+	}
+	return client.queryPage(ctx, client.makeURL("queryAll?q="+url.QueryEscape(q)))
+}
@@ -0,0 +1,34 @@
+package simpleforce
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClient_QueryAll(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v54.0/queryAll":
+			if q := r.URL.Query().Get("q"); q != "SELECT Id, IsDeleted FROM Task" {
+				t.Errorf("unexpected query %s", q)
+			}
+			w.Write([]byte(`{"totalSize":2,"done":false,"nextRecordsUrl":"/services/data/v54.0/query/01gD0000002HU6KIAW-1","records":[{"attributes":{"type":"Task"},"Id":"00T000000000001AAA","IsDeleted":true}]}`))
+		case "/services/data/v54.0/query/01gD0000002HU6KIAW-1":
+			w.Write([]byte(`{"totalSize":2,"done":true,"records":[{"attributes":{"type":"Task"},"Id":"00T000000000002AAA","IsDeleted":false}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	result, err := client.QueryAll("SELECT Id, IsDeleted FROM Task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Records) != 1 || result.Records[0].InterfaceField("IsDeleted") != true || result.Records[0].client() != client {
+		t.Errorf("unexpected records %v", result.Records)
+	}
+	next, err := result.Next(client)
+	if err != nil || len(next.Records) != 1 || !next.Done || next.Records[0].ID() != "00T000000000002AAA" {
+		t.Errorf("unexpected next page %+v, %v", next, err)
+	}
+}
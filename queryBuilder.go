@@ -0,0 +1,318 @@
+package simpleforce
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	soqlDateTimeFormat = "2006-01-02T15:04:05Z"
+
+	// MaxQueryOffset is the largest OFFSET Salesforce accepts in a SOQL query.
+	MaxQueryOffset = 2000
+)
+
+var (
+	// ErrInvalidQuery is returned when a query can't be rendered from the provided builder or parameters.
+	ErrInvalidQuery = errors.New("invalid query")
+
+	soqlEscaper = strings.NewReplacer(
+		`\`, `\\`,
+		`'`, `\'`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+		"\b", `\b`,
+		"\f", `\f`,
+	)
+)
+
+// Condition is a filter expression usable in the WHERE clause of a QueryBuilder.
+type Condition interface {
+	// render renders the condition. fields maps lower-cased field names to their describe type and is nil when no
+	// describe metadata is available.
+	render(fields fieldTypes) (string, error)
+}
+
+type rawCondition struct {
+	clause string
+	args   []interface{}
+}
+
+func (c rawCondition) render(fieldTypes) (string, error) {
+	return FormatSOQL(c.clause, c.args...)
+}
+
+// Raw creates a Condition from a SOQL fragment. Each "?" placeholder in clause is replaced by the properly quoted and
+// escaped value of the corresponding argument, e.g. Raw("CreatedDate > ? AND Subject = ?", LastNDays(7), subject).
+func Raw(clause string, args ...interface{}) Condition {
+	return rawCondition{clause: clause, args: args}
+}
+
+// QueryBuilder composes SOQL queries piece by piece. The zero value is not useful; start with Select.
+type QueryBuilder struct {
+	fields    []string
+	object    string
+	where     []Condition
+	orderBy   []string
+	limit     int
+	offset    int
+	forClause string
+	types     fieldTypes
+}
+
+// Select starts a new query selecting the provided fields. Fields are rendered verbatim, so that they may hold
+// subqueries or functions such as toLabel(Status), and must not come from user input; only the values of conditions
+// are escaped.
+func Select(fields ...string) *QueryBuilder {
+	return &QueryBuilder{fields: fields}
+}
+
+// From sets the object to be queried. Build rejects names which aren't SOQL identifiers.
+func (qb *QueryBuilder) From(object string) *QueryBuilder {
+	qb.object = object
+	return qb
+}
+
+// WithDescribe makes the conditions of the query quote their values according to the field types found in meta, the
+// describe metadata of the queried object. Conditions on fields missing from meta are rejected.
+func (qb *QueryBuilder) WithDescribe(meta *SObjectMeta) *QueryBuilder {
+	qb.types = newFieldTypes(meta)
+	return qb
+}
+
+// Where adds a filter to the query. Multiple calls are combined with AND.
+func (qb *QueryBuilder) Where(cond Condition) *QueryBuilder {
+	qb.where = append(qb.where, cond)
+	return qb
+}
+
+// OrderBy appends ORDER BY expressions, e.g. OrderBy("CreatedDate DESC", "Name"). Like fields, they are rendered
+// verbatim.
+func (qb *QueryBuilder) OrderBy(fields ...string) *QueryBuilder {
+	qb.orderBy = append(qb.orderBy, fields...)
+	return qb
+}
+
+// Limit sets the maximum number of records returned. Zero means no limit.
+func (qb *QueryBuilder) Limit(n int) *QueryBuilder {
+	qb.limit = n
+	return qb
+}
+
+// Offset skips the first m records. Salesforce rejects offsets larger than MaxQueryOffset; Build reports an error for
+// such queries while Client.RunQuery transparently falls back to ID-keyset pagination.
+func (qb *QueryBuilder) Offset(m int) *QueryBuilder {
+	qb.offset = m
+	return qb
+}
+
+// ForUpdate locks the returned records for the duration of the transaction. Salesforce doesn't allow ORDER BY in
+// locking queries. Lock contention surfaces as ErrRecordLocked or ErrQueryTimeout, both of which can be retried.
+func (qb *QueryBuilder) ForUpdate() *QueryBuilder {
+	qb.forClause = "FOR UPDATE"
+	return qb
+}
+
+// ForView updates the LastViewedDate of the returned records and adds them to the running user's recent items.
+func (qb *QueryBuilder) ForView() *QueryBuilder {
+	qb.forClause = "FOR VIEW"
+	return qb
+}
+
+// ForReference updates the LastReferencedDate of the returned records and adds them to the running user's recent
+// items.
+func (qb *QueryBuilder) ForReference() *QueryBuilder {
+	qb.forClause = "FOR REFERENCE"
+	return qb
+}
+
+// Build renders the SOQL query, or returns an error if the query is incomplete or a parameter can't be rendered.
+func (qb *QueryBuilder) Build() (string, error) {
+	if len(qb.fields) == 0 || qb.object == "" {
+		return "", errors.Wrap(ErrInvalidQuery, "fields and object are required")
+	}
+	if err := checkIdentifier(qb.object); err != nil {
+		return "", err
+	}
+	if qb.forClause == "FOR UPDATE" && len(qb.orderBy) > 0 {
+		return "", errors.Wrap(ErrInvalidQuery, "ORDER BY can't be used with FOR UPDATE")
+	}
+	if qb.limit < 0 || qb.offset < 0 {
+		return "", errors.Wrap(ErrInvalidQuery, "limit and offset must not be negative")
+	}
+	if qb.offset > MaxQueryOffset {
+		return "", errors.Wrap(ErrInvalidQuery, fmt.Sprintf("offset %d exceeds the maximum of %d", qb.offset, MaxQueryOffset))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	sb.WriteString(strings.Join(qb.fields, ", "))
+	sb.WriteString(" FROM ")
+	sb.WriteString(qb.object)
+
+	if len(qb.where) > 0 {
+		clauses := make([]string, 0, len(qb.where))
+		for _, cond := range qb.where {
+			clause, err := cond.render(qb.types)
+			if err != nil {
+				return "", err
+			}
+			clauses = append(clauses, clause)
+		}
+		sb.WriteString(" WHERE ")
+		if len(clauses) == 1 {
+			sb.WriteString(clauses[0])
+		} else {
+			sb.WriteString("(" + strings.Join(clauses, ") AND (") + ")")
+		}
+	}
+
+	if len(qb.orderBy) > 0 {
+		sb.WriteString(" ORDER BY ")
+		sb.WriteString(strings.Join(qb.orderBy, ", "))
+	}
+
+	if qb.limit > 0 {
+		sb.WriteString(" LIMIT " + strconv.Itoa(qb.limit))
+	}
+	if qb.offset > 0 {
+		sb.WriteString(" OFFSET " + strconv.Itoa(qb.offset))
+	}
+
+	if qb.forClause != "" {
+		sb.WriteString(" ")
+		sb.WriteString(qb.forClause)
+	}
+
+	return sb.String(), nil
+}
+
+// String renders the query, returning an empty string if the query can't be built.
+func (qb *QueryBuilder) String() string {
+	q, err := qb.Build()
+	if err != nil {
+		return ""
+	}
+	return q
+}
+
+// clone returns a copy of qb which can be modified without affecting qb.
+func (qb *QueryBuilder) clone() *QueryBuilder {
+	c := *qb
+	c.fields = append([]string{}, qb.fields...)
+	c.where = append([]Condition{}, qb.where...)
+	c.orderBy = append([]string{}, qb.orderBy...)
+	return &c
+}
+
+// keysetPage returns a copy of qb selecting fields of the records following lastID in ID order, without offset.
+func (qb *QueryBuilder) keysetPage(fields []string, lastID string, limit int) *QueryBuilder {
+	page := &QueryBuilder{
+		fields:  fields,
+		object:  qb.object,
+		types:   qb.types,
+		where:   append([]Condition{}, qb.where...),
+		orderBy: []string{"Id"},
+		limit:   limit,
+	}
+	if lastID != "" {
+		page.where = append(page.where, Raw("Id > ?", lastID))
+	}
+	return page
+}
+
+// FormatSOQL replaces each "?" placeholder of format with the SOQL representation of the corresponding argument.
+// Strings are quoted and escaped, time.Time values are rendered in UTC as dateTime, DateLiteral values are rendered
+// verbatim, and slices are rendered as a parenthesized list suitable for IN. Placeholders inside quoted string
+// literals of format are left untouched.
+func FormatSOQL(format string, args ...interface{}) (string, error) {
+	var sb strings.Builder
+	argIdx := 0
+	inString := false
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		switch {
+		case inString && ch == '\\' && i+1 < len(format):
+			sb.WriteByte(ch)
+			i++
+			ch = format[i]
+		case ch == '\'':
+			inString = !inString
+		case ch == '?' && !inString:
+			if argIdx >= len(args) {
+				return "", errors.Wrap(ErrInvalidQuery, "not enough arguments for placeholders")
+			}
+			value, err := formatSOQLValue(args[argIdx])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(value)
+			argIdx++
+			continue
+		}
+		sb.WriteByte(ch)
+	}
+	if argIdx != len(args) {
+		return "", errors.Wrap(ErrInvalidQuery, "too many arguments for placeholders")
+	}
+	return sb.String(), nil
+}
+
+// quoteSOQL renders s as a quoted SOQL string literal.
+func quoteSOQL(s string) string {
+	return "'" + soqlEscaper.Replace(s) + "'"
+}
+
+// formatSOQLValue renders a Go value as a SOQL literal.
+func formatSOQLValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return quoteSOQL(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.UTC().Format(soqlDateTimeFormat), nil
+	case DateLiteral:
+		if err := v.Validate(); err != nil {
+			return "", err
+		}
+		return v.String(), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		if rv.Len() == 0 {
+			return "", errors.Wrap(ErrInvalidQuery, "empty list")
+		}
+		items := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item, err := formatSOQLValue(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "(" + strings.Join(items, ", ") + ")", nil
+	}
+
+	return "", errors.Wrap(ErrInvalidQuery, fmt.Sprintf("unsupported value type %T", value))
+}
@@ -0,0 +1,83 @@
+package simpleforce
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatSOQL(t *testing.T) {
+	created := time.Date(2022, 4, 29, 10, 30, 0, 0, time.FixedZone("CEST", 2*3600))
+	q, err := FormatSOQL("SELECT Id FROM Case WHERE Subject = ? AND CreatedDate > ? AND LastModifiedDate = ? AND Status IN ? AND Note = '?'",
+		"it's \\ done", created, LastNDays(30), []string{"New", "Closed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `SELECT Id FROM Case WHERE Subject = 'it\'s \\ done' AND CreatedDate > 2022-04-29T08:30:00Z AND LastModifiedDate = LAST_N_DAYS:30 AND Status IN ('New', 'Closed') AND Note = '?'`
+	if q != expected {
+		t.Errorf("unexpected query:\n%s\n%s", q, expected)
+	}
+
+	if _, err := FormatSOQL("Id = ? AND Name = ?", "x"); err == nil {
+		t.Error("expected error for missing argument")
+	}
+	if _, err := FormatSOQL("Id = ?", "x", "y"); err == nil {
+		t.Error("expected error for extra argument")
+	}
+	if _, err := FormatSOQL("CreatedDate = ?", LastNDays(-1)); err == nil {
+		t.Error("expected error for invalid date literal")
+	}
+	if _, err := FormatSOQL("Id IN ?", []string{}); err == nil {
+		t.Error("expected error for empty list")
+	}
+}
+
+func TestQueryBuilder_Build(t *testing.T) {
+	q, err := Select("Id", "Subject").
+		From("Case").
+		Where(Raw("CreatedDate = ?", ThisFiscalQuarter)).
+		Where(Raw("Status = ? OR Status = ?", "New", "Escalated")).
+		OrderBy("CreatedDate DESC").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "SELECT Id, Subject FROM Case WHERE (CreatedDate = THIS_FISCAL_QUARTER) AND (Status = 'New' OR Status = 'Escalated') ORDER BY CreatedDate DESC"
+	if q != expected {
+		t.Errorf("unexpected query:\n%s\n%s", q, expected)
+	}
+
+	if _, err := Select("Id").Build(); err == nil {
+		t.Error("expected error for missing object")
+	}
+	if Select("Id").From("Case").Where(Raw("CreatedDate = ?", NDaysAgo(-2))).String() != "" {
+		t.Error("expected empty string for invalid query")
+	}
+}
+
+func TestQueryBuilder_ForClauses(t *testing.T) {
+	if q := Select("Id").From("Account").Where(Raw("Name = ?", "Acme")).ForUpdate().String(); q != "SELECT Id FROM Account WHERE Name = 'Acme' FOR UPDATE" {
+		t.Errorf("unexpected query %s", q)
+	}
+	if q := Select("Id").From("Account").OrderBy("Name").ForView().String(); q != "SELECT Id FROM Account ORDER BY Name FOR VIEW" {
+		t.Errorf("unexpected query %s", q)
+	}
+	if q := Select("Id").From("Account").ForReference().String(); q != "SELECT Id FROM Account FOR REFERENCE" {
+		t.Errorf("unexpected query %s", q)
+	}
+	if _, err := Select("Id").From("Account").OrderBy("Name").ForUpdate().Build(); err == nil {
+		t.Error("expected error for ORDER BY with FOR UPDATE")
+	}
+}
+
+func TestQueryBuilder_LimitOffset(t *testing.T) {
+	q := Select("Id").From("Account").OrderBy("Name").Limit(10).Offset(20).ForView().String()
+	if q != "SELECT Id FROM Account ORDER BY Name LIMIT 10 OFFSET 20 FOR VIEW" {
+		t.Errorf("unexpected query %s", q)
+	}
+	if _, err := Select("Id").From("Account").Offset(MaxQueryOffset + 1).Build(); err == nil {
+		t.Error("expected error for offset beyond the maximum")
+	}
+	if _, err := Select("Id").From("Account").Limit(-1).Build(); err == nil {
+		t.Error("expected error for negative limit")
+	}
+}
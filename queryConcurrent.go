@@ -0,0 +1,66 @@
+package simpleforce
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// QueryConcurrent runs queries with at most maxParallel of them in flight at once, and returns their results in the
+// order of queries. Like Query, each result holds the first page of records of its query. The first failure cancels
+// the queries which are still pending or running and is returned, wrapped with the index of the failed query.
+// maxParallel <= 0 runs all the queries at once.
+func (client *Client) QueryConcurrent(ctx context.Context, queries []string, maxParallel int) ([]*QueryResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+	if maxParallel <= 0 || maxParallel > len(queries) {
+		maxParallel = len(queries)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		slots    = make(chan struct{}, maxParallel)
+		results  = make([]*QueryResult, len(queries))
+	)
+	for idx, q := range queries {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(idx int, q string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result, err := client.QueryContext(ctx, q)
+			if err != nil {
+				once.Do(func() {
+					firstErr = errors.Wrapf(err, "query %d failed", idx)
+					cancel()
+				})
+				return
+			}
+			results[idx] = result
+		}(idx, q)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,71 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_QueryConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		q := r.URL.Query().Get("q")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"totalSize": 1, "done": true,
+			"records": []map[string]interface{}{{"Name": strings.TrimPrefix(q, "SELECT Name FROM ")}},
+		})
+	})
+
+	queries := []string{"SELECT Name FROM A", "SELECT Name FROM B", "SELECT Name FROM C", "SELECT Name FROM D", "SELECT Name FROM E"}
+	results, err := client.QueryConcurrent(context.Background(), queries, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx, expected := range []string{"A", "B", "C", "D", "E"} {
+		if name := results[idx].Records[0].StringField("Name"); name != expected {
+			t.Errorf("result %d: expected %s, got %s", idx, expected, name)
+		}
+	}
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 queries in flight, got %d", maxInFlight)
+	}
+}
+
+func TestClient_QueryConcurrentError(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("q"), "Broken") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`[{"errorCode":"INVALID_TYPE","message":"sObject type 'Broken' is not supported."}]`))
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	start := time.Now()
+	_, err := client.QueryConcurrent(context.Background(), []string{"SELECT Id FROM Account", "SELECT Id FROM Broken"}, 0)
+	if err == nil || !strings.Contains(err.Error(), "query 1 failed") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Error("expected the pending query to be canceled")
+	}
+}
@@ -0,0 +1,64 @@
+package simpleforce
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// QueryMore retrieves the next page of records of a query, from the NextRecordsURL of its previous page.
+func (client *Client) QueryMore(nextRecordsURL string) (*QueryResult, error) {
+	return client.QueryMoreContext(context.Background(), nextRecordsURL)
+}
+
+// QueryMoreContext is like QueryMore, with the request bound to ctx.
+func (client *Client) QueryMoreContext(ctx context.Context, nextRecordsURL string) (*QueryResult, error) {
+	if !strings.HasPrefix(nextRecordsURL, "/services/data") {
+		return nil, errors.Wrap(ErrInvalidQuery, "not a nextRecordsUrl: "+nextRecordsURL)
+	}
+	return client.QueryContext(ctx, nextRecordsURL)
+}
+
+// Next retrieves the page of records following result with client. It returns nil and no error once the last page was
+// reached, so that all pages of a query are walked with:
+//
+//	for page, err := client.Query(q); page != nil || err != nil; page, err = page.Next(client) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (result *QueryResult) Next(client *Client) (*QueryResult, error) {
+	return result.NextContext(context.Background(), client)
+}
+
+// NextContext is like Next, with the request bound to ctx.
+func (result *QueryResult) NextContext(ctx context.Context, client *Client) (*QueryResult, error) {
+	if result.Done || result.NextRecordsURL == "" {
+		return nil, nil
+	}
+	return client.QueryMoreContext(ctx, result.NextRecordsURL)
+}
+
+// QueryEach runs an SOQL query and calls fn with every record, following nextRecordsUrl until all records are
+// retrieved. It stops at the first error returned by fn, which is returned.
+func (client *Client) QueryEach(q string, fn func(record *SObject) error) error {
+	return client.QueryEachContext(context.Background(), q, fn)
+}
+
+// QueryEachContext is like QueryEach, with the requests bound to ctx.
+func (client *Client) QueryEachContext(ctx context.Context, q string, fn func(record *SObject) error) error {
+	for page, err := client.QueryContext(ctx, q); page != nil || err != nil; page, err = page.NextContext(ctx, client) {
+		if err != nil {
+			return err
+		}
+		for idx := range page.Records {
+			err = fn(&page.Records[idx])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
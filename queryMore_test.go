@@ -0,0 +1,83 @@
+package simpleforce
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// pagedQueryHandler serves the records 1 to 5 of a query in pages of 2 records.
+func pagedQueryHandler(t *testing.T) http.HandlerFunc {
+	pages := map[string]string{
+		"": `{"totalSize":5,"done":false,"nextRecordsUrl":"/services/data/v54.0/query/01g-2","records":[
+			{"attributes":{"type":"Account"},"Id":"1"},{"attributes":{"type":"Account"},"Id":"2"}]}`,
+		"01g-2": `{"totalSize":5,"done":false,"nextRecordsUrl":"/services/data/v54.0/query/01g-4","records":[
+			{"attributes":{"type":"Account"},"Id":"3"},{"attributes":{"type":"Account"},"Id":"4"}]}`,
+		"01g-4": `{"totalSize":5,"done":true,"records":[{"attributes":{"type":"Account"},"Id":"5"}]}`,
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		cursor := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/services/data/v54.0/query"), "/")
+		page, ok := pages[cursor]
+		if !ok {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(page))
+	}
+}
+
+func TestClient_QueryMore(t *testing.T) {
+	client := requireMockClient(t, pagedQueryHandler(t))
+
+	var ids []string
+	for page, err := client.Query("SELECT Id FROM Account"); page != nil || err != nil; page, err = page.Next(client) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, record := range page.Records {
+			ids = append(ids, record.ID())
+		}
+	}
+	if strings.Join(ids, ",") != "1,2,3,4,5" {
+		t.Errorf("unexpected records %v", ids)
+	}
+
+	result, err := client.QueryMore("/services/data/v54.0/query/01g-4")
+	if err != nil || !result.Done || len(result.Records) != 1 {
+		t.Errorf("unexpected result %+v, %v", result, err)
+	}
+	if _, err := client.QueryMore("SELECT Id FROM Account"); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("expected ErrInvalidQuery, got %v", err)
+	}
+}
+
+func TestClient_QueryEach(t *testing.T) {
+	client := requireMockClient(t, pagedQueryHandler(t))
+
+	var ids []string
+	err := client.QueryEach("SELECT Id FROM Account", func(record *SObject) error {
+		if record.client() != client {
+			t.Error("record not associated with the client")
+		}
+		ids = append(ids, record.ID())
+		return nil
+	})
+	if err != nil || strings.Join(ids, ",") != "1,2,3,4,5" {
+		t.Errorf("unexpected records %v, %v", ids, err)
+	}
+
+	stop := errors.New("stop")
+	count := 0
+	err = client.QueryEach("SELECT Id FROM Account", func(record *SObject) error {
+		count++
+		if count == 3 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || count != 3 {
+		t.Errorf("expected to stop at the third record, got %d, %v", count, err)
+	}
+}
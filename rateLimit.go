@@ -0,0 +1,120 @@
+package simpleforce
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrAPIBudgetExceeded is returned without sending the request when the API usage of the org reached the budget set
+// by WithDailyAPIBudget.
+var ErrAPIBudgetExceeded = errors.New("daily API budget exceeded")
+
+// rateLimiter is a token bucket, holding up to burst tokens and refilled with rate tokens per second. Every request
+// takes a token, waiting for it if the bucket is empty.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a rate limiter letting requestsPerSecond requests through, with bursts of up to one second
+// of requests.
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	burst := requestsPerSecond
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: requestsPerSecond, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait takes a token, waiting for it until ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	// Reserve the token, which may not be there yet; later requests queue behind.
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WithRateLimit limits the requests of the client, including the ones of its copies and of concurrent queries, to
+// requestsPerSecond on average. Requests wait for their turn, or until their context is done.
+func WithRateLimit(requestsPerSecond float64) ClientOption {
+	return func(client *Client) {
+		client.limiter = nil
+		if requestsPerSecond > 0 {
+			client.limiter = newRateLimiter(requestsPerSecond)
+		}
+	}
+}
+
+// WithDailyAPIBudget makes the requests of the client fail with ErrAPIBudgetExceeded once the API requests of the org
+// in the last 24 hours reach maxUsage of its allocation, e.g. 0.8 to leave 20% of the allocation to the other
+// integrations of the org. The usage is the one reported by the last response received by the client, see
+// Client.APIUsage, which counts the requests of all the integrations of the org. While the budget is exceeded, a
+// single request is let through every 5 minutes to refresh the usage, so that the client resumes once the requests of
+// the last 24 hours fall back under the budget.
+func WithDailyAPIBudget(maxUsage float64) ClientOption {
+	return func(client *Client) {
+		client.apiBudget = maxUsage
+	}
+}
+
+// apiBudgetProbeInterval is the time between the requests let through an exceeded API budget to refresh the usage.
+const apiBudgetProbeInterval = 5 * time.Minute
+
+// checkAPIBudget returns ErrAPIBudgetExceeded if the API usage reached the daily budget of the client, unless the
+// request is let through to refresh the usage.
+func (client *Client) checkAPIBudget() error {
+	if client.apiBudget <= 0 {
+		return nil
+	}
+	used, max, ok := client.APIUsage()
+	if !ok || max == 0 {
+		return nil
+	}
+	if float64(used) >= client.apiBudget*float64(max) && !client.responses.probe(time.Now(), apiBudgetProbeInterval) {
+		return errors.Wrapf(ErrAPIBudgetExceeded, "%d of %d API requests used", used, max)
+	}
+	return nil
+}
+
+// probe reports whether a request may be sent at now to refresh a usage recorded more than interval ago, letting a
+// single request through per interval.
+func (r *responseRecorder) probe(now time.Time, interval time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.Sub(r.usageAt) < interval || now.Sub(r.probeAt) < interval {
+		return false
+	}
+	r.probeAt = now
+	return true
+}
@@ -0,0 +1,83 @@
+package simpleforce
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithRateLimit(20))
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	// The first 20 requests are a burst, the next 10 wait for 50ms each.
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 450*time.Millisecond {
+		t.Errorf("expected requests to be limited, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.QueryContext(ctx, "SELECT Id FROM Account"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to wait past its deadline, got %v", err)
+	}
+}
+
+func TestWithDailyAPIBudget(t *testing.T) {
+	usage := "api-usage=100/1000"
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Sforce-Limit-Info", usage)
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithDailyAPIBudget(0.8))
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	usage = "api-usage=800/1000"
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Query("SELECT Id FROM Account"); !errors.Is(err, ErrAPIBudgetExceeded) || requests != 2 {
+		t.Errorf("expected ErrAPIBudgetExceeded without request, got %v after %d requests", err, requests)
+	}
+
+	// Once the usage is old enough, a single request refreshes it.
+	client.responses.usageAt = time.Now().Add(-apiBudgetProbeInterval)
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil || requests != 3 {
+		t.Errorf("expected the usage to be probed, got %v after %d requests", err, requests)
+	}
+	if _, err := client.Query("SELECT Id FROM Account"); !errors.Is(err, ErrAPIBudgetExceeded) || requests != 3 {
+		t.Errorf("expected the budget to be still exceeded, got %v after %d requests", err, requests)
+	}
+
+	// The client resumes once the requests of the last 24 hours fell back under the budget.
+	usage = "api-usage=120/1000"
+	client.responses.usageAt = time.Now().Add(-apiBudgetProbeInterval)
+	client.responses.probeAt = time.Time{}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 5 {
+		t.Errorf("expected the client to resume, got %d requests", requests)
+	}
+}
@@ -0,0 +1,163 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxIDsPerQuery is the number of IDs of a single chunk of QueryByIDs, which keeps the query URL well below the
+// length limits of Salesforce.
+const maxIDsPerQuery = 300
+
+// ErrInvalidID is returned for strings which are not 15 or 18 character Salesforce IDs.
+var ErrInvalidID = errors.New("invalid Salesforce ID")
+
+// id18Suffix are the characters of the case-safe suffix of 18 character IDs.
+const id18Suffix = "ABCDEFGHIJKLMNOPQRSTUVWXYZ012345"
+
+// ToID18 converts a 15 character case-sensitive ID to its 18 character case-insensitive form. 18 character IDs are
+// returned as is.
+func ToID18(id string) (string, error) {
+	switch len(id) {
+	case 18:
+		return id, nil
+	case 15:
+	default:
+		return "", errors.Wrap(ErrInvalidID, id)
+	}
+
+	suffix := make([]byte, 0, 3)
+	for block := 0; block < 3; block++ {
+		bits := 0
+		for i := 0; i < 5; i++ {
+			c := id[block*5+i]
+			switch {
+			case c >= 'A' && c <= 'Z':
+				bits |= 1 << i
+			case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			default:
+				return "", errors.Wrap(ErrInvalidID, id)
+			}
+		}
+		suffix = append(suffix, id18Suffix[bits])
+	}
+	return id + string(suffix), nil
+}
+
+// SameID reports whether a and b are the 15 or 18 character forms of the same ID.
+func SameID(a, b string) bool {
+	a18, errA := ToID18(a)
+	b18, errB := ToID18(b)
+	return errA == nil && errB == nil && a18 == b18
+}
+
+// recordKey returns the key identifying record across record sets: its 18 character ID, or "" if it has none.
+func recordKey(record SObject) string {
+	id, err := ToID18(record.ID())
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(id)
+}
+
+// MergeRecords merges record sets, e.g. the results of several queries, into a single set with one record per ID.
+// IDs are compared in their 18 character form, so 15 and 18 character IDs of the same record match. The fields of
+// the duplicates of a record are merged, the first non-null value winning, so that records selected with different
+// fields end up with all of them. Records are returned in order of first appearance; records without an ID are kept
+// as is.
+func MergeRecords(sets ...[]SObject) []SObject {
+	merged := []SObject{}
+	byKey := map[string]SObject{}
+	for _, set := range sets {
+		for _, record := range set {
+			key := recordKey(record)
+			if key == "" {
+				merged = append(merged, record)
+				continue
+			}
+			existing, ok := byKey[key]
+			if !ok {
+				copied := make(SObject, len(record))
+				for field, value := range record {
+					copied[field] = value
+				}
+				byKey[key] = copied
+				merged = append(merged, copied)
+				continue
+			}
+			for field, value := range record {
+				if current, ok := existing[field]; !ok || current == nil {
+					existing[field] = value
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// ChunkIDs removes the duplicates of ids, comparing them in their 18 character form, and splits them into chunks of
+// at most size IDs. Invalid IDs are kept and compared as is.
+func ChunkIDs(ids []string, size int) [][]string {
+	if size <= 0 {
+		size = maxIDsPerQuery
+	}
+
+	seen := map[string]bool{}
+	var chunks [][]string
+	var chunk []string
+	for _, id := range ids {
+		key := id
+		if id18, err := ToID18(id); err == nil {
+			key = strings.ToLower(id18)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		chunk = append(chunk, id)
+		if len(chunk) == size {
+			chunks = append(chunks, chunk)
+			chunk = nil
+		}
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// QueryByIDs runs qb for the records whose field, e.g. "Id" or "AccountId", is one of ids. Any number of IDs is
+// supported: they are deduplicated and split into as many "field IN (...)" queries as needed, whose records are
+// fetched entirely and merged with MergeRecords.
+func (client *Client) QueryByIDs(qb *QueryBuilder, field string, ids []string) ([]SObject, error) {
+	var sets [][]SObject
+	for _, chunk := range ChunkIDs(ids, maxIDsPerQuery) {
+		values := make([]interface{}, len(chunk))
+		for i, id := range chunk {
+			values[i] = id
+		}
+		q, err := qb.clone().Where(In(field, values...)).Build()
+		if err != nil {
+			return nil, err
+		}
+
+		var records []SObject
+		err = client.queryEach(q, func(raw json.RawMessage) error {
+			var page []SObject
+			err := json.Unmarshal(raw, &page)
+			for idx := range page {
+				page[idx].setClient(client)
+			}
+			records = append(records, page...)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, records)
+	}
+	return MergeRecords(sets...), nil
+}
@@ -0,0 +1,81 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestToID18(t *testing.T) {
+	for id, expected := range map[string]string{
+		"0015000000VALDt":    "0015000000VALDtAAP",
+		"0015000000VALDtAAP": "0015000000VALDtAAP",
+		"001000000000001":    "001000000000001AAA",
+	} {
+		actual, err := ToID18(id)
+		if err != nil || actual != expected {
+			t.Errorf("%s: expected %s, got %s, %v", id, expected, actual, err)
+		}
+	}
+	for _, id := range []string{"", "001", "0015000000VALD!"} {
+		if _, err := ToID18(id); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("%q: expected ErrInvalidID, got %v", id, err)
+		}
+	}
+	if !SameID("0015000000VALDt", "0015000000VALDtAAP") || SameID("0015000000VALDt", "0015000000VALdt") {
+		t.Error("unexpected SameID result")
+	}
+}
+
+func TestMergeRecords(t *testing.T) {
+	merged := MergeRecords(
+		[]SObject{{"Id": "0015000000VALDt", "Name": "Acme"}, {"Name": "no id"}},
+		[]SObject{{"Id": "0015000000VALDtAAP", "Name": "Other", "Industry": "Banking"}, {"Id": "001000000000002", "Name": "Globex"}},
+	)
+	if len(merged) != 3 {
+		t.Fatalf("unexpected records %v", merged)
+	}
+	if merged[0]["Name"] != "Acme" || merged[0]["Industry"] != "Banking" || merged[2]["Name"] != "Globex" {
+		t.Errorf("unexpected records %v", merged)
+	}
+}
+
+func TestChunkIDs(t *testing.T) {
+	chunks := ChunkIDs([]string{"001000000000001", "001000000000001AAA", "001000000000002", "001000000000003"}, 2)
+	if len(chunks) != 2 || len(chunks[0]) != 2 || chunks[1][0] != "001000000000003" {
+		t.Errorf("unexpected chunks %v", chunks)
+	}
+}
+
+func TestClient_QueryByIDs(t *testing.T) {
+	var queries []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		queries = append(queries, q)
+		ids := strings.Split(strings.TrimSuffix(strings.SplitN(q, "Id IN (", 2)[1], ")"), ", ")
+		var records []map[string]interface{}
+		for _, id := range ids {
+			records = append(records, map[string]interface{}{"Id": strings.Trim(id, "'")})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"done": true, "records": records})
+	})
+
+	var ids []string
+	for i := 0; i < 2500; i++ {
+		ids = append(ids, fmt.Sprintf("001%012d", i))
+	}
+	records, err := client.QueryByIDs(Select("Id").From("Account").Where(Eq("IsDeleted", false)), "Id", append(ids, ids[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2500 {
+		t.Errorf("expected 2500 records, got %d", len(records))
+	}
+	if len(queries) != 9 || !strings.HasPrefix(queries[0], "SELECT Id FROM Account WHERE (IsDeleted = false) AND (Id IN (") {
+		t.Errorf("unexpected queries %d %s", len(queries), queries[0])
+	}
+}
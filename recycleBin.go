@@ -0,0 +1,70 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/xml"
+)
+
+// maxRecycleBinRecords is the maximum number of records of a single undelete or emptyRecycleBin call.
+const maxRecycleBinRecords = 200
+
+// Undelete restores deleted records from the Recycle Bin, splitting them into calls of up to 200 IDs. Results are
+// returned in the order of ids. Deleted records are found with QueryAll, e.g. "SELECT Id FROM Task WHERE IsDeleted =
+// true".
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api.meta/api/sforce_api_calls_undelete.htm
+func (client *Client) Undelete(ids []string) ([]SaveResult, error) {
+	return client.UndeleteContext(context.Background(), ids)
+}
+
+// UndeleteContext is like Undelete, with the requests bound to ctx.
+func (client *Client) UndeleteContext(ctx context.Context, ids []string) ([]SaveResult, error) {
+	return client.recycleBinCall(ctx, "undelete", ids)
+}
+
+// EmptyRecycleBin permanently deletes records from the Recycle Bin, splitting them into calls of up to 200 IDs. Records
+// which weren't deleted are deleted first. Results are returned in the order of ids.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api.meta/api/sforce_api_calls_emptyrecyclebin.htm
+func (client *Client) EmptyRecycleBin(ids []string) ([]SaveResult, error) {
+	return client.EmptyRecycleBinContext(context.Background(), ids)
+}
+
+// EmptyRecycleBinContext is like EmptyRecycleBin, with the requests bound to ctx.
+func (client *Client) EmptyRecycleBinContext(ctx context.Context, ids []string) ([]SaveResult, error) {
+	return client.recycleBinCall(ctx, "emptyRecycleBin", ids)
+}
+
+// recycleBinCall sends the SOAP call action, undelete or emptyRecycleBin, for ids.
+func (client *Client) recycleBinCall(ctx context.Context, action string, ids []string) ([]SaveResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	results := make([]SaveResult, 0, len(ids))
+	for start := 0; start < len(ids); start += maxRecycleBinRecords {
+		end := start + maxRecycleBinRecords
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		request := struct {
+			XMLName xml.Name
+			IDs     []string `xml:"ids"`
+		}{
+			XMLName: xml.Name{Space: "urn:partner.soap.sforce.com", Local: action},
+			IDs:     ids[start:end],
+		}
+		var envelope struct {
+			Body struct {
+				Response struct {
+					Results []SaveResult `xml:"result"`
+				} `xml:",any"`
+			}
+		}
+		err := client.soapCall(ctx, action, request, &envelope)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, envelope.Body.Response.Results...)
+	}
+	return results, nil
+}
@@ -0,0 +1,96 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_Undelete(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/services/Soap/u/54.0" || r.Header.Get("SOAPAction") != "undelete" {
+			t.Errorf("unexpected request %s %s", r.URL.Path, r.Header.Get("SOAPAction"))
+			return
+		}
+		var envelope struct {
+			SessionID string   `xml:"Header>SessionHeader>sessionId"`
+			IDs       []string `xml:"Body>undelete>ids"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&envelope); err != nil || envelope.SessionID != "__SESSION_ID__" {
+			t.Errorf("unexpected envelope %+v, %v", envelope, err)
+			return
+		}
+
+		var results strings.Builder
+		for _, id := range envelope.IDs {
+			if id == "00T000000000000AAA" {
+				results.WriteString(`<result><errors><message>entity is not in the recycle bin</message><statusCode>UNDELETE_FAILED</statusCode></errors><id>` + id + `</id><success>false</success></result>`)
+			} else {
+				results.WriteString(`<result><id>` + id + `</id><success>true</success></result>`)
+			}
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:partner.soap.sforce.com"><soapenv:Body><undeleteResponse>` +
+			results.String() + `</undeleteResponse></soapenv:Body></soapenv:Envelope>`))
+	})
+
+	var ids []string
+	for i := 0; i < 250; i++ {
+		ids = append(ids, fmt.Sprintf("00T%012dAAA", i))
+	}
+	results, err := client.Undelete(ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || len(results) != 250 || results[249].ID != ids[249] || !results[249].Success {
+		t.Errorf("unexpected results %d %d %+v", calls, len(results), results)
+	}
+	var sfErr SalesforceError
+	if !errors.As(results[0].Err(), &sfErr) || sfErr.ErrorCode != "UNDELETE_FAILED" {
+		t.Errorf("unexpected result %+v", results[0])
+	}
+}
+
+func TestClient_EmptyRecycleBinFault(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("SOAPAction") != "emptyRecycleBin" {
+			t.Errorf("unexpected action %s", r.Header.Get("SOAPAction"))
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:sf="urn:fault.partner.soap.sforce.com"><soapenv:Body><soapenv:Fault><faultcode>sf:INVALID_ID_FIELD</faultcode><faultstring>INVALID_ID_FIELD: bad id</faultstring></soapenv:Fault></soapenv:Body></soapenv:Envelope>`))
+	})
+	WithRetry(3, time.Millisecond)(client)
+
+	_, err := client.EmptyRecycleBin([]string{"bad"})
+	var sfErr SalesforceError
+	if !errors.As(err, &sfErr) || sfErr.ErrorCode != "INVALID_ID_FIELD" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected faults not to be retried, got %d calls", calls)
+	}
+}
+
+func TestClient_UndeleteContext(t *testing.T) {
+	calls := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.UndeleteContext(ctx, []string{"00T000000000001"})
+	if !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("expected the canceled context to stop the call, got %v after %d calls", err, calls)
+	}
+}
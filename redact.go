@@ -0,0 +1,146 @@
+package simpleforce
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// redactedPlaceholder replaces the redacted values.
+const redactedPlaceholder = "[REDACTED]"
+
+// defaultRedactedFields are the JSON fields, XML elements and URL parameters whose values are always redacted.
+var defaultRedactedFields = []string{
+	"access_token",
+	"assertion",
+	"client_secret",
+	"code",
+	"password",
+	"refresh_token",
+	"sessionId",
+	"sid",
+}
+
+// Patterns of credentials which are redacted wherever they appear.
+var (
+	bearerPattern    = regexp.MustCompile(`(?i)(bearer\s+)[^\s"',]+`)
+	sessionIDPattern = regexp.MustCompile(`\b00D[a-zA-Z0-9]{12,15}![a-zA-Z0-9._]+`)
+)
+
+// redactor removes secrets and personal data from the strings the library logs or puts in errors.
+type redactor struct {
+	mu      sync.RWMutex
+	secrets map[string]bool
+	fields  map[string]bool
+
+	// Patterns matching the values of fields, rebuilt whenever fields change.
+	jsonPattern  *regexp.Regexp
+	xmlPattern   *regexp.Regexp
+	paramPattern *regexp.Regexp
+	// SOQL comparisons of the fields, as written and as URL-encoded in query URLs.
+	soqlPattern        *regexp.Regexp
+	encodedSOQLPattern *regexp.Regexp
+}
+
+// defaultRedactor redacts the default fields for callers without client, see Client.Redact.
+var defaultRedactor = newRedactor()
+
+// newRedactor creates a redactor for the default fields.
+func newRedactor() *redactor {
+	r := &redactor{secrets: map[string]bool{}, fields: map[string]bool{}}
+	r.addFields(defaultRedactedFields...)
+	return r
+}
+
+// addSecret redacts every occurrence of secret. Short values are ignored as they would redact unrelated text.
+func (r *redactor) addSecret(secret string) {
+	if len(secret) < 4 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secrets[secret] = true
+}
+
+// addFields redacts the values of the JSON fields, XML elements and URL parameters named fields, regardless of case.
+func (r *redactor) addFields(fields ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, field := range fields {
+		if field != "" {
+			r.fields[strings.ToLower(field)] = true
+		}
+	}
+
+	names := make([]string, 0, len(r.fields))
+	for field := range r.fields {
+		names = append(names, regexp.QuoteMeta(field))
+	}
+	// Longest names first so that alternatives sharing a prefix match entirely.
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	alternatives := strings.Join(names, "|")
+
+	r.jsonPattern = regexp.MustCompile(`(?i)("(?:` + alternatives + `)"\s*:\s*)("(?:[^"\\]|\\.)*"|[^\s,}\]]+)`)
+	r.xmlPattern = regexp.MustCompile(`(?i)(<(?:\w+:)?(?:` + alternatives + `)(?:\s[^>]*)?>)[^<]*`)
+	r.paramPattern = regexp.MustCompile(`(?i)([?&](?:` + alternatives + `)=)[^&\s"]*`)
+	r.soqlPattern = regexp.MustCompile(`(?i)(\b(?:` + alternatives + `)\s*(?:!=|<>|<=|>=|=|<|>|\sLIKE\s)\s*)` +
+		`(?:'(?:[^'\\]|\\.)*'|[\w.:-]+)`)
+	r.encodedSOQLPattern = regexp.MustCompile(`(?i)(\b(?:` + alternatives + `)(?:\+|%20)*` +
+		`(?:%21%3D|%3C%3E|%3C%3D|%3E%3D|%3D|%3C|%3E|(?:\+|%20)LIKE(?:\+|%20))(?:\+|%20)*)` +
+		`(?:%27(?:%5C%[0-9a-f]{2}|%[0-9a-f]{2}|[^%&\s"])*?%27|[\w.:-]+)`)
+}
+
+// redact returns s without the secrets and field values it contains.
+func (r *redactor) redact(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for secret := range r.secrets {
+		s = strings.Replace(s, secret, redactedPlaceholder, -1)
+	}
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redactedPlaceholder)
+	s = sessionIDPattern.ReplaceAllString(s, redactedPlaceholder)
+	s = r.jsonPattern.ReplaceAllString(s, `${1}"`+redactedPlaceholder+`"`)
+	s = r.xmlPattern.ReplaceAllString(s, "${1}"+redactedPlaceholder)
+	s = r.paramPattern.ReplaceAllString(s, "${1}"+redactedPlaceholder)
+	s = r.soqlPattern.ReplaceAllString(s, "${1}'"+redactedPlaceholder+"'")
+	s = r.encodedSOQLPattern.ReplaceAllString(s, "${1}%27"+redactedPlaceholder+"%27")
+	return s
+}
+
+// SetRedactedFields adds fields, such as personal data fields of custom objects, to the fields whose values are
+// redacted from the logs and errors of the library. Session IDs, OAuth tokens and passwords are always redacted.
+func (client *Client) SetRedactedFields(fields ...string) {
+	client.redactor.addFields(fields...)
+}
+
+// Redact returns s without the credentials of the client and the values of the redacted fields, for callers logging
+// requests or responses themselves. A nil client redacts the fields which are always redacted.
+func (client *Client) Redact(s string) string {
+	if client == nil {
+		return defaultRedactor.redact(s)
+	}
+	if sessionID := client.getSessionID(); sessionID != "" {
+		s = strings.Replace(s, sessionID, redactedPlaceholder, -1)
+	}
+	return client.redactor.redact(s)
+}
+
+// redactError redacts the messages of err if it is a SalesforceError, and its URL if it is a *url.Error, as returned
+// by the HTTP client when a request fails, whose URL may hold an SOQL query. Other errors are returned as is.
+func (client *Client) redactError(err error) error {
+	switch e := err.(type) {
+	case SalesforceError:
+		e.Message = client.Redact(e.Message)
+		e.ErrorMessage = client.Redact(e.ErrorMessage)
+		return e
+	case *url.Error:
+		return &url.Error{Op: e.Op, URL: client.Redact(e.URL), Err: e.Err}
+	}
+	return err
+}
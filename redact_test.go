@@ -0,0 +1,84 @@
+package simpleforce
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_Redact(t *testing.T) {
+	client := NewClient(DefaultURL, DefaultClientID, DefaultAPIVersion)
+	client.SetSidLoc("00D000000000001!AQ4AQFsGnG0.bqLb", "https://example.my.salesforce.com")
+	client.SetRedactedFields("SSN__c", "Email")
+
+	cases := map[string]string{
+		"session 00D000000000001!AQ4AQFsGnG0.bqLb expired":                                                "session [REDACTED] expired",
+		"Authorization: Bearer abc.def":                                                                   "Authorization: Bearer [REDACTED]",
+		`{"access_token":"tok","instance_url":"https://x"}`:                                               `{"access_token":"[REDACTED]","instance_url":"https://x"}`,
+		`{"SSN__c": "123-45-6789", "Name": "Doe", "email":"a@b.c"}`:                                       `{"SSN__c": "[REDACTED]", "Name": "Doe", "email":"[REDACTED]"}`,
+		`{"Age":42,"ssn__c":null}`:                                                                        `{"Age":42,"ssn__c":"[REDACTED]"}`,
+		"<n1:password>hunter2token</n1:password><n1:username>u</n1:username>":                             "<n1:password>[REDACTED]</n1:password><n1:username>u</n1:username>",
+		"https://x/callback?code=abc&state=1":                                                             "https://x/callback?code=[REDACTED]&state=1",
+		"nothing to hide":                                                                                 "nothing to hide",
+		"SELECT Id FROM Contact WHERE Email = 'jdoe@example.com' AND Name = 'Doe'":                        "SELECT Id FROM Contact WHERE Email = '[REDACTED]' AND Name = 'Doe'",
+		"WHERE Contact.Email LIKE 'j\\'doe%' OR SSN__c!=123":                                              "WHERE Contact.Email LIKE '[REDACTED]' OR SSN__c!='[REDACTED]'",
+		"/query?q=SELECT+Id+FROM+Contact+WHERE+Email+%3D+%27jdoe%40example.com%27+AND+Name+%3D+%27Doe%27": "/query?q=SELECT+Id+FROM+Contact+WHERE+Email+%3D+%27[REDACTED]%27+AND+Name+%3D+%27Doe%27",
+		"/query?q=SELECT+Id+FROM+Contact+WHERE+OtherEmail%3D%27x%27":                                      "/query?q=SELECT+Id+FROM+Contact+WHERE+OtherEmail%3D%27x%27",
+	}
+	for input, expected := range cases {
+		if actual := client.Redact(input); actual != expected {
+			t.Errorf("redact %q:\nexpected %q\ngot      %q", input, expected, actual)
+		}
+	}
+
+	client.redactor.addSecret("hunter2")
+	if actual := client.Redact("password hunter2 rejected"); actual != "password [REDACTED] rejected" {
+		t.Errorf("expected secret to be redacted, got %q", actual)
+	}
+}
+
+func TestClient_RedactErrors(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`[{"errorCode":"INVALID_FIELD","message":"bad value for __SESSION_ID__"}]`))
+	})
+
+	_, err := client.Query("SELECT Id FROM Account")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), "__SESSION_ID__") {
+		t.Errorf("expected session id to be redacted from %q", err)
+	}
+	sfErr, ok := err.(SalesforceError)
+	if !ok || sfErr.ErrorCode != "INVALID_FIELD" || strings.Contains(sfErr.ErrorMessage, "__SESSION_ID__") {
+		t.Errorf("unexpected error %#v", err)
+	}
+}
+
+func TestClient_RedactTransportErrors(t *testing.T) {
+	logger := &recordingLogger{}
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset by peer")
+	})
+	client := NewClient(DefaultURL, DefaultClientID, DefaultAPIVersion, WithTransport(transport), WithLogger(logger))
+	client.SetSidLoc("__SESSION_ID__", "https://example.my.salesforce.com")
+	client.SetRedactedFields("Email")
+
+	_, err := client.Query("SELECT Id FROM Contact WHERE Email = 'jdoe@example.com'")
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || !strings.Contains(urlErr.URL, "/query?q=") {
+		t.Fatalf("expected the URL of the request in the error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "jdoe") {
+		t.Errorf("expected the email to be redacted from %q", err)
+	}
+	for _, msg := range logger.messages {
+		if strings.Contains(msg, "jdoe") {
+			t.Errorf("expected the email to be redacted from %q", msg)
+		}
+	}
+}
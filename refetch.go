@@ -0,0 +1,55 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CreateAndFetch creates the SObject like Create, then reads the created record back in the same composite call so
+// that values computed by Salesforce, such as formulas, auto-numbers and system fields, are available on the SObject.
+// Only the listed fields are read back, or all of them if there are none. The record isn't created if it can't be
+// read back.
+func (obj *SObject) CreateAndFetch(fields ...string) error {
+	if obj.Type() == "" || obj.client() == nil {
+		// Sanity check.
+		return ErrFailure
+	}
+	return obj.saveAndFetch(http.MethodPost, "", fields)
+}
+
+// UpdateAndFetch updates the SObject like Update, then reads the updated record back in the same composite call. See
+// CreateAndFetch. ID is required.
+func (obj *SObject) UpdateAndFetch(fields ...string) error {
+	if obj.Type() == "" || obj.client() == nil || obj.ID() == "" {
+		// Sanity check.
+		return ErrFailure
+	}
+	return obj.saveAndFetch(http.MethodPatch, obj.ID(), fields)
+}
+
+// saveAndFetch sends the SObject with method to its record resource, or to the object resource if id is empty, and
+// decodes the record read back into it.
+func (obj *SObject) saveAndFetch(method, id string, fields []string) error {
+	client := obj.client()
+	save := CompositeSubrequest{Method: method, URL: client.sobjectPath(obj.Type()), ReferenceID: "save", Body: obj.makeCopy()}
+	fetch := CompositeSubrequest{Method: http.MethodGet, URL: client.sobjectPath(obj.Type() + "/@{save.id}"), ReferenceID: "fetch"}
+	if id != "" {
+		save.URL = client.sobjectPath(obj.Type() + "/" + id)
+		fetch.URL = save.URL
+	}
+	if len(fields) > 0 {
+		fetch.URL += "?fields=" + url.QueryEscape(strings.Join(fields, ","))
+	}
+
+	result, err := client.Composite(true, []CompositeSubrequest{save, fetch})
+	if err != nil {
+		return err
+	}
+	err = result.Err()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(result.Response("fetch").Body, obj)
+}
@@ -0,0 +1,108 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestSObject_CreateAndFetch(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/composite" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var req struct {
+			AllOrNone        bool                  `json:"allOrNone"`
+			CompositeRequest []CompositeSubrequest `json:"compositeRequest"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		if !req.AllOrNone || len(req.CompositeRequest) != 2 {
+			t.Errorf("unexpected request %+v", req)
+			return
+		}
+		save, fetch := req.CompositeRequest[0], req.CompositeRequest[1]
+		if save.Method != http.MethodPost || save.URL != "/services/data/v54.0/sobjects/Case" {
+			t.Errorf("unexpected save subrequest %+v", save)
+		}
+		if body, _ := save.Body.(map[string]interface{}); body["Subject"] != "Broken" || body[sobjectAttributesKey] != nil {
+			t.Errorf("unexpected save body %v", save.Body)
+		}
+		if fetch.Method != http.MethodGet || fetch.URL != "/services/data/v54.0/sobjects/Case/@{save.id}?fields=CaseNumber%2CStatus" {
+			t.Errorf("unexpected fetch subrequest %+v", fetch)
+		}
+		w.Write([]byte(`{"compositeResponse":[
+			{"body":{"id":"5003000000D8cuIAAR","success":true,"errors":[]},"httpStatusCode":201,"referenceId":"save"},
+			{"body":{"attributes":{"type":"Case"},"Id":"5003000000D8cuIAAR","CaseNumber":"00001026","Status":"New"},"httpStatusCode":200,"referenceId":"fetch"}
+		]}`))
+	})
+
+	obj := client.SObject("Case").Set("Subject", "Broken")
+	if err := obj.CreateAndFetch("CaseNumber", "Status"); err != nil {
+		t.Fatal(err)
+	}
+	if obj.ID() != "5003000000D8cuIAAR" || obj.StringField("CaseNumber") != "00001026" || obj.StringField("Subject") != "Broken" {
+		t.Errorf("unexpected record %v", *obj)
+	}
+	if obj.client() != client {
+		t.Error("record lost its client")
+	}
+}
+
+func TestSObject_UpdateAndFetch(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			CompositeRequest []CompositeSubrequest `json:"compositeRequest"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		if req.CompositeRequest[0].Method != http.MethodPatch || req.CompositeRequest[1].URL != "/services/data/v54.0/sobjects/Case/5003000000D8cuIAAR" {
+			t.Errorf("unexpected subrequests %+v", req.CompositeRequest)
+		}
+		w.Write([]byte(`{"compositeResponse":[
+			{"body":[{"errorCode":"FIELD_CUSTOM_VALIDATION_EXCEPTION","message":"Subject is too short"}],"httpStatusCode":400,"referenceId":"save"},
+			{"body":[{"errorCode":"PROCESSING_HALTED","message":"The transaction was rolled back since another operation in the same transaction failed."}],"httpStatusCode":400,"referenceId":"fetch"}
+		]}`))
+	})
+
+	obj := client.SObject("Case").Set("Id", "5003000000D8cuIAAR").Set("Subject", "-")
+	err := obj.UpdateAndFetch()
+	var compositeErr *CompositeError
+	if !errors.As(err, &compositeErr) || len(compositeErr.Failures) != 1 || compositeErr.Failures[0].ReferenceID != "save" {
+		t.Errorf("expected the update to fail, got %v", err)
+	}
+
+	// Negative: no ID.
+	if client.SObject("Case").UpdateAndFetch() != ErrFailure {
+		t.Error("expected ErrFailure without ID")
+	}
+}
+
+func TestSObject_UpdateAndFetchTooling(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			CompositeRequest []CompositeSubrequest `json:"compositeRequest"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, sub := range req.CompositeRequest {
+			if sub.URL != "/services/data/v54.0/tooling/sobjects/TraceFlag/7tf000000000001AAA" {
+				t.Errorf("unexpected subrequest %+v", sub)
+			}
+		}
+		w.Write([]byte(`{"compositeResponse":[
+			{"body":null,"httpStatusCode":204,"referenceId":"save"},
+			{"body":{"attributes":{"type":"TraceFlag"},"Id":"7tf000000000001AAA","LogType":"USER_DEBUG"},"httpStatusCode":200,"referenceId":"fetch"}
+		]}`))
+	})
+
+	obj := client.ToolingClient().SObject(ToolingTraceFlag).Set("Id", "7tf000000000001AAA").Set("LogType", "USER_DEBUG")
+	if err := obj.UpdateAndFetch(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,96 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DeletedRecord identifies a record deleted within the time span of GetDeleted.
+type DeletedRecord struct {
+	ID          string   `json:"id"`
+	DeletedDate DateTime `json:"deletedDate"`
+}
+
+// DeletedRecords is the result of GetDeleted.
+type DeletedRecords struct {
+	DeletedRecords []DeletedRecord `json:"deletedRecords"`
+	// EarliestDateAvailable is the time of the oldest deletion still tracked by Salesforce, usually 15 days ago.
+	EarliestDateAvailable DateTime `json:"earliestDateAvailable"`
+	// LatestDateCovered is the end of the time span actually covered, which may be before the requested end. Deletions
+	// after it are returned by the next call.
+	LatestDateCovered DateTime `json:"latestDateCovered"`
+}
+
+// GetDeleted returns the records of sobject deleted between start and end, as tracked for data replication. The
+// span must start within the last 30 days.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_getdeleted.htm
+func (client *Client) GetDeleted(sobject string, start, end time.Time) (*DeletedRecords, error) {
+	return client.GetDeletedContext(context.Background(), sobject, start, end)
+}
+
+// GetDeletedContext is like GetDeleted, with the request bound to ctx.
+func (client *Client) GetDeletedContext(ctx context.Context, sobject string, start, end time.Time) (*DeletedRecords, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.replicationURL(sobject, "deleted", start, end)
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var deleted DeletedRecords
+	err = json.Unmarshal(data, &deleted)
+	if err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+// UpdatedRecords is the result of GetUpdated.
+type UpdatedRecords struct {
+	IDs []string `json:"ids"`
+	// LatestDateCovered is the end of the time span actually covered, as with DeletedRecords.
+	LatestDateCovered DateTime `json:"latestDateCovered"`
+}
+
+// GetUpdated returns the IDs of the records of sobject created or updated between start and end, as tracked for data
+// replication. The span must start within the last 30 days. Along with GetDeleted, it supports incremental syncs
+// which don't query SystemModstamp: each sync covers the span from the LatestDateCovered of the previous one.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_getupdated.htm
+func (client *Client) GetUpdated(sobject string, start, end time.Time) (*UpdatedRecords, error) {
+	return client.GetUpdatedContext(context.Background(), sobject, start, end)
+}
+
+// GetUpdatedContext is like GetUpdated, with the request bound to ctx.
+func (client *Client) GetUpdatedContext(ctx context.Context, sobject string, start, end time.Time) (*UpdatedRecords, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.replicationURL(sobject, "updated", start, end)
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var updated UpdatedRecords
+	err = json.Unmarshal(data, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// replicationURL returns the URL of the deleted or updated records of sobject between start and end.
+func (client *Client) replicationURL(sobject, resource string, start, end time.Time) string {
+	return client.makeURL(fmt.Sprintf("sobjects/%s/%s/?start=%s&end=%s", sobject, resource,
+		url.QueryEscape(start.UTC().Format(time.RFC3339)), url.QueryEscape(end.UTC().Format(time.RFC3339))))
+}
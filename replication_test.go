@@ -0,0 +1,51 @@
+package simpleforce
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_GetDeleted(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/sobjects/Account/deleted/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if start, end := r.URL.Query().Get("start"), r.URL.Query().Get("end"); start != "2022-04-29T10:00:00Z" || end != "2022-04-29T11:00:00Z" {
+			t.Errorf("unexpected span %s - %s", start, end)
+		}
+		w.Write([]byte(`{"deletedRecords":[{"id":"0013000000Db2wKAAR","deletedDate":"2022-04-29T10:20:00.000+0000"}],"earliestDateAvailable":"2022-04-14T10:00:00.000+0000","latestDateCovered":"2022-04-29T10:59:00.000+0000"}`))
+	})
+
+	start := time.Date(2022, 4, 29, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	deleted, err := client.GetDeleted("Account", start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted.DeletedRecords) != 1 || deleted.DeletedRecords[0].ID != "0013000000Db2wKAAR" ||
+		!deleted.LatestDateCovered.Equal(time.Date(2022, 4, 29, 10, 59, 0, 0, time.UTC)) {
+		t.Errorf("unexpected deleted records %+v", deleted)
+	}
+}
+
+func TestClient_GetUpdated(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/sobjects/Contact/updated/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if start, end := r.URL.Query().Get("start"), r.URL.Query().Get("end"); start != "2022-04-29T10:00:00Z" || end != "2022-04-29T11:00:00Z" {
+			t.Errorf("unexpected span %s - %s", start, end)
+		}
+		w.Write([]byte(`{"ids":["003D000000QqWfNIAV","003D000000QqWfOIAV"],"latestDateCovered":"2022-04-29T10:59:00.000+0000"}`))
+	})
+
+	start := time.Date(2022, 4, 29, 10, 0, 0, 0, time.UTC)
+	updated, err := client.GetUpdated("Contact", start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.IDs) != 2 || updated.IDs[1] != "003D000000QqWfOIAV" ||
+		!updated.LatestDateCovered.Equal(time.Date(2022, 4, 29, 10, 59, 0, 0, time.UTC)) {
+		t.Errorf("unexpected updated records %+v", updated)
+	}
+}
@@ -0,0 +1,77 @@
+package simpleforce
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Salesforce REST headers controlling how records are saved.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/headers.htm
+const (
+	HeaderAutoAssign    = "Sforce-Auto-Assign"
+	HeaderDuplicateRule = "Sforce-Duplicate-Rule-Header"
+	HeaderMRU           = "Sforce-Mru"
+	HeaderCallOptions   = "Sforce-Call-Options"
+)
+
+// headerContextKey is the key of the headers added to the requests of a context.
+type headerContextKey struct{}
+
+// ContextWithHeader returns a copy of ctx whose requests carry the header key set to value, in addition to the
+// headers of ctx, e.g. to create a lead without running assignment rules:
+//
+//	obj.CreateContext(simpleforce.ContextWithHeader(ctx, simpleforce.HeaderAutoAssign, "FALSE"))
+func ContextWithHeader(ctx context.Context, key, value string) context.Context {
+	header := headersOf(ctx).Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(key, value)
+	return context.WithValue(ctx, headerContextKey{}, header)
+}
+
+// headersOf returns the headers added to the requests of ctx.
+func headersOf(ctx context.Context) http.Header {
+	header, _ := ctx.Value(headerContextKey{}).(http.Header)
+	return header
+}
+
+// ContextWithAutoAssign returns a copy of ctx whose creates and updates of accounts, cases and leads run the active
+// assignment rule if enabled is set, and no assignment rule otherwise.
+func ContextWithAutoAssign(ctx context.Context, enabled bool) context.Context {
+	value := "FALSE"
+	if enabled {
+		value = "TRUE"
+	}
+	return ContextWithHeader(ctx, HeaderAutoAssign, value)
+}
+
+// ContextWithAssignmentRule returns a copy of ctx whose creates and updates run the assignment rule ruleID rather than
+// the active one.
+func ContextWithAssignmentRule(ctx context.Context, ruleID string) context.Context {
+	return ContextWithHeader(ctx, HeaderAutoAssign, ruleID)
+}
+
+// DuplicateRuleOptions controls the duplicate rules run by creates and updates.
+type DuplicateRuleOptions struct {
+	// AllowSave saves records detected as duplicates by rules whose action is to alert rather than block.
+	AllowSave bool
+	// IncludeRecordDetails returns the fields of the duplicates found in the errors.
+	IncludeRecordDetails bool
+	// RunAsCurrentUser applies the sharing rules of the current user to the duplicates found.
+	RunAsCurrentUser bool
+}
+
+// ContextWithDuplicateRules returns a copy of ctx whose creates and updates run duplicate rules with options.
+func ContextWithDuplicateRules(ctx context.Context, options DuplicateRuleOptions) context.Context {
+	return ContextWithHeader(ctx, HeaderDuplicateRule, "allowSave="+strconv.FormatBool(options.AllowSave)+
+		"; includeRecordDetails="+strconv.FormatBool(options.IncludeRecordDetails)+
+		"; runAsCurrentUser="+strconv.FormatBool(options.RunAsCurrentUser))
+}
+
+// ContextWithMRUUpdate returns a copy of ctx whose requests add the records they read or save to the recently used
+// items of the user, like the user interface does.
+func ContextWithMRUUpdate(ctx context.Context) context.Context {
+	return ContextWithHeader(ctx, HeaderMRU, "updateMru=true")
+}
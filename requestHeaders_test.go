@@ -0,0 +1,40 @@
+package simpleforce
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestContextWithHeader(t *testing.T) {
+	var headers []http.Header
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"00Q000000000001AAA","success":true,"errors":[]}`))
+	})
+
+	ctx := ContextWithAutoAssign(context.Background(), false)
+	ctx = ContextWithDuplicateRules(ctx, DuplicateRuleOptions{AllowSave: true, IncludeRecordDetails: true})
+	lead := client.SObject("Lead").Set("LastName", "Doe").CreateContext(ContextWithMRUUpdate(ctx))
+	if lead == nil || lead.ID() != "00Q000000000001AAA" {
+		t.Fatalf("unexpected lead %v", lead)
+	}
+	client.SObject("Lead").Set("LastName", "Roe").CreateContext(ContextWithAssignmentRule(ctx, "01Q000000000001AAA"))
+	client.SObject("Lead").Set("LastName", "Poe").Create()
+
+	if len(headers) != 3 {
+		t.Fatalf("unexpected requests %d", len(headers))
+	}
+	if headers[0].Get(HeaderAutoAssign) != "FALSE" || headers[0].Get(HeaderMRU) != "updateMru=true" ||
+		headers[0].Get(HeaderDuplicateRule) != "allowSave=true; includeRecordDetails=true; runAsCurrentUser=false" ||
+		headers[0].Get("Authorization") != "Bearer __SESSION_ID__" {
+		t.Errorf("unexpected headers %v", headers[0])
+	}
+	if headers[1].Get(HeaderAutoAssign) != "01Q000000000001AAA" || headers[1].Get(HeaderMRU) != "" {
+		t.Errorf("unexpected headers %v", headers[1])
+	}
+	if headers[2].Get(HeaderAutoAssign) != "" || headers[2].Get(HeaderDuplicateRule) != "" {
+		t.Errorf("unexpected headers %v", headers[2])
+	}
+}
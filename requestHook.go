@@ -0,0 +1,32 @@
+package simpleforce
+
+import "net/http"
+
+// RequestHook modifies a request built by the client right before it is sent, e.g. to sign it or to add the headers
+// required by a gateway fronting Salesforce. A request failing its hook is not sent and the error is returned to the
+// caller.
+//
+// The hook runs again when a request is sent a second time, e.g. after an API version upgrade, on the request as built
+// by the client: headers added or signed by the previous run aren't kept. The body of requests can be read without
+// consuming it through req.GetBody, which is set for the requests of the client that have a body except for streamed
+// uploads.
+type RequestHook func(req *http.Request) error
+
+// SetRequestHook registers hook to be applied to every request of the client, including logins, downloads and
+// Apex REST calls. Only one hook is kept; nil removes it.
+func (client *Client) SetRequestHook(hook RequestHook) {
+	client.requestHook = hook
+}
+
+// requestHookError is returned when the request hook fails. Such requests are never retried.
+type requestHookError struct {
+	err error
+}
+
+func (err *requestHookError) Error() string {
+	return "request hook failed: " + err.err.Error()
+}
+
+func (err *requestHookError) Unwrap() error {
+	return err.err
+}
@@ -0,0 +1,91 @@
+package simpleforce
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_SetRequestHook(t *testing.T) {
+	sign := func(body []byte) string {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	requests := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		if got := r.Header.Get("X-Signature"); got != sign(body) {
+			t.Errorf("unexpected signature %q for %s", got, body)
+		}
+		w.Write([]byte(`{"id":"0013000000Db2wKAAR","success":true}`))
+	})
+
+	client.SetRequestHook(func(req *http.Request) error {
+		var body []byte
+		if req.GetBody != nil {
+			rc, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			body, _ = ioutil.ReadAll(rc)
+			rc.Close()
+		}
+		req.Header.Set("X-Signature", sign(body))
+		return nil
+	})
+	if _, err := client.httpRequest(http.MethodPost, client.makeURL("sobjects/Account/"), strings.NewReader(`{"Name":"Acme"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+
+	errNoKey := errors.New("no signing key")
+	client.SetRequestHook(func(req *http.Request) error {
+		return errNoKey
+	})
+	if _, err := client.Query("SELECT Id FROM Account"); !errors.Is(err, errNoKey) {
+		t.Errorf("expected the hook error, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests to be sent, got %d", requests)
+	}
+}
+
+func TestClient_SetRequestHookReplay(t *testing.T) {
+	var hops [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hops = append(hops, r.Header.Values("X-Hop"))
+		if len(hops) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`[{"message":"Server temporarily unavailable","errorCode":"SERVER_UNAVAILABLE"}]`))
+			return
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithRetry(1, time.Millisecond))
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+	client.SetRequestHook(func(req *http.Request) error {
+		req.Header.Add("X-Hop", "gateway")
+		return nil
+	})
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	if len(hops) != 2 || len(hops[0]) != 1 || len(hops[1]) != 1 {
+		t.Errorf("expected the hook to apply once to each attempt, got %q", hops)
+	}
+}
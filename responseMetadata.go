@@ -0,0 +1,118 @@
+package simpleforce
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseMetadata describes the HTTP response of a request sent to Salesforce, successful or not.
+type ResponseMetadata struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// RequestID is the value of the Sforce-Request-Id header, which Salesforce support asks for when investigating a
+	// request.
+	RequestID string
+	// LimitInfo is the value of the Sforce-Limit-Info header, e.g. "api-usage=25/15000".
+	LimitInfo string
+	Header    http.Header
+	// Duration is the time until the response headers were received.
+	Duration time.Duration
+}
+
+// APIUsage parses the API requests made in the last 24 hours and their limit from LimitInfo.
+func (meta *ResponseMetadata) APIUsage() (used, max int, ok bool) {
+	for _, info := range strings.Split(meta.LimitInfo, ",") {
+		info = strings.TrimSpace(info)
+		if !strings.HasPrefix(info, "api-usage=") {
+			continue
+		}
+		counts := strings.SplitN(strings.TrimPrefix(info, "api-usage="), "/", 2)
+		if len(counts) != 2 {
+			return 0, 0, false
+		}
+		var usedErr, maxErr error
+		used, usedErr = strconv.Atoi(counts[0])
+		max, maxErr = strconv.Atoi(counts[1])
+		if usedErr != nil || maxErr != nil {
+			return 0, 0, false
+		}
+		return used, max, true
+	}
+	return 0, 0, false
+}
+
+// ResponseObserver is called with the metadata of every response received by a client.
+type ResponseObserver func(meta *ResponseMetadata)
+
+// responseRecorder keeps the metadata of the last response of a client and notifies its observer.
+type responseRecorder struct {
+	mu       sync.Mutex
+	last     *ResponseMetadata
+	observer ResponseObserver
+	// usage is the last API usage reported, which not all responses carry, at usageAt.
+	usage    [2]int
+	hasUsage bool
+	usageAt  time.Time
+	// probeAt is when the last request was let through an exceeded API budget to refresh usage.
+	probeAt time.Time
+}
+
+// record stores the metadata of resp, received after elapsed.
+func (r *responseRecorder) record(req *http.Request, resp *http.Response, elapsed time.Duration) {
+	if r == nil {
+		return
+	}
+	meta := &ResponseMetadata{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("Sforce-Request-Id"),
+		LimitInfo:  resp.Header.Get("Sforce-Limit-Info"),
+		Header:     resp.Header.Clone(),
+		Duration:   elapsed,
+	}
+
+	used, max, ok := meta.APIUsage()
+
+	r.mu.Lock()
+	r.last = meta
+	if ok {
+		r.usage = [2]int{used, max}
+		r.hasUsage = true
+		r.usageAt = time.Now()
+	}
+	observer := r.observer
+	r.mu.Unlock()
+	if observer != nil {
+		observer(meta)
+	}
+}
+
+// OnResponse registers observer to be called with the metadata of every response received by the client, from the
+// goroutine which sent the request, e.g. for auditing. Only one observer is kept; nil removes it.
+func (client *Client) OnResponse(observer ResponseObserver) {
+	client.responses.mu.Lock()
+	defer client.responses.mu.Unlock()
+	client.responses.observer = observer
+}
+
+// LastResponse returns the metadata of the last response received by the client, or nil. When requests are sent
+// concurrently, use OnResponse instead.
+func (client *Client) LastResponse() *ResponseMetadata {
+	client.responses.mu.Lock()
+	defer client.responses.mu.Unlock()
+	return client.responses.last
+}
+
+// APIUsage returns the API requests made by the org in the last 24 hours and their limit, as reported by the last
+// response which carried them (see ResponseMetadata.APIUsage), so that applications can throttle themselves before
+// hitting the limit. ok is false until such a response was received.
+func (client *Client) APIUsage() (used, max int, ok bool) {
+	client.responses.mu.Lock()
+	defer client.responses.mu.Unlock()
+	return client.responses.usage[0], client.responses.usage[1], client.responses.hasUsage
+}
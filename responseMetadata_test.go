@@ -0,0 +1,65 @@
+package simpleforce
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClient_OnResponse(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Sforce-Request-Id", "TID:13079969000061d7d6")
+		w.Header().Set("Sforce-Limit-Info", "api-usage=25/15000")
+		if r.URL.Query().Get("q") == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`[{"errorCode":"MALFORMED_QUERY","message":"unexpected token: bad"}]`))
+			return
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	})
+	if client.LastResponse() != nil {
+		t.Error("expected no response before the first request")
+	}
+
+	var observed []*ResponseMetadata
+	client.OnResponse(func(meta *ResponseMetadata) {
+		observed = append(observed, meta)
+	})
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Query("bad"); err == nil {
+		t.Fatal("expected the query to fail")
+	}
+
+	if len(observed) != 2 || observed[0].StatusCode != http.StatusOK || observed[1].StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected observed responses %+v", observed)
+	}
+	meta := client.LastResponse()
+	if meta != observed[1] || meta.Method != http.MethodGet || meta.RequestID != "TID:13079969000061d7d6" {
+		t.Errorf("unexpected last response %+v", meta)
+	}
+	if used, max, ok := meta.APIUsage(); !ok || used != 25 || max != 15000 {
+		t.Errorf("unexpected API usage %d/%d %v", used, max, ok)
+	}
+
+	client.OnResponse(nil)
+	client.Query("SELECT Id FROM Account")
+	if len(observed) != 2 {
+		t.Error("observer was not removed")
+	}
+}
+
+func TestResponseMetadata_APIUsage(t *testing.T) {
+	for limitInfo, want := range map[string]bool{
+		"api-usage=1/100":                        true,
+		"per-app-api-usage=1/10,api-usage=1/100": true,
+		"":                                       false,
+		"api-usage=1":                            false,
+		"api-usage=a/100":                        false,
+	} {
+		_, _, ok := (&ResponseMetadata{LimitInfo: limitInfo}).APIUsage()
+		if ok != want {
+			t.Errorf("%q: expected %v, got %v", limitInfo, want, ok)
+		}
+	}
+}
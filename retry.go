@@ -0,0 +1,164 @@
+package simpleforce
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxRetryBackoff bounds the delay between two attempts of a request.
+const maxRetryBackoff = 30 * time.Second
+
+// Error codes of the transient failures retried by the retry policy.
+var transientErrorCodes = map[string]bool{
+	"REQUEST_LIMIT_EXCEEDED": true,
+	"SERVER_UNAVAILABLE":     true,
+}
+
+// retryPolicy is how many times and how quickly failed requests are sent again.
+type retryPolicy struct {
+	max     int
+	backoff time.Duration
+}
+
+// retryContextKey is the key of the retry policy overriding the one of the client for the requests of a context.
+type retryContextKey struct{}
+
+// retryNonIdempotentContextKey is the key marking the requests of a context as safe to send twice.
+type retryNonIdempotentContextKey struct{}
+
+// ContextWithRetry returns a copy of ctx whose requests are retried up to max times with backoff, overriding the
+// policy set by WithRetry. A max of 0 disables retries, e.g. for requests whose caller retries on its own.
+func ContextWithRetry(ctx context.Context, max int, backoff time.Duration) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, retryPolicy{max: max, backoff: backoff})
+}
+
+// ContextWithNonIdempotentRetry returns a copy of ctx whose requests are retried on any transient error, like GET
+// requests, whatever their method. By default, requests such as a POST creating records are only retried if they were
+// rejected before being processed, as a lost response would otherwise create the records twice. Use it for requests
+// which are safe to send twice, e.g. GraphQL queries or upserts by external ID through composite requests:
+//
+//	client.GraphQLContext(simpleforce.ContextWithNonIdempotentRetry(ctx), query, nil)
+func ContextWithNonIdempotentRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryNonIdempotentContextKey{}, true)
+}
+
+// retryPolicyOf returns the retry policy of the requests of ctx.
+func (client *Client) retryPolicyOf(ctx context.Context) retryPolicy {
+	if policy, ok := ctx.Value(retryContextKey{}).(retryPolicy); ok {
+		return policy
+	}
+	return client.retry
+}
+
+// delay returns how long to wait before the attempt following the failed attempt attempt, counted from 0: backoff
+// doubles with every attempt, with a random jitter of up to half of it so that clients don't retry in lockstep.
+func (policy retryPolicy) delay(attempt int) time.Duration {
+	delay := policy.backoff
+	for i := 0; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// isTransient reports whether the request req failing with err may succeed if sent again: on the errors caused by the
+// load of the org and connections which failed, which leave the request unprocessed, and for idempotent requests
+// also on other network errors and 5xx responses, after which the request may have been processed.
+func isTransient(req *http.Request, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	var hookErr *requestHookError
+	if errors.As(err, &hookErr) {
+		return false
+	}
+	var sfErr SalesforceError
+	if !errors.As(err, &sfErr) {
+		return isIdempotent(req) || notSent(err)
+	}
+	if transientErrorCodes[sfErr.ErrorCode] {
+		return true
+	}
+	if req.Header.Get("SOAPAction") != "" && sfErr.ErrorCode != "" {
+		// SOAP faults are sent with a 500 status whatever their cause.
+		return false
+	}
+	return sfErr.HttpCode >= http.StatusInternalServerError && isIdempotent(req)
+}
+
+// isIdempotent reports whether sending req twice has the same effect as sending it once, so that it can be sent again
+// when its outcome is unknown: GET, HEAD, OPTIONS, PUT and DELETE requests, and PATCH requests updating records,
+// unless they're Apex REST requests, whose effect is up to the org. ContextWithNonIdempotentRetry marks the other
+// requests of a context as idempotent.
+func isIdempotent(req *http.Request) bool {
+	if marked, _ := req.Context().Value(retryNonIdempotentContextKey{}).(bool); marked {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPatch:
+		return !strings.Contains(req.URL.Path, "/services/apexrest/")
+	}
+	return false
+}
+
+// notSent reports whether err tells the request failed before it was sent, as the connection to the server couldn't
+// be established.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// sendRequest sends req to the salesforce server, see httpResponse, and again following the retry policy of its
+// context as long as it fails with transient errors. Requests whose body can't be read again are sent once.
+func (client *Client) sendRequest(req *http.Request) (*http.Response, error) {
+	policy := client.retryPolicyOf(req.Context())
+	resp, err := client.sendOnce(req)
+	for attempt := 0; err != nil && attempt < policy.max && isTransient(req, err); attempt++ {
+		retry, replayErr := replayRequest(req, req.URL.String())
+		if replayErr != nil {
+			break
+		}
+
+		timer := time.NewTimer(policy.delay(attempt))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+
+		client.logInfo("retrying request after transient failure,", err)
+		resp, err = client.sendOnce(retry)
+	}
+	return resp, err
+}
+
+// sleepContext waits for d, or until ctx is done, in which case the error of ctx is returned. It paces the polling of
+// the jobs of the org.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
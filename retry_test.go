@@ -0,0 +1,142 @@
+package simpleforce
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestWithRetry(t *testing.T) {
+	failures := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`[{"message":"Server temporarily unavailable","errorCode":"SERVER_UNAVAILABLE"}]`))
+			return
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer server.Close()
+
+	networkErrors := 0
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if networkErrors > 0 {
+			networkErrors--
+			return nil, errors.New("connection reset by peer")
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client := NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithTransport(transport), WithRetry(2, time.Millisecond))
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	failures, networkErrors = 1, 1
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil || failures != 0 || networkErrors != 0 {
+		t.Errorf("expected the request to succeed after retries, got %v", err)
+	}
+
+	failures = 3
+	if _, err := client.Query("SELECT Id FROM Account"); err == nil || failures != 0 {
+		t.Errorf("expected the request to fail after 2 retries, got %v with %d failures left", err, failures)
+	}
+
+	failures = 1
+	ctx := ContextWithRetry(context.Background(), 0, 0)
+	if _, err := client.QueryContext(ctx, "SELECT Id FROM Account"); err == nil || failures != 0 {
+		t.Errorf("expected the request not to be retried, got %v", err)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := retryPolicy{max: 10, backoff: 100 * time.Millisecond}
+	for attempt, expected := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond} {
+		if delay := policy.delay(attempt); delay < expected/2 || delay > expected {
+			t.Errorf("unexpected delay %s for attempt %d", delay, attempt)
+		}
+	}
+	if delay := policy.delay(20); delay > maxRetryBackoff {
+		t.Errorf("unexpected delay %s", delay)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if isTransient(req, ParseSalesforceError(400, []byte(`[{"message":"bad","errorCode":"MALFORMED_QUERY"}]`))) {
+		t.Error("expected a malformed query not to be transient")
+	}
+	if !isTransient(req, ParseSalesforceError(403, []byte(`[{"message":"limit","errorCode":"REQUEST_LIMIT_EXCEEDED"}]`))) {
+		t.Error("expected REQUEST_LIMIT_EXCEEDED to be transient")
+	}
+	if isTransient(req, &requestHookError{err: errors.New("signing failed")}) {
+		t.Error("expected a request hook error not to be transient")
+	}
+}
+
+func TestIsTransient_NonIdempotent(t *testing.T) {
+	networkErr := errors.New("connection reset by peer")
+	serverErr := ParseSalesforceError(500, []byte(`[{"message":"boom","errorCode":"UNKNOWN_EXCEPTION"}]`))
+	unavailable := ParseSalesforceError(503, []byte(`[{"message":"unavailable","errorCode":"SERVER_UNAVAILABLE"}]`))
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	get := httptest.NewRequest(http.MethodGet, "/services/data/v54.0/sobjects/Account/001", nil)
+	patch := httptest.NewRequest(http.MethodPatch, "/services/data/v54.0/sobjects/Account/001", nil)
+	post := httptest.NewRequest(http.MethodPost, "/services/data/v54.0/sobjects/Account/", nil)
+	apexPatch := httptest.NewRequest(http.MethodPatch, "/services/apexrest/Invoices/", nil)
+	for _, req := range []*http.Request{get, patch} {
+		if !isTransient(req, networkErr) || !isTransient(req, serverErr) {
+			t.Errorf("expected %s %s to be retried", req.Method, req.URL.Path)
+		}
+	}
+	for _, req := range []*http.Request{post, apexPatch} {
+		if isTransient(req, networkErr) || isTransient(req, serverErr) {
+			t.Errorf("expected %s %s not to be retried once it may have been processed", req.Method, req.URL.Path)
+		}
+		if !isTransient(req, unavailable) || !isTransient(req, dialErr) {
+			t.Errorf("expected %s %s to be retried when it wasn't processed", req.Method, req.URL.Path)
+		}
+	}
+
+	marked := post.WithContext(ContextWithNonIdempotentRetry(context.Background()))
+	if !isTransient(marked, networkErr) || !isTransient(marked, serverErr) {
+		t.Error("expected a POST marked by ContextWithNonIdempotentRetry to be retried")
+	}
+}
+
+func TestWithRetry_Create(t *testing.T) {
+	creates := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		creates++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"0013000000Db2wKAAR","success":true,"errors":[]}`))
+	}))
+	defer server.Close()
+
+	lostResponses := 0
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := http.DefaultTransport.RoundTrip(req)
+		if err == nil && lostResponses > 0 {
+			lostResponses--
+			resp.Body.Close()
+			return nil, errors.New("connection reset by peer")
+		}
+		return resp, err
+	})
+	client := NewClient(server.URL, DefaultClientID, DefaultAPIVersion, WithTransport(transport), WithRetry(2, time.Millisecond))
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	lostResponses = 1
+	if obj := client.SObject("Account").Set("Name", "Acme").Create(); obj != nil || creates != 1 {
+		t.Errorf("expected the create not to be sent again, got %v after %d requests", obj, creates)
+	}
+
+	creates, lostResponses = 0, 1
+	ctx := ContextWithNonIdempotentRetry(context.Background())
+	if obj := client.SObject("Account").Set("Name", "Acme").CreateContext(ctx); obj == nil || creates != 2 {
+		t.Errorf("expected the create to be sent again, got %v after %d requests", obj, creates)
+	}
+}
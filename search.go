@@ -0,0 +1,122 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SearchResult holds the records found by a SOSL search, across all the searched objects.
+type SearchResult struct {
+	Records []SObject `json:"searchRecords"`
+}
+
+// ByType groups the records of the result by object type, keeping their order of relevance.
+func (result *SearchResult) ByType() map[string][]SObject {
+	grouped := map[string][]SObject{}
+	for _, record := range result.Records {
+		grouped[record.Type()] = append(grouped[record.Type()], record)
+	}
+	return grouped
+}
+
+// SearchObject restricts a parameterized search to an object, optionally with the fields to return, a filter and a
+// limit.
+type SearchObject struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields,omitempty"`
+	Where  string   `json:"where,omitempty"`
+	Limit  int      `json:"limit,omitempty"`
+}
+
+// SearchRequest describes a parameterized search: the search term Q, without SOSL syntax, and where to search it.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_search_parameterized.htm
+type SearchRequest struct {
+	Q string `json:"q"`
+	// In is the scope of the search: ALL, NAME, EMAIL, PHONE or SIDEBAR fields.
+	In string `json:"in,omitempty"`
+	// Fields are returned for all the objects which don't specify their own.
+	Fields       []string       `json:"fields,omitempty"`
+	SObjects     []SearchObject `json:"sobjects,omitempty"`
+	OverallLimit int            `json:"overallLimit,omitempty"`
+	DefaultLimit int            `json:"defaultLimit,omitempty"`
+	// SpellCorrection is enabled by default.
+	SpellCorrection *bool `json:"spellCorrection,omitempty"`
+}
+
+// Search runs a SOSL search, e.g. "FIND {Acme} IN NAME FIELDS RETURNING Account(Id, Name), Contact(Id, Name)". Use
+// EscapeSOSL to put user input in the search term.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_search.htm
+func (client *Client) Search(sosl string) (*SearchResult, error) {
+	return client.SearchContext(context.Background(), sosl)
+}
+
+// SearchContext is like Search, with the request bound to ctx.
+func (client *Client) SearchContext(ctx context.Context, sosl string) (*SearchResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("search/?q=" + url.QueryEscape(sosl))
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+	return client.decodeSearchResult(data)
+}
+
+// ParameterizedSearch runs a search described by req, which needs no SOSL and takes the search term as is.
+func (client *Client) ParameterizedSearch(req SearchRequest) (*SearchResult, error) {
+	return client.ParameterizedSearchContext(context.Background(), req)
+}
+
+// ParameterizedSearchContext is like ParameterizedSearch, with the request bound to ctx.
+func (client *Client) ParameterizedSearchContext(ctx context.Context, req SearchRequest) (*SearchResult, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	reqData, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.makeURL("parameterizedSearch/")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+	return client.decodeSearchResult(data)
+}
+
+// decodeSearchResult decodes the records found by a search.
+func (client *Client) decodeSearchResult(data []byte) (*SearchResult, error) {
+	var result SearchResult
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range result.Records {
+		result.Records[idx].setClient(client)
+	}
+	return &result, nil
+}
+
+// soslReplacer escapes the reserved characters of SOSL search terms.
+var soslReplacer = func() *strings.Replacer {
+	var pairs []string
+	for _, c := range `\?&|!{}[]()^~*:"'+-` {
+		pairs = append(pairs, string(c), `\`+string(c))
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// EscapeSOSL escapes the reserved characters of term, to be searched literally in the braces of a FIND clause.
+func EscapeSOSL(term string) string {
+	return soslReplacer.Replace(term)
+}
@@ -0,0 +1,58 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestClient_Search(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v54.0/search/":
+			if q := r.URL.Query().Get("q"); q != `FIND {Acme \& Co} RETURNING Account(Id, Name), Contact(Id, Name)` {
+				t.Errorf("unexpected search %s", q)
+			}
+		case "/services/data/v54.0/parameterizedSearch/":
+			var req SearchRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Q != "Acme & Co" || len(req.SObjects) != 2 || req.SObjects[1].Limit != 5 || req.In != "NAME" {
+				t.Errorf("unexpected search %+v", req)
+			}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"searchRecords":[
+			{"attributes":{"type":"Account"},"Id":"0013000000Db2wKAAR","Name":"Acme & Co"},
+			{"attributes":{"type":"Contact"},"Id":"0033000000Db2wKAAR","Name":"Jane Acme"},
+			{"attributes":{"type":"Account"},"Id":"0013000000Db2wLAAR","Name":"Acme & Co Europe"}
+		]}`))
+	})
+
+	result, err := client.Search("FIND {" + EscapeSOSL("Acme & Co") + "} RETURNING Account(Id, Name), Contact(Id, Name)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grouped := result.ByType()
+	if len(result.Records) != 3 || len(grouped["Account"]) != 2 || grouped["Contact"][0].StringField("Name") != "Jane Acme" {
+		t.Errorf("unexpected result %v", grouped)
+	}
+	if result.Records[0].client() != client {
+		t.Error("records not associated with the client")
+	}
+
+	result, err = client.ParameterizedSearch(SearchRequest{
+		Q:        "Acme & Co",
+		In:       "NAME",
+		SObjects: []SearchObject{{Name: "Account"}, {Name: "Contact", Limit: 5}},
+	})
+	if err != nil || len(result.Records) != 3 {
+		t.Errorf("unexpected result %v, %v", result, err)
+	}
+}
+
+func TestEscapeSOSL(t *testing.T) {
+	if escaped := EscapeSOSL(`50% off: "Acme" (B2B)?`); escaped != `50% off\: \"Acme\" \(B2B\)\?` {
+		t.Errorf("unexpected escape %s", escaped)
+	}
+}
@@ -0,0 +1,226 @@
+package simpleforce
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// fixtureRefPrefix marks the field values referencing another record of the fixtures by its ref. Values actually
+// starting with "@" are escaped by doubling it.
+const fixtureRefPrefix = "@"
+
+// Fixtures describes a set of related records to create. Lookup fields reference other records of the set by their
+// ref, prefixed with "@":
+//
+//	records:
+//	  - ref: acme
+//	    type: Account
+//	    fields:
+//	      Name: Acme
+//	  - ref: jdoe
+//	    type: Contact
+//	    fields:
+//	      LastName: Doe
+//	      AccountId: "@acme"
+type Fixtures struct {
+	Records []FixtureRecord `json:"records" yaml:"records"`
+}
+
+// FixtureRecord is a single record of Fixtures. Ref is only required if other records reference it.
+type FixtureRecord struct {
+	Ref    string                 `json:"ref" yaml:"ref"`
+	Type   string                 `json:"type" yaml:"type"`
+	Fields map[string]interface{} `json:"fields" yaml:"fields"`
+}
+
+// LoadFixtures decodes fixtures from r, in either YAML or JSON.
+func LoadFixtures(r io.Reader) (*Fixtures, error) {
+	var fixtures Fixtures
+	err := yaml.NewDecoder(r).Decode(&fixtures)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to decode fixtures")
+	}
+	return &fixtures, nil
+}
+
+// LoadFixtureFiles decodes and merges the fixtures of the files found at paths. Records may reference records of
+// other files.
+func LoadFixtureFiles(paths ...string) (*Fixtures, error) {
+	merged := &Fixtures{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		fixtures, err := LoadFixtures(f)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, path)
+		}
+		merged.Records = append(merged.Records, fixtures.Records...)
+	}
+	return merged, nil
+}
+
+// fixtureRef returns the ref a field value points to, if any.
+func fixtureRef(value interface{}) (string, bool) {
+	s, ok := value.(string)
+	if !ok || !strings.HasPrefix(s, fixtureRefPrefix) || strings.HasPrefix(s, fixtureRefPrefix+fixtureRefPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(s, fixtureRefPrefix), true
+}
+
+// insertLevels groups the records of fixtures by insertion level: records of a level only reference records of the
+// previous levels. Records are identified by their index.
+func (fixtures *Fixtures) insertLevels() ([][]int, error) {
+	refs := map[string]int{}
+	for idx, record := range fixtures.Records {
+		if record.Type == "" {
+			return nil, errors.Errorf("record %d has no type", idx)
+		}
+		if record.Ref == "" {
+			continue
+		}
+		if _, ok := refs[record.Ref]; ok {
+			return nil, errors.Errorf("duplicate ref %s", record.Ref)
+		}
+		refs[record.Ref] = idx
+	}
+
+	pending := map[int][]int{}
+	for idx, record := range fixtures.Records {
+		for field, value := range record.Fields {
+			ref, ok := fixtureRef(value)
+			if !ok {
+				continue
+			}
+			dep, ok := refs[ref]
+			if !ok {
+				return nil, errors.Errorf("%s.%s references unknown record %s", record.Type, field, ref)
+			}
+			pending[idx] = append(pending[idx], dep)
+		}
+	}
+
+	var levels [][]int
+	done := map[int]bool{}
+	for len(done) < len(fixtures.Records) {
+		var level []int
+		for idx := range fixtures.Records {
+			if done[idx] {
+				continue
+			}
+			ready := true
+			for _, dep := range pending[idx] {
+				ready = ready && done[dep]
+			}
+			if ready {
+				level = append(level, idx)
+			}
+		}
+		if len(level) == 0 {
+			return nil, errors.New("fixtures contain a reference cycle")
+		}
+		for _, idx := range level {
+			done[idx] = true
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// Seed creates the records of fixtures, parents first, through SObject Collections. It returns the IDs of the created
+// records keyed by their ref. Each level is created in calls of up to 10 object types. If a record fails to be
+// created, the IDs of the records created so far are returned along with the error; they are not rolled back.
+func (client *Client) Seed(fixtures *Fixtures) (map[string]string, error) {
+	levels, err := fixtures.insertLevels()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[string]string{}
+	for _, level := range levels {
+		// SObject Collections require records of the same type to be contiguous.
+		sort.SliceStable(level, func(i, j int) bool {
+			return fixtures.Records[level[i]].Type < fixtures.Records[level[j]].Type
+		})
+		for _, batch := range fixtures.typeBatches(level) {
+			if err := client.seedBatch(fixtures, batch, ids); err != nil {
+				return ids, err
+			}
+		}
+	}
+	return ids, nil
+}
+
+// typeBatches splits a level sorted by type into batches of records of up to maxCollectionTypes types.
+func (fixtures *Fixtures) typeBatches(level []int) [][]int {
+	var batches [][]int
+	start, types := 0, 0
+	for i, idx := range level {
+		if i > 0 && fixtures.Records[idx].Type == fixtures.Records[level[i-1]].Type {
+			continue
+		}
+		if types == maxCollectionTypes {
+			batches = append(batches, level[start:i])
+			start, types = i, 0
+		}
+		types++
+	}
+	if start < len(level) {
+		batches = append(batches, level[start:])
+	}
+	return batches
+}
+
+// seedBatch creates the records of fixtures identified by batch and adds the IDs of the created ones to ids.
+func (client *Client) seedBatch(fixtures *Fixtures, batch []int, ids map[string]string) error {
+	records := make([]*SObject, 0, len(batch))
+	for _, idx := range batch {
+		fixture := fixtures.Records[idx]
+		obj := client.SObject(fixture.Type)
+		for field, value := range fixture.Fields {
+			if ref, ok := fixtureRef(value); ok {
+				value = ids[ref]
+			} else if s, ok := value.(string); ok && strings.HasPrefix(s, fixtureRefPrefix+fixtureRefPrefix) {
+				value = strings.TrimPrefix(s, fixtureRefPrefix)
+			}
+			obj.Set(field, value)
+		}
+		records = append(records, obj)
+	}
+
+	results, err := client.saveCollection(http.MethodPost, records, true)
+	// Records of the previous calls of a rolled back batch remain created.
+	for i, result := range results {
+		if ref := fixtures.Records[batch[i]].Ref; ref != "" && result.Success {
+			ids[ref] = result.ID
+		}
+	}
+	var rollbackErr *RollbackError
+	if errors.As(err, &rollbackErr) {
+		fixture := fixtures.Records[batch[rollbackErr.Failures[0].Index]]
+		name := fixture.Ref
+		if name == "" {
+			name = fixture.Type
+		}
+		return errors.Wrapf(err, "failed to create %s", name)
+	}
+	return err
+}
+
+// SeedFiles loads the fixtures found at paths and creates their records, see Seed.
+func (client *Client) SeedFiles(paths ...string) (map[string]string, error) {
+	fixtures, err := LoadFixtureFiles(paths...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Seed(fixtures)
+}
@@ -0,0 +1,135 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const accountFixtures = `
+records:
+  - ref: acme
+    type: Account
+    fields:
+      Name: Acme
+      Handle__c: "@@acme"
+`
+
+const contactFixtures = `{"records": [
+	{"ref": "jdoe", "type": "Contact", "fields": {"LastName": "Doe", "AccountId": "@acme"}},
+	{"type": "Case", "fields": {"Subject": "Broken", "ContactId": "@jdoe", "AccountId": "@acme"}},
+	{"type": "Account", "fields": {"Name": "Globex"}}
+]}`
+
+func TestLoadFixtures_InsertLevels(t *testing.T) {
+	fixtures, err := LoadFixtures(strings.NewReader(contactFixtures))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fixtures.insertLevels()
+	if err == nil || !strings.Contains(err.Error(), "unknown record acme") {
+		t.Errorf("expected unknown ref error, got %v", err)
+	}
+
+	cycle := &Fixtures{Records: []FixtureRecord{
+		{Ref: "a", Type: "Account", Fields: map[string]interface{}{"ParentId": "@b"}},
+		{Ref: "b", Type: "Account", Fields: map[string]interface{}{"ParentId": "@a"}},
+	}}
+	_, err = cycle.insertLevels()
+	if err == nil {
+		t.Error("expected cycle to be detected")
+	}
+}
+
+func TestClient_SeedFiles(t *testing.T) {
+	dir := t.TempDir()
+	ioutil.WriteFile(filepath.Join(dir, "accounts.yaml"), []byte(accountFixtures), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "contacts.json"), []byte(contactFixtures), 0644)
+
+	var calls [][]map[string]interface{}
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			AllOrNone bool                     `json:"allOrNone"`
+			Records   []map[string]interface{} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if !body.AllOrNone {
+			t.Error("expected allOrNone to be set")
+		}
+		calls = append(calls, body.Records)
+
+		var results []string
+		for i := range body.Records {
+			results = append(results, fmt.Sprintf(`{"id":"id%d-%d","success":true,"errors":[]}`, len(calls), i))
+		}
+		w.Write([]byte("[" + strings.Join(results, ",") + "]"))
+	})
+
+	ids, err := client.SeedFiles(filepath.Join(dir, "accounts.yaml"), filepath.Join(dir, "contacts.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 insertion levels, got %d", len(calls))
+	}
+	if len(calls[0]) != 2 || calls[0][0]["Handle__c"] != "@acme" {
+		t.Errorf("unexpected first level %v", calls[0])
+	}
+	if ids["acme"] != "id1-0" || ids["jdoe"] != "id2-0" {
+		t.Errorf("unexpected ids %v", ids)
+	}
+	if calls[1][0]["AccountId"] != "id1-0" || calls[2][0]["ContactId"] != "id2-0" {
+		t.Errorf("expected references to be resolved, got %v", calls)
+	}
+}
+
+func TestClient_SeedFailure(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"success":false,"errors":[{"statusCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [Name]","fields":["Name"]}]}]`))
+	})
+
+	fixtures := &Fixtures{Records: []FixtureRecord{{Ref: "acme", Type: "Account"}}}
+	_, err := client.Seed(fixtures)
+	if err == nil || !strings.Contains(err.Error(), "failed to create acme") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestClient_SeedTypeBatches(t *testing.T) {
+	var calls [][]map[string]interface{}
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Records []map[string]interface{} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		calls = append(calls, body.Records)
+		if len(calls) > 1 {
+			w.Write([]byte(`[{"success":false,"errors":[{"statusCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [Name]"}]}]`))
+			return
+		}
+		var results []string
+		for i := range body.Records {
+			results = append(results, fmt.Sprintf(`{"id":"id%d","success":true,"errors":[]}`, i))
+		}
+		w.Write([]byte("[" + strings.Join(results, ",") + "]"))
+	})
+
+	fixtures := &Fixtures{}
+	for i := 0; i < 11; i++ {
+		fixtures.Records = append(fixtures.Records, FixtureRecord{Ref: fmt.Sprintf("r%d", i), Type: fmt.Sprintf("Object%02d__c", i)})
+	}
+	ids, err := client.Seed(fixtures)
+	if err == nil || !strings.Contains(err.Error(), "failed to create r10") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if len(calls) != 2 || len(calls[0]) != 10 || len(calls[1]) != 1 {
+		t.Fatalf("expected calls of 10 and 1 records, got %v", calls)
+	}
+	if len(ids) != 10 || ids["r0"] != "id0" || ids["r9"] != "id9" {
+		t.Errorf("expected the ids of the first call, got %v", ids)
+	}
+}
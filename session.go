@@ -0,0 +1,140 @@
+package simpleforce
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// sessionRenewal holds the session of a client, shared with its copies, and how to renew it, which is recorded by the
+// login methods supporting it.
+type sessionRenewal struct {
+	mu       sync.Mutex
+	renew    func(ctx context.Context, client *Client) error
+	observer SessionObserver
+
+	// tokenMu guards token and instanceURL apart from mu, which is held while the session is renewed, as well as the API
+	// version of the client and its copies.
+	tokenMu     sync.RWMutex
+	token       string
+	instanceURL string
+}
+
+// getSessionID returns the session ID, or OAuth access token, of the client.
+func (client *Client) getSessionID() string {
+	client.session.tokenMu.RLock()
+	defer client.session.tokenMu.RUnlock()
+	return client.session.token
+}
+
+// setSessionID replaces the session ID of the client, and of the copies sharing its session.
+func (client *Client) setSessionID(sessionID string) {
+	client.session.tokenMu.Lock()
+	defer client.session.tokenMu.Unlock()
+	client.session.token = sessionID
+}
+
+// getInstanceURL returns the URL requests of the client are sent to: the URL of its site, if set by WithSiteURL, or the
+// instance URL of its session.
+func (client *Client) getInstanceURL() string {
+	if client.siteURL != "" {
+		return client.siteURL
+	}
+	client.session.tokenMu.RLock()
+	defer client.session.tokenMu.RUnlock()
+	return client.session.instanceURL
+}
+
+// setInstanceURL replaces the instance URL of the client, and of the copies sharing its session.
+func (client *Client) setInstanceURL(instanceURL string) {
+	client.session.tokenMu.Lock()
+	defer client.session.tokenMu.Unlock()
+	client.session.instanceURL = instanceURL
+}
+
+// clone returns a copy of the client sharing its session, see WithAPIVersion.
+func (client *Client) clone() *Client {
+	client.session.tokenMu.RLock()
+	defer client.session.tokenMu.RUnlock()
+	override := *client
+	return &override
+}
+
+// swapInstanceURL replaces the instance URL like setInstanceURL, and returns the previous one.
+func (client *Client) swapInstanceURL(instanceURL string) string {
+	client.session.tokenMu.Lock()
+	defer client.session.tokenMu.Unlock()
+	previous := client.session.instanceURL
+	client.session.instanceURL = instanceURL
+	return previous
+}
+
+// setIfChanged sets *field to value unless it holds it already. Renewing the session of a client in use then doesn't
+// write the fields read by concurrent requests, such as the ID of the user, which don't change from one login to the
+// next.
+func setIfChanged(field *string, value string) {
+	if *field != value {
+		*field = value
+	}
+}
+
+// SessionObserver is called after every attempt to renew the session of a client, with the error of the attempt or
+// nil if the session was renewed.
+type SessionObserver func(err error)
+
+// SetAutoRenewSession makes the client renew its session when a request fails with ErrSessionExpired, e.g. after the
+// access token expired or was revoked, and send the request again once. The session is renewed with the credentials
+// of the last login, which must be LoginRefreshToken, LoginJWT or LoginPassword. LoginPassword only keeps the
+// password if automatic renewal was enabled before it was called.
+func (client *Client) SetAutoRenewSession(enabled bool) {
+	client.autoRenewSession = enabled
+}
+
+// OnSessionRenewal registers observer to be notified whenever the client renews its session, e.g. to record the new
+// session or to alert when the credentials stopped working. Only one observer is kept; nil removes it.
+func (client *Client) OnSessionRenewal(observer SessionObserver) {
+	client.session.mu.Lock()
+	defer client.session.mu.Unlock()
+	client.session.observer = observer
+}
+
+// setSessionRenewal records how to renew the session after a successful login.
+func (client *Client) setSessionRenewal(renew func(ctx context.Context, client *Client) error) {
+	client.session.mu.Lock()
+	defer client.session.mu.Unlock()
+	client.session.renew = renew
+}
+
+// renewSession renews the session of the client after req failed with err, and reports whether req can be sent again
+// with the new session. Concurrent requests failing with the same session renew it only once.
+func (client *Client) renewSession(req *http.Request, err error) bool {
+	if !client.autoRenewSession || !errors.Is(err, ErrSessionExpired) {
+		return false
+	}
+
+	client.session.mu.Lock()
+	if client.session.renew == nil {
+		client.session.mu.Unlock()
+		return false
+	}
+	if strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ") != client.getSessionID() {
+		// Already renewed by another request.
+		client.session.mu.Unlock()
+		return true
+	}
+	renewErr := client.session.renew(req.Context(), client)
+	observer := client.session.observer
+	client.session.mu.Unlock()
+
+	if observer != nil {
+		observer(renewErr)
+	}
+	if renewErr != nil {
+		client.logError("failed to renew session,", renewErr)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package simpleforce
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_SetAutoRenewSession(t *testing.T) {
+	tokens := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/oauth2/token" {
+			tokens++
+			w.Write([]byte(`{"access_token":"00D000000000062!AQ` + strings.Repeat("x", tokens) + `","instance_url":"` +
+				"http://" + r.Host + `","id":"https://login.salesforce.com/id/00D000000000062EAA/005000000000001AAA"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer 00D000000000062!AQxx" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`[{"message":"Session expired or invalid","errorCode":"INVALID_SESSION_ID"}]`))
+			return
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	})
+	if err := client.LoginRefreshToken("3MVG9_consumer_key", "secret", "5Aep861_refresh_token"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Disabled by default.
+	if _, err := client.Query("SELECT Id FROM Account"); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("expected ErrSessionExpired, got %v", err)
+	}
+
+	client.SetAutoRenewSession(true)
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	if tokens != 2 || client.getSessionID() != "00D000000000062!AQxx" {
+		t.Errorf("expected the session to be renewed once, got %d tokens, session %s", tokens, client.getSessionID())
+	}
+
+	// Sessions set from outside can't be renewed.
+	client.SetSidLoc("00D000000000062!AQzz", client.getInstanceURL())
+	if _, err := client.Query("SELECT Id FROM Account"); !errors.Is(err, ErrSessionExpired) || tokens != 2 {
+		t.Errorf("expected ErrSessionExpired without renewal, got %v", err)
+	}
+}
+
+func TestClient_AutoRenewPasswordSession(t *testing.T) {
+	logins := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/Soap/u/54.0" {
+			logins++
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><loginResponse><result>
+<serverUrl>http://` + r.Host + `/services/Soap/u/54.0/00D000000000062</serverUrl>
+<sessionId>00D000000000062!AQ` + strings.Repeat("x", logins) + `</sessionId><userId>005000000000001AAA</userId>
+<userInfo><userName>user@example.com</userName></userInfo></result></loginResponse></soapenv:Body></soapenv:Envelope>`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer 00D000000000062!AQxx" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`[{"message":"Session expired or invalid","errorCode":"INVALID_SESSION_ID"}]`))
+			return
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	})
+
+	// Credentials aren't kept unless renewal is enabled first.
+	if err := client.LoginPassword("user@example.com", "password", "token"); err != nil {
+		t.Fatal(err)
+	}
+	client.SetAutoRenewSession(true)
+	if _, err := client.Query("SELECT Id FROM Account"); !errors.Is(err, ErrSessionExpired) || logins != 1 {
+		t.Fatalf("expected ErrSessionExpired without renewal, got %v", err)
+	}
+
+	var renewals []error
+	client.OnSessionRenewal(func(err error) {
+		renewals = append(renewals, err)
+	})
+	logins = 0
+	if err := client.LoginPassword("user@example.com", "password", "token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	if logins != 2 || len(renewals) != 1 || renewals[0] != nil {
+		t.Errorf("expected the session to be renewed once, got %d logins, renewals %v", logins, renewals)
+	}
+}
+
+func TestClient_RenewSessionConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	tokens := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/oauth2/token" {
+			mu.Lock()
+			tokens++
+			token := strings.Repeat("x", tokens)
+			mu.Unlock()
+			w.Write([]byte(`{"access_token":"00D000000000062!AQ` + token + `","instance_url":"` +
+				"http://" + r.Host + `","id":"https://login.salesforce.com/id/00D000000000062EAA/005000000000001AAA"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer 00D000000000062!AQxx" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`[{"message":"Session expired or invalid","errorCode":"INVALID_SESSION_ID"}]`))
+			return
+		}
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	})
+	if err := client.LoginRefreshToken("3MVG9_consumer_key", "secret", "5Aep861_refresh_token"); err != nil {
+		t.Fatal(err)
+	}
+	client.SetAutoRenewSession(true)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Stagger the requests so that some are sent while the session is renewed.
+			time.Sleep(time.Duration(i) * time.Millisecond)
+			_, err := client.Query("SELECT Id FROM Account")
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if tokens != 2 {
+		t.Errorf("expected the session to be renewed once, got %d tokens", tokens)
+	}
+}
@@ -0,0 +1,54 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SetupAuditTrailEntry is a configuration change recorded in the Setup Audit Trail.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.object_reference.meta/object_reference/sforce_api_objects_setupaudittrail.htm
+type SetupAuditTrailEntry struct {
+	ID          string   `json:"Id"`
+	Action      string   `json:"Action"`
+	Section     string   `json:"Section"`
+	Display     string   `json:"Display"`
+	CreatedDate DateTime `json:"CreatedDate"`
+	CreatedByID string   `json:"CreatedById"`
+	CreatedBy   *struct {
+		Name     string `json:"Name"`
+		Username string `json:"Username"`
+	} `json:"CreatedBy"`
+	// DelegateUser is the username of the user who made the change while logged in as CreatedBy.
+	DelegateUser               string `json:"DelegateUser"`
+	ResponsibleNamespacePrefix string `json:"ResponsibleNamespacePrefix"`
+	CreatedByContext           string `json:"CreatedByContext"`
+	CreatedByIssuer            string `json:"CreatedByIssuer"`
+}
+
+// SetupAuditTrail returns the configuration changes made since the provided time, by ascending CreatedDate. All
+// pages of results are retrieved.
+func (client *Client) SetupAuditTrail(since time.Time) ([]SetupAuditTrailEntry, error) {
+	qb := Select("Id", "Action", "Section", "Display", "CreatedDate", "CreatedById", "CreatedBy.Name",
+		"CreatedBy.Username", "DelegateUser", "ResponsibleNamespacePrefix", "CreatedByContext", "CreatedByIssuer").
+		From("SetupAuditTrail").
+		OrderBy("CreatedDate")
+	if !since.IsZero() {
+		qb.Where(Gte("CreatedDate", since))
+	}
+	q, err := qb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []SetupAuditTrailEntry{}
+	err = client.queryEach(q, func(records json.RawMessage) error {
+		var page []SetupAuditTrailEntry
+		err := json.Unmarshal(records, &page)
+		entries = append(entries, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,37 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_SetupAuditTrail(t *testing.T) {
+	page := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page++
+		if page == 1 && !strings.Contains(r.URL.Query().Get("q"), "WHERE CreatedDate >= 2022-04-01T00:00:00Z ORDER BY CreatedDate") {
+			t.Errorf("unexpected query %s", r.URL.Query().Get("q"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"done":           page == 2,
+			"nextRecordsUrl": "/services/data/v" + DefaultAPIVersion + "/query/0r8-2000",
+			"records": []map[string]interface{}{{
+				"Id": "0Ym00000000000" + string(rune('0'+page)), "Action": "changedPassword", "Section": "Manage Users",
+				"CreatedDate": "2022-04-29T10:30:00.000+0000", "DelegateUser": "admin@example.com",
+				"CreatedBy": map[string]interface{}{"attributes": map[string]string{"type": "User"}, "Name": "Jane Doe"},
+			}},
+		})
+	})
+
+	entries, err := client.SetupAuditTrail(time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].ID != "0Ym000000000002" || entries[0].CreatedBy.Name != "Jane Doe" ||
+		entries[0].DelegateUser != "admin@example.com" {
+		t.Errorf("unexpected entries %v", entries)
+	}
+}
@@ -0,0 +1,272 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Access levels of share records.
+const (
+	AccessLevelNone = "None"
+	AccessLevelRead = "Read"
+	AccessLevelEdit = "Edit"
+	AccessLevelAll  = "All"
+
+	// RowCauseManual is the row cause of manually created shares.
+	RowCauseManual = "Manual"
+)
+
+// ErrInvalidShare is returned when a share request doesn't satisfy the constraints of its share object.
+var ErrInvalidShare = errors.New("invalid share")
+
+// ShareRequest describes a share record granting a user or group access to a record.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.apexcode.meta/apexcode/apex_bulk_sharing_creating_with_apex.htm
+type ShareRequest struct {
+	// Object is the object of the shared record, e.g. "Account", "Opportunity" or "Invoice__c".
+	Object        string
+	RecordID      string
+	UserOrGroupID string
+	// AccessLevel is either AccessLevelRead or AccessLevelEdit.
+	AccessLevel string
+	// RowCause is only supported for custom objects, where it defaults to RowCauseManual. Apex managed sharing uses
+	// the name of a sharing reason, e.g. "Recruiter__c".
+	RowCause string
+
+	// Access to the related records of an account, only used for AccountShare. Opportunities and cases default to
+	// AccessLevelNone; contact access is left to the org default if empty.
+	OpportunityAccessLevel string
+	CaseAccessLevel        string
+	ContactAccessLevel     string
+}
+
+// shareObject describes the fields of a share object.
+type shareObject struct {
+	name        string
+	parentField string
+	accessField string
+	custom      bool
+}
+
+// standardShareObjects lists the supported share objects of standard objects.
+var standardShareObjects = map[string]shareObject{
+	"account":     {"AccountShare", "AccountId", "AccountAccessLevel", false},
+	"opportunity": {"OpportunityShare", "OpportunityId", "OpportunityAccessLevel", false},
+	"case":        {"CaseShare", "CaseId", "CaseAccessLevel", false},
+	"lead":        {"LeadShare", "LeadId", "LeadAccessLevel", false},
+	"contact":     {"ContactShare", "ContactId", "ContactAccessLevel", false},
+}
+
+// shareObjectOf returns the share object of object.
+func shareObjectOf(object string) (shareObject, error) {
+	if share, ok := standardShareObjects[strings.ToLower(object)]; ok {
+		return share, nil
+	}
+	if strings.HasSuffix(object, "__c") {
+		return shareObject{strings.TrimSuffix(object, "__c") + "__Share", "ParentId", "AccessLevel", true}, nil
+	}
+	return shareObject{}, errors.Wrap(ErrInvalidShare, "manual sharing is not supported for "+object)
+}
+
+func validateAccessLevel(field, level string, allowNone bool) error {
+	switch level {
+	case AccessLevelRead, AccessLevelEdit:
+		return nil
+	case AccessLevelNone:
+		if allowNone {
+			return nil
+		}
+	}
+	return errors.Wrap(ErrInvalidShare, fmt.Sprintf("%q is not a valid %s", level, field))
+}
+
+// record validates req and converts it into a share record.
+func (req ShareRequest) record(client *Client) (*SObject, error) {
+	share, err := shareObjectOf(req.Object)
+	if err != nil {
+		return nil, err
+	}
+	if req.RecordID == "" || req.UserOrGroupID == "" {
+		return nil, errors.Wrap(ErrInvalidShare, "record and user or group are required")
+	}
+	if err = validateAccessLevel(share.accessField, req.AccessLevel, false); err != nil {
+		return nil, err
+	}
+
+	obj := client.SObject(share.name).
+		Set(share.parentField, req.RecordID).
+		Set("UserOrGroupId", req.UserOrGroupID).
+		Set(share.accessField, req.AccessLevel)
+
+	if share.custom {
+		rowCause := req.RowCause
+		if rowCause == "" {
+			rowCause = RowCauseManual
+		}
+		obj.Set("RowCause", rowCause)
+	} else if req.RowCause != "" && req.RowCause != RowCauseManual {
+		return nil, errors.Wrap(ErrInvalidShare, "only manual shares can be created for "+req.Object)
+	}
+
+	if share.name == "AccountShare" {
+		for _, related := range []struct{ field, level string }{
+			{"OpportunityAccessLevel", req.OpportunityAccessLevel},
+			{"CaseAccessLevel", req.CaseAccessLevel},
+			{"ContactAccessLevel", req.ContactAccessLevel},
+		} {
+			level := related.level
+			if level == "" {
+				if related.field == "ContactAccessLevel" {
+					continue
+				}
+				level = AccessLevelNone
+			}
+			if err = validateAccessLevel(related.field, level, true); err != nil {
+				return nil, err
+			}
+			obj.Set(related.field, level)
+		}
+	} else if req.OpportunityAccessLevel != "" || req.CaseAccessLevel != "" || req.ContactAccessLevel != "" {
+		return nil, errors.Wrap(ErrInvalidShare, "related access levels are only supported for accounts")
+	}
+
+	return obj, nil
+}
+
+// CreateShare creates a share record and returns its ID.
+func (client *Client) CreateShare(req ShareRequest) (string, error) {
+	return client.CreateShareContext(context.Background(), req)
+}
+
+// CreateShareContext is like CreateShare, with the request bound to ctx.
+func (client *Client) CreateShareContext(ctx context.Context, req ShareRequest) (string, error) {
+	results, err := client.CreateSharesContext(ctx, []ShareRequest{req}, false)
+	if err != nil {
+		return "", err
+	}
+	return results[0].ID, results[0].Err()
+}
+
+// CreateShares creates several share records at once, 200 per API call. Requests are validated before anything is
+// sent. If allOrNone is false, each record then succeeds or fails on its own; otherwise a RollbackError identifies
+// the records which prevented the others from being created.
+func (client *Client) CreateShares(reqs []ShareRequest, allOrNone bool) ([]SaveResult, error) {
+	return client.CreateSharesContext(context.Background(), reqs, allOrNone)
+}
+
+// CreateSharesContext is like CreateShares, with the requests bound to ctx.
+func (client *Client) CreateSharesContext(ctx context.Context, reqs []ShareRequest, allOrNone bool) ([]SaveResult, error) {
+	records := make([]*SObject, 0, len(reqs))
+	for _, req := range reqs {
+		obj, err := req.record(client)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, obj)
+	}
+	return client.saveCollectionContext(ctx, http.MethodPost, records, allOrNone)
+}
+
+// DeleteShare deletes a share record of object, e.g. ("Account", "00r...").
+func (client *Client) DeleteShare(object, shareID string) error {
+	return client.DeleteShareContext(context.Background(), object, shareID)
+}
+
+// DeleteShareContext is like DeleteShare, with the request bound to ctx.
+func (client *Client) DeleteShareContext(ctx context.Context, object, shareID string) error {
+	share, err := shareObjectOf(object)
+	if err != nil {
+		return err
+	}
+	return client.SObject(share.name).Set("Id", shareID).DeleteContext(ctx)
+}
+
+// SharingModel is the organization-wide default of an object for internal and external users, e.g. "Private",
+// "Read", "ReadWrite" or "ControlledByParent".
+type SharingModel struct {
+	Object   string `json:"QualifiedApiName"`
+	Internal string `json:"InternalSharingModel"`
+	External string `json:"ExternalSharingModel"`
+}
+
+// SharingModelDifference reports an object whose organization-wide default differs between two orgs.
+type SharingModelDifference struct {
+	Object string
+	// Audience is either "internal" or "external".
+	Audience string
+	A        string
+	B        string
+}
+
+// SharingModels returns the organization-wide defaults of the provided objects, keyed by object name. Objects which
+// don't exist are left out of the result.
+func (client *Client) SharingModels(objects ...string) (map[string]SharingModel, error) {
+	return client.SharingModelsContext(context.Background(), objects...)
+}
+
+// SharingModelsContext is like SharingModels, with the requests bound to ctx.
+func (client *Client) SharingModelsContext(ctx context.Context, objects ...string) (map[string]SharingModel, error) {
+	if len(objects) == 0 {
+		return nil, errors.New("at least one object is required")
+	}
+	names := make([]interface{}, 0, len(objects))
+	for _, object := range objects {
+		names = append(names, object)
+	}
+	q, err := Select("QualifiedApiName", "InternalSharingModel", "ExternalSharingModel").
+		From("EntityDefinition").
+		Where(In("QualifiedApiName", names...)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	models := map[string]SharingModel{}
+	err = client.queryEachContext(ctx, q, func(records json.RawMessage) error {
+		var page []SharingModel
+		err := json.Unmarshal(records, &page)
+		for _, model := range page {
+			models[model.Object] = model
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+// CompareSharingModels reports the objects whose organization-wide defaults differ between a and b, typically the
+// results of SharingModels called against two environments, e.g. a sandbox and production. Objects missing from
+// either side are reported with an empty sharing model. Differences are sorted by object.
+func CompareSharingModels(a, b map[string]SharingModel) []SharingModelDifference {
+	objects := map[string]bool{}
+	for object := range a {
+		objects[object] = true
+	}
+	for object := range b {
+		objects[object] = true
+	}
+	names := make([]string, 0, len(objects))
+	for object := range objects {
+		names = append(names, object)
+	}
+	sort.Strings(names)
+
+	diffs := []SharingModelDifference{}
+	for _, object := range names {
+		modelA, modelB := a[object], b[object]
+		if modelA.Internal != modelB.Internal {
+			diffs = append(diffs, SharingModelDifference{object, "internal", modelA.Internal, modelB.Internal})
+		}
+		if modelA.External != modelB.External {
+			diffs = append(diffs, SharingModelDifference{object, "external", modelA.External, modelB.External})
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,124 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_CreateShares(t *testing.T) {
+	var records []map[string]interface{}
+	deleted := ""
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var req struct {
+			Records []map[string]interface{} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		records = req.Records
+		results := []SaveResult{}
+		for range records {
+			results = append(results, SaveResult{ID: "00r000000000001", Success: true})
+		}
+		json.NewEncoder(w).Encode(results)
+	})
+
+	_, err := client.CreateShares([]ShareRequest{
+		{Object: "Account", RecordID: "001000000000001", UserOrGroupID: "005000000000001", AccessLevel: AccessLevelEdit, CaseAccessLevel: AccessLevelRead},
+		{Object: "Opportunity", RecordID: "006000000000001", UserOrGroupID: "00G000000000001", AccessLevel: AccessLevelRead},
+		{Object: "Invoice__c", RecordID: "a00000000000001", UserOrGroupID: "005000000000001", AccessLevel: AccessLevelRead, RowCause: "Recruiter__c"},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]interface{}{
+		{"attributes": map[string]interface{}{"type": "AccountShare"}, "AccountId": "001000000000001", "UserOrGroupId": "005000000000001",
+			"AccountAccessLevel": "Edit", "OpportunityAccessLevel": "None", "CaseAccessLevel": "Read"},
+		{"attributes": map[string]interface{}{"type": "OpportunityShare"}, "OpportunityId": "006000000000001", "UserOrGroupId": "00G000000000001",
+			"OpportunityAccessLevel": "Read"},
+		{"attributes": map[string]interface{}{"type": "Invoice__Share"}, "ParentId": "a00000000000001", "UserOrGroupId": "005000000000001",
+			"AccessLevel": "Read", "RowCause": "Recruiter__c"},
+	}
+	for i := range expected {
+		actual, _ := json.Marshal(records[i])
+		wanted, _ := json.Marshal(expected[i])
+		if string(actual) != string(wanted) {
+			t.Errorf("unexpected record:\n%s\n%s", actual, wanted)
+		}
+	}
+
+	invalid := []ShareRequest{
+		{Object: "Account", RecordID: "001000000000001", UserOrGroupID: "005000000000001", AccessLevel: AccessLevelAll},
+		{Object: "Account", RecordID: "001000000000001", UserOrGroupID: "005000000000001", AccessLevel: AccessLevelRead, CaseAccessLevel: "Full"},
+		{Object: "Opportunity", RecordID: "006000000000001", UserOrGroupID: "005000000000001", AccessLevel: AccessLevelRead, RowCause: "Team"},
+		{Object: "Opportunity", RecordID: "006000000000001", UserOrGroupID: "005000000000001", AccessLevel: AccessLevelRead, CaseAccessLevel: AccessLevelRead},
+		{Object: "Product2", RecordID: "01t000000000001", UserOrGroupID: "005000000000001", AccessLevel: AccessLevelRead},
+		{Object: "Invoice__c", UserOrGroupID: "005000000000001", AccessLevel: AccessLevelRead},
+	}
+	for _, req := range invalid {
+		if _, err := client.CreateShare(req); errors.Cause(err) != ErrInvalidShare {
+			t.Errorf("expected %v to be rejected, got %v", req, err)
+		}
+	}
+
+	if err = client.DeleteShare("Invoice__c", "02c000000000001"); err != nil || deleted != "/services/data/v"+DefaultAPIVersion+"/sobjects/Invoice__Share/02c000000000001" {
+		t.Errorf("unexpected deletion %s, %v", deleted, err)
+	}
+}
+
+func TestClient_CreateShareMissingResult(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	})
+
+	if _, err := client.CreateShare(ShareRequest{Object: "Account", RecordID: "001000000000001", UserOrGroupID: "005000000000001", AccessLevel: AccessLevelRead}); err == nil {
+		t.Error("expected error for a response without results")
+	}
+}
+
+func TestClient_SharingModels(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != "SELECT QualifiedApiName, InternalSharingModel, ExternalSharingModel FROM EntityDefinition WHERE QualifiedApiName IN ('Account', 'Invoice__c')" {
+			t.Errorf("unexpected query %s", q)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"done": true,
+			"records": []map[string]interface{}{
+				{"QualifiedApiName": "Account", "InternalSharingModel": "Private", "ExternalSharingModel": "Private"},
+				{"QualifiedApiName": "Invoice__c", "InternalSharingModel": "ReadWrite", "ExternalSharingModel": "Private"},
+			},
+		})
+	})
+
+	production, err := client.SharingModels("Account", "Invoice__c")
+	if err != nil || production["Account"].Internal != "Private" {
+		t.Fatalf("unexpected sharing models %v, %v", production, err)
+	}
+
+	sandbox := map[string]SharingModel{
+		"Account": {Object: "Account", Internal: "Read", External: "Private"},
+		"Lead":    {Object: "Lead", Internal: "ReadWrite", External: "Private"},
+	}
+	diffs := CompareSharingModels(production, sandbox)
+	expected := []SharingModelDifference{
+		{"Account", "internal", "Private", "Read"},
+		{"Invoice__c", "internal", "ReadWrite", ""},
+		{"Invoice__c", "external", "Private", ""},
+		{"Lead", "internal", "", "ReadWrite"},
+		{"Lead", "external", "", "Private"},
+	}
+	if len(diffs) != len(expected) {
+		t.Fatalf("unexpected differences %v", diffs)
+	}
+	for i := range expected {
+		if diffs[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], diffs[i])
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package simpleforce
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Record is a record flowing from Salesforce to a RecordSink.
+type Record struct {
+	Object string `json:"object"`
+	ID     string `json:"id"`
+	// Fields holds the fields of the record; it is nil for deleted records.
+	Fields  SObject `json:"fields,omitempty"`
+	Deleted bool    `json:"deleted,omitempty"`
+	// ModifiedAt is the SystemModstamp of the record, or its deletion time, if known.
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
+
+// NewRecord creates the Record of a queried SObject, without its attributes.
+func NewRecord(obj SObject) Record {
+	record := Record{Object: obj.Type(), ID: obj.ID(), Fields: SObject{}}
+	for key, value := range obj {
+		if key != sobjectClientKey && key != sobjectAttributesKey && key != sobjectETagKey {
+			record.Fields[key] = value
+		}
+	}
+	if modstamp, err := parseDateTime(obj.StringField("SystemModstamp")); err == nil {
+		record.ModifiedAt = modstamp
+	}
+	return record
+}
+
+// RecordSink receives records read from Salesforce, e.g. to publish them on a message bus. Writes may be buffered
+// until Flush, after which the records must be durably handed over: the callers of a sink, such as ExportQuery and
+// the sync package, consider records delivered once Flush returns.
+type RecordSink interface {
+	Write(ctx context.Context, records []Record) error
+	Flush(ctx context.Context) error
+}
+
+// RecordSinkFunc adapts a function to the RecordSink interface. Its Flush does nothing.
+type RecordSinkFunc func(ctx context.Context, records []Record) error
+
+// Write calls f.
+func (f RecordSinkFunc) Write(ctx context.Context, records []Record) error {
+	return f(ctx, records)
+}
+
+// Flush implements RecordSink.
+func (f RecordSinkFunc) Flush(ctx context.Context) error {
+	return nil
+}
+
+// JSONLinesSink writes records to a writer, such as a file, as JSON Lines.
+type JSONLinesSink struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+// NewJSONLinesSink creates a JSONLinesSink writing to w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{w: bufio.NewWriter(w)}
+}
+
+// Write implements RecordSink.
+func (s *JSONLinesSink) Write(ctx context.Context, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	encoder := json.NewEncoder(s.w)
+	for i := range records {
+		err := encoder.Encode(&records[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush implements RecordSink.
+func (s *JSONLinesSink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Flush()
+}
+
+// Producer publishes messages on a message bus, typically wrapping a Kafka, NATS or Pub/Sub client.
+type Producer interface {
+	Produce(ctx context.Context, key string, value []byte) error
+}
+
+// FlushingProducer is a Producer buffering messages until Flush.
+type FlushingProducer interface {
+	Producer
+	Flush(ctx context.Context) error
+}
+
+// ProducerSink publishes every record as a JSON message keyed by its ID, which keeps the changes of a record ordered
+// on buses partitioning by key.
+type ProducerSink struct {
+	Producer Producer
+}
+
+// Write implements RecordSink.
+func (s *ProducerSink) Write(ctx context.Context, records []Record) error {
+	for i := range records {
+		value, err := json.Marshal(&records[i])
+		if err != nil {
+			return err
+		}
+		err = s.Producer.Produce(ctx, records[i].ID, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush implements RecordSink, flushing the producer if it is a FlushingProducer.
+func (s *ProducerSink) Flush(ctx context.Context) error {
+	if producer, ok := s.Producer.(FlushingProducer); ok {
+		return producer.Flush(ctx)
+	}
+	return nil
+}
+
+// ExportQuery writes the records matching soql to sink, page by page, and flushes it. The number of records written
+// is returned.
+func (client *Client) ExportQuery(ctx context.Context, soql string, sink RecordSink) (int, error) {
+	count := 0
+	for q := soql; q != ""; {
+		page, err := client.QueryContext(ctx, q)
+		if err != nil {
+			return count, err
+		}
+		records := make([]Record, len(page.Records))
+		for i := range page.Records {
+			records[i] = NewRecord(page.Records[i])
+		}
+		if len(records) > 0 {
+			err = sink.Write(ctx, records)
+			if err != nil {
+				return count, err
+			}
+			count += len(records)
+		}
+		q = ""
+		if !page.Done {
+			q = page.NextRecordsURL
+		}
+	}
+	return count, sink.Flush(ctx)
+}
@@ -0,0 +1,66 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// memoryProducer collects produced messages until they are flushed.
+type memoryProducer struct {
+	pending, flushed []string
+	keys             []string
+}
+
+func (p *memoryProducer) Produce(ctx context.Context, key string, value []byte) error {
+	p.keys = append(p.keys, key)
+	p.pending = append(p.pending, string(value))
+	return nil
+}
+
+func (p *memoryProducer) Flush(ctx context.Context) error {
+	p.flushed = append(p.flushed, p.pending...)
+	p.pending = nil
+	return nil
+}
+
+func TestClient_ExportQuery(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"totalSize":2,"done":true,"records":[
+			{"attributes":{"type":"Account"},"Id":"0013000000Db2wKAAR","Name":"Acme","SystemModstamp":"2022-04-29T10:35:00.000+0000"},
+			{"attributes":{"type":"Account"},"Id":"0013000000Db2wLAAR","Name":"Globex","SystemModstamp":"2022-04-29T10:36:00.000+0000"}
+		]}`))
+	})
+
+	var buf bytes.Buffer
+	n, err := client.ExportQuery(context.Background(), "SELECT Id, Name, SystemModstamp FROM Account", NewJSONLinesSink(&buf))
+	if err != nil || n != 2 {
+		t.Fatalf("unexpected export %d, %v", n, err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("unexpected lines %q", lines)
+	}
+	var record Record
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Object != "Account" || record.ID != "0013000000Db2wKAAR" || record.Fields["Name"] != "Acme" ||
+		record.Fields[sobjectAttributesKey] != nil || record.ModifiedAt.IsZero() {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if strings.Contains(lines[0], sobjectClientKey) {
+		t.Errorf("the client leaked into the record: %s", lines[0])
+	}
+
+	producer := &memoryProducer{}
+	if _, err := client.ExportQuery(context.Background(), "SELECT Id, Name, SystemModstamp FROM Account", &ProducerSink{Producer: producer}); err != nil {
+		t.Fatal(err)
+	}
+	if len(producer.flushed) != 2 || len(producer.pending) != 0 || producer.keys[1] != "0013000000Db2wLAAR" {
+		t.Errorf("unexpected messages %+v", producer)
+	}
+}
@@ -0,0 +1,102 @@
+package simpleforce
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// snapshotSchemaVersion is the version of the snapshot format, increased whenever it changes incompatibly.
+const snapshotSchemaVersion = 1
+
+// SnapshotManifest describes a snapshot written by Snapshot.
+type SnapshotManifest struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	Object        string    `json:"object"`
+	Fields        []string  `json:"fields"`
+	RowCount      int       `json:"rowCount"`
+	CreatedAt     time.Time `json:"createdAt"`
+	// SHA256 is the hex encoded SHA-256 checksum of the JSON Lines written.
+	SHA256 string `json:"sha256"`
+}
+
+// WriteJSON writes the manifest as indented JSON, typically next to the snapshot.
+func (manifest *SnapshotManifest) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(manifest)
+}
+
+// snapshotFields returns the fields of object exported by default: all the fields reported by describe, except
+// base64 ones which can't be queried in bulk.
+func (client *Client) snapshotFields(ctx context.Context, object string) ([]string, error) {
+	meta, err := client.describeContext(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	described, err := describedFields(meta)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(described))
+	for _, field := range described {
+		if field.Type != "base64" {
+			fields = append(fields, field.Name)
+		}
+	}
+	return fields, nil
+}
+
+// Snapshot exports every record of object to w as JSON Lines, one JSON object per record, for backups and offline
+// analysis. Records are retrieved through QueryAll, so that deleted records still in the Recycle Bin and archived
+// records are part of the snapshot; deleted records have IsDeleted set if the field is exported. The listed fields are
+// exported, or all the fields of the object if there are none. The returned manifest describes the snapshot and allows
+// checking its integrity.
+func (client *Client) Snapshot(ctx context.Context, object string, w io.Writer, fields ...string) (*SnapshotManifest, error) {
+	if len(fields) == 0 {
+		var err error
+		fields, err = client.snapshotFields(ctx, object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe %s", object)
+		}
+	}
+	q, err := Select(fields...).From(object).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &SnapshotManifest{
+		SchemaVersion: snapshotSchemaVersion,
+		Object:        object,
+		Fields:        fields,
+		CreatedAt:     time.Now().UTC(),
+	}
+	checksum := sha256.New()
+	encoder := json.NewEncoder(io.MultiWriter(w, checksum))
+	err = client.queryAllEachContext(ctx, q, func(raw json.RawMessage) error {
+		var page []map[string]interface{}
+		err := json.Unmarshal(raw, &page)
+		if err != nil {
+			return err
+		}
+		for _, record := range page {
+			delete(record, sobjectAttributesKey)
+			err = encoder.Encode(record)
+			if err != nil {
+				return err
+			}
+			manifest.RowCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifest.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	return manifest, nil
+}
@@ -0,0 +1,50 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_Snapshot(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v54.0/sobjects/Document/describe":
+			w.Write([]byte(`{"name":"Document","fields":[{"name":"Id","type":"id"},{"name":"Name","type":"string"},{"name":"Body","type":"base64"}]}`))
+		case "/services/data/v54.0/queryAll":
+			if q := r.URL.Query().Get("q"); q != "SELECT Id, Name FROM Document" {
+				t.Errorf("unexpected query %s", q)
+			}
+			w.Write([]byte(`{"totalSize":2,"done":false,"nextRecordsUrl":"/services/data/v54.0/query/01gD0000002HU6KIAW-2000","records":[{"attributes":{"type":"Document"},"Id":"01530000000bAe1AAE","Name":"Logo"}]}`))
+		case "/services/data/v54.0/query/01gD0000002HU6KIAW-2000":
+			w.Write([]byte(`{"totalSize":2,"done":true,"records":[{"attributes":{"type":"Document"},"Id":"01530000000bAe2AAE","Name":null}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	manifest, err := client.Snapshot(context.Background(), "Document", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"Id":"01530000000bAe1AAE","Name":"Logo"}` + "\n" + `{"Id":"01530000000bAe2AAE","Name":null}` + "\n"
+	if buf.String() != want {
+		t.Errorf("unexpected snapshot %s", buf.String())
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	if manifest.SchemaVersion != 1 || manifest.RowCount != 2 || manifest.SHA256 != hex.EncodeToString(sum[:]) ||
+		strings.Join(manifest.Fields, ",") != "Id,Name" || manifest.CreatedAt.IsZero() {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+
+	var out bytes.Buffer
+	if err := manifest.WriteJSON(&out); err != nil || !strings.Contains(out.String(), `"rowCount": 2`) {
+		t.Errorf("unexpected manifest JSON %s, %v", out.String(), err)
+	}
+}
@@ -0,0 +1,154 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// soapEnvelope is a request of the partner SOAP API.
+type soapEnvelope struct {
+	XMLName xml.Name   `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope"`
+	Header  soapHeader `xml:"Header"`
+	// Body holds the call, named by its own XMLName.
+	Body interface{} `xml:"Body>call"`
+}
+
+type soapHeader struct {
+	SessionHeader struct {
+		XMLName   xml.Name `xml:"urn:partner.soap.sforce.com SessionHeader"`
+		SessionID string   `xml:"sessionId"`
+	}
+}
+
+// SOAPEnvelope builds the envelope of a SOAP request holding sessionID.
+type SOAPEnvelope func(sessionID string) ([]byte, error)
+
+// SOAPCall sends a request to a SOAP API of the instance, e.g. "m" for the Metadata API or "u" for the partner API,
+// and returns the response envelope. action is the SOAPAction of the request. The envelope is built again with the
+// new session if the session of the client is renewed, see SetAutoRenewSession. SOAP faults are returned as
+// SalesforceError.
+func (client *Client) SOAPCall(ctx context.Context, api, action string, envelope SOAPEnvelope) ([]byte, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	sessionID := client.getSessionID()
+	data, err := client.sendSOAP(ctx, api, action, envelope)
+	if errors.Is(err, ErrSessionExpired) && client.getSessionID() != sessionID {
+		// The session was renewed, but the envelope replayed by httpResponse still held the expired one.
+		data, err = client.sendSOAP(ctx, api, action, envelope)
+	}
+	return data, err
+}
+
+// sendSOAP sends the envelope holding the session of the client, and returns the response envelope.
+func (client *Client) sendSOAP(ctx context.Context, api, action string, envelope SOAPEnvelope) ([]byte, error) {
+	reqData, err := envelope(client.getSessionID())
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "text/xml; charset=UTF-8")
+	header.Set("SOAPAction", action)
+	u := client.getInstanceURL() + "/services/Soap/" + api + "/" + client.APIVersion()
+	resp, err := client.httpResponseContext(ctx, http.MethodPost, u, bytes.NewReader(reqData), header)
+	if err != nil {
+		client.logError("SOAP", action, "call failed:", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
+
+// soapCall sends a call of the partner SOAP API and decodes the response envelope into response. request is marshaled
+// into the body of the envelope and must set its XMLName in the partner namespace, e.g.
+// `xml:"urn:partner.soap.sforce.com undelete"`.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api.meta/api/sforce_api_calls_list.htm
+func (client *Client) soapCall(ctx context.Context, action string, request, response interface{}) error {
+	data, err := client.SOAPCall(ctx, "u", action, func(sessionID string) ([]byte, error) {
+		envelope := soapEnvelope{Body: request}
+		envelope.Header.SessionHeader.SessionID = sessionID
+		reqData, err := xml.Marshal(envelope)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(xml.Header), reqData...), nil
+	})
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(data, response)
+}
+
+// soapFieldName matches the names of fields, which are written as XML elements.
+var soapFieldName = regexp.MustCompile(`^[A-Za-z]\w*$`)
+
+// soapSObject is a record of the partner SOAP API. Nil fields are cleared through fieldsToNull.
+type soapSObject struct {
+	Type   string
+	ID     string
+	Fields map[string]interface{}
+}
+
+// MarshalXML writes the type and ID of the record, followed by its fields in name order.
+func (obj soapSObject) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	const sobjectNamespace = "urn:sobject.partner.soap.sforce.com"
+	names := make([]string, 0, len(obj.Fields))
+	for name := range obj.Fields {
+		if !soapFieldName.MatchString(name) {
+			return errors.Errorf("invalid field name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	write := func(name, value string) error {
+		return e.EncodeElement(value, xml.StartElement{Name: xml.Name{Space: sobjectNamespace, Local: name}})
+	}
+	if err = write("type", obj.Type); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if obj.Fields[name] == nil {
+			if err = write("fieldsToNull", name); err != nil {
+				return err
+			}
+		}
+	}
+	if obj.ID != "" {
+		if err = write("Id", obj.ID); err != nil {
+			return err
+		}
+	}
+	for _, name := range names {
+		var value string
+		switch v := obj.Fields[name].(type) {
+		case nil:
+			continue
+		case time.Time:
+			value = v.UTC().Format(time.RFC3339)
+		case DateTime:
+			value = v.UTC().Format(time.RFC3339)
+		default:
+			value = fmt.Sprint(v)
+		}
+		if err = write(name, value); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
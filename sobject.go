@@ -2,9 +2,11 @@ package simpleforce
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"log"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -15,6 +17,7 @@ const (
 	sobjectAttributesKey          = "attributes" // points to the attributes structure which should be common to all SObjects.
 	sobjectIDKey                  = "Id"
 	sobjectExternalIDFieldNameKey = "ExternalIDField"
+	sobjectETagKey                = "__etag__" // private attribute holding the ETag of the record as last read.
 )
 
 var (
@@ -53,25 +56,37 @@ type SObjectAttributes struct {
 	URL  string `json:"url"`
 }
 
-// Describe queries the metadata of an SObject using the "describe" API.
+// Describe queries the metadata of an SObject using the "describe" API. Metadata is cached for ten minutes and shared
+// between callers, it must not be modified; see Client.ResetDescribeCache.
 // Ref: https://developer.salesforce.com/docs/atlas.en-us.214.0.api_rest.meta/api_rest/resources_sobject_describe.htm
 func (obj *SObject) Describe() *SObjectMeta {
+	return obj.DescribeContext(context.Background())
+}
+
+// DescribeContext is like Describe, with the request bound to ctx.
+func (obj *SObject) DescribeContext(ctx context.Context) *SObjectMeta {
 	if obj.Type() == "" || obj.client() == nil {
 		// Sanity check.
 		return nil
 	}
-	url := obj.client().makeURL("sobjects/" + obj.Type() + "/describe")
-	data, err := obj.client().httpRequest(http.MethodGet, url, nil)
+	meta, err := obj.client().describeContext(ctx, obj.Type())
 	if err != nil {
 		return nil
 	}
+	return meta
+}
 
-	var meta SObjectMeta
-	err = json.Unmarshal(data, &meta)
-	if err != nil {
-		return nil
+// fieldNames returns the names of the fields described by meta, in describe order.
+func (meta *SObjectMeta) fieldNames() []string {
+	rawFields, _ := (*meta)["fields"].([]interface{})
+	names := make([]string, 0, len(rawFields))
+	for _, rawField := range rawFields {
+		field, _ := rawField.(map[string]interface{})
+		if name, _ := field["name"].(string); name != "" {
+			names = append(names, name)
+		}
 	}
-	return &meta
+	return names
 }
 
 // Get retrieves all the data fields of an SObject. If id is provided, the SObject with the provided external ID will
@@ -80,6 +95,11 @@ func (obj *SObject) Describe() *SObjectMeta {
 // If query is successful, the SObject is updated in-place and exact same address is returned; otherwise, nil is
 // returned if failed.
 func (obj *SObject) Get(id ...string) *SObject {
+	return obj.GetContext(context.Background(), id...)
+}
+
+// GetContext is like Get, with the request bound to ctx.
+func (obj *SObject) GetContext(ctx context.Context, id ...string) *SObject {
 	if obj.Type() == "" || obj.client() == nil {
 		// Sanity check.
 		return nil
@@ -90,24 +110,44 @@ func (obj *SObject) Get(id ...string) *SObject {
 		oid = id[0]
 	}
 	if oid == "" {
-		log.Println(logPrefix, "object id not found.")
+		obj.client().logError("object id not found.")
 		return nil
 	}
 
-	url := obj.client().makeURL("sobjects/" + obj.Type() + "/" + oid)
-	data, err := obj.client().httpRequest(http.MethodGet, url, nil)
+	err := obj.getRecord(ctx, oid, nil, nil)
 	if err != nil {
-		log.Println(logPrefix, "http request failed,", err)
+		obj.client().logError("failed to get record,", err)
 		return nil
 	}
 
-	err = json.Unmarshal(data, obj)
+	return obj
+}
+
+// getRecord reads the fields of the record oid into the SObject, along with its ETag. Only fields are read, unless
+// empty. header is added to the request.
+func (obj *SObject) getRecord(ctx context.Context, oid string, fields []string, header http.Header) error {
+	u := obj.client().sobjectURL(obj.Type() + "/" + oid)
+	if len(fields) > 0 {
+		u += "?fields=" + url.QueryEscape(strings.Join(fields, ","))
+	}
+	resp, err := obj.client().httpResponseContext(ctx, http.MethodGet, u, nil, header)
 	if err != nil {
-		log.Println(logPrefix, "json decode failed,", err)
-		return nil
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
 	}
 
-	return obj
+	err = json.Unmarshal(data, obj)
+	if err != nil {
+		return err
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		(*obj)[sobjectETagKey] = etag
+	}
+	return nil
 }
 
 // Create posts the JSON representation of the SObject to salesforce to create the entry.
@@ -115,6 +155,11 @@ func (obj *SObject) Get(id ...string) *SObject {
 // returned for failures.
 // Ref: https://developer.salesforce.com/docs/atlas.en-us.214.0.api_rest.meta/api_rest/dome_sobject_create.htm
 func (obj *SObject) Create() *SObject {
+	return obj.CreateContext(context.Background())
+}
+
+// CreateContext is like Create, with the request bound to ctx.
+func (obj *SObject) CreateContext(ctx context.Context) *SObject {
 	if obj.Type() == "" || obj.client() == nil {
 		// Sanity check.
 		return nil
@@ -124,20 +169,20 @@ func (obj *SObject) Create() *SObject {
 	reqObj := obj.makeCopy()
 	reqData, err := json.Marshal(reqObj)
 	if err != nil {
-		log.Println(logPrefix, "failed to convert sobject to json,", err)
+		obj.client().logError("failed to convert sobject to json,", err)
 		return nil
 	}
 
-	url := obj.client().makeURL("sobjects/" + obj.Type() + "/")
-	respData, err := obj.client().httpRequest(http.MethodPost, url, bytes.NewReader(reqData))
+	url := obj.client().sobjectURL(obj.Type() + "/")
+	respData, err := obj.client().httpRequestContext(ctx, http.MethodPost, url, bytes.NewReader(reqData))
 	if err != nil {
-		log.Println(logPrefix, "failed to process http request,", err)
+		obj.client().logError("failed to process http request,", err)
 		return nil
 	}
 
 	err = obj.setIDFromResponseData(respData)
 	if err != nil {
-		log.Println(logPrefix, "failed to parse response,", err)
+		obj.client().logError("failed to parse response,", err)
 		return nil
 	}
 
@@ -147,6 +192,11 @@ func (obj *SObject) Create() *SObject {
 // Update updates SObject in place. Upon successful, same SObject is returned for chained access.
 // ID is required.
 func (obj *SObject) Update() *SObject {
+	return obj.UpdateContext(context.Background())
+}
+
+// UpdateContext is like Update, with the request bound to ctx.
+func (obj *SObject) UpdateContext(ctx context.Context) *SObject {
 	if obj.Type() == "" || obj.client() == nil || obj.ID() == "" {
 		// Sanity check.
 		return nil
@@ -156,34 +206,72 @@ func (obj *SObject) Update() *SObject {
 	reqObj := obj.makeCopy()
 	reqData, err := json.Marshal(reqObj)
 	if err != nil {
-		log.Println(logPrefix, "failed to convert sobject to json,", err)
+		obj.client().logError("failed to convert sobject to json,", err)
 		return nil
 	}
 
-	queryBase := "sobjects/"
-	if obj.client().useToolingAPI {
-		queryBase = "tooling/sobjects/"
-	}
-	url := obj.client().makeURL(queryBase + obj.Type() + "/" + obj.ID())
-	respData, err := obj.client().httpRequest(http.MethodPatch, url, bytes.NewReader(reqData))
+	url := obj.recordURL()
+	respData, err := obj.client().httpRequestContext(ctx, http.MethodPatch, url, bytes.NewReader(reqData))
 	if err != nil {
-		log.Println(logPrefix, "failed to process http request,", err)
+		obj.client().logError("failed to process http request,", err)
 		return nil
 	}
-	log.Println(string(respData))
+	obj.client().logDebug(obj.client().Redact(string(respData)))
 
 	return obj
 }
 
+// UpdateIfUnmodified updates SObject in place on condition that the record wasn't modified since it was read, based
+// on its LastModifiedDate field. ErrConflict is returned if someone else modified the record in the meantime; read
+// the record again, e.g. with Get, and retry the update. ID and LastModifiedDate are required.
+func (obj *SObject) UpdateIfUnmodified() error {
+	return obj.UpdateIfUnmodifiedContext(context.Background())
+}
+
+// UpdateIfUnmodifiedContext is like UpdateIfUnmodified, with the request bound to ctx.
+func (obj *SObject) UpdateIfUnmodifiedContext(ctx context.Context) error {
+	if obj.Type() == "" || obj.client() == nil || obj.ID() == "" {
+		// Sanity check.
+		return ErrFailure
+	}
+	lastModified, err := parseDateTime(obj.StringField("LastModifiedDate"))
+	if err != nil {
+		return errors.Wrap(err, "LastModifiedDate is required to detect conflicts")
+	}
+
+	reqData, err := json.Marshal(obj.makeCopy())
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{"If-Unmodified-Since": {lastModified.UTC().Format(http.TimeFormat)}}
+	resp, err := obj.client().httpResponseContext(ctx, http.MethodPatch, obj.recordURL(), bytes.NewReader(reqData), header)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// recordURL returns the URL of the record resource of the SObject.
+func (obj *SObject) recordURL() string {
+	return obj.client().sobjectURL(obj.Type() + "/" + obj.ID())
+}
+
 // Upsert creates SObject or updates existing SObject in place. Upon successful upsert, same SObject is returned for chained access.
 // ID, ExternalIDField and Type are required. ID is the value of the external ID in this case.
 func (obj *SObject) Upsert() *SObject {
-	log.Println(logPrefix, "ExternalID:", obj.ExternalID())
-	log.Println(logPrefix, "ExternalIDField:", obj.ExternalIDFieldName())
+	return obj.UpsertContext(context.Background())
+}
+
+// UpsertContext is like Upsert, with the request bound to ctx.
+func (obj *SObject) UpsertContext(ctx context.Context) *SObject {
+	obj.client().logDebug("ExternalID:", obj.ExternalID())
+	obj.client().logDebug("ExternalIDField:", obj.ExternalIDFieldName())
 	if obj.Type() == "" || obj.client() == nil || obj.ExternalIDFieldName() == "" ||
 		obj.ExternalID() == "" {
 		// Sanity check.
-		log.Println(logPrefix, "required fields are missing")
+		obj.client().logError("required fields are missing")
 		return nil
 	}
 
@@ -191,19 +279,14 @@ func (obj *SObject) Upsert() *SObject {
 	reqObj := obj.makeCopy()
 	reqData, err := json.Marshal(reqObj)
 	if err != nil {
-		log.Println(logPrefix, "failed to convert sobject to json,", err)
+		obj.client().logError("failed to convert sobject to json,", err)
 		return nil
 	}
 
-	queryBase := "sobjects/"
-	if obj.client().useToolingAPI {
-		queryBase = "tooling/sobjects/"
-	}
-	url := obj.client().
-		makeURL(queryBase + obj.Type() + "/" + obj.ExternalIDFieldName() + "/" + obj.ExternalID())
-	respData, err := obj.client().httpRequest(http.MethodPatch, url, bytes.NewReader(reqData))
+	url := obj.client().sobjectURL(obj.Type() + "/" + obj.ExternalIDFieldName() + "/" + obj.ExternalID())
+	respData, err := obj.client().httpRequestContext(ctx, http.MethodPatch, url, bytes.NewReader(reqData))
 	if err != nil {
-		log.Println(logPrefix, "failed to process http request,", err)
+		obj.client().logError("failed to process http request,", err)
 		return nil
 	}
 
@@ -212,7 +295,7 @@ func (obj *SObject) Upsert() *SObject {
 	if len(respData) > 0 {
 		err = obj.setIDFromResponseData(respData)
 		if err != nil {
-			log.Println(logPrefix, "failed to parse response,", err)
+			obj.client().logError("failed to parse response,", err)
 			return nil
 		}
 	}
@@ -220,9 +303,64 @@ func (obj *SObject) Upsert() *SObject {
 	return obj
 }
 
+// UpsertByExternalID creates the record whose external ID field has value, or updates it if it exists, and reports
+// whether it was created. The ID of the SObject is set to the ID of the record when the response holds it: always on
+// creation, and on update with API versions 46.0 and later only, older ones answering updates with no body. field and
+// value don't need to be set on the SObject, and are never sent in the request body.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/dome_upsert.htm
+func (obj *SObject) UpsertByExternalID(field, value string) (created bool, err error) {
+	return obj.UpsertByExternalIDContext(context.Background(), field, value)
+}
+
+// UpsertByExternalIDContext is like UpsertByExternalID, with the request bound to ctx.
+func (obj *SObject) UpsertByExternalIDContext(ctx context.Context, field, value string) (created bool, err error) {
+	if obj.Type() == "" || obj.client() == nil || field == "" || value == "" {
+		// Sanity check.
+		return false, ErrFailure
+	}
+
+	reqObj := obj.makeCopy()
+	delete(reqObj, field)
+	reqData, err := json.Marshal(reqObj)
+	if err != nil {
+		return false, err
+	}
+
+	u := obj.client().sobjectURL(obj.Type() + "/" + field + "/" + url.PathEscape(value))
+	resp, err := obj.client().httpResponseContext(ctx, http.MethodPatch, u, bytes.NewReader(reqData), nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	// Recent API versions answer updates with 200 and the ID of the record, older ones with 204 and no body.
+	var result struct {
+		ID string `json:"id"`
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to parse response")
+		}
+	}
+	created = resp.StatusCode == http.StatusCreated
+	if created && result.ID == "" {
+		return false, errors.New("request was unsuccessful")
+	}
+	if result.ID != "" {
+		obj.setID(result.ID)
+	}
+	return created, nil
+}
+
 // Delete deletes an SObject record identified by external ID. nil is returned if the operation completes successfully;
 // otherwise an error is returned
 func (obj *SObject) Delete(id ...string) error {
+	return obj.DeleteContext(context.Background(), id...)
+}
+
+// DeleteContext is like Delete, with the request bound to ctx.
+func (obj *SObject) DeleteContext(ctx context.Context, id ...string) error {
 	if obj.Type() == "" || obj.client() == nil {
 		// Sanity check
 		return ErrFailure
@@ -236,9 +374,9 @@ func (obj *SObject) Delete(id ...string) error {
 		return ErrFailure
 	}
 
-	url := obj.client().makeURL("sobjects/" + obj.Type() + "/" + obj.ID())
-	log.Println(url)
-	_, err := obj.client().httpRequest(http.MethodDelete, url, nil)
+	url := obj.client().sobjectURL(obj.Type() + "/" + oid)
+	obj.client().logDebug(url)
+	_, err := obj.client().httpRequestContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return err
 	}
@@ -282,7 +420,9 @@ func (obj *SObject) StringField(key string) string {
 }
 
 // SObjectField accesses a field in the SObject as another SObject. This is only applicable if the field is an external
-// ID to another object. The typeName of the SObject must be provided. <nil> is returned if the field is empty.
+// ID to another object, or a parent record queried through a relationship, e.g. Account of
+// "SELECT Name, Account.Name FROM Contact". The typeName of the SObject must be provided for IDs, parent records hold
+// their own type. <nil> is returned if the field is empty.
 func (obj *SObject) SObjectField(typeName, key string) *SObject {
 	// First check if there's an associated ID directly.
 	oid := obj.StringField(key)
@@ -295,8 +435,7 @@ func (obj *SObject) SObjectField(typeName, key string) *SObject {
 	}
 
 	// Secondly, check if this could be a linked object, which doesn't have an ID but has the attributes.
-	linkedObjRaw := obj.InterfaceField(key)
-	linkedObjMapper, ok := linkedObjRaw.(map[string]interface{})
+	linkedObjMapper, ok := relatedMap(obj.InterfaceField(key))
 	if !ok {
 		return nil
 	}
@@ -308,19 +447,22 @@ func (obj *SObject) SObjectField(typeName, key string) *SObject {
 	// Reusing typeName here, which is ok
 	typeName, _ = attrs["type"].(string)
 	url, _ := attrs["url"].(string)
-	if typeName == "" || url == "" {
+	if typeName == "" {
 		return nil
 	}
 
-	// Both type and url exist in attributes, this is a linked object!
-	// Get the ID from URL.
-	rIndex := strings.LastIndex(url, "/")
-	if rIndex == -1 || rIndex+1 == len(url) {
-		// hmm... this shouldn't happen, unless the URL is hand crafted.
-		log.Println(logPrefix, "invalid url,", url)
-		return nil
+	// The type exists in attributes, this is a linked object!
+	// Get the ID from URL, or from the Id field of the records which have no URL, e.g. in ChildRecords.
+	oid, _ = linkedObjMapper[sobjectIDKey].(string)
+	if url != "" {
+		rIndex := strings.LastIndex(url, "/")
+		if rIndex == -1 || rIndex+1 == len(url) {
+			// hmm... this shouldn't happen, unless the URL is hand crafted.
+			obj.client().logError("invalid url,", url)
+			return nil
+		}
+		oid = url[rIndex+1:]
 	}
-	oid = url[rIndex+1:]
 
 	object := obj.client().SObject(typeName)
 	object.setID(oid)
@@ -331,6 +473,63 @@ func (obj *SObject) SObjectField(typeName, key string) *SObject {
 	return object
 }
 
+// ChildRecords accesses a field in the SObject holding the records of a child relationship subquery, e.g. Contacts of
+// "SELECT Name, (SELECT Id FROM Contacts) FROM Account". <nil> is returned if the field is empty. Salesforce returns
+// large child relationships in pages, which are retrieved with the Next method of the result.
+func (obj *SObject) ChildRecords(key string) *QueryResult {
+	mapper, ok := relatedMap(obj.InterfaceField(key))
+	if !ok {
+		return nil
+	}
+	rawRecords, ok := mapper["records"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := &QueryResult{}
+	result.Done, _ = mapper["done"].(bool)
+	result.NextRecordsURL, _ = mapper["nextRecordsUrl"].(string)
+	if totalSize, ok := mapper["totalSize"].(float64); ok {
+		result.TotalSize = int(totalSize)
+	}
+	result.Records = make([]SObject, 0, len(rawRecords))
+	for _, rawRecord := range rawRecords {
+		record, ok := relatedMap(rawRecord)
+		if !ok {
+			continue
+		}
+		object := SObject(record)
+		object.setClient(obj.client())
+		result.Records = append(result.Records, object)
+	}
+	return result
+}
+
+// RelatedRecords returns the records of a child relationship subquery, see ChildRecords. Only the records of the first
+// page are returned; nil is returned if the field is empty.
+func (obj *SObject) RelatedRecords(key string) []SObject {
+	result := obj.ChildRecords(key)
+	if result == nil {
+		return nil
+	}
+	return result.Records
+}
+
+// relatedMap returns the fields of a nested record or subquery result decoded from JSON.
+func relatedMap(value interface{}) (map[string]interface{}, bool) {
+	switch mapper := value.(type) {
+	case map[string]interface{}:
+		return mapper, true
+	case SObject:
+		return mapper, true
+	case *SObject:
+		if mapper != nil {
+			return *mapper, true
+		}
+	}
+	return nil, false
+}
+
 // InterfaceField accesses a field in the SObject as raw interface. This allows access to any type of fields.
 func (obj *SObject) InterfaceField(key string) interface{} {
 	return (*obj)[key]
@@ -412,6 +611,7 @@ func (obj *SObject) makeCopy() map[string]interface{} {
 			key == sobjectAttributesKey ||
 			key == sobjectIDKey ||
 			key == sobjectExternalIDFieldNameKey ||
+			key == sobjectETagKey ||
 			key == obj.ExternalIDFieldName() {
 			continue
 		}
@@ -432,12 +632,12 @@ func (obj *SObject) setIDFromResponseData(respData []byte) error {
 	}
 	err := json.Unmarshal(respData, &respVal)
 	if err != nil {
-		log.Println(logPrefix, "failed to process response data,", err)
+		obj.client().logError("failed to process response data,", err)
 		return err
 	}
 
 	if !respVal.Success || respVal.ID == "" {
-		log.Println(logPrefix, "unsuccessful")
+		obj.client().logError("unsuccessful")
 		return errors.New("request was unsuccessful")
 	}
 
@@ -1,11 +1,15 @@
 package simpleforce
 
 import (
+	"io/ioutil"
 	"log"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
 func TestSObject_AttributesField(t *testing.T) {
@@ -76,6 +80,59 @@ func TestSObject_SObjectField(t *testing.T) {
 	}
 }
 
+func TestSObject_Relationships(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"totalSize":1,"done":true,"records":[{
+			"attributes":{"type":"Account","url":"/services/data/v54.0/sobjects/Account/001D000000IqhSLIAZ"},
+			"Name":"Acme",
+			"Owner":{"attributes":{"type":"User","url":"/services/data/v54.0/sobjects/User/005D0000001KyEIIA0"},"Name":"Jane"},
+			"Parent":null,
+			"Contacts":{"totalSize":3,"done":false,"nextRecordsUrl":"/services/data/v54.0/query/01gD0000002HU6KIAW-2000","records":[
+				{"attributes":{"type":"Contact"},"Id":"003D000000QqWfNIAV","LastName":"Doe"},
+				{"attributes":{"type":"Contact"},"Id":"003D000000QqWfOIAV","LastName":"Roe"}
+			]}
+		}]}`))
+	})
+
+	result, err := client.Query("SELECT Name, Owner.Name, Parent.Name, (SELECT Id, LastName FROM Contacts) FROM Account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := &result.Records[0]
+
+	owner := account.SObjectField("User", "Owner")
+	if owner == nil || owner.Type() != "User" || owner.ID() != "005D0000001KyEIIA0" || owner.StringField("Name") != "Jane" ||
+		owner.client() != client {
+		t.Errorf("unexpected owner %v", owner)
+	}
+	if account.SObjectField("Account", "Parent") != nil || account.ChildRecords("Parent") != nil {
+		t.Error("expected nil for empty relationship")
+	}
+
+	contacts := account.ChildRecords("Contacts")
+	if contacts == nil || contacts.TotalSize != 3 || contacts.Done ||
+		contacts.NextRecordsURL != "/services/data/v54.0/query/01gD0000002HU6KIAW-2000" {
+		t.Fatalf("unexpected contacts %+v", contacts)
+	}
+	records := account.RelatedRecords("Contacts")
+	if len(records) != 2 || records[1].ID() != "003D000000QqWfOIAV" || records[1].Type() != "Contact" ||
+		records[1].StringField("LastName") != "Roe" || records[1].client() != client {
+		t.Errorf("unexpected records %v", records)
+	}
+	if account.RelatedRecords("Name") != nil {
+		t.Error("expected nil for a field which isn't a relationship")
+	}
+
+	// Child records without url are linked by their Id.
+	detached := SObject{"Contact": map[string]interface{}{
+		"attributes": map[string]interface{}{"type": "Contact"},
+		"Id":         "003D000000QqWfNIAV",
+	}}
+	if contact := detached.SObjectField("Contact", "Contact"); contact == nil || contact.ID() != "003D000000QqWfNIAV" {
+		t.Errorf("unexpected contact %v", contact)
+	}
+}
+
 func TestSObject_Describe(t *testing.T) {
 	client := requireClient(t, true)
 	meta := client.SObject("Case").Describe()
@@ -199,6 +256,103 @@ func TestSObject_Update(t *testing.T) {
 	}
 }
 
+func TestSObject_CRUDMock(t *testing.T) {
+	records := map[string]string{}
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch path := strings.TrimPrefix(r.URL.Path, "/services/data/v54.0/sobjects/Case/"); {
+		case r.Method == http.MethodPost && path == "":
+			records["5003000000D8cuIAAR"] = string(body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"5003000000D8cuIAAR","success":true,"errors":[]}`))
+		case records[path] == "":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"attributes":{"type":"Case"},"Id":"` + path + `",` + strings.TrimPrefix(records[path], "{")))
+		case r.Method == http.MethodPatch:
+			records[path] = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			delete(records, path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	obj := client.SObject("Case").Set("Subject", "Created").Create()
+	if obj == nil || obj.ID() != "5003000000D8cuIAAR" {
+		t.Fatalf("unexpected created object %v", obj)
+	}
+
+	obj = client.SObject("Case").Get("5003000000D8cuIAAR")
+	if obj == nil || obj.StringField("Subject") != "Created" {
+		t.Fatalf("unexpected retrieved object %v", obj)
+	}
+
+	if obj.Set("Subject", "Updated").Update() == nil {
+		t.Fatal("update failed")
+	}
+	if strings.Contains(records[obj.ID()], `"Id"`) || !strings.Contains(records[obj.ID()], "Updated") {
+		t.Errorf("unexpected update %s", records[obj.ID()])
+	}
+	if client.SObject("Case").Get(obj.ID()).StringField("Subject") != "Updated" {
+		t.Error("update not retrieved")
+	}
+
+	if err := obj.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if client.SObject("Case").Get(obj.ID()) != nil {
+		t.Error("expected the deleted object not to be found")
+	}
+	if err := obj.Delete(); err == nil {
+		t.Error("expected an error deleting a missing object")
+	}
+}
+
+func TestSObject_UpdateIfUnmodified(t *testing.T) {
+	modified := false
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || !strings.HasSuffix(r.URL.Path, "/sobjects/Case/5003000000D8cuIAAR") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("If-Unmodified-Since"); got != "Fri, 29 Apr 2022 10:30:00 GMT" {
+			t.Errorf("unexpected If-Unmodified-Since header %q", got)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(body), "LastModifiedDate") {
+			t.Errorf("LastModifiedDate must not be sent: %s", body)
+		}
+		if modified {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`[{"errorCode":"PRECONDITION_FAILED","message":"The requested entity has been modified."}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	obj := client.SObject("Case").
+		Set("Id", "5003000000D8cuIAAR").
+		Set("LastModifiedDate", "2022-04-29T12:30:00.000+0200").
+		Set("Subject", "Updated")
+	if err := obj.UpdateIfUnmodified(); err != nil {
+		t.Fatal(err)
+	}
+
+	modified = true
+	if err := obj.UpdateIfUnmodified(); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+
+	// Negative: no LastModifiedDate.
+	obj = client.SObject("Case").Set("Id", "5003000000D8cuIAAR")
+	if err := obj.UpdateIfUnmodified(); err == nil {
+		t.Error("expected an error without LastModifiedDate")
+	}
+}
+
 func TestSObject_Upsert(t *testing.T) {
 	client := requireClient(t, true)
 
@@ -267,6 +421,42 @@ func TestSObject_Upsert(t *testing.T) {
 	}
 }
 
+func TestSObject_UpsertByExternalID(t *testing.T) {
+	exists := false
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.EscapedPath() != "/services/data/v54.0/sobjects/Account/Ref__c/A%2F42" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(body), "Ref__c") || !strings.Contains(string(body), "Acme") {
+			t.Errorf("unexpected body %s", body)
+		}
+		if exists {
+			w.Write([]byte(`{"id":"0013000000Db2wKAAR","success":true,"errors":[],"created":false}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"0013000000Db2wKAAR","success":true,"errors":[],"created":true}`))
+	})
+
+	obj := client.SObject("Account").Set("Name", "Acme").Set("Ref__c", "A/42")
+	created, err := obj.UpsertByExternalID("Ref__c", "A/42")
+	if err != nil || !created || obj.ID() != "0013000000Db2wKAAR" {
+		t.Errorf("unexpected upsert %v, %v, %s", created, err, obj.ID())
+	}
+
+	exists = true
+	obj = client.SObject("Account").Set("Name", "Acme")
+	created, err = obj.UpsertByExternalID("Ref__c", "A/42")
+	if err != nil || created || obj.ID() != "0013000000Db2wKAAR" {
+		t.Errorf("unexpected upsert %v, %v, %s", created, err, obj.ID())
+	}
+
+	if _, err := obj.UpsertByExternalID("", "A/42"); err == nil {
+		t.Error("expected an error without external ID field")
+	}
+}
+
 func TestSObject_Delete(t *testing.T) {
 	client := requireClient(t, true)
 
@@ -288,6 +478,22 @@ func TestSObject_Delete(t *testing.T) {
 	}
 }
 
+func TestSObject_DeleteByID(t *testing.T) {
+	var deleted []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.SObject("Case").Delete("5003000000D8cuIAAR"); err != nil {
+		t.Fatal(err)
+	}
+	expected := "DELETE /services/data/v" + DefaultAPIVersion + "/sobjects/Case/5003000000D8cuIAAR"
+	if len(deleted) != 1 || deleted[0] != expected {
+		t.Errorf("expected %s, got %v", expected, deleted)
+	}
+}
+
 // TestSObject_GetUpdate validates updating of existing records.
 func TestSObject_GetUpdate(t *testing.T) {
 	client := requireClient(t, true)
@@ -0,0 +1,438 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Replay IDs to subscribe with, besides the replay ID of an event already received.
+const (
+	// ReplayNewEvents receives the events published after subscribing.
+	ReplayNewEvents int64 = -1
+	// ReplayAllEvents receives all the events stored by Salesforce, i.e. of the last 24 hours or 3 days depending on
+	// the event type, before the new ones.
+	ReplayAllEvents int64 = -2
+)
+
+// Bayeux channels of the CometD protocol.
+const (
+	bayeuxHandshake  = "/meta/handshake"
+	bayeuxConnect    = "/meta/connect"
+	bayeuxSubscribe  = "/meta/subscribe"
+	bayeuxDisconnect = "/meta/disconnect"
+	bayeuxMetaPrefix = "/meta/"
+)
+
+// Reconnect advices of the Bayeux protocol.
+const (
+	adviceHandshake = "handshake"
+	adviceNone      = "none"
+)
+
+// defaultRetryDelay is the delay before handshaking again when the server didn't advise one.
+const defaultRetryDelay = time.Second
+
+// Event is an event received on a streaming channel.
+type Event struct {
+	Channel     string
+	ReplayID    int64
+	CreatedDate string
+	// Type is the operation of PushTopic events: "created", "updated", "deleted" or "undeleted".
+	Type string
+	// SObject holds the fields of the record of PushTopic events, as selected by the query of the PushTopic.
+	SObject SObject
+	// Payload is the payload of generic and platform events.
+	Payload json.RawMessage
+	// Data is the whole data of the event, for the properties not decoded into the other fields.
+	Data json.RawMessage
+}
+
+// EventHandler processes the events of a channel.
+type EventHandler func(event Event)
+
+// parseEvent decodes the data of an event received on channel.
+func parseEvent(channel string, data json.RawMessage) (Event, error) {
+	var raw struct {
+		Event struct {
+			CreatedDate string `json:"createdDate"`
+			ReplayID    int64  `json:"replayId"`
+			Type        string `json:"type"`
+		} `json:"event"`
+		SObject SObject         `json:"sobject"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return Event{}, errors.Wrapf(err, "invalid event on %s", channel)
+	}
+	return Event{
+		Channel:     channel,
+		ReplayID:    raw.Event.ReplayID,
+		CreatedDate: raw.Event.CreatedDate,
+		Type:        raw.Event.Type,
+		SObject:     raw.SObject,
+		Payload:     raw.Payload,
+		Data:        data,
+	}, nil
+}
+
+// bayeuxMessage is a message of the Bayeux protocol, sent or received.
+type bayeuxMessage struct {
+	Channel                  string                 `json:"channel"`
+	ClientID                 string                 `json:"clientId,omitempty"`
+	Version                  string                 `json:"version,omitempty"`
+	MinimumVersion           string                 `json:"minimumVersion,omitempty"`
+	SupportedConnectionTypes []string               `json:"supportedConnectionTypes,omitempty"`
+	ConnectionType           string                 `json:"connectionType,omitempty"`
+	Subscription             string                 `json:"subscription,omitempty"`
+	Successful               bool                   `json:"successful,omitempty"`
+	Error                    string                 `json:"error,omitempty"`
+	Advice                   *bayeuxAdvice          `json:"advice,omitempty"`
+	Ext                      map[string]interface{} `json:"ext,omitempty"`
+	Data                     json.RawMessage        `json:"data,omitempty"`
+}
+
+// bayeuxAdvice tells how to reconnect after a connect.
+type bayeuxAdvice struct {
+	Reconnect string `json:"reconnect"`
+	// Interval is the delay in milliseconds before connecting again.
+	Interval int `json:"interval"`
+}
+
+// streamingSubscription is a channel subscribed to, along with the replay ID of the last event received.
+type streamingSubscription struct {
+	replayID int64
+	handler  EventHandler
+}
+
+// StreamingClient receives the events of PushTopics, generic streaming channels, platform events and change data
+// capture through the CometD long-polling protocol of the Streaming API. Events are delivered to the handlers of their
+// channel one at a time, in order, by Run.
+//
+// The replay ID of the last event received on every channel is kept, so that calling Run again after it failed
+// resumes the subscriptions without missing the events published in between, as long as Salesforce still stores them.
+// Long polling requests last up to two minutes: the http.Client of the client must not time out earlier.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_streaming.meta/api_streaming/intro_stream.htm
+type StreamingClient struct {
+	client *Client
+
+	mu            sync.Mutex
+	subscriptions map[string]*streamingSubscription
+	clientID      string
+	cookies       map[string]string
+	store         ReplayStore
+}
+
+// NewStreamingClient creates a StreamingClient using the session of the client.
+func (client *Client) NewStreamingClient() *StreamingClient {
+	return &StreamingClient{
+		client:        client,
+		subscriptions: map[string]*streamingSubscription{},
+		cookies:       map[string]string{},
+	}
+}
+
+// Subscribe subscribes to the new events of channel, e.g. "/topic/AccountUpdates" or "/u/Notifications", which are
+// passed to handler. Channels subscribed to before Run are subscribed to by Run, the others right away.
+func (s *StreamingClient) Subscribe(channel string, handler EventHandler) error {
+	return s.SubscribeFrom(channel, ReplayNewEvents, handler)
+}
+
+// SubscribeFrom is like Subscribe, receiving the events following the event replayID first, which is typically the
+// last event processed before a restart, or ReplayAllEvents. The replay ID saved in the replay store of the streaming
+// client, if any, takes precedence.
+func (s *StreamingClient) SubscribeFrom(channel string, replayID int64, handler EventHandler) error {
+	if channel == "" || handler == nil {
+		return errors.New("channel and handler are required")
+	}
+	replayID, err := s.loadReplayID(context.Background(), channel, replayID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.subscriptions[channel] = &streamingSubscription{replayID: replayID, handler: handler}
+	connected := s.clientID != ""
+	s.mu.Unlock()
+	if !connected {
+		return nil
+	}
+	return s.subscribe(context.Background(), []string{channel})
+}
+
+// ReplayID returns the replay ID of the last event received on channel, to be saved in order to resume the
+// subscription with SubscribeFrom after a restart.
+func (s *StreamingClient) ReplayID(channel string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.subscriptions[channel]; ok {
+		return sub.replayID
+	}
+	return ReplayNewEvents
+}
+
+// Run connects to the Streaming API, subscribes to the channels and delivers their events until ctx is done or the
+// connection fails. Run returns ctx.Err() once ctx is done.
+func (s *StreamingClient) Run(ctx context.Context) error {
+	if !s.client.isLoggedIn() {
+		return ErrAuthentication
+	}
+
+	defer s.disconnect()
+	err := s.connectSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		s.mu.Lock()
+		clientID := s.clientID
+		s.mu.Unlock()
+		responses, err := s.send(ctx, bayeuxMessage{
+			Channel:        bayeuxConnect,
+			ClientID:       clientID,
+			ConnectionType: "long-polling",
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		var advice *bayeuxAdvice
+		reconnected := false
+		for _, msg := range responses {
+			if !strings.HasPrefix(msg.Channel, bayeuxMetaPrefix) {
+				s.deliver(msg)
+				continue
+			}
+			if msg.Channel != bayeuxConnect {
+				continue
+			}
+			advice = msg.Advice
+			if msg.Successful {
+				continue
+			}
+			if advice == nil || advice.Reconnect != adviceHandshake {
+				return errors.Errorf("streaming connect failed: %s", msg.Error)
+			}
+			s.client.logInfo("streaming connection lost, handshaking again,", msg.Error)
+			err = s.sleep(ctx, advice)
+			if err != nil {
+				return err
+			}
+			err = s.connectSubscriptions(ctx)
+			if err != nil {
+				return err
+			}
+			reconnected = true
+		}
+
+		if advice != nil && advice.Reconnect == adviceNone {
+			return errors.New("streaming connection closed by the server")
+		}
+		if !reconnected && advice != nil && advice.Interval > 0 {
+			err = s.sleep(ctx, advice)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sleep waits for the interval of advice, or until ctx is done.
+func (s *StreamingClient) sleep(ctx context.Context, advice *bayeuxAdvice) error {
+	delay := defaultRetryDelay
+	if advice != nil && advice.Interval > 0 {
+		delay = time.Duration(advice.Interval) * time.Millisecond
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// connectSubscriptions handshakes and subscribes to all the channels, from the last event received on each of them.
+func (s *StreamingClient) connectSubscriptions(ctx context.Context) error {
+	err := s.handshake(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	channels := make([]string, 0, len(s.subscriptions))
+	for channel := range s.subscriptions {
+		channels = append(channels, channel)
+	}
+	s.mu.Unlock()
+	if len(channels) == 0 {
+		return nil
+	}
+	sort.Strings(channels)
+	return s.subscribe(ctx, channels)
+}
+
+// handshake opens a new Bayeux session, supporting the replay extension.
+func (s *StreamingClient) handshake(ctx context.Context) error {
+	s.mu.Lock()
+	s.clientID = ""
+	s.cookies = map[string]string{}
+	s.mu.Unlock()
+
+	responses, err := s.send(ctx, bayeuxMessage{
+		Channel:                  bayeuxHandshake,
+		Version:                  "1.0",
+		MinimumVersion:           "1.0",
+		SupportedConnectionTypes: []string{"long-polling"},
+		Ext:                      map[string]interface{}{"replay": true},
+	})
+	if err != nil {
+		return err
+	}
+	for _, msg := range responses {
+		if msg.Channel != bayeuxHandshake {
+			continue
+		}
+		if !msg.Successful || msg.ClientID == "" {
+			return errors.Errorf("streaming handshake failed: %s", msg.Error)
+		}
+		s.mu.Lock()
+		s.clientID = msg.ClientID
+		s.mu.Unlock()
+		return nil
+	}
+	return errors.New("streaming handshake failed: no response")
+}
+
+// subscribe subscribes to channels in a single request.
+func (s *StreamingClient) subscribe(ctx context.Context, channels []string) error {
+	s.mu.Lock()
+	messages := make([]bayeuxMessage, 0, len(channels))
+	for _, channel := range channels {
+		messages = append(messages, bayeuxMessage{
+			Channel:      bayeuxSubscribe,
+			ClientID:     s.clientID,
+			Subscription: channel,
+			Ext: map[string]interface{}{
+				"replay": map[string]int64{channel: s.subscriptions[channel].replayID},
+			},
+		})
+	}
+	s.mu.Unlock()
+
+	responses, err := s.send(ctx, messages...)
+	if err != nil {
+		return err
+	}
+	for _, msg := range responses {
+		if msg.Channel == bayeuxSubscribe && !msg.Successful {
+			return errors.Errorf("failed to subscribe to %s: %s", msg.Subscription, msg.Error)
+		}
+	}
+	return nil
+}
+
+// disconnect closes the Bayeux session, if any.
+func (s *StreamingClient) disconnect() {
+	s.mu.Lock()
+	clientID := s.clientID
+	s.clientID = ""
+	s.mu.Unlock()
+	if clientID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := s.send(ctx, bayeuxMessage{Channel: bayeuxDisconnect, ClientID: clientID})
+	if err != nil {
+		s.client.logError("streaming disconnect failed,", err)
+	}
+}
+
+// deliver passes an event to the handler of its channel, and records its replay ID.
+func (s *StreamingClient) deliver(msg bayeuxMessage) {
+	event, err := parseEvent(msg.Channel, msg.Data)
+	if err != nil {
+		s.client.logError(err)
+		return
+	}
+
+	s.mu.Lock()
+	sub, ok := s.subscriptions[msg.Channel]
+	if ok && event.ReplayID != 0 {
+		sub.replayID = event.ReplayID
+	}
+	s.mu.Unlock()
+	if !ok {
+		s.client.logError("event received on unknown channel:", msg.Channel)
+		return
+	}
+	sub.handler(event)
+	s.saveReplayID(msg.Channel, event.ReplayID)
+}
+
+// streamingURL returns the URL of the CometD endpoint.
+func (s *StreamingClient) streamingURL() string {
+	return fmt.Sprintf("%s/cometd/%s", s.client.getInstanceURL(), s.client.APIVersion())
+}
+
+// send posts messages to the CometD endpoint and returns the messages received. The cookies set by Salesforce, which
+// identify the session with the servers, are sent back with every request.
+func (s *StreamingClient) send(ctx context.Context, messages ...bayeuxMessage) ([]bayeuxMessage, error) {
+	reqData, err := json.Marshal(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	cookies := make([]string, 0, len(s.cookies))
+	for name, value := range s.cookies {
+		cookies = append(cookies, name+"="+value)
+	}
+	s.mu.Unlock()
+	var header http.Header
+	if len(cookies) > 0 {
+		sort.Strings(cookies)
+		header = http.Header{"Cookie": {strings.Join(cookies, "; ")}}
+	}
+
+	u := s.streamingURL()
+	resp, err := s.client.httpResponseContext(ctx, http.MethodPost, u, bytes.NewReader(reqData), header)
+	if err != nil {
+		s.client.logError("HTTP POST request failed:", u)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	s.mu.Lock()
+	for _, cookie := range resp.Cookies() {
+		s.cookies[cookie.Name] = cookie.Value
+	}
+	s.mu.Unlock()
+
+	var responses []bayeuxMessage
+	err = json.NewDecoder(resp.Body).Decode(&responses)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid streaming response")
+	}
+	return responses, nil
+}
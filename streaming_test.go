@@ -0,0 +1,119 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStreamingClient_Run(t *testing.T) {
+	var channels, replays []string
+	connects := 0
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cometd/54.0" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			return
+		}
+		var messages []map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&messages)
+		if err != nil || len(messages) != 1 {
+			t.Errorf("unexpected messages %v, %v", messages, err)
+			return
+		}
+		msg := messages[0]
+		channel := msg["channel"].(string)
+		channels = append(channels, channel)
+		if channel != "/meta/handshake" && r.Header.Get("Cookie") != "BAYEUX_BROWSER=abc" {
+			t.Errorf("unexpected cookie %q", r.Header.Get("Cookie"))
+		}
+
+		switch channel {
+		case "/meta/handshake":
+			http.SetCookie(w, &http.Cookie{Name: "BAYEUX_BROWSER", Value: "abc"})
+			w.Write([]byte(`[{"channel":"/meta/handshake","clientId":"client1","successful":true,"version":"1.0"}]`))
+		case "/meta/subscribe":
+			replay, _ := json.Marshal(msg["ext"])
+			replays = append(replays, string(replay))
+			w.Write([]byte(`[{"channel":"/meta/subscribe","clientId":"client1","subscription":"/topic/AccountUpdates","successful":true}]`))
+		case "/meta/connect":
+			connects++
+			switch connects {
+			case 1:
+				w.Write([]byte(`[
+					{"channel":"/topic/AccountUpdates","data":{"event":{"createdDate":"2022-04-29T10:35:00.000Z","replayId":5,"type":"updated"},"sobject":{"Id":"0013000000Db2wKAAR","Name":"Acme"}}},
+					{"channel":"/meta/connect","clientId":"client1","successful":true,"advice":{"reconnect":"retry","interval":0}}
+				]`))
+			case 2:
+				w.Write([]byte(`[{"channel":"/meta/connect","successful":false,"error":"403::Unknown client","advice":{"reconnect":"handshake","interval":1}}]`))
+			default:
+				w.Write([]byte(`[
+					{"channel":"/topic/AccountUpdates","data":{"event":{"createdDate":"2022-04-29T10:36:00.000Z","replayId":6,"type":"deleted"},"sobject":{"Id":"0013000000Db2wKAAR"}}},
+					{"channel":"/meta/connect","clientId":"client1","successful":true}
+				]`))
+			}
+		case "/meta/disconnect":
+			w.Write([]byte(`[{"channel":"/meta/disconnect","successful":true}]`))
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var events []Event
+	streaming := client.NewStreamingClient()
+	err := streaming.Subscribe("/topic/AccountUpdates", func(event Event) {
+		events = append(events, event)
+		if event.Type == "deleted" {
+			cancel()
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = streaming.Run(ctx)
+	if err != context.Canceled {
+		t.Errorf("unexpected error %v", err)
+	}
+	if len(events) != 2 || events[0].ReplayID != 5 || events[0].SObject.StringField("Name") != "Acme" ||
+		events[1].Type != "deleted" {
+		t.Errorf("unexpected events %+v", events)
+	}
+	if streaming.ReplayID("/topic/AccountUpdates") != 6 {
+		t.Errorf("unexpected replay ID %d", streaming.ReplayID("/topic/AccountUpdates"))
+	}
+
+	expected := []string{"/meta/handshake", "/meta/subscribe", "/meta/connect", "/meta/connect",
+		"/meta/handshake", "/meta/subscribe", "/meta/connect", "/meta/disconnect"}
+	if !reflect.DeepEqual(channels, expected) {
+		t.Errorf("unexpected channels %v", channels)
+	}
+	if len(replays) != 2 || !strings.Contains(replays[0], `"/topic/AccountUpdates":-1`) ||
+		!strings.Contains(replays[1], `"/topic/AccountUpdates":5`) {
+		t.Errorf("unexpected replay extensions %v", replays)
+	}
+}
+
+func TestStreamingClient_SubscribeFailure(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var messages []map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&messages)
+		switch messages[0]["channel"] {
+		case "/meta/handshake":
+			w.Write([]byte(`[{"channel":"/meta/handshake","clientId":"client1","successful":true}]`))
+		case "/meta/subscribe":
+			w.Write([]byte(`[{"channel":"/meta/subscribe","subscription":"/topic/Missing","successful":false,"error":"403::Unknown channel"}]`))
+		default:
+			w.Write([]byte(`[{"channel":"/meta/disconnect","successful":true}]`))
+		}
+	})
+
+	streaming := client.NewStreamingClient()
+	streaming.Subscribe("/topic/Missing", func(event Event) {})
+	err := streaming.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "403::Unknown channel") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,373 @@
+package simpleforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// structTag is the struct tag naming the Salesforce field of a struct field, e.g. `sf:"Name"`. Options follow the
+// name: "omitempty" leaves zero values out of creates and updates, and "readonly" leaves the field out of them
+// entirely, e.g. for formula or system fields. Fields tagged `sf:"-"` are ignored; untagged exported fields map to
+// the field of the same name.
+const structTag = "sf"
+
+// SObjectTyper is implemented by the structs which don't have the name of their SObject type, e.g. a struct Customer
+// mapping Account records.
+type SObjectTyper interface {
+	SObjectType() string
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	dateTimeType = reflect.TypeOf(DateTime{})
+	rawType      = reflect.TypeOf(json.RawMessage{})
+	decoderType  = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// structField maps a struct field to a Salesforce field.
+type structField struct {
+	index     []int
+	name      string
+	omitEmpty bool
+	readOnly  bool
+	// relationship is set for struct fields holding a parent record, e.g. Account of a Contact.
+	relationship bool
+	// children is set for slices of structs holding the records of a child relationship subquery.
+	children bool
+}
+
+// structFieldsCache caches the structFields of struct types.
+var structFieldsCache sync.Map
+
+// structFieldsOf returns the fields of the struct type t mapped to Salesforce fields.
+func structFieldsOf(t reflect.Type) []structField {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField)
+	}
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup(structTag)
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		if f.Anonymous && !hasTag && f.Type.Kind() == reflect.Struct {
+			// Fields of embedded structs are promoted, like with encoding/json.
+			for _, embedded := range structFieldsOf(f.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+			continue
+		}
+
+		options := strings.Split(tag, ",")
+		field := structField{index: []int{i}, name: options[0]}
+		if field.name == "" {
+			field.name = f.Name
+		}
+		for _, option := range options[1:] {
+			switch option {
+			case "omitempty":
+				field.omitEmpty = true
+			case "readonly":
+				field.readOnly = true
+			}
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		field.relationship = isRecordStruct(ft)
+		field.children = ft.Kind() == reflect.Slice && ft != rawType && isRecordStruct(indirectType(ft.Elem()))
+		fields = append(fields, field)
+	}
+
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+// isRecordStruct reports whether t is a struct mapping a record, rather than a value decoded as a whole.
+func isRecordStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && t != dateTimeType && !reflect.PtrTo(t).Implements(decoderType)
+}
+
+// indirectType returns the type pointed to by t, or t if it's not a pointer.
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// maxParentDepth is the maximum number of parent relationships traversed by a field of an SOQL query.
+const maxParentDepth = 5
+
+// StructFields returns the Salesforce fields mapped by the struct v, or pointer to it, to be selected by a query
+// decoded with QueryInto. The fields of parent relationships are prefixed with the name of the relationship, e.g.
+// "Account.Name", up to the 5 levels allowed by SOQL, which bounds self-referencing structs such as an Account
+// with its parent Account; child relationships are left out, as they require a subquery.
+func StructFields(v interface{}) []string {
+	t := indirectType(reflect.TypeOf(v))
+	if t.Kind() == reflect.Slice {
+		t = indirectType(t.Elem())
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return structFieldNames(t, "", 0)
+}
+
+// structFieldNames returns the fields mapped by t, prefixed with prefix, which traverses depth parent relationships.
+func structFieldNames(t reflect.Type, prefix string, depth int) []string {
+	var names []string
+	for _, field := range structFieldsOf(t) {
+		switch {
+		case field.children:
+		case field.relationship:
+			if depth < maxParentDepth {
+				parent := indirectType(t.FieldByIndex(field.index).Type)
+				names = append(names, structFieldNames(parent, prefix+field.name+".", depth+1)...)
+			}
+		default:
+			names = append(names, prefix+field.name)
+		}
+	}
+	return names
+}
+
+// QueryInto runs an SOQL query, following nextRecordsUrl until all records are retrieved, and decodes the records
+// into dest, a pointer to a slice of structs or of pointers to structs, e.g. *[]Account. Struct fields are mapped to
+// Salesforce fields by their sf tag, see StructFields. Salesforce dateTime values decode into time.Time fields.
+func (client *Client) QueryInto(q string, dest interface{}) error {
+	return client.QueryIntoContext(context.Background(), q, dest)
+}
+
+// QueryIntoContext is like QueryInto, with the requests bound to ctx.
+func (client *Client) QueryIntoContext(ctx context.Context, q string, dest interface{}) error {
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Ptr || slice.IsNil() || slice.Elem().Kind() != reflect.Slice ||
+		!isRecordStruct(indirectType(slice.Elem().Type().Elem())) {
+		return errors.Errorf("dest must be a pointer to a slice of structs, got %T", dest)
+	}
+	slice = slice.Elem()
+	slice.Set(slice.Slice(0, 0))
+
+	return client.queryEachContext(ctx, q, func(raw json.RawMessage) error {
+		return decodeRecords(raw, slice)
+	})
+}
+
+// decodeRecords appends the records of the JSON array raw to slice.
+func decodeRecords(raw json.RawMessage, slice reflect.Value) error {
+	var records []json.RawMessage
+	err := json.Unmarshal(raw, &records)
+	if err != nil {
+		return err
+	}
+	elemType := slice.Type().Elem()
+	for _, record := range records {
+		elem := reflect.New(indirectType(elemType))
+		err = decodeStruct(record, elem.Elem())
+		if err != nil {
+			return err
+		}
+		if elemType.Kind() != reflect.Ptr {
+			elem = elem.Elem()
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+	return nil
+}
+
+// decodeStruct decodes the JSON record raw into the struct v.
+func decodeStruct(raw json.RawMessage, v reflect.Value) error {
+	var record map[string]json.RawMessage
+	err := json.Unmarshal(raw, &record)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range structFieldsOf(v.Type()) {
+		value, ok := record[field.name]
+		if !ok {
+			// Salesforce answers with the case of the describe, which queries may not follow.
+			for key, candidate := range record {
+				if strings.EqualFold(key, field.name) {
+					value, ok = candidate, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		err = decodeValue(value, v.FieldByIndex(field.index), field)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode %s", field.name)
+		}
+	}
+	return nil
+}
+
+// decodeValue decodes the JSON value raw of field into v.
+func decodeValue(raw json.RawMessage, v reflect.Value, field structField) error {
+	if bytes.Equal(raw, []byte("null")) {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		elem := reflect.New(v.Type().Elem())
+		err := decodeValue(raw, elem.Elem(), field)
+		if err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	switch {
+	case v.Type() == timeType:
+		var t DateTime
+		err := json.Unmarshal(raw, &t)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t.Time))
+		return nil
+	case field.relationship:
+		return decodeStruct(raw, v)
+	case field.children:
+		var result struct {
+			Records json.RawMessage `json:"records"`
+		}
+		err := json.Unmarshal(raw, &result)
+		if err != nil {
+			return err
+		}
+		v.Set(v.Slice(0, 0))
+		return decodeRecords(result.Records, v)
+	}
+	return json.Unmarshal(raw, v.Addr().Interface())
+}
+
+// structRecord returns the SObject type, ID field and writable fields of the struct v, or pointer to it.
+func structRecord(v interface{}) (sobjectType string, id reflect.Value, fields map[string]interface{}, err error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || !isRecordStruct(rv.Type()) {
+		return "", reflect.Value{}, nil, errors.Errorf("expected a struct, got %T", v)
+	}
+
+	sobjectType = rv.Type().Name()
+	if typer, ok := v.(SObjectTyper); ok {
+		sobjectType = typer.SObjectType()
+	}
+	fields = map[string]interface{}{}
+	for _, field := range structFieldsOf(rv.Type()) {
+		fv := rv.FieldByIndex(field.index)
+		if strings.EqualFold(field.name, sobjectIDKey) {
+			if fv.Kind() == reflect.String {
+				id = fv
+			}
+			continue
+		}
+		if field.readOnly || field.relationship || field.children || (field.omitEmpty && fv.IsZero()) {
+			continue
+		}
+		switch {
+		case fv.Type() == timeType:
+			fields[field.name] = DateTime{fv.Interface().(time.Time)}
+		case fv.Type() == reflect.PtrTo(timeType) && !fv.IsNil():
+			fields[field.name] = DateTime{fv.Elem().Interface().(time.Time)}
+		default:
+			fields[field.name] = fv.Interface()
+		}
+	}
+	return sobjectType, id, fields, nil
+}
+
+// CreateFromStruct creates a record from the fields of the struct v, mapped by their sf tag, and returns its ID. The
+// SObject type is the name of the struct type, unless it implements SObjectTyper. The ID is set on the Id field of
+// v if v is a pointer.
+func (client *Client) CreateFromStruct(v interface{}) (string, error) {
+	return client.CreateFromStructContext(context.Background(), v)
+}
+
+// CreateFromStructContext is like CreateFromStruct, with the request bound to ctx.
+func (client *Client) CreateFromStructContext(ctx context.Context, v interface{}) (string, error) {
+	if !client.isLoggedIn() {
+		return "", ErrAuthentication
+	}
+	sobjectType, id, fields, err := structRecord(v)
+	if err != nil {
+		return "", err
+	}
+	reqData, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	u := client.sobjectURL(sobjectType + "/")
+	data, err := client.httpRequestContext(ctx, http.MethodPost, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP POST request failed:", u)
+		return "", err
+	}
+
+	var result SaveResult
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return "", err
+	}
+	err = result.Err()
+	if err != nil {
+		return "", err
+	}
+	if id.IsValid() && id.CanSet() {
+		id.SetString(result.ID)
+	}
+	return result.ID, nil
+}
+
+// UpdateFromStruct updates the record identified by the Id field of the struct v with its other fields, like
+// CreateFromStruct.
+func (client *Client) UpdateFromStruct(v interface{}) error {
+	return client.UpdateFromStructContext(context.Background(), v)
+}
+
+// UpdateFromStructContext is like UpdateFromStruct, with the request bound to ctx.
+func (client *Client) UpdateFromStructContext(ctx context.Context, v interface{}) error {
+	if !client.isLoggedIn() {
+		return ErrAuthentication
+	}
+	sobjectType, id, fields, err := structRecord(v)
+	if err != nil {
+		return err
+	}
+	if !id.IsValid() || id.String() == "" {
+		return errors.New("record id is required")
+	}
+	reqData, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	u := client.sobjectURL(sobjectType + "/" + id.String())
+	_, err = client.httpRequestContext(ctx, http.MethodPatch, u, bytes.NewReader(reqData))
+	if err != nil {
+		client.logError("HTTP PATCH request failed:", u)
+		return err
+	}
+	return nil
+}
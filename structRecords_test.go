@@ -0,0 +1,144 @@
+package simpleforce
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testAccount struct {
+	ID          string     `sf:"Id"`
+	Name        string     `sf:"Name"`
+	Employees   *int       `sf:"NumberOfEmployees"`
+	Website     string     `sf:"Website,omitempty"`
+	CreatedDate time.Time  `sf:"CreatedDate,readonly"`
+	Owner       *testUser  `sf:"Owner"`
+	Contacts    []testUser `sf:"Contacts"`
+	Internal    string     `sf:"-"`
+}
+
+type testUser struct {
+	Name string
+}
+
+type testCustomer struct {
+	ID   string `sf:"Id"`
+	Name string `sf:"Name"`
+}
+
+func (testCustomer) SObjectType() string {
+	return "Account"
+}
+
+func TestStructFields(t *testing.T) {
+	fields := StructFields([]testAccount{})
+	expected := []string{"Id", "Name", "NumberOfEmployees", "Website", "CreatedDate", "Owner.Name"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("unexpected fields %v", fields)
+	}
+}
+
+// testHierarchyAccount references its own type through its parent account.
+type testHierarchyAccount struct {
+	Name   string                `sf:"Name"`
+	Parent *testHierarchyAccount `sf:"Parent"`
+}
+
+func TestStructFields_SelfReference(t *testing.T) {
+	fields := StructFields(testHierarchyAccount{})
+	expected := []string{"Name", "Parent.Name", "Parent.Parent.Name", "Parent.Parent.Parent.Name",
+		"Parent.Parent.Parent.Parent.Name", "Parent.Parent.Parent.Parent.Parent.Name"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("unexpected fields %v", fields)
+	}
+}
+
+func TestClient_QueryInto(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/data/v54.0/query/01gD0000002HU6KIAW-2000" {
+			w.Write([]byte(`{"totalSize":2,"done":true,"records":[
+				{"attributes":{"type":"Account"},"Id":"0013000000Db2wLAAR","name":"Globex","NumberOfEmployees":null,"Owner":null,"Contacts":null}
+			]}`))
+			return
+		}
+		w.Write([]byte(`{"totalSize":2,"done":false,"nextRecordsUrl":"/services/data/v54.0/query/01gD0000002HU6KIAW-2000","records":[
+			{"attributes":{"type":"Account"},"Id":"0013000000Db2wKAAR","Name":"Acme","NumberOfEmployees":120,
+			 "CreatedDate":"2022-04-29T10:35:00.000+0000","Owner":{"attributes":{"type":"User"},"Name":"Jane Doe"},
+			 "Contacts":{"totalSize":1,"done":true,"records":[{"attributes":{"type":"Contact"},"Name":"John Roe"}]}}
+		]}`))
+	})
+
+	var accounts []testAccount
+	if err := client.QueryInto("SELECT Id FROM Account", &accounts); err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("unexpected accounts %+v", accounts)
+	}
+	acme := accounts[0]
+	if acme.ID != "0013000000Db2wKAAR" || acme.Name != "Acme" || acme.Employees == nil || *acme.Employees != 120 ||
+		!acme.CreatedDate.Equal(time.Date(2022, 4, 29, 10, 35, 0, 0, time.UTC)) || acme.Owner == nil ||
+		acme.Owner.Name != "Jane Doe" || len(acme.Contacts) != 1 || acme.Contacts[0].Name != "John Roe" {
+		t.Errorf("unexpected account %+v", acme)
+	}
+	globex := accounts[1]
+	if globex.Name != "Globex" || globex.Employees != nil || globex.Owner != nil || globex.Contacts != nil {
+		t.Errorf("unexpected account %+v", globex)
+	}
+
+	var pointers []*testAccount
+	if err := client.QueryInto("SELECT Id FROM Account", &pointers); err != nil || len(pointers) != 2 {
+		t.Errorf("unexpected accounts %v, %v", pointers, err)
+	}
+	if err := client.QueryInto("SELECT Id FROM Account", accounts); err == nil {
+		t.Error("expected an error for a non pointer destination")
+	}
+}
+
+func TestClient_CreateFromStruct(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		data, _ := ioutil.ReadAll(r.Body)
+		var body map[string]interface{}
+		json.Unmarshal(data, &body)
+		bodies = append(bodies, body)
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"0013000000Db2wKAAR","success":true,"errors":[]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	account := testAccount{Name: "Acme", CreatedDate: time.Now(), Internal: "secret"}
+	id, err := client.CreateFromStruct(&account)
+	if err != nil || id != "0013000000Db2wKAAR" || account.ID != id {
+		t.Fatalf("unexpected result %s, %v", id, err)
+	}
+	if !reflect.DeepEqual(bodies[0], map[string]interface{}{"Name": "Acme", "NumberOfEmployees": nil}) {
+		t.Errorf("unexpected body %v", bodies[0])
+	}
+
+	if err = client.UpdateFromStruct(testCustomer{ID: "0013000000Db2wKAAR", Name: "Acme Corp"}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bodies[1], map[string]interface{}{"Name": "Acme Corp"}) {
+		t.Errorf("unexpected body %v", bodies[1])
+	}
+	expected := []string{
+		"POST /services/data/v54.0/sobjects/testAccount/",
+		"PATCH /services/data/v54.0/sobjects/Account/0013000000Db2wKAAR",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("unexpected requests %v", requests)
+	}
+
+	if err = client.UpdateFromStruct(testCustomer{Name: "Acme Corp"}); err == nil {
+		t.Error("expected an error without ID")
+	}
+}
@@ -0,0 +1,239 @@
+// Package sync incrementally replicates Salesforce objects: every run pulls the records created, updated or deleted
+// since the previous one, based on per-object SystemModstamp watermarks, and hands them to a simpleforce.RecordSink.
+// Thanks to the overlap window a record may be delivered more than once, so sinks must apply records idempotently,
+// e.g. upserts by ID.
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/simpleforce/simpleforce"
+)
+
+const (
+	// DefaultOverlap is the default window by which a run reads again the changes preceding the watermark.
+	DefaultOverlap = 5 * time.Minute
+	// DefaultClockSkew is the default maximum difference assumed between the local and Salesforce clocks.
+	DefaultClockSkew = time.Minute
+)
+
+// WatermarkStore persists the watermark of every synchronized object between runs.
+type WatermarkStore interface {
+	// Load returns the watermark of object, or the zero time if it was never synchronized.
+	Load(ctx context.Context, object string) (time.Time, error)
+	Save(ctx context.Context, object string, watermark time.Time) error
+}
+
+// MemoryStore keeps watermarks in memory, for tests and long running processes.
+type MemoryStore struct {
+	mu         sync.Mutex
+	watermarks map[string]time.Time
+}
+
+// Load implements WatermarkStore.
+func (s *MemoryStore) Load(ctx context.Context, object string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.watermarks[object], nil
+}
+
+// Save implements WatermarkStore.
+func (s *MemoryStore) Save(ctx context.Context, object string, watermark time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.watermarks == nil {
+		s.watermarks = map[string]time.Time{}
+	}
+	s.watermarks[object] = watermark
+	return nil
+}
+
+// FileStore keeps watermarks in a JSON file, keyed by object.
+type FileStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// read decodes the watermarks of the file, which may not exist yet.
+func (s *FileStore) read() (map[string]time.Time, error) {
+	watermarks := map[string]time.Time{}
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return watermarks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &watermarks)
+	if err != nil {
+		return nil, errors.Wrap(err, s.Path)
+	}
+	return watermarks, nil
+}
+
+// Load implements WatermarkStore.
+func (s *FileStore) Load(ctx context.Context, object string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	watermarks, err := s.read()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return watermarks[object], nil
+}
+
+// Save implements WatermarkStore. The file is replaced atomically.
+func (s *FileStore) Save(ctx context.Context, object string, watermark time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	watermarks, err := s.read()
+	if err != nil {
+		return err
+	}
+	watermarks[object] = watermark
+	data, err := json.MarshalIndent(watermarks, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.Path + ".tmp"
+	err = ioutil.WriteFile(tmp, data, 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.Path)
+}
+
+// Engine synchronizes objects incrementally.
+type Engine struct {
+	client *simpleforce.Client
+	store  WatermarkStore
+	sink   simpleforce.RecordSink
+
+	// Overlap is how far before the watermark a run starts reading changes again, to catch the records of
+	// transactions which committed after the previous run although their SystemModstamp precedes it.
+	Overlap time.Duration
+	// ClockSkew is the maximum difference assumed between the local and Salesforce clocks. Deletions are only read up
+	// to the local time minus ClockSkew, so that the requested span never ends in the future of Salesforce.
+	ClockSkew time.Duration
+}
+
+// New creates an Engine reading changes with client, keeping watermarks in store and handing changed records to sink.
+func New(client *simpleforce.Client, store WatermarkStore, sink simpleforce.RecordSink) *Engine {
+	return &Engine{
+		client:    client,
+		store:     store,
+		sink:      sink,
+		Overlap:   DefaultOverlap,
+		ClockSkew: DefaultClockSkew,
+	}
+}
+
+// Result is the outcome of the synchronization of an object.
+type Result struct {
+	Object    string
+	Upserts   int
+	Deletes   int
+	Watermark time.Time
+}
+
+// Sync hands the changes of object since the previous run to the sink, then saves the new watermark. The first run
+// of an object delivers all its records. fields are the fields of the records to read; Id and SystemModstamp are
+// always read. The watermark is only saved once the sink accepted and flushed all records, so a failed run is simply
+// resumed by the next one.
+func (e *Engine) Sync(ctx context.Context, object string, fields ...string) (*Result, error) {
+	watermark, err := e.store.Load(ctx, object)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the watermark of %s", object)
+	}
+	result := &Result{Object: object, Watermark: watermark}
+
+	// Read deletions first: the span they cover bounds the next watermark, which must not skip the records
+	// modified while the query below runs.
+	var from time.Time
+	next := time.Now().Add(-e.ClockSkew)
+	if !watermark.IsZero() {
+		from = watermark.Add(-e.Overlap)
+		deleted, err := e.client.GetDeletedContext(ctx, object, from, next)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the deleted records of %s", object)
+		}
+		changes := make([]simpleforce.Record, 0, len(deleted.DeletedRecords))
+		for _, record := range deleted.DeletedRecords {
+			changes = append(changes, simpleforce.Record{Object: object, ID: record.ID, Deleted: true, ModifiedAt: record.DeletedDate.Time})
+		}
+		if len(changes) > 0 {
+			err = e.sink.Write(ctx, changes)
+			if err != nil {
+				return nil, err
+			}
+			result.Deletes += len(changes)
+		}
+		if covered := deleted.LatestDateCovered.Time; !covered.IsZero() && covered.Before(next) {
+			next = covered
+		}
+	}
+
+	qb := simpleforce.Select(selectedFields(fields)...).From(object).OrderBy("SystemModstamp", "Id")
+	if !from.IsZero() {
+		qb.Where(simpleforce.Gte("SystemModstamp", from))
+	}
+	q, err := qb.Build()
+	if err != nil {
+		return nil, err
+	}
+	for q != "" {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, err := e.client.QueryContext(ctx, q)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to query %s", object)
+		}
+		changes := make([]simpleforce.Record, 0, len(page.Records))
+		for _, record := range page.Records {
+			changes = append(changes, simpleforce.NewRecord(record))
+		}
+		if len(changes) > 0 {
+			err = e.sink.Write(ctx, changes)
+			if err != nil {
+				return nil, err
+			}
+			result.Upserts += len(changes)
+		}
+		q = ""
+		if !page.Done {
+			q = page.NextRecordsURL
+		}
+	}
+
+	err = e.sink.Flush(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if next.After(watermark) {
+		result.Watermark = next
+		err = e.store.Save(ctx, object, next)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to save the watermark of %s", object)
+		}
+	}
+	return result, nil
+}
+
+// selectedFields adds Id and SystemModstamp to fields if missing.
+func selectedFields(fields []string) []string {
+	selected := []string{"Id", "SystemModstamp"}
+	for _, field := range fields {
+		if field != "Id" && field != "SystemModstamp" {
+			selected = append(selected, field)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,104 @@
+package sync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simpleforce/simpleforce"
+)
+
+func TestEngine_Sync(t *testing.T) {
+	var queries []string
+	deletedCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/sobjects/Account/deleted/"):
+			deletedCalls++
+			if start := r.URL.Query().Get("start"); start != "2022-04-29T10:25:00Z" {
+				t.Errorf("unexpected start %s", start)
+			}
+			w.Write([]byte(`{"deletedRecords":[{"id":"0013000000Db2wLAAR","deletedDate":"2022-04-29T10:40:00.000+0000"}],"latestDateCovered":"2022-04-29T11:00:00.000+0000"}`))
+		case strings.HasSuffix(r.URL.Path, "/query"):
+			queries = append(queries, r.URL.Query().Get("q"))
+			w.Write([]byte(`{"totalSize":1,"done":true,"records":[{"attributes":{"type":"Account"},"Id":"0013000000Db2wKAAR","SystemModstamp":"2022-04-29T10:35:00.000+0000","Name":"Acme"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := simpleforce.NewClient(server.URL, simpleforce.DefaultClientID, simpleforce.DefaultAPIVersion)
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	var changes []simpleforce.Record
+	store := &FileStore{Path: filepath.Join(t.TempDir(), "watermarks.json")}
+	engine := New(client, store, simpleforce.RecordSinkFunc(func(ctx context.Context, batch []simpleforce.Record) error {
+		changes = append(changes, batch...)
+		return nil
+	}))
+
+	// Initial run: everything is read, deletions are irrelevant.
+	result, err := engine.Sync(context.Background(), "Account", "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deletedCalls != 0 || result.Upserts != 1 || result.Watermark.IsZero() {
+		t.Errorf("unexpected initial run %+v", result)
+	}
+	if queries[0] != "SELECT Id, SystemModstamp, Name FROM Account ORDER BY SystemModstamp, Id" {
+		t.Errorf("unexpected query %s", queries[0])
+	}
+
+	// Incremental run from a watermark.
+	watermark := time.Date(2022, 4, 29, 10, 30, 0, 0, time.UTC)
+	store.Save(context.Background(), "Account", watermark)
+	changes = nil
+	result, err = engine.Sync(context.Background(), "Account", "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(queries[1], "WHERE SystemModstamp >= 2022-04-29T10:25:00Z") {
+		t.Errorf("unexpected query %s", queries[1])
+	}
+	if result.Upserts != 1 || result.Deletes != 1 || len(changes) != 2 {
+		t.Fatalf("unexpected result %+v, changes %+v", result, changes)
+	}
+	if !changes[0].Deleted || changes[0].ID != "0013000000Db2wLAAR" || changes[0].Fields != nil {
+		t.Errorf("unexpected deletion %+v", changes[0])
+	}
+	if changes[1].Deleted || changes[1].Fields.StringField("Name") != "Acme" || !changes[1].ModifiedAt.Equal(time.Date(2022, 4, 29, 10, 35, 0, 0, time.UTC)) {
+		t.Errorf("unexpected update %+v", changes[1])
+	}
+
+	// The new watermark is bounded by the span covered by the deletions.
+	saved, err := store.Load(context.Background(), "Account")
+	if err != nil || !saved.Equal(time.Date(2022, 4, 29, 11, 0, 0, 0, time.UTC)) || !result.Watermark.Equal(saved) {
+		t.Errorf("unexpected watermark %v, %v", saved, err)
+	}
+}
+
+func TestEngine_SyncSinkFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"totalSize":1,"done":true,"records":[{"attributes":{"type":"Account"},"Id":"0013000000Db2wKAAR","SystemModstamp":"2022-04-29T10:35:00.000+0000"}]}`))
+	}))
+	defer server.Close()
+
+	client := simpleforce.NewClient(server.URL, simpleforce.DefaultClientID, simpleforce.DefaultAPIVersion)
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	store := &MemoryStore{}
+	engine := New(client, store, simpleforce.RecordSinkFunc(func(ctx context.Context, batch []simpleforce.Record) error {
+		return context.DeadlineExceeded
+	}))
+	if _, err := engine.Sync(context.Background(), "Account"); err == nil {
+		t.Fatal("expected the sink error")
+	}
+	if watermark, _ := store.Load(context.Background(), "Account"); !watermark.IsZero() {
+		t.Errorf("watermark must not be saved after a failure, got %v", watermark)
+	}
+}
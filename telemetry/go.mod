@@ -0,0 +1,23 @@
+module github.com/simpleforce/simpleforce/telemetry
+
+go 1.20
+
+require (
+	github.com/simpleforce/simpleforce v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.21.0
+	go.opentelemetry.io/otel/metric v1.21.0
+	go.opentelemetry.io/otel/sdk v1.21.0
+	go.opentelemetry.io/otel/sdk/metric v1.21.0
+	go.opentelemetry.io/otel/trace v1.21.0
+)
+
+require (
+	github.com/go-logr/logr v1.3.0 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/sys v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Resolve simpleforce to this repository until a release ships the middleware support telemetry relies on.
+replace github.com/simpleforce/simpleforce => ../
@@ -0,0 +1,212 @@
+// Package telemetry instruments simpleforce clients with OpenTelemetry: every request sent to Salesforce is traced
+// by a client span, and measured by request duration and API usage metrics.
+//
+// It is a separate module, so that the simpleforce module doesn't depend on OpenTelemetry.
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/simpleforce/simpleforce"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer and meter of the package.
+const instrumentationName = "github.com/simpleforce/simpleforce/telemetry"
+
+// Attributes of the spans and metrics, besides the HTTP ones.
+const (
+	// EndpointKey is the REST resource called, e.g. "sobjects", "query" or "composite", without IDs so that its
+	// cardinality stays low.
+	EndpointKey = attribute.Key("salesforce.endpoint")
+	// SObjectKey is the type of the records of sobjects calls, e.g. "Account".
+	SObjectKey = attribute.Key("salesforce.sobject")
+	// APIVersionKey is the API version of the request, e.g. "54.0".
+	APIVersionKey = attribute.Key("salesforce.api_version")
+
+	methodKey     = attribute.Key("http.request.method")
+	statusCodeKey = attribute.Key("http.response.status_code")
+	serverKey     = attribute.Key("server.address")
+)
+
+// Option configures the instrumentation of WithTelemetry.
+type Option func(c *config)
+
+type config struct {
+	meterProvider metric.MeterProvider
+}
+
+// WithMeterProvider records the metrics with mp instead of the global meter provider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithTelemetry instruments the requests of a client with spans from tp, and with the metrics below, recorded with
+// the global meter provider unless WithMeterProvider is set:
+//
+//   - salesforce.client.request.duration, a histogram of the duration of the requests in seconds.
+//   - salesforce.api.usage and salesforce.api.limit, gauges of the API requests made by the org in the last 24 hours
+//     and their limit, as reported by the Sforce-Limit-Info header of the last response.
+//
+// A nil tp uses the global tracer provider. Spans hold the endpoint, SObject type, HTTP status and API version of the
+// requests, but neither their query strings, which may hold SOQL queries, nor their bodies.
+func WithTelemetry(tp trace.TracerProvider, opts ...Option) simpleforce.ClientOption {
+	c := config{}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if c.meterProvider == nil {
+		c.meterProvider = otel.GetMeterProvider()
+	}
+
+	inst := newInstrumentation(tp.Tracer(instrumentationName), c.meterProvider.Meter(instrumentationName))
+	return func(client *simpleforce.Client) {
+		client.Use(inst.middleware)
+	}
+}
+
+// instrumentation holds the tracer and instruments shared by the requests of a client, along with the last API usage
+// reported.
+type instrumentation struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+
+	mu       sync.Mutex
+	usage    int64
+	limit    int64
+	hasUsage bool
+}
+
+func newInstrumentation(tracer trace.Tracer, meter metric.Meter) *instrumentation {
+	inst := &instrumentation{tracer: tracer}
+
+	var err error
+	inst.duration, err = meter.Float64Histogram("salesforce.client.request.duration",
+		metric.WithDescription("Duration of the requests sent to Salesforce."), metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	usage, err := meter.Int64ObservableGauge("salesforce.api.usage",
+		metric.WithDescription("API requests made by the org in the last 24 hours."), metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	limit, err := meter.Int64ObservableGauge("salesforce.api.limit",
+		metric.WithDescription("API requests allowed to the org in 24 hours."), metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		inst.mu.Lock()
+		defer inst.mu.Unlock()
+		if inst.hasUsage {
+			o.ObserveInt64(usage, inst.usage)
+			o.ObserveInt64(limit, inst.limit)
+		}
+		return nil
+	}, usage, limit)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return inst
+}
+
+// middleware traces and measures the requests sent by next.
+func (inst *instrumentation) middleware(next simpleforce.Doer) simpleforce.Doer {
+	return simpleforce.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		endpoint, sobject, version := describeRequest(req)
+		attrs := []attribute.KeyValue{
+			methodKey.String(req.Method),
+			EndpointKey.String(endpoint),
+			serverKey.String(req.URL.Hostname()),
+		}
+		if sobject != "" {
+			attrs = append(attrs, SObjectKey.String(sobject))
+		}
+		if version != "" {
+			attrs = append(attrs, APIVersionKey.String(version))
+		}
+
+		ctx, span := inst.tracer.Start(req.Context(), "Salesforce "+req.Method+" "+endpoint,
+			trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		start := time.Now()
+		resp, err := next.Do(req.WithContext(ctx))
+		elapsed := time.Since(start)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else {
+			span.SetAttributes(statusCodeKey.Int(resp.StatusCode))
+			attrs = append(attrs, statusCodeKey.Int(resp.StatusCode))
+			if resp.StatusCode >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+			inst.recordUsage(resp)
+		}
+		if inst.duration != nil {
+			inst.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+		}
+		return resp, err
+	})
+}
+
+// recordUsage keeps the API usage reported by resp, if any.
+func (inst *instrumentation) recordUsage(resp *http.Response) {
+	meta := simpleforce.ResponseMetadata{LimitInfo: resp.Header.Get("Sforce-Limit-Info")}
+	used, max, ok := meta.APIUsage()
+	if !ok {
+		return
+	}
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	inst.usage, inst.limit, inst.hasUsage = int64(used), int64(max), true
+}
+
+// describeRequest returns the endpoint, SObject type and API version of req, e.g. "sobjects", "Account" and "54.0"
+// for /services/data/v54.0/sobjects/Account/0013000000Db2wKAAR.
+func describeRequest(req *http.Request) (endpoint, sobject, version string) {
+	path := strings.Trim(req.URL.Path, "/")
+	switch {
+	case strings.HasPrefix(path, "services/data/v"):
+	case strings.HasPrefix(path, "services/Soap/"):
+		return "soap", "", ""
+	case strings.HasPrefix(path, "services/oauth2/"):
+		return "oauth2", "", ""
+	case strings.HasPrefix(path, "services/apexrest/"):
+		return "apexrest", "", ""
+	case strings.HasPrefix(path, "cometd/"):
+		return "cometd", "", strings.TrimPrefix(path, "cometd/")
+	default:
+		return "other", "", ""
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "services/data/v"), "/")
+	version = segments[0]
+	if len(segments) < 2 || segments[1] == "" {
+		return "versions", "", version
+	}
+	endpoint = segments[1]
+	rest := segments[2:]
+	if endpoint == "tooling" && len(rest) > 0 {
+		endpoint, rest = "tooling/"+rest[0], rest[1:]
+	}
+	if strings.HasSuffix(endpoint, "sobjects") && len(rest) > 0 && rest[0] != "" {
+		sobject = rest[0]
+	}
+	return endpoint, sobject, version
+}
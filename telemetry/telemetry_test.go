@@ -0,0 +1,108 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/simpleforce/simpleforce"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTelemetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Sforce-Limit-Info", "api-usage=25/15000")
+		if r.URL.Path == "/services/data/v54.0/sobjects/Account/0013000000Db2wKAAR" {
+			w.Write([]byte(`{"attributes":{"type":"Account"},"Id":"0013000000Db2wKAAR"}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`[{"message":"unexpected token: FORM","errorCode":"MALFORMED_QUERY"}]`))
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	client := simpleforce.NewClient(server.URL, simpleforce.DefaultClientID, simpleforce.DefaultAPIVersion,
+		WithTelemetry(tp, WithMeterProvider(mp)))
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+	if obj := client.SObject("Account").Get("0013000000Db2wKAAR"); obj == nil {
+		t.Fatal("failed to get the account")
+	}
+	if _, err := client.Query("SELECT Id FORM Account"); err == nil {
+		t.Fatal("expected the query to fail")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("unexpected spans %v", ended)
+	}
+	get := attributes(ended[0].Attributes())
+	if ended[0].Name() != "Salesforce GET sobjects" || get[SObjectKey] != "Account" || get[APIVersionKey] != "54.0" ||
+		get[statusCodeKey] != "200" || ended[0].Status().Code == codes.Error {
+		t.Errorf("unexpected span %s %v", ended[0].Name(), get)
+	}
+	query := attributes(ended[1].Attributes())
+	if ended[1].Name() != "Salesforce GET query" || query[statusCodeKey] != "400" || ended[1].Status().Code != codes.Error {
+		t.Errorf("unexpected span %s %v", ended[1].Name(), query)
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			found[m.Name] = true
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				if len(data.DataPoints) != 2 {
+					t.Errorf("unexpected duration data points %v", data.DataPoints)
+				}
+			case metricdata.Gauge[int64]:
+				if len(data.DataPoints) != 1 || (m.Name == "salesforce.api.usage" && data.DataPoints[0].Value != 25) {
+					t.Errorf("unexpected %s data points %v", m.Name, data.DataPoints)
+				}
+			}
+		}
+	}
+	if !found["salesforce.client.request.duration"] || !found["salesforce.api.usage"] || !found["salesforce.api.limit"] {
+		t.Errorf("unexpected metrics %v", found)
+	}
+}
+
+func TestDescribeRequest(t *testing.T) {
+	for path, expected := range map[string][3]string{
+		"/services/data/v54.0/sobjects/Account/0013000000Db2wKAAR": {"sobjects", "Account", "54.0"},
+		"/services/data/v54.0/query":                               {"query", "", "54.0"},
+		"/services/data/v54.0/tooling/sobjects/ApexClass/":         {"tooling/sobjects", "ApexClass", "54.0"},
+		"/services/data/v54.0/composite/sobjects":                  {"composite", "", "54.0"},
+		"/services/Soap/u/54.0":                                    {"soap", "", ""},
+		"/cometd/54.0":                                             {"cometd", "", "54.0"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		endpoint, sobject, version := describeRequest(req)
+		if [3]string{endpoint, sobject, version} != expected {
+			t.Errorf("unexpected description of %s: %s %s %s", path, endpoint, sobject, version)
+		}
+	}
+}
+
+// attributes returns the values of attrs by key, as strings.
+func attributes(attrs []attribute.KeyValue) map[attribute.Key]string {
+	values := map[attribute.Key]string{}
+	for _, attr := range attrs {
+		values[attr.Key] = attr.Value.Emit()
+	}
+	return values
+}
@@ -1,9 +1,10 @@
 package simpleforce
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"net/http"
 	"net/url"
 )
 
@@ -18,30 +19,102 @@ type ExecuteAnonymousResult struct {
 	ExceptionMessage    interface{} `json:"exceptionMessage"`
 }
 
-// Tooling is called to specify Tooling API, e.g. client.Tooling().Query(q)
+// Tooling API objects commonly used by development tools.
+const (
+	ToolingApexClass         = "ApexClass"
+	ToolingApexTrigger       = "ApexTrigger"
+	ToolingApexLog           = "ApexLog"
+	ToolingTraceFlag         = "TraceFlag"
+	ToolingDebugLevel        = "DebugLevel"
+	ToolingMetadataContainer = "MetadataContainer"
+	ToolingApexClassMember   = "ApexClassMember"
+	ToolingContainerAsync    = "ContainerAsyncRequest"
+)
+
+// Tooling switches the client to the Tooling API until UnTooling is called, and returns it, e.g.
+// client.Tooling().Query(q). As the switch affects every user of the client, prefer ToolingClient for clients shared
+// between goroutines.
 func (client *Client) Tooling() *Client {
 	client.useToolingAPI = true
 	return client
 }
 
+// ToolingClient returns a copy of the client targeting the Tooling API: the queries, describes and the Get, Create,
+// Update, Upsert and Delete calls of the SObjects of the copy target Tooling objects, such as ApexClass, TraceFlag or
+// MetadataContainer, while the client keeps targeting the REST API. The copy shares the session and instance URL of
+// the client, which follow a new login or instance migration of either of them.
+//
+//	client.ToolingClient().SObject(ToolingTraceFlag).Set("TracedEntityId", userID).Set("DebugLevelId", levelID).Create()
+func (client *Client) ToolingClient() *Client {
+	override := client.clone()
+	override.useToolingAPI = true
+	return override
+}
+
+// UnTooling switches the client back to the REST API after Tooling.
 func (client *Client) UnTooling() {
 	client.useToolingAPI = false
 }
 
+// sobjectsResource returns the resource of the SObjects, of the Tooling API if the client uses it.
+func (client *Client) sobjectsResource() string {
+	if client.useToolingAPI {
+		return "tooling/sobjects/"
+	}
+	return "sobjects/"
+}
+
+// sobjectURL returns the URL of path under the resource of the SObjects, e.g. "Account/001xx000003DGb2AAG".
+func (client *Client) sobjectURL(path string) string {
+	return client.getInstanceURL() + client.sobjectPath(path)
+}
+
+// sobjectPath is like sobjectURL, without the instance URL, as expected by the subrequests of composite calls.
+func (client *Client) sobjectPath(path string) string {
+	return fmt.Sprintf("/services/data/v%s/%s%s", client.APIVersion(), client.sobjectsResource(), path)
+}
+
+// ApexLogBody downloads the content of the debug log logID, whether or not the client uses the Tooling API. Debug logs
+// are listed by querying ApexLog with the Tooling API.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.api_tooling.meta/api_tooling/tooling_api_objects_apexlog.htm
+func (client *Client) ApexLogBody(logID string) ([]byte, error) {
+	return client.ApexLogBodyContext(context.Background(), logID)
+}
+
+// ApexLogBodyContext is like ApexLogBody, with the request bound to ctx.
+func (client *Client) ApexLogBodyContext(ctx context.Context, logID string) ([]byte, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("tooling/sobjects/" + ToolingApexLog + "/" + logID + "/Body")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+	return data, nil
+}
+
 // ExecuteAnonymous executes a body of Apex code
 func (client *Client) ExecuteAnonymous(apexBody string) (*ExecuteAnonymousResult, error) {
+	return client.ExecuteAnonymousContext(context.Background(), apexBody)
+}
+
+// ExecuteAnonymousContext is like ExecuteAnonymous, with the request bound to ctx.
+func (client *Client) ExecuteAnonymousContext(ctx context.Context, apexBody string) (*ExecuteAnonymousResult, error) {
 	if !client.isLoggedIn() {
 		return nil, ErrAuthentication
 	}
 
 	// Create the endpoint
 	formatString := "%s/services/data/v%s/tooling/executeAnonymous/?anonymousBody=%s"
-	baseURL := client.instanceURL
-	endpoint := fmt.Sprintf(formatString, baseURL, client.apiVersion, url.QueryEscape(apexBody))
+	baseURL := client.getInstanceURL()
+	endpoint := fmt.Sprintf(formatString, baseURL, client.APIVersion(), url.QueryEscape(apexBody))
 
-	data, err := client.httpRequest("GET", endpoint, nil)
+	data, err := client.httpRequestContext(ctx, "GET", endpoint, nil)
 	if err != nil {
-		log.Println(logPrefix, "HTTP GET request failed:", endpoint)
+		client.logError("HTTP GET request failed:", endpoint)
 		return nil, err
 	}
 
@@ -1,6 +1,9 @@
 package simpleforce
 
 import (
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -32,3 +35,100 @@ func TestClient_ExecuteAnonymous(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestClient_Tooling_CRUDMock(t *testing.T) {
+	var requests []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"7tf000000000001AAA","success":true,"errors":[]}`))
+		case http.MethodGet:
+			if strings.HasSuffix(r.URL.Path, "/Body") {
+				w.Header().Set("Content-Type", "text/plain")
+				w.Write([]byte("54.0 APEX_CODE,DEBUG\n"))
+				return
+			}
+			w.Write([]byte(`{"attributes":{"type":"TraceFlag"},"Id":"7tf000000000001AAA","LogType":"USER_DEBUG"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	tooling := client.ToolingClient()
+	flag := tooling.SObject(ToolingTraceFlag).Set("LogType", "USER_DEBUG").Create()
+	if flag == nil || flag.ID() != "7tf000000000001AAA" {
+		t.Fatalf("unexpected trace flag %v", flag)
+	}
+	if got := tooling.SObject(ToolingTraceFlag).Get(flag.ID()); got == nil || got.StringField("LogType") != "USER_DEBUG" {
+		t.Errorf("unexpected trace flag %v", got)
+	}
+	if err := flag.Delete(); err != nil {
+		t.Error(err)
+	}
+	if client.useToolingAPI {
+		t.Error("expected the client to keep targeting the REST API")
+	}
+
+	body, err := client.ApexLogBody("07L000000000001AAA")
+	if err != nil || string(body) != "54.0 APEX_CODE,DEBUG\n" {
+		t.Errorf("unexpected log body %q, %v", body, err)
+	}
+
+	expected := []string{
+		"POST /services/data/v" + DefaultAPIVersion + "/tooling/sobjects/TraceFlag/",
+		"GET /services/data/v" + DefaultAPIVersion + "/tooling/sobjects/TraceFlag/7tf000000000001AAA",
+		"DELETE /services/data/v" + DefaultAPIVersion + "/tooling/sobjects/TraceFlag/7tf000000000001AAA",
+		"GET /services/data/v" + DefaultAPIVersion + "/tooling/sobjects/ApexLog/07L000000000001AAA/Body",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestClient_ToolingClient(t *testing.T) {
+	var paths []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	})
+
+	tooling := client.ToolingClient()
+	if _, err := tooling.Query("SELECT Id FROM ApexClass"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"/services/data/v54.0/tooling/query", "/services/data/v54.0/query"}
+	if !reflect.DeepEqual(paths, expected) || client.useToolingAPI || tooling.GetSid() != client.GetSid() {
+		t.Errorf("expected the client to be left unchanged, got requests %v", paths)
+	}
+
+	client.SetSidLoc("00D000000000062!AQzz", "https://na2.salesforce.com")
+	if tooling.GetSid() != "00D000000000062!AQzz" || tooling.InstanceURL() != "https://na2.salesforce.com" {
+		t.Errorf("expected the copy to follow the new session, got %s at %s", tooling.GetSid(), tooling.InstanceURL())
+	}
+}
+
+func TestClient_ToolingSwitch(t *testing.T) {
+	var paths []string
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	})
+
+	client.Tooling()
+	if _, err := client.Query("SELECT Id FROM ApexClass"); err != nil {
+		t.Fatal(err)
+	}
+	client.UnTooling()
+	if _, err := client.Query("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"/services/data/v54.0/tooling/query", "/services/data/v54.0/query"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected the client to switch to the Tooling API and back, got requests %v", paths)
+	}
+}
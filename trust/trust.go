@@ -0,0 +1,170 @@
+// Package trust queries the Salesforce Trust status API, which reports the incidents and maintenances of Salesforce
+// instances. It lets retry and circuit breaking logic tell planned maintenance apart from real failures.
+// Ref: https://api.status.salesforce.com/v1/docs/
+package trust
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/simpleforce/simpleforce"
+)
+
+// DefaultURL is the base URL of the Trust status API.
+const DefaultURL = "https://api.status.salesforce.com"
+
+// Instance statuses. Statuses suffixed with _CORE affect the core services (API, UI), the _NONCORE ones only affect
+// secondary services.
+const (
+	StatusOK                   = "OK"
+	StatusMajorIncidentCore    = "MAJOR_INCIDENT_CORE"
+	StatusMinorIncidentCore    = "MINOR_INCIDENT_CORE"
+	StatusMaintenanceCore      = "MAINTENANCE_CORE"
+	StatusInformationalCore    = "INFORMATIONAL_CORE"
+	StatusMajorIncidentNonCore = "MAJOR_INCIDENT_NONCORE"
+	StatusMinorIncidentNonCore = "MINOR_INCIDENT_NONCORE"
+	StatusMaintenanceNonCore   = "MAINTENANCE_NONCORE"
+	StatusInformationalNonCore = "INFORMATIONAL_NONCORE"
+)
+
+// Message is the operator facing description of an incident or maintenance.
+type Message struct {
+	Name        string `json:"name"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	EventStatus string `json:"eventStatus"`
+}
+
+// IncidentImpact is a period during which an incident had an effect of a given severity.
+type IncidentImpact struct {
+	Type      string     `json:"type"`
+	Severity  string     `json:"severity"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   *time.Time `json:"endTime"`
+}
+
+// Incident is an unplanned service disruption.
+type Incident struct {
+	ID              int              `json:"id"`
+	IsCore          bool             `json:"isCore"`
+	Message         Message          `json:"message"`
+	IncidentImpacts []IncidentImpact `json:"IncidentImpacts"`
+	CreatedAt       time.Time        `json:"createdAt"`
+	UpdatedAt       time.Time        `json:"updatedAt"`
+}
+
+// Maintenance is a planned maintenance window.
+type Maintenance struct {
+	ID               int       `json:"id"`
+	IsCore           bool      `json:"isCore"`
+	Message          Message   `json:"message"`
+	PlannedStartTime time.Time `json:"plannedStartTime"`
+	PlannedEndTime   time.Time `json:"plannedEndTime"`
+}
+
+// Active reports whether the maintenance window includes t.
+func (m *Maintenance) Active(t time.Time) bool {
+	return !t.Before(m.PlannedStartTime) && t.Before(m.PlannedEndTime)
+}
+
+// Status is the current status of a Salesforce instance.
+type Status struct {
+	Key            string        `json:"key"`
+	Location       string        `json:"location"`
+	Environment    string        `json:"environment"`
+	ReleaseVersion string        `json:"releaseVersion"`
+	Status         string        `json:"status"`
+	IsActive       bool          `json:"isActive"`
+	Incidents      []Incident    `json:"Incidents"`
+	Maintenances   []Maintenance `json:"Maintenances"`
+}
+
+// OK reports whether the instance is fully operational.
+func (s *Status) OK() bool {
+	return s.Status == StatusOK
+}
+
+// UnderMaintenance reports whether a maintenance of the core services is in progress, during which failures are
+// expected and should not trip circuit breakers.
+func (s *Status) UnderMaintenance() bool {
+	if s.Status == StatusMaintenanceCore {
+		return true
+	}
+	now := time.Now()
+	for i := range s.Maintenances {
+		if s.Maintenances[i].IsCore && s.Maintenances[i].Active(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCoreIncident reports whether an incident currently affects the core services.
+func (s *Status) HasCoreIncident() bool {
+	return s.Status == StatusMajorIncidentCore || s.Status == StatusMinorIncidentCore
+}
+
+// Client queries the Trust status API. The API is public, no authentication is required.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a Client for the given base URL, DefaultURL if empty. httpClient may be nil.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), httpClient: httpClient}
+}
+
+// Status returns the current status of the instance identified by key, e.g. "NA135" or "EU45".
+func (c *Client) Status(ctx context.Context, key string) (*Status, error) {
+	u := fmt.Sprintf("%s/v1/instances/%s/status?childProducts=false", c.baseURL, url.PathEscape(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("trust status of %s: unexpected status code %d", key, resp.StatusCode)
+	}
+
+	var status Status
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// InstanceKey returns the name of the instance hosting the org of client, the key of its Trust status.
+func InstanceKey(client *simpleforce.Client) (string, error) {
+	result, err := client.Query("SELECT InstanceName FROM Organization")
+	if err != nil {
+		return "", err
+	}
+	if len(result.Records) == 0 {
+		return "", errors.New("organization not found")
+	}
+	key := result.Records[0].StringField("InstanceName")
+	if key == "" {
+		return "", errors.New("organization has no instance name")
+	}
+	return key, nil
+}
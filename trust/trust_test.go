@@ -0,0 +1,60 @@
+package trust
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/simpleforce/simpleforce"
+)
+
+func TestClient_Status(t *testing.T) {
+	start := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	end := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/instances/NA135/status" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"key":"NA135","location":"NA","environment":"production","status":"MAINTENANCE_NONCORE","isActive":true,
+			"Incidents":[],
+			"Maintenances":[{"id":42,"isCore":true,"message":{"name":"Major release"},"plannedStartTime":"` + start + `","plannedEndTime":"` + end + `"}]}`))
+	}))
+	defer server.Close()
+
+	status, err := NewClient(server.URL, nil).Status(context.Background(), "NA135")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.OK() || status.HasCoreIncident() || !status.UnderMaintenance() {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if status.Maintenances[0].Message.Name != "Major release" {
+		t.Errorf("unexpected maintenance %+v", status.Maintenances[0])
+	}
+}
+
+func TestClient_StatusNotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := NewClient(server.URL, nil).Status(context.Background(), "XX1")
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestInstanceKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"totalSize":1,"done":true,"records":[{"InstanceName":"EU45"}]}`))
+	}))
+	defer server.Close()
+	client := simpleforce.NewClient(server.URL, simpleforce.DefaultClientID, simpleforce.DefaultAPIVersion)
+	client.SetSidLoc("__SESSION_ID__", server.URL)
+
+	key, err := InstanceKey(client)
+	if err != nil || key != "EU45" {
+		t.Errorf("unexpected key %s, %v", key, err)
+	}
+}
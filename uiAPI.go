@@ -0,0 +1,418 @@
+package simpleforce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// masterRecordTypeID is the ID of the master record type, which the UI API uses for records without record type.
+const masterRecordTypeID = "012000000000000AAA"
+
+// UIFieldValue is the value of a field of a record of the UI API, along with its value formatted for display: the
+// label of picklist values, the name of related records, or dates in the locale of the user.
+type UIFieldValue struct {
+	// DisplayValue is nil if the value is displayed as is, e.g. for text fields.
+	DisplayValue *string `json:"displayValue"`
+	// Value is the raw value of the field; for relationship fields, it's the related record as a map.
+	Value interface{} `json:"value"`
+}
+
+// String returns the value to display.
+func (v UIFieldValue) String() string {
+	if v.DisplayValue != nil {
+		return *v.DisplayValue
+	}
+	if v.Value == nil {
+		return ""
+	}
+	return fmt.Sprint(v.Value)
+}
+
+// UIRecord is a record of the UI API.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.uiapi.meta/uiapi/ui_api_responses_record.htm
+type UIRecord struct {
+	ID               string                  `json:"id"`
+	APIName          string                  `json:"apiName"`
+	RecordTypeID     string                  `json:"recordTypeId"`
+	Fields           map[string]UIFieldValue `json:"fields"`
+	LastModifiedDate DateTime                `json:"lastModifiedDate"`
+}
+
+// UILayoutComponent is a component of an item of a layout, usually a field.
+type UILayoutComponent struct {
+	APIName       string `json:"apiName"`
+	ComponentType string `json:"componentType"`
+	Label         string `json:"label"`
+}
+
+// UILayoutItem is a cell of a section of a layout.
+type UILayoutItem struct {
+	Label             string              `json:"label"`
+	Required          bool                `json:"required"`
+	EditableForNew    bool                `json:"editableForNew"`
+	EditableForUpdate bool                `json:"editableForUpdate"`
+	Components        []UILayoutComponent `json:"layoutComponents"`
+}
+
+// UILayoutSection is a section of a layout, made of rows of items.
+type UILayoutSection struct {
+	ID         string `json:"id"`
+	Heading    string `json:"heading"`
+	UseHeading bool   `json:"useHeading"`
+	Columns    int    `json:"columns"`
+	Rows       []struct {
+		Items []UILayoutItem `json:"layoutItems"`
+	} `json:"layoutRows"`
+}
+
+// UILayout is a page layout as Lightning displays it.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.uiapi.meta/uiapi/ui_api_responses_record_layout.htm
+type UILayout struct {
+	ID         string            `json:"id"`
+	LayoutType string            `json:"layoutType"`
+	Mode       string            `json:"mode"`
+	Sections   []UILayoutSection `json:"sections"`
+}
+
+// UIFieldInfo describes a field of an object of the UI API.
+type UIFieldInfo struct {
+	APIName    string `json:"apiName"`
+	Label      string `json:"label"`
+	DataType   string `json:"dataType"`
+	Required   bool   `json:"required"`
+	Updateable bool   `json:"updateable"`
+	// CompoundFieldName is the compound field the field is a component of, e.g. "BillingAddress" for BillingCity,
+	// and CompoundComponentName the part of the compound field it holds, e.g. "City".
+	CompoundFieldName     string `json:"compoundFieldName"`
+	CompoundComponentName string `json:"compoundComponentName"`
+}
+
+// UIObjectInfo describes an object of the UI API.
+type UIObjectInfo struct {
+	APIName     string                 `json:"apiName"`
+	Label       string                 `json:"label"`
+	LabelPlural string                 `json:"labelPlural"`
+	Fields      map[string]UIFieldInfo `json:"fields"`
+}
+
+// UIRecord retrieves a record through the UI API. fields are qualified by the object, e.g. "Account.Name"; without
+// fields, the fields of the full layout of the record are retrieved.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.uiapi.meta/uiapi/ui_api_resources_record_get.htm
+func (client *Client) UIRecord(id string, fields ...string) (*UIRecord, error) {
+	return client.UIRecordContext(context.Background(), id, fields...)
+}
+
+// UIRecordContext is like UIRecord, with the request bound to ctx.
+func (client *Client) UIRecordContext(ctx context.Context, id string, fields ...string) (*UIRecord, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	params := url.Values{}
+	if len(fields) > 0 {
+		params.Set("fields", strings.Join(fields, ","))
+	} else {
+		params.Set("layoutTypes", "Full")
+	}
+	u := client.makeURL("ui-api/records/" + url.PathEscape(id) + "?" + params.Encode())
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var record UIRecord
+	err = json.Unmarshal(data, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// UILayout retrieves the full layout of the records of an object in view mode, for the record type recordTypeID, or
+// the default record type of the user if empty.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.uiapi.meta/uiapi/ui_api_resources_record_layout.htm
+func (client *Client) UILayout(objectAPIName, recordTypeID string) (*UILayout, error) {
+	return client.UILayoutContext(context.Background(), objectAPIName, recordTypeID)
+}
+
+// UILayoutContext is like UILayout, with the request bound to ctx.
+func (client *Client) UILayoutContext(ctx context.Context, objectAPIName, recordTypeID string) (*UILayout, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	params := url.Values{}
+	params.Set("layoutType", "Full")
+	params.Set("mode", "View")
+	if recordTypeID != "" {
+		params.Set("recordTypeId", recordTypeID)
+	}
+	u := client.makeURL("ui-api/layout/" + url.PathEscape(objectAPIName) + "?" + params.Encode())
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var layout UILayout
+	err = json.Unmarshal(data, &layout)
+	if err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// RecordUI is a record along with the layout and description of its object, everything needed to display it the
+// way Lightning does.
+type RecordUI struct {
+	Record     UIRecord
+	Layout     UILayout
+	ObjectInfo UIObjectInfo
+}
+
+// GetRecordUI retrieves a record along with its full layout in view mode and the description of its object in a
+// single call.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.uiapi.meta/uiapi/ui_api_resources_record_ui.htm
+func (client *Client) GetRecordUI(id string) (*RecordUI, error) {
+	return client.GetRecordUIContext(context.Background(), id)
+}
+
+// GetRecordUIContext is like GetRecordUI, with the request bound to ctx.
+func (client *Client) GetRecordUIContext(ctx context.Context, id string) (*RecordUI, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.makeURL("ui-api/record-ui/" + url.PathEscape(id) + "?layoutTypes=Full&modes=View")
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var result struct {
+		Records map[string]UIRecord `json:"records"`
+		// Layouts are keyed by object, record type, layout type and mode.
+		Layouts     map[string]map[string]map[string]map[string]UILayout `json:"layouts"`
+		ObjectInfos map[string]UIObjectInfo                              `json:"objectInfos"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	record, ok := result.Records[id]
+	if !ok && len(result.Records) == 1 {
+		// Records are keyed by their 18 characters ID, which id may not be.
+		for _, only := range result.Records {
+			record, ok = only, true
+		}
+	}
+	if !ok {
+		return nil, errors.Errorf("record %s not found in response", id)
+	}
+	recordTypeID := record.RecordTypeID
+	if recordTypeID == "" {
+		recordTypeID = masterRecordTypeID
+	}
+	layout, ok := result.Layouts[record.APIName][recordTypeID]["Full"]["View"]
+	if !ok {
+		return nil, errors.Errorf("no layout for record %s", id)
+	}
+	return &RecordUI{Record: record, Layout: layout, ObjectInfo: result.ObjectInfos[record.APIName]}, nil
+}
+
+// UILayoutValue is the value displayed by an item of a layout.
+type UILayoutValue struct {
+	Label string
+	// Fields are the fields displayed by the item; compound fields are replaced by their components.
+	Fields []string
+	// DisplayValue joins the display values of Fields with spaces.
+	DisplayValue string
+}
+
+// compoundComponentOrder orders the components of compound fields as Lightning displays them.
+var compoundComponentOrder = []string{
+	"Salutation", "FirstName", "MiddleName", "LastName", "Suffix",
+	"Street", "City", "State", "PostalCode", "Country", "Latitude", "Longitude",
+}
+
+// componentFields returns the component fields of the compound field name, or name if it's not a compound field.
+func (view *RecordUI) componentFields(name string) []string {
+	var components []UIFieldInfo
+	for _, field := range view.ObjectInfo.Fields {
+		if field.CompoundFieldName == name && field.APIName != name {
+			components = append(components, field)
+		}
+	}
+	if len(components) == 0 {
+		return []string{name}
+	}
+
+	rank := func(field UIFieldInfo) int {
+		for i, component := range compoundComponentOrder {
+			if component == field.CompoundComponentName {
+				return i
+			}
+		}
+		return len(compoundComponentOrder)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		ri, rj := rank(components[i]), rank(components[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return components[i].APIName < components[j].APIName
+	})
+	names := make([]string, len(components))
+	for i, field := range components {
+		names[i] = field.APIName
+	}
+	return names
+}
+
+// LayoutValues returns the values displayed by the items of the layout showing fields, section by section, row by
+// row.
+func (view *RecordUI) LayoutValues() []UILayoutValue {
+	var values []UILayoutValue
+	for _, section := range view.Layout.Sections {
+		for _, row := range section.Rows {
+			for _, item := range row.Items {
+				value := UILayoutValue{Label: item.Label}
+				var displayed []string
+				for _, component := range item.Components {
+					if component.ComponentType != "Field" {
+						continue
+					}
+					for _, name := range view.componentFields(component.APIName) {
+						value.Fields = append(value.Fields, name)
+						if display := view.Record.Fields[name].String(); display != "" {
+							displayed = append(displayed, display)
+						}
+					}
+				}
+				if len(value.Fields) == 0 {
+					continue
+				}
+				value.DisplayValue = strings.Join(displayed, " ")
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// UIPicklistValue is a value of a picklist field available to a record type.
+type UIPicklistValue struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	// ValidFor are the indexes, in the ControllerValues of the picklist, of the values of the controlling field this
+	// value is valid for, if the picklist is dependent.
+	ValidFor []int `json:"validFor"`
+}
+
+// UIPicklistValues are the values of a picklist field available to a record type.
+type UIPicklistValues struct {
+	// ControllerValues maps the values of the controlling field of a dependent picklist to their index.
+	ControllerValues map[string]int    `json:"controllerValues"`
+	DefaultValue     *UIPicklistValue  `json:"defaultValue"`
+	Values           []UIPicklistValue `json:"values"`
+}
+
+// ValuesFor returns the values of a dependent picklist valid for the value controllerValue of its controlling field.
+// All values are returned if the picklist isn't dependent.
+func (picklist *UIPicklistValues) ValuesFor(controllerValue string) []UIPicklistValue {
+	if len(picklist.ControllerValues) == 0 {
+		return picklist.Values
+	}
+	index, ok := picklist.ControllerValues[controllerValue]
+	if !ok {
+		return nil
+	}
+	var values []UIPicklistValue
+	for _, value := range picklist.Values {
+		for _, validFor := range value.ValidFor {
+			if validFor == index {
+				values = append(values, value)
+				break
+			}
+		}
+	}
+	return values
+}
+
+// picklistValuesURL returns the URL of the picklist values of the record type recordTypeID, or of the master record
+// type if empty, of an object.
+func (client *Client) picklistValuesURL(objectAPIName, recordTypeID string) string {
+	if recordTypeID == "" {
+		recordTypeID = masterRecordTypeID
+	}
+	return client.makeURL("ui-api/object-info/" + url.PathEscape(objectAPIName) + "/picklist-values/" +
+		url.PathEscape(recordTypeID))
+}
+
+// GetPicklistValues returns the values of the picklist field fieldAPIName available to the record type recordTypeID of
+// an object, or to its master record type if recordTypeID is empty.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.uiapi.meta/uiapi/ui_api_resources_picklist_values.htm
+func (client *Client) GetPicklistValues(objectAPIName, recordTypeID, fieldAPIName string) (*UIPicklistValues, error) {
+	return client.GetPicklistValuesContext(context.Background(), objectAPIName, recordTypeID, fieldAPIName)
+}
+
+// GetPicklistValuesContext is like GetPicklistValues, with the request bound to ctx.
+func (client *Client) GetPicklistValuesContext(ctx context.Context, objectAPIName, recordTypeID, fieldAPIName string) (*UIPicklistValues, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.picklistValuesURL(objectAPIName, recordTypeID) + "/" + url.PathEscape(fieldAPIName)
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var picklist UIPicklistValues
+	err = json.Unmarshal(data, &picklist)
+	if err != nil {
+		return nil, err
+	}
+	return &picklist, nil
+}
+
+// GetAllPicklistValues returns the values of all the picklist fields of an object available to a record type, keyed
+// by field, like GetPicklistValues.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.uiapi.meta/uiapi/ui_api_resources_picklist_values_collection.htm
+func (client *Client) GetAllPicklistValues(objectAPIName, recordTypeID string) (map[string]UIPicklistValues, error) {
+	return client.GetAllPicklistValuesContext(context.Background(), objectAPIName, recordTypeID)
+}
+
+// GetAllPicklistValuesContext is like GetAllPicklistValues, with the request bound to ctx.
+func (client *Client) GetAllPicklistValuesContext(ctx context.Context, objectAPIName, recordTypeID string) (map[string]UIPicklistValues, error) {
+	if !client.isLoggedIn() {
+		return nil, ErrAuthentication
+	}
+
+	u := client.picklistValuesURL(objectAPIName, recordTypeID)
+	data, err := client.httpRequestContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		client.logError("HTTP GET request failed:", u)
+		return nil, err
+	}
+
+	var result struct {
+		PicklistFieldValues map[string]UIPicklistValues `json:"picklistFieldValues"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.PicklistFieldValues, nil
+}
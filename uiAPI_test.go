@@ -0,0 +1,152 @@
+package simpleforce
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_UIRecord(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/ui-api/records/0013000000Db2wKAAR" ||
+			r.URL.Query().Get("fields") != "Account.Name,Account.Industry" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"apiName":"Account","id":"0013000000Db2wKAAR","recordTypeId":"012000000000000AAA",
+			"lastModifiedDate":"2022-05-02T10:00:00.000Z",
+			"fields":{"Name":{"displayValue":null,"value":"Acme"},"Industry":{"displayValue":"Énergie","value":"Energy"}}}`))
+	})
+
+	record, err := client.UIRecord("0013000000Db2wKAAR", "Account.Name", "Account.Industry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.APIName != "Account" || record.Fields["Name"].String() != "Acme" || record.Fields["Industry"].String() != "Énergie" ||
+		record.Fields["Industry"].Value != "Energy" || record.LastModifiedDate.IsZero() {
+		t.Errorf("unexpected record %+v", record)
+	}
+}
+
+func TestClient_UILayout(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/services/data/v54.0/ui-api/layout/Account" || query.Get("layoutType") != "Full" ||
+			query.Get("mode") != "View" || query.Get("recordTypeId") != "0123000000AbcdeAAA" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"id":"00h30000000AbcdAAC","layoutType":"Full","mode":"View","sections":[{"id":"01B30000000AbcdEAC",
+			"heading":"Account Information","useHeading":true,"columns":2,"layoutRows":[{"layoutItems":[{"label":"Account Name",
+			"required":true,"layoutComponents":[{"apiName":"Name","componentType":"Field","label":"Account Name"}]}]}]}]}`))
+	})
+
+	layout, err := client.UILayout("Account", "0123000000AbcdeAAA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layout.Sections) != 1 || layout.Sections[0].Heading != "Account Information" ||
+		layout.Sections[0].Rows[0].Items[0].Components[0].APIName != "Name" {
+		t.Errorf("unexpected layout %+v", layout)
+	}
+}
+
+func TestClient_GetRecordUI(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/ui-api/record-ui/0013000000Db2wK" || r.URL.Query().Get("layoutTypes") != "Full" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{
+			"records":{"0013000000Db2wKAAR":{"apiName":"Account","id":"0013000000Db2wKAAR","recordTypeId":null,"fields":{
+				"Name":{"displayValue":null,"value":"Acme"},
+				"Type":{"displayValue":"Client","value":"Customer"},
+				"BillingStreet":{"displayValue":null,"value":"1 Market St"},
+				"BillingCity":{"displayValue":null,"value":"San Francisco"},
+				"BillingPostalCode":{"displayValue":null,"value":"94105"},
+				"BillingCountry":{"displayValue":null,"value":null}}}},
+			"layouts":{"Account":{"012000000000000AAA":{"Full":{"View":{"id":"00h30000000AbcdAAC","layoutType":"Full","mode":"View",
+				"sections":[{"heading":"Account Information","layoutRows":[
+					{"layoutItems":[
+						{"label":"Account Name","layoutComponents":[{"apiName":"Name","componentType":"Field"}]},
+						{"label":"Type","layoutComponents":[{"apiName":"Type","componentType":"Field"}]}]},
+					{"layoutItems":[
+						{"label":"Billing Address","layoutComponents":[{"apiName":"BillingAddress","componentType":"Field"}]},
+						{"label":"","layoutComponents":[{"apiName":null,"componentType":"EmptySpace"}]}]}]}]}}}}},
+			"objectInfos":{"Account":{"apiName":"Account","label":"Account","fields":{
+				"Name":{"apiName":"Name","label":"Account Name","dataType":"String"},
+				"Type":{"apiName":"Type","label":"Type","dataType":"Picklist"},
+				"BillingAddress":{"apiName":"BillingAddress","label":"Billing Address","dataType":"Address","compoundFieldName":"BillingAddress"},
+				"BillingCountry":{"apiName":"BillingCountry","compoundFieldName":"BillingAddress","compoundComponentName":"Country"},
+				"BillingPostalCode":{"apiName":"BillingPostalCode","compoundFieldName":"BillingAddress","compoundComponentName":"PostalCode"},
+				"BillingCity":{"apiName":"BillingCity","compoundFieldName":"BillingAddress","compoundComponentName":"City"},
+				"BillingStreet":{"apiName":"BillingStreet","compoundFieldName":"BillingAddress","compoundComponentName":"Street"}}}}
+		}`))
+	})
+
+	view, err := client.GetRecordUI("0013000000Db2wK")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Record.ID != "0013000000Db2wKAAR" || view.Layout.ID != "00h30000000AbcdAAC" || view.ObjectInfo.Label != "Account" {
+		t.Fatalf("unexpected record UI %+v", view)
+	}
+	expected := []UILayoutValue{
+		{Label: "Account Name", Fields: []string{"Name"}, DisplayValue: "Acme"},
+		{Label: "Type", Fields: []string{"Type"}, DisplayValue: "Client"},
+		{
+			Label:        "Billing Address",
+			Fields:       []string{"BillingStreet", "BillingCity", "BillingPostalCode", "BillingCountry"},
+			DisplayValue: "1 Market St San Francisco 94105",
+		},
+	}
+	if values := view.LayoutValues(); !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected %+v, got %+v", expected, values)
+	}
+}
+
+func TestClient_GetPicklistValues(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/ui-api/object-info/Account/picklist-values/0123000000AbcdeAAA/Tier__c" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"controllerValues":{"Banking":0,"Energy":1},"defaultValue":null,
+			"values":[
+				{"attributes":null,"label":"Gold","validFor":[0,1],"value":"Gold"},
+				{"attributes":null,"label":"Platinum","validFor":[0],"value":"Platinum"}]}`))
+	})
+
+	picklist, err := client.GetPicklistValues("Account", "0123000000AbcdeAAA", "Tier__c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(picklist.Values) != 2 || picklist.DefaultValue != nil {
+		t.Fatalf("unexpected picklist %+v", picklist)
+	}
+	if values := picklist.ValuesFor("Energy"); len(values) != 1 || values[0].Value != "Gold" {
+		t.Errorf("unexpected values for Energy %+v", values)
+	}
+	if values := picklist.ValuesFor("Banking"); len(values) != 2 {
+		t.Errorf("unexpected values for Banking %+v", values)
+	}
+	if values := picklist.ValuesFor("Retail"); len(values) != 0 {
+		t.Errorf("unexpected values for Retail %+v", values)
+	}
+}
+
+func TestClient_GetAllPicklistValues(t *testing.T) {
+	client := requireMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v54.0/ui-api/object-info/Account/picklist-values/012000000000000AAA" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"picklistFieldValues":{"Industry":{"controllerValues":{},
+			"defaultValue":{"label":"Banking","validFor":[],"value":"Banking"},
+			"values":[{"label":"Banking","validFor":[],"value":"Banking"},{"label":"Energy","validFor":[],"value":"Energy"}]}}}`))
+	})
+
+	picklists, err := client.GetAllPicklistValues("Account", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	industry := picklists["Industry"]
+	if industry.DefaultValue == nil || industry.DefaultValue.Value != "Banking" || len(industry.ValuesFor("")) != 2 {
+		t.Errorf("unexpected picklists %+v", picklists)
+	}
+}